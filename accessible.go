@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/glyph"
+)
+
+// spoken are the symbols output uses, with the words accessible mode
+// writes in their place, since screen readers skip or mangle them.
+// Replacements are tried in order, so longer ones come first.
+var spoken = strings.NewReplacer(
+	"★", "[earned]",
+	"☆", "[not yet]",
+	"☑", "[done]",
+	"☐", "[to do]",
+	"◀", "(left is ahead)",
+	"▶", "(right is ahead)",
+	"⚠ ", "warning: ",
+	" — ", ", ",
+	"—", "none",
+	"–", " to ",
+	"…", "...",
+	"₽", "Pokédollars ",
+)
+
+// plainASCII are the symbols output uses, with the ASCII written in their
+// place for terminals that can only show that.
+var plainASCII = strings.NewReplacer(
+	"★", "*",
+	"☆", "-",
+	"☑", "[x]",
+	"☐", "[ ]",
+	"◀", "<",
+	"▶", ">",
+	"⚠ ", "! ",
+	"—", "-",
+	"–", "-",
+	"…", "...",
+	"₽", "P",
+	"é", "e",
+)
+
+// say is s as it should be written: unchanged, with its symbols in words
+// in accessible mode, or in ASCII where only that can be shown.
+func say(cfg *config, s string) string {
+	switch {
+	case cfg.Settings.Accessible:
+		return spoken.Replace(s)
+	case cfg.Settings.glyphs() == glyph.ASCII:
+		return plainASCII.Replace(s)
+	}
+	return s
+}
+
+// hpText describes how much HP a Pokémon has left.
+func hpText(cfg *config, hp, max int) string {
+	if cfg.Settings.Accessible {
+		return fmt.Sprintf("HP %d of %d", hp, max)
+	}
+	return fmt.Sprintf("%d/%d HP", hp, max)
+}
+
+func setAccessible(cfg *config, value string) error {
+	switch value {
+	case "on":
+		cfg.Settings.Accessible = true
+		fmt.Println("Accessible mode is on: output is plain text without colors, symbols or redrawn lines.")
+	case "off":
+		cfg.Settings.Accessible = false
+		fmt.Println("Accessible mode is off.")
+	default:
+		fmt.Println("Accessible mode can be on or off.")
+		return nil
+	}
+	cfg.Chat.plainly(cfg.Settings.Accessible)
+	return saveSettings(cfg)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxAliasDepth is how deep aliases can refer to other aliases before
+// expanding one is given up on, as it must refer back to itself.
+const maxAliasDepth = 10
+
+// splitCommands splits a line into the commands separated by semicolons
+// in it, leaving semicolons inside quotes alone.
+func splitCommands(line string) []string {
+	var commands []string
+	var quote rune
+	start := 0
+	for i, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ';':
+			commands = append(commands, line[start:i])
+			start = i + 1
+		}
+	}
+	// Quotes that don't pair up are kept as they are, as splitLine does.
+	if quote != 0 {
+		return []string{line}
+	}
+	return append(commands, line[start:])
+}
+
+// expandLine splits a line typed at the prompt into the commands to run,
+// one after another, with every alias expanded.
+func expandLine(cfg *config, line string) ([][]string, error) {
+	return expandText(cfg, line, 0)
+}
+
+func expandText(cfg *config, text string, depth int) ([][]string, error) {
+	var lines [][]string
+	for _, command := range splitCommands(text) {
+		words := splitLine(command)
+		if len(words) == 0 {
+			continue
+		}
+		expanded, err := expandWords(cfg, words, depth)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, expanded...)
+	}
+	return lines, nil
+}
+
+// expandWords expands words if they start with an alias. Words after the
+// alias are added to the last command it expands to, so `alias e explore`
+// makes `e pallet-town-area` explore it.
+func expandWords(cfg *config, words []string, depth int) ([][]string, error) {
+	expansion, ok := cfg.Settings.Aliases[words[0]]
+	if !ok {
+		return [][]string{words}, nil
+	}
+	if depth >= maxAliasDepth {
+		return nil, fmt.Errorf("the alias %s refers back to itself", words[0])
+	}
+	lines, err := expandText(cfg, expansion, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("the alias %s is empty", words[0])
+	}
+	last := len(lines) - 1
+	lines[last] = append(lines[last], words[1:]...)
+	return lines, nil
+}
+
+// aliasText joins the words an alias was defined with. A single word is
+// taken as it is, so a macro can be quoted whole; otherwise words with
+// spaces or semicolons in them are quoted to stay one word.
+func aliasText(words []string) string {
+	if len(words) == 1 {
+		return words[0]
+	}
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = w
+		if strings.ContainsAny(w, " \t;") {
+			q := `"`
+			if strings.Contains(w, q) {
+				q = "'"
+			}
+			quoted[i] = q + w + q
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+func commandAlias(cfg *config, args []string) error {
+	commands := commandRegistry()
+	switch {
+	case len(args) >= 2 && args[0] == "remove":
+		if _, ok := cfg.Settings.Aliases[args[1]]; !ok {
+			fmt.Printf("There is no alias called %s.\n", args[1])
+			return nil
+		}
+		delete(cfg.Settings.Aliases, args[1])
+		fmt.Printf("Removed the alias %s.\n", args[1])
+		return saveSettings(cfg)
+	case len(args) == 1:
+		expansion, ok := cfg.Settings.Aliases[args[0]]
+		if !ok {
+			fmt.Printf("There is no alias called %s.\n", args[0])
+			return nil
+		}
+		fmt.Printf("%s: %s\n", args[0], expansion)
+		return nil
+	case len(args) == 0:
+		printAliases(cfg)
+		return nil
+	}
+
+	name, expansion := args[0], aliasText(args[1:])
+	if problem := aliasProblem(name, expansion); problem != "" {
+		fmt.Println(problem)
+		return nil
+	}
+	if _, ok := commands[name]; ok {
+		fmt.Printf("%s is already a command.\n", name)
+		return nil
+	}
+	old, existed := cfg.Settings.Aliases[name]
+	if cfg.Settings.Aliases == nil {
+		cfg.Settings.Aliases = make(map[string]string)
+	}
+	cfg.Settings.Aliases[name] = expansion
+	// The expansion is tried out before it is kept, so an alias that loops,
+	// or runs a command that doesn't exist, is caught now and not when it
+	// is used.
+	lines, err := expandWords(cfg, []string{name}, 0)
+	if err == nil {
+		for _, words := range lines {
+			if _, ok := commands[words[0]]; !ok {
+				err = fmt.Errorf("there is no %s command", words[0])
+				break
+			}
+		}
+	}
+	if err != nil {
+		if existed {
+			cfg.Settings.Aliases[name] = old
+		} else {
+			delete(cfg.Settings.Aliases, name)
+		}
+		fmt.Printf("Can't add that alias: %v.\n", err)
+		return nil
+	}
+	fmt.Printf("%s now runs %s.\n", name, expansion)
+	return saveSettings(cfg)
+}
+
+// aliasProblem returns what is wrong with an alias, if anything.
+func aliasProblem(name, expansion string) string {
+	switch {
+	case name == "remove":
+		return "An alias can't be called remove."
+	case strings.HasPrefix(name, "-"):
+		return "An alias can't start with -."
+	case strings.ContainsAny(name, " \t;'\""):
+		return "An alias is one word, without quotes or semicolons."
+	case strings.TrimSpace(expansion) == "":
+		return "Give the command the alias runs."
+	}
+	return ""
+}
+
+func printAliases(cfg *config) {
+	if len(cfg.Settings.Aliases) == 0 {
+		fmt.Println("No aliases yet. Add one with `alias <name> <command>`, like `alias e explore`.")
+		return
+	}
+	names := make([]string, 0, len(cfg.Settings.Aliases))
+	for name := range cfg.Settings.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf(" - %s: %s\n", name, cfg.Settings.Aliases[name])
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/danger"
+)
+
+// rateArea remembers the levels of the area just explored and says how
+// tough it is for the player's Pokémon.
+func rateArea(cfg *config, area string) error {
+	var levels levelRange
+	for _, encounter := range cfg.Encounters {
+		if encounter.Max > 0 {
+			levels = levels.include(encounter.Min, encounter.Max)
+		}
+	}
+	if levels.Max == 0 {
+		return nil
+	}
+	fmt.Printf("Wild Pokémon here: %s\n", areaDanger(cfg, levels))
+	cfg.State.Areas[area] = levels
+	return saveState(cfg)
+}
+
+// areaDanger compares an area with the party, or with every caught Pokémon
+// if there is no party.
+func areaDanger(cfg *config, levels levelRange) string {
+	var party []int
+	for _, pokemon := range partyMembers(cfg) {
+		party = append(party, levelOf(pokemon))
+	}
+	return say(cfg, danger.Describe(levels.Min, levels.Max, party))
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/biome"
+	"github.com/eymardfreire/pokedexcli/internal/fakemon"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// encounterWay is one way of meeting a Pokémon in an area, as
+// `explore --details` shows it.
+type encounterWay struct {
+	Name       string   `json:"name"`
+	Method     string   `json:"method"`
+	Conditions []string `json:"conditions,omitempty"`
+	MinLevel   int      `json:"min_level"`
+	MaxLevel   int      `json:"max_level"`
+	// Chance is the percent chance that an encounter by Method is with
+	// this Pokémon, in the version where that is likeliest.
+	Chance   int      `json:"chance"`
+	Versions []string `json:"versions"`
+}
+
+// areaVersions are the versions of the games any Pokémon in an area is met
+// in, in the order PokeAPI lists them.
+func areaVersions(area pokeapi.LocationArea) []string {
+	var versions []string
+	for _, encounter := range area.PokemonEncounters {
+		for _, version := range encounter.VersionDetails {
+			if !slices.Contains(versions, version.Version.Name) {
+				versions = append(versions, version.Version.Name)
+			}
+		}
+	}
+	return versions
+}
+
+// inVersion narrows an area down to what is met in one version of the
+// games. Homebrew species, which have a version of their own, are kept.
+func inVersion(area pokeapi.LocationArea, version string) pokeapi.LocationArea {
+	narrowed := area
+	narrowed.PokemonEncounters = nil
+	for _, encounter := range area.PokemonEncounters {
+		var details []pokeapi.EncounterVersion
+		for _, v := range encounter.VersionDetails {
+			if v.Version.Name == version || v.Version.Name == fakemon.Version {
+				details = append(details, v)
+			}
+		}
+		if len(details) > 0 {
+			encounter.VersionDetails = details
+			narrowed.PokemonEncounters = append(narrowed.PokemonEncounters, encounter)
+		}
+	}
+	narrowed.EncounterMethodRates = nil
+	for _, rate := range area.EncounterMethodRates {
+		var details []pokeapi.EncounterRateInfo
+		for _, v := range rate.VersionDetails {
+			if v.Version.Name == version {
+				details = append(details, v)
+			}
+		}
+		if len(details) > 0 {
+			rate.VersionDetails = details
+			narrowed.EncounterMethodRates = append(narrowed.EncounterMethodRates, rate)
+		}
+	}
+	return narrowed
+}
+
+// encounterWays breaks the Pokémon found in an area down by how they are
+// met: by method, then by the conditions it takes. Within a version, the
+// chances of slots alike add up; across versions, the best is kept.
+// They are grouped by method, and within it sorted as explore's --sort
+// says, or the most likely first.
+func encounterWays(found []foundPokemon, sortBy string) []encounterWay {
+	var ways []encounterWay
+	for _, f := range found {
+		index := make(map[string]int)
+		for _, version := range f.encounter.VersionDetails {
+			chances := make(map[string]int)
+			for _, detail := range version.EncounterDetails {
+				var conditions []string
+				for _, c := range detail.ConditionValues {
+					conditions = append(conditions, c.Name)
+				}
+				key := detail.Method.Name + " " + strings.Join(conditions, ",")
+				chances[key] += detail.Chance
+				i, ok := index[key]
+				if !ok {
+					i = len(ways)
+					index[key] = i
+					ways = append(ways, encounterWay{
+						Name:       f.encounter.Pokemon.Name,
+						Method:     detail.Method.Name,
+						Conditions: conditions,
+						MinLevel:   detail.MinLevel,
+						MaxLevel:   detail.MaxLevel,
+					})
+				}
+				w := &ways[i]
+				levels := levelRange{w.MinLevel, w.MaxLevel}.include(detail.MinLevel, detail.MaxLevel)
+				w.MinLevel, w.MaxLevel = levels.Min, levels.Max
+				w.Chance = max(w.Chance, chances[key])
+				if !slices.Contains(w.Versions, version.Version.Name) {
+					w.Versions = append(w.Versions, version.Version.Name)
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(ways, func(a, b encounterWay) int {
+		if c := cmp.Compare(a.Method, b.Method); c != 0 {
+			return c
+		}
+		if sortBy == "level" {
+			if c := cmp.Compare(a.MinLevel, b.MinLevel); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.MaxLevel, b.MaxLevel)
+		}
+		return cmp.Compare(b.Chance, a.Chance)
+	})
+	return ways
+}
+
+// methodRates are how often each method turns up a Pokémon in an area, in
+// the version where it does most often.
+func methodRates(area pokeapi.LocationArea) map[string]int {
+	rates := make(map[string]int)
+	for _, rate := range area.EncounterMethodRates {
+		for _, v := range rate.VersionDetails {
+			rates[rate.EncounterMethod.Name] = max(rates[rate.EncounterMethod.Name], v.Rate)
+		}
+	}
+	return rates
+}
+
+// showEncounterWays is explore with --details: every way each Pokémon
+// explored is met in the area, grouped by method.
+func showEncounterWays(cfg *config, area pokeapi.LocationArea, b biome.Biome, found []foundPokemon, explored []exploredPokemon, filter encounterFilter) error {
+	kept := make(map[string]bool)
+	for _, p := range explored {
+		kept[p.Name] = true
+	}
+	found = slices.DeleteFunc(slices.Clone(found), func(f foundPokemon) bool {
+		return !kept[f.encounter.Pokemon.Name]
+	})
+	ways := encounterWays(found, filter.Sort)
+	return show(cfg, ways, func() {
+		if b != "" {
+			fmt.Printf("This is a %s area.\n", b)
+		}
+		if len(ways) == 0 {
+			fmt.Println("None of the Pokémon here match.")
+			return
+		}
+		fmt.Println("Found Pokemon:")
+		printEncounterWays(cfg, ways, methodRates(area))
+		if versions := areaVersions(area); filter.Version == "" && len(versions) > 1 {
+			fmt.Printf("Chances are for the version each is likeliest in; pick one with --version: %s.\n", strings.Join(versions, ", "))
+		}
+	})
+}
+
+// printEncounterWays lists ways under a heading for each method.
+func printEncounterWays(cfg *config, ways []encounterWay, rates map[string]int) {
+	method := ""
+	for _, w := range ways {
+		if w.Method != method {
+			method = w.Method
+			if rate, ok := rates[method]; ok {
+				fmt.Printf("%s (%d%% encounter rate):\n", method, rate)
+			} else {
+				fmt.Printf("%s:\n", method)
+			}
+		}
+		levels := fmt.Sprintf("Lv. %d", w.MinLevel)
+		if w.MaxLevel != w.MinLevel {
+			levels = say(cfg, fmt.Sprintf("Lv. %d–%d", w.MinLevel, w.MaxLevel))
+		}
+		line := fmt.Sprintf(" - %s: %s, %d%%", w.Name, levels, w.Chance)
+		if len(w.Conditions) > 0 {
+			line += " (" + strings.Join(w.Conditions, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,8 @@
+package main
+
+import "path/filepath"
+
+// balancePath is the file the game's balance is tuned in.
+func balancePath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Config, "balance.json")
+}
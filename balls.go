@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+)
+
+// ballNames are how balls are written in messages.
+var ballNames = map[string]string{
+	"poke-ball":   "Poké Ball",
+	"great-ball":  "Great Ball",
+	"ultra-ball":  "Ultra Ball",
+	"master-ball": "Master Ball",
+}
+
+// ballName is how a ball is written in messages. Balls added in the
+// balance file are named after their item, like Dusk Ball for dusk-ball.
+func ballName(ball string) string {
+	if name, ok := ballNames[ball]; ok {
+		return name
+	}
+	words := strings.Split(ball, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// collectSupplyOnStartup hands out the day's balls the first time the
+// player plays each day.
+func collectSupplyOnStartup(cfg *config) {
+	if err := collectSupply(cfg); err != nil {
+		fmt.Println("Error collecting today's supply:", err)
+	}
+}
+
+// collectSupply hands out the day's balls, unless they already were.
+func collectSupply(cfg *config) error {
+	today := time.Now().Format("2006-01-02")
+	if cfg.State.Supplied == today {
+		return nil
+	}
+	cfg.State.Supplied = today
+	var balls []string
+	for ball := range capture.Supply {
+		balls = append(balls, ball)
+	}
+	sort.Slice(balls, func(i, j int) bool { return capture.Prices[balls[i]] < capture.Prices[balls[j]] })
+	var got []string
+	for _, ball := range balls {
+		if capture.Supply[ball] == 0 {
+			continue
+		}
+		cfg.State.Items[ball] += capture.Supply[ball]
+		got = append(got, fmt.Sprintf("%d %s", capture.Supply[ball], ball))
+	}
+	fmt.Printf("Today's supply arrived: %s.\n", strings.Join(got, ", "))
+	return saveState(cfg)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+// defaultLevel is used for Pokémon whose level is unknown, such as gifts.
+const defaultLevel = 5
+
+func levelOf(pokemon Pokemon) int {
+	if pokemon.Level > 0 {
+		return pokemon.Level
+	}
+	return defaultLevel
+}
+
+func typeNames(pokemon Pokemon) []string {
+	var names []string
+	for _, typ := range pokemon.Types {
+		names = append(names, typ.Type.Name)
+	}
+	return names
+}
+
+// combatantFor sets up a Pokémon for battle, knowing the moves it would have
+// learned by levelling up to level. Stats PokeAPI doesn't have are
+// middling.
+func combatantFor(cfg *config, pokemon Pokemon, level int) (*battle.Combatant, error) {
+	base := make(map[string]int)
+	for _, stat := range missing.Fill(pokemon.Pokemon).Stats {
+		base[stat.Stat.Name] = stat.BaseStat
+	}
+	c := battle.NewCombatant(pokemon.Name, level, typeNames(pokemon), base)
+	for _, ability := range pokemon.Abilities {
+		if !ability.IsHidden {
+			c.Ability = ability.Ability.Name
+			break
+		}
+	}
+	// A battle needs every move, so there is no budget to wait within.
+	ms, loading, err := fetchMoves(cfg, knownMoves(pokemon, level), 0, nil)
+	if err == nil && len(loading) > 0 {
+		err = cfg.Ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.Moves = ms
+	return c, nil
+}
+
+// knownMoves are the moves a Pokémon battles with at the given level.
+func knownMoves(pokemon Pokemon, level int) []string {
+	if pokemon.Known != nil {
+		return pokemon.Known
+	}
+	return moves.LevelUp(pokemon.Moves, level)
+}
+
+// turnPrinter prints battle turns, showing each side's stat stages
+// whenever they change.
+type turnPrinter struct {
+	shown map[string]string
+}
+
+func newTurnPrinter() *turnPrinter {
+	return &turnPrinter{shown: make(map[string]string)}
+}
+
+func (p *turnPrinter) print(turn battle.Turn) {
+	if turn.Attacker == "" {
+		for _, note := range turn.Notes {
+			fmt.Printf("  %s\n", note)
+		}
+		return
+	}
+	fmt.Printf("  %s\n", turn)
+	for _, note := range turn.Notes {
+		fmt.Printf("    %s\n", note)
+	}
+	p.showStages(turn.Attacker, turn.AttackerStages)
+	p.showStages(turn.Defender, turn.DefenderStages)
+}
+
+func (p *turnPrinter) showStages(name string, stages map[string]int) {
+	summary := battle.FormatStages(stages)
+	if summary == p.shown[name] {
+		return
+	}
+	p.shown[name] = summary
+	if summary == "" {
+		summary = "back to normal"
+	}
+	fmt.Printf("    [%s: %s]\n", name, summary)
+}
+
+func printBattleLog(result battle.Result) {
+	p := newTurnPrinter()
+	for _, turn := range result.Turns {
+		p.print(turn)
+	}
+	printOutcome(result)
+}
+
+func printOutcome(result battle.Result) {
+	fmt.Println(outcome(result))
+}
+
+func outcome(result battle.Result) string {
+	if result.Winner == nil {
+		return "Neither Pokémon could finish the battle."
+	}
+	return fmt.Sprintf("%s fainted! %s wins.", result.Loser.Name, result.Winner.Name)
+}
+
+// startAI starts the opponent AI described by spec. The returned function
+// must be called once the battles are over.
+func startAI(spec string) (battle.Controller, func(), error) {
+	ai, err := battle.NewController(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	stop := func() {
+		if closer, ok := ai.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	return ai, stop, nil
+}
+
+// battleWith runs a battle between the player's Pokémon and a foe controlled
+// by ai, prints it and lets the rest of the game know how it went.
+func battleWith(cfg *config, player, foe *battle.Combatant, ai battle.Controller) (battle.Result, error) {
+	result, err := battle.FightWith(player, foe, battle.Auto{}, ai, battle.Conditions{Generation: cfg.State.generation()}, cfg.RNG.Get(rng.Battle))
+	if err != nil {
+		return result, err
+	}
+	printBattleLog(result)
+	reportBattle(cfg, player, foe, result)
+	return result, nil
+}
+
+// reportBattle lets the rest of the game know how a battle went for the
+// player's Pokémon.
+func reportBattle(cfg *config, player, foe *battle.Combatant, result battle.Result) {
+	cfg.Events.Publish(events.Event{Kind: events.PokemonSeen, Subject: foe.Name})
+	if result.Winner == player {
+		cfg.Events.Publish(events.Event{Kind: events.BattleWon, Subject: player.Name, Other: foe.Name})
+	} else if player.Fainted() {
+		cfg.Events.Publish(events.Event{Kind: events.PokemonFainted, Subject: player.Name, Other: foe.Name})
+	}
+}
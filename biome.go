@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/eymardfreire/pokedexcli/internal/biome"
+	"github.com/eymardfreire/pokedexcli/internal/danger"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+// areaBiome is the biome of an area: as classified when it was explored,
+// or from its name alone until then.
+func areaBiome(cfg *config, area string) biome.Biome {
+	if b, ok := cfg.State.Biomes[area]; ok {
+		return b
+	}
+	return biome.Classify(area, nil)
+}
+
+// classifyArea works out an area's biome from its name and how its
+// Pokémon are met, and remembers it.
+func classifyArea(cfg *config, area pokeapi.LocationArea) biome.Biome {
+	var met []string
+	for _, encounter := range area.PokemonEncounters {
+		met = append(met, methods(encounter)...)
+	}
+	b := biome.Classify(area.Name, met)
+	cfg.State.Biomes[area.Name] = b
+	return b
+}
+
+// biomeChance is the chance of meeting an encounter in an area of biome b,
+// once the biome favours the Pokémon's types or not. If the Pokémon can't
+// be looked up, the chance is left as PokeAPI gives it.
+func biomeChance(cfg *config, b biome.Biome, encounter pokeapi.PokemonEncounter) int {
+	chance := bestChance(encounter)
+	if b == "" {
+		return chance
+	}
+	pokemon, err := fetchPokemon(cfg, encounter.Pokemon.Name)
+	if err != nil {
+		if cfg.Log != nil {
+			cfg.Log.Printf("types of %s: %v", encounter.Pokemon.Name, err)
+		}
+		return chance
+	}
+	return biome.Modify(b, chance, typeNames(pokemon))
+}
+
+// pickArea picks an area of biome b to explore, from those on the map and
+// those explored before. Areas easy for the party are likeliest, so new
+// players start somewhere they can catch things; areas that are too hard
+// are least likely.
+func pickArea(cfg *config, b biome.Biome) string {
+	names := slices.Clone(cfg.Current)
+	for area := range cfg.State.Areas {
+		if !slices.Contains(names, area) {
+			names = append(names, area)
+		}
+	}
+	sort.Strings(names)
+
+	var party []int
+	for _, pokemon := range partyMembers(cfg) {
+		party = append(party, levelOf(pokemon))
+	}
+	var candidates []biome.Candidate
+	for _, area := range names {
+		if areaBiome(cfg, area) != b {
+			continue
+		}
+		weight := 2
+		if levels, ok := cfg.State.Areas[area]; ok {
+			switch danger.Rate(levels.Min, levels.Max, party) {
+			case danger.Below:
+				weight = 3
+			case danger.Above:
+				weight = 1
+			}
+		}
+		candidates = append(candidates, biome.Candidate{Area: area, Weight: weight})
+	}
+	return biome.Pick(candidates, cfg.RNG.Get(rng.World))
+}
+
+// watchBiomes counts the Pokémon caught in each biome and awards the
+// achievements for them.
+func watchBiomes(cfg *config) {
+	cfg.Events.Subscribe(events.PokemonCaught, func(e events.Event) {
+		b := areaBiome(cfg, cfg.Area)
+		if cfg.Area == "" || b == "" {
+			return
+		}
+		before := biome.Earned(cfg.State.BiomeCatches)
+		cfg.State.BiomeCatches[b]++
+		for _, a := range biome.Earned(cfg.State.BiomeCatches) {
+			if slices.Contains(before, a) {
+				continue
+			}
+			fmt.Printf("Achievement unlocked: %s, for catching %d Pokémon in %s areas!\n", a.Name, a.Catches, a.Biome)
+		}
+		if err := saveState(cfg); err != nil {
+			fmt.Println("Error saving progress:", err)
+		}
+	})
+}
+
+func commandBiomes(cfg *config, args []string) error {
+	explored := make(map[biome.Biome]int)
+	for area := range cfg.State.Areas {
+		explored[areaBiome(cfg, area)]++
+	}
+	earned := biome.Earned(cfg.State.BiomeCatches)
+	for _, b := range biome.All {
+		fmt.Printf("%s: %d areas explored, %d Pokémon caught\n", b, explored[b], cfg.State.BiomeCatches[b])
+		for _, a := range biome.Achievements {
+			if a.Biome != b {
+				continue
+			}
+			if slices.Contains(earned, a) {
+				fmt.Printf(say(cfg, "  ★ %s\n"), a.Name)
+			} else {
+				fmt.Printf(say(cfg, "  ☆ %s: catch %d\n"), a.Name, a.Catches)
+			}
+		}
+	}
+	return nil
+}
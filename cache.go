@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/pokecache"
+)
+
+// PokeAPI responses are kept in memory for a few minutes and on disk for a
+// week, unless the player chose otherwise; PokeAPI's data hardly ever
+// changes.
+const (
+	memoryCacheTTL = 5 * time.Minute
+	diskCacheTTL   = 7 * 24 * time.Hour
+)
+
+// The memory cache drops the least recently used responses beyond these,
+// so long sessions don't grow without bound.
+const (
+	memoryCacheEntries = 2000
+	memoryCacheBytes   = 64 << 20
+)
+
+// apiCache is the cache for PokeAPI responses: in memory, and stored
+// between sessions unless the player turned that off. The memory cache is
+// made once a session.
+func apiCache(cfg *config) pokeapi.Cache {
+	if cfg.Memory == nil {
+		cfg.Memory = pokecache.NewCache(memoryCacheTTL)
+		cfg.Memory.SetLimits(memoryCacheEntries, memoryCacheBytes)
+	}
+	if cfg.Settings.CacheOff {
+		return cfg.Memory
+	}
+	stored, err := storedCache(cfg)
+	if err != nil {
+		fmt.Println("Error opening the cache, so responses are only kept in memory:", err)
+		return cfg.Memory
+	}
+	return pokecache.Layers{cfg.Memory, stored}
+}
+
+// storedCache is where PokeAPI responses are kept between sessions, with
+// the backend the player chose.
+func storedCache(cfg *config) (pokecache.Cache, error) {
+	return pokecache.Open(cfg.Settings.cacheBackend(), filepath.Join(cfg.Paths.Cache, "pokeapi"), cfg.Settings.cacheTTL())
+}
+
+func setCache(cfg *config, value string) error {
+	switch value {
+	case "on":
+		cfg.Settings.CacheOff = false
+		fmt.Println("PokeAPI responses are now kept on disk between sessions.")
+	case "off":
+		cfg.Settings.CacheOff = true
+		fmt.Println("PokeAPI responses are now only kept in memory.")
+	default:
+		fmt.Println("The disk cache can be on or off.")
+		return nil
+	}
+	cfg.API.SetCache(apiCache(cfg))
+	return saveSettings(cfg)
+}
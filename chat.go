@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/community"
+)
+
+// chatFeed follows the current chat channel in the background. Messages
+// are only printed while the player is at a prompt, above it, so they never
+// land in the middle of a command's output.
+type chatFeed struct {
+	mu      sync.Mutex
+	client  *community.Client
+	channel string
+	// after is the last message seen in the channel, or -1 until the feed
+	// has caught up with it.
+	after  int
+	prompt string
+	// redraw, if set, draws the prompt again instead of printing it.
+	redraw func()
+	// plain starts messages on a new line instead of clearing the prompt's,
+	// for screen readers.
+	plain   bool
+	pending []community.Message
+	// own holds the player's own messages, which were shown when sent.
+	own map[int]bool
+	// running are the server's community events, once known; started is
+	// called with each one that starts after that.
+	running map[string]bool
+	started func(name string)
+}
+
+func newChatFeed(server string, started func(name string)) *chatFeed {
+	f := &chatFeed{own: make(map[int]bool), started: started}
+	f.connect(server)
+	f.join(community.Lobby)
+	go f.run()
+	return f
+}
+
+// connect switches servers; an empty server turns chat off.
+func (f *chatFeed) connect(server string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.client = nil
+	if server != "" {
+		f.client = community.New(server)
+	}
+	f.after = -1
+	f.pending = nil
+	f.running = nil
+}
+
+// join moves the feed to another channel and returns the one it was on.
+func (f *chatFeed) join(channel string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	previous := f.channel
+	f.channel, f.after, f.pending = channel, -1, nil
+	return previous
+}
+
+func (f *chatFeed) current() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.channel
+}
+
+// waiting shows prompt, with any messages that arrived since the last one
+// above it, and lets new messages through until busy is called.
+func (f *chatFeed) waiting(prompt string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prompt, f.redraw = prompt, nil
+	f.flush()
+	fmt.Print(prompt)
+}
+
+// editing is waiting for a prompt the line editor draws; redraw draws it
+// again under new messages.
+func (f *chatFeed) editing(prompt string, redraw func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prompt, f.redraw = prompt, redraw
+	f.flush()
+}
+
+// plainly turns plain output on or off.
+func (f *chatFeed) plainly(on bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.plain = on
+}
+
+func (f *chatFeed) busy() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prompt, f.redraw = "", nil
+}
+
+// flush prints the pending messages. The caller holds the lock.
+func (f *chatFeed) flush() {
+	for _, m := range f.pending {
+		fmt.Printf("[%s] %s: %s\n", f.channel, community.Clean(m.Trainer), community.Clean(m.Text))
+	}
+	f.pending = nil
+}
+
+func (f *chatFeed) run() {
+	for {
+		time.Sleep(community.PollEvery)
+		f.mu.Lock()
+		client, channel, after := f.client, f.channel, f.after
+		f.mu.Unlock()
+		if client == nil {
+			continue
+		}
+		// Network trouble is not worth interrupting the player for; the
+		// next poll tries again.
+		messages, err := client.Messages(channel, max(after, 0))
+		if err != nil {
+			continue
+		}
+
+		f.mu.Lock()
+		if f.client != client || f.channel != channel {
+			f.mu.Unlock()
+			continue
+		}
+		for _, m := range messages {
+			f.after = max(f.after, m.ID)
+			if after >= 0 && !f.own[m.ID] {
+				f.pending = append(f.pending, m)
+			}
+		}
+		f.after = max(f.after, 0)
+		if f.prompt != "" && len(f.pending) > 0 {
+			// Clear the prompt line and draw it again under the messages.
+			if f.plain {
+				fmt.Println()
+			} else {
+				fmt.Print("\r\033[K")
+			}
+			f.flush()
+			if f.redraw != nil && !f.plain {
+				f.redraw()
+			} else {
+				fmt.Print(f.prompt)
+			}
+		}
+		f.mu.Unlock()
+		f.follow(client)
+	}
+}
+
+// follow looks for community events that have started on the server since
+// the last poll.
+func (f *chatFeed) follow(client *community.Client) {
+	names, err := client.Events()
+	if err != nil {
+		return
+	}
+	f.mu.Lock()
+	if f.client != client {
+		f.mu.Unlock()
+		return
+	}
+	var started []string
+	if f.running != nil {
+		for _, name := range names {
+			if !f.running[name] {
+				started = append(started, name)
+			}
+		}
+	}
+	f.running = make(map[string]bool)
+	for _, name := range names {
+		f.running[name] = true
+	}
+	f.mu.Unlock()
+	for _, name := range started {
+		f.started(name)
+	}
+}
+
+// say posts to the current channel.
+func (f *chatFeed) say(cfg *config, text string) error {
+	f.mu.Lock()
+	client, channel := f.client, f.channel
+	f.mu.Unlock()
+	if client == nil {
+		fmt.Println("Chat needs a community server; choose one with `set server <url>`.")
+		return nil
+	}
+	m, err := client.Say(channel, community.Player{Trainer: cfg.State.Trainer.Name, TrainerID: cfg.State.Trainer.ID}, text)
+	if err != nil {
+		fmt.Printf("Could not send your message: %v\n", err)
+		return nil
+	}
+	f.mu.Lock()
+	f.own[m.ID] = true
+	f.mu.Unlock()
+	fmt.Printf("[%s] %s: %s\n", channel, cfg.State.Trainer.Name, community.Clean(m.Text))
+	return nil
+}
+
+func commandSay(cfg *config, args []string) error {
+	text := strings.Join(args, " ")
+	if community.Clean(text) == "" {
+		fmt.Println("Usage: say <message>")
+		return nil
+	}
+	return cfg.Chat.say(cfg, text)
+}
@@ -0,0 +1,61 @@
+// Command examplebot is a reference battle AI for the Pokedex. Run a battle
+// against it with --ai external:./examplebot.
+//
+// It reads one JSON situation per line from stdin and answers with the
+// attack that does the most damage, unless its opponent is already low on
+// HP, in which case it uses its stronger raw stat to finish it off. Each
+// answer echoes the id of the situation it is for.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type stats struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"special_attack"`
+	SpDefense int `json:"special_defense"`
+}
+
+type view struct {
+	Stats stats `json:"stats"`
+	HP    int   `json:"hp"`
+}
+
+type situation struct {
+	ID       int  `json:"id"`
+	Self     view `json:"self"`
+	Opponent view `json:"opponent"`
+}
+
+func choose(s situation) string {
+	if s.Opponent.HP*4 < s.Opponent.Stats.HP {
+		if s.Self.Stats.SpAttack > s.Self.Stats.Attack {
+			return "special"
+		}
+		return "physical"
+	}
+	physical := s.Self.Stats.Attack * s.Opponent.Stats.SpDefense
+	special := s.Self.Stats.SpAttack * s.Opponent.Stats.Defense
+	if special > physical {
+		return "special"
+	}
+	return "physical"
+}
+
+func main() {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var s situation
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			fmt.Fprintln(os.Stderr, "examplebot:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("{\"id\":%d,\"action\":%q}\n", s.ID, choose(s))
+	}
+}
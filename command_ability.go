@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/fanout"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// abilityInfo is an ability as `ability` shows it.
+type abilityInfo struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
+	Effect string `json:"effect,omitempty"`
+	// Loading is set when the ability was still loading once fetchBudget
+	// had passed, so its effect is missing.
+	Loading bool `json:"loading,omitempty"`
+}
+
+func commandAbility(cfg *config, args []string) error {
+	pokemon, ok, err := lookUpPokemon(cfg, args[0])
+	if !ok {
+		return err
+	}
+	var names []string
+	for _, a := range pokemon.Abilities {
+		names = append(names, a.Ability.Name)
+	}
+	// As text, each ability is printed as soon as it comes.
+	text := textOutput(cfg)
+	if text && len(names) > 0 {
+		fmt.Printf("%s's abilities:\n", args[0])
+	}
+	var abilities []abilityInfo
+	results := fetchEach(cfg, "ability", names, fetchBudget, func(i int, r fanout.Result[pokeapi.AbilityDetails]) {
+		if r.Err != nil {
+			return
+		}
+		a := pokemon.Abilities[i]
+		abilities = append(abilities, abilityInfo{a.Ability.Name, a.IsHidden, r.Value.ShortEffect("en"), r.Late})
+		if text {
+			printAbility(abilities[len(abilities)-1])
+		}
+	})
+	for _, r := range results {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return show(cfg, abilities, func() {
+		if len(abilities) == 0 {
+			fmt.Printf("%s has no abilities.\n", args[0])
+		}
+	})
+}
+
+func printAbility(a abilityInfo) {
+	name := a.Name
+	if a.Hidden {
+		name += " (hidden)"
+	}
+	effect := a.Effect
+	if a.Loading {
+		effect = fmt.Sprintf("still loading after %s; run it again in a moment", fetchBudget)
+	}
+	fmt.Printf(" - %s: %s\n", name, effect)
+}
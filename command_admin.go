@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/eymardfreire/pokedexcli/internal/community"
+)
+
+// adminTokenEnv holds the community server's admin token for operators.
+const adminTokenEnv = "POKEDEXCLI_ADMIN_TOKEN"
+
+func commandAdmin(cfg *config, args []string) error {
+	client := communityClient(cfg, "The admin command")
+	if client == nil {
+		return nil
+	}
+	token := os.Getenv(adminTokenEnv)
+	if token == "" {
+		fmt.Printf("Admin commands need the server's admin token in %s.\n", adminTokenEnv)
+		return nil
+	}
+	client.Authorize(token)
+
+	var err error
+	switch args[0] {
+	case "spawn":
+		if err = client.Spawn(community.Spawn{Pokemon: args[1], Area: args[2]}); err == nil {
+			fmt.Printf("%s now appears in %s for everyone.\n", args[1], args[2])
+		}
+	case "grant":
+		if err = client.Grant(community.Grant{To: args[1], Item: args[2]}); err == nil {
+			fmt.Printf("%s will get a %s next time they play.\n", args[1], args[2])
+		}
+	case "event":
+		if err = client.StartEvent(args[2]); err == nil {
+			fmt.Printf("%s has started.\n", args[2])
+		}
+	case "stats":
+		var stats community.Stats
+		if stats, err = client.Stats(); err == nil {
+			fmt.Printf("Players: %d\nQueued for ranked: %d\nMatches played: %d\n", stats.Players, stats.Queued, stats.Matches)
+			for _, event := range stats.Events {
+				fmt.Printf("Running: %s\n", event)
+			}
+		}
+	}
+	if err != nil {
+		fmt.Printf("The server refused: %v\n", err)
+	}
+	return nil
+}
+
+// announceSpawns tells the player about the Pokémon the server's operators
+// put in an area. Without a server, offline, or if it can't be reached,
+// there are none.
+func announceSpawns(cfg *config, area string) {
+	if server(cfg) == "" {
+		return
+	}
+	spawns, err := community.New(server(cfg)).Spawns(area)
+	if err != nil {
+		return
+	}
+	for _, spawn := range spawns {
+		fmt.Printf("A wild %s appears, brought here for a community event!\n", spawn.Pokemon)
+	}
+}
+
+// communityEvents lists the events running on the player's server, if any.
+func communityEvents(cfg *config) []string {
+	if server(cfg) == "" {
+		return nil
+	}
+	events, _ := community.New(server(cfg)).Events()
+	return events
+}
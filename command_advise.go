@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/balance"
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+// adviseStatuses are the situations compared: no status, and the weaker
+// and stronger kinds of status.
+var adviseStatuses = []string{"", "paralysis", "sleep"}
+
+func commandAdvise(cfg *config, args []string) error {
+	count := cfg.Input.Flags["n"]
+	n := 10000
+	if count != "" {
+		var err error
+		if n, err = strconv.Atoi(count); err != nil || n < 1 || n > maxSimulations {
+			fmt.Printf("--n must be between 1 and %d.\n", maxSimulations)
+			return nil
+		}
+	}
+	name := args[1]
+	rate, err := captureRate(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	if len(capture.Strategies(cfg.State.Items, "")) == 0 {
+		fmt.Println("You have no Poké Balls to throw.")
+		return nil
+	}
+	fmt.Printf("Catching %s (capture rate %d) with what's in your bag, over %d attempts each:\n", name, rate, n)
+	for _, status := range adviseStatuses {
+		var outcomes []capture.Outcome
+		for _, s := range capture.Strategies(cfg.State.Items, status) {
+			outcomes = append(outcomes, capture.Simulate(s, rate, cfg.State.Items, n, cfg.RNG.Get(rng.World)))
+		}
+		best := capture.Best(outcomes)
+		situation := "As it is"
+		if status != "" {
+			situation = "If you inflict " + status
+		}
+		fmt.Printf("%s: %s\n", situation, describeStrategy(best.Strategy))
+		fmt.Printf(say(cfg, "  catches it %.1f%% of the time, using %.1f balls and ₽%.0f of items on average\n"), 100*best.Success, best.Throws, best.Cost)
+	}
+	return nil
+}
+
+func describeStrategy(s capture.Strategy) string {
+	how := "throw " + strings.Join(s.Balls, ", then ")
+	if len(s.Balls) > 1 {
+		how += " as each runs out"
+	}
+	if s.Berry != "" {
+		how += ", with a " + s.Berry + " before each throw"
+	}
+	return how
+}
+
+// captureRate is the species' capture rate, or an estimate from its base
+// experience if PokeAPI doesn't have one.
+func captureRate(cfg *config, name string) (int, error) {
+	species, err := cfg.API.GetSpecies(name)
+	if err != nil && !errors.Is(err, pokeapi.ErrNotFound) {
+		return 0, err
+	}
+	rate := species.CaptureRate
+	if rate == 0 {
+		pokemon, err := fetchPokemon(cfg, name)
+		if err != nil {
+			return 0, err
+		}
+		rate = capture.Estimate(missing.Fill(pokemon.Pokemon).BaseExperience)
+	}
+	return max(balance.Scale(rate, cfg.Balance.CatchRate), 1), nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/balance"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/growth"
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+// commandBattle fights one of the player's Pokémon against a wild one.
+// Winning earns experience.
+func commandBattle(cfg *config, args []string) error {
+	aiSpec := cfg.Input.Flags["ai"]
+	pokemon, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	wildName := args[1]
+	if ro := cfg.State.Roamer; ro.Active() && wildName == ro.Species && !roamerHere(cfg) {
+		fmt.Printf("There is no %s here. Try `whereis roaming`.\n", wildName)
+		return nil
+	}
+	wild, err := fetchPokemon(cfg, wildName)
+	if err != nil {
+		return err
+	}
+	// Wild Pokémon from the last explored area come at its levels; any
+	// other is a match for the player's.
+	level := levelOf(pokemon)
+	if levels, ok := cfg.Encounters[wild.Name]; ok && levels.Max > 0 {
+		level = levels.Min + cfg.RNG.Get(rng.Encounter).Intn(levels.Max-levels.Min+1)
+	}
+
+	ai, stopAI, err := startAI(aiSpec)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	defer stopAI()
+
+	player, err := combatantFor(cfg, pokemon, levelOf(pokemon))
+	if err != nil {
+		return err
+	}
+	foe, err := combatantFor(cfg, wild, level)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("A wild %s (Lv. %d) appeared! Go, %s (Lv. %d)!\n", foe.Name, foe.Level, player.Name, player.Level)
+	result, err := battleWith(cfg, player, foe, ai)
+	if err != nil || result.Winner != player {
+		return err
+	}
+	gainExperience(cfg, pokemon.Name, balance.Scale(growth.Gain(missing.Fill(wild.Pokemon).BaseExperience, level), cfg.Balance.BattleXP))
+	return nil
+}
+
+// gainExperience adds experience to one of the player's Pokémon and levels
+// it up as far as that goes. The Pokémon is looked up again rather than
+// passed in, so what the battle's events changed, like friendship, is
+// kept.
+func gainExperience(cfg *config, name string, gain int) {
+	pokemon := cfg.Caught[name]
+	level := levelOf(pokemon)
+	if pokemon.Experience == 0 {
+		pokemon.Experience = growth.Total(level)
+	}
+	pokemon.Experience += gain
+	pokemon.Level = growth.Level(pokemon.Experience)
+	fmt.Printf("%s gained %d experience.\n", pokemon.Name, gain)
+	if pokemon.Level > level {
+		fmt.Printf("%s grew to Lv. %d!\n", pokemon.Name, pokemon.Level)
+	}
+	cfg.Caught[pokemon.Name] = pokemon
+	if pokemon.Level > level {
+		cfg.Events.Publish(events.Event{Kind: events.PokemonLevelled, Subject: pokemon.Name, Count: pokemon.Level})
+	}
+}
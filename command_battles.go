@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/eymardfreire/pokedexcli/internal/netplay"
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+func replaysPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "battles.json")
+}
+
+func loadReplays(cfg *config) ([]netplay.Replay, error) {
+	var replays []netplay.Replay
+	err := storage.ReadJSON(replaysPath(cfg), &replays)
+	return replays, err
+}
+
+// keepReplay saves a finished duel so it can be verified later.
+func keepReplay(cfg *config, replay netplay.Replay) error {
+	replays, err := loadReplays(cfg)
+	if err != nil {
+		return err
+	}
+	replay.ID = 1
+	if len(replays) > 0 {
+		replay.ID = replays[len(replays)-1].ID + 1
+	}
+	replays = append(replays, replay)
+	if err := storage.WriteJSON(replaysPath(cfg), replays); err != nil {
+		return err
+	}
+	fmt.Printf("Saved as battle %d.\n", replay.ID)
+	return nil
+}
+
+func commandBattles(cfg *config, args []string) error {
+	replays, err := loadReplays(cfg)
+	if err != nil {
+		return err
+	}
+	if len(args) >= 2 && args[0] == "verify" {
+		return verifyBattle(replays, args[1])
+	}
+	if len(args) > 0 {
+		fmt.Println("Usage: battles | battles verify <id>")
+		return nil
+	}
+
+	if len(replays) == 0 {
+		fmt.Println("No duels played yet.")
+		return nil
+	}
+	for _, r := range replays {
+		fmt.Printf("%3d  %s  %s's %s vs %s's %s: %s\n", r.ID, r.Played.Format("2006-01-02 15:04"),
+			r.Host.Trainer, r.Host.Fighter.Name, r.Guest.Trainer, r.Guest.Fighter.Name, r.Outcome)
+	}
+	return nil
+}
+
+func verifyBattle(replays []netplay.Replay, arg string) error {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Println("Battle IDs are numbers; see `battles`.")
+		return nil
+	}
+	for _, r := range replays {
+		if r.ID != id {
+			continue
+		}
+		if err := r.Verify(); err != nil {
+			fmt.Printf("Battle %d does not replay the same way: %v\n", id, err)
+			return nil
+		}
+		fmt.Printf("Battle %d replays exactly as recorded: %s.\n", id, r.Summary)
+		return nil
+	}
+	fmt.Printf("There is no battle %d.\n", id)
+	return nil
+}
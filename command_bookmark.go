@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+func commandBookmark(cfg *config, args []string) error {
+	switch {
+	case len(args) >= 3 && args[0] == "add":
+		area, name := args[1], args[2]
+		if old, exists := cfg.State.Bookmarks[name]; exists && old != area {
+			fmt.Printf("%s already points to %s; remove it first.\n", name, old)
+			return nil
+		}
+		cfg.State.Bookmarks[name] = area
+		fmt.Printf("Bookmarked %s as %s. Use `goto %s` to travel there.\n", area, name, name)
+		return saveState(cfg)
+	case len(args) >= 2 && args[0] == "remove":
+		if _, exists := cfg.State.Bookmarks[args[1]]; !exists {
+			fmt.Printf("There is no bookmark called %s.\n", args[1])
+			return nil
+		}
+		delete(cfg.State.Bookmarks, args[1])
+		fmt.Printf("Removed %s.\n", args[1])
+		return saveState(cfg)
+	case len(args) >= 1 && args[0] == "list":
+		if len(cfg.State.Bookmarks) == 0 {
+			fmt.Println("No bookmarks yet.")
+			return nil
+		}
+		names := make([]string, 0, len(cfg.State.Bookmarks))
+		for name := range cfg.State.Bookmarks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf(" - %s: %s\n", name, cfg.State.Bookmarks[name])
+		}
+		return nil
+	default:
+		fmt.Println("Usage: bookmark add <area_name> <name> | bookmark list | bookmark remove <name>")
+		return nil
+	}
+}
+
+// commandGoto travels to a bookmark, or to an area by name, and explores it.
+func commandGoto(cfg *config, args []string) error {
+	area := args[0]
+	if bookmarked, ok := cfg.State.Bookmarks[area]; ok {
+		area = bookmarked
+	}
+	return commandExplore(cfg, []string{area})
+}
@@ -0,0 +1,47 @@
+package main
+
+import "fmt"
+
+// mediaQuota is how much space downloaded sprites and cries may take.
+const mediaQuota = 100 << 20
+
+func commandCache(cfg *config, args []string) error {
+	switch args[1] {
+	case "stats":
+		s, err := cfg.Media.Stats()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d files, %.1f MB of %.0f MB\n", s.Files, megabytes(s.Bytes), megabytes(s.Quota))
+	case "purge":
+		n, err := cfg.Media.Purge()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed %d files.\n", n)
+	default:
+		fmt.Println("Usage: cache media stats|purge")
+	}
+	return nil
+}
+
+func commandCacheStats(cfg *config, args []string) error {
+	s := cfg.Memory.Stats()
+	fmt.Printf("Entries: %d of %d\n", s.Entries, memoryCacheEntries)
+	fmt.Printf("Size: %.1f MB of %.0f MB\n", megabytes(s.Bytes), megabytes(memoryCacheBytes))
+	hitRate := 0.0
+	if s.Hits+s.Misses > 0 {
+		hitRate = 100 * float64(s.Hits) / float64(s.Hits+s.Misses)
+	}
+	fmt.Printf("Hits: %d\nMisses: %d (%.0f%% hit rate)\nEvictions: %d\n", s.Hits, s.Misses, hitRate, s.Evictions)
+	if !cfg.Settings.CacheOff {
+		if stored, err := storedCache(cfg); err == nil {
+			fmt.Printf("Stored (%s): %d entries\n", cfg.Settings.cacheBackend(), stored.Len())
+		}
+	}
+	return nil
+}
+
+func megabytes(n int64) float64 {
+	return float64(n) / (1 << 20)
+}
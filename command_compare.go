@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/eymardfreire/pokedexcli/internal/compare"
+)
+
+// comparedRow is a row of `compare`, as it shows it in other formats.
+type comparedRow struct {
+	Row    string `json:"row"`
+	Left   string `json:"left"`
+	Right  string `json:"right"`
+	Better string `json:"better,omitempty"`
+}
+
+// betterMark points at the Pokémon ahead in a row.
+var betterMark = map[compare.Side]string{compare.Left: "◀", compare.Right: "▶"}
+
+func commandCompare(cfg *config, args []string) error {
+	left, ok, err := lookUpPokemon(cfg, args[0])
+	if !ok {
+		return err
+	}
+	right, ok, err := lookUpPokemon(cfg, args[1])
+	if !ok {
+		return err
+	}
+	rows := compare.Rows(left.Pokemon, right.Pokemon)
+	var compared []comparedRow
+	for _, r := range rows {
+		row := comparedRow{Row: r.Label, Left: r.Left, Right: r.Right}
+		switch r.Better {
+		case compare.Left:
+			row.Better = args[0]
+		case compare.Right:
+			row.Better = args[1]
+		}
+		compared = append(compared, row)
+	}
+	return show(cfg, compared, func() {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "\t%s\t%s\t\n", displayName(left), displayName(right))
+		for _, r := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Label, r.Left, r.Right, say(cfg, betterMark[r.Better]))
+		}
+		tw.Flush()
+		l, r := compare.Wins(rows)
+		switch {
+		case l > r:
+			fmt.Printf("%s is ahead in %d rows to %d.\n", args[0], l, r)
+		case r > l:
+			fmt.Printf("%s is ahead in %d rows to %d.\n", args[1], r, l)
+		default:
+			fmt.Printf("They are even, ahead in %d rows each.\n", l)
+		}
+	})
+}
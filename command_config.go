@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+)
+
+// setting is one of the player's settings as `config show` lists it.
+type setting struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// File is where the setting is kept: config.json for how the program
+	// behaves, save.json for how the game plays.
+	File string `json:"file"`
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// allSettings lists every setting set can change, in the order set lists
+// them.
+func allSettings(cfg *config) []setting {
+	s, p := cfg.State, cfg.Settings
+	gen := "latest"
+	if s.Generation != 0 {
+		gen = strconv.Itoa(s.Generation)
+	}
+	player, server, format := p.Player, s.Server, p.Format
+	if player == "" {
+		player = "off"
+	}
+	if server == "" {
+		server = "off"
+	}
+	if format == "" {
+		format = "text"
+	}
+	color := p.Color
+	if color == "" {
+		color = "auto"
+	}
+	glyphs := p.Glyphs
+	if glyphs == "" {
+		glyphs = fmt.Sprintf("auto (%s)", p.glyphs())
+	}
+	game, program := filepath.Base(statePath(cfg)), filepath.Base(settingsPath(cfg))
+	return []setting{
+		{"generation", gen, game},
+		{"stamina", onOff(!s.StaminaOff), game},
+		{"cache", onOff(!p.CacheOff), program},
+		{"player", player, program},
+		{"server", server, game},
+		{"format", format, program},
+		{"sprites", onOff(!p.SpritesOff), program},
+		{"timeout", p.requestTimeout().String(), program},
+		{"tips", onOff(p.Tips), program},
+		{"dryrun", onOff(s.DryRun), game},
+		{"fakemon", onOff(s.Fakemon), game},
+		{"pagesize", strconv.Itoa(p.pageSize()), program},
+		{"cachettl", p.cacheTTL().String(), program},
+		{"cachebackend", p.cacheBackend(), program},
+		{"color", color, program},
+		{"glyphs", glyphs, program},
+		{"api", apiURL(cfg), program},
+		{"accessible", onOff(p.Accessible), program},
+	}
+}
+
+func commandConfig(cfg *config, args []string) error {
+	settings := allSettings(cfg)
+	return show(cfg, settings, func() {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+		for _, s := range settings {
+			fmt.Fprintf(tw, "%s\t%s\n", s.Name, s.Value)
+		}
+		tw.Flush()
+		fmt.Println("Change them with `set <setting> <value>`. `paths` shows where they are kept.")
+	})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// commandCry plays a Pokémon's cry with the player's audio player, or
+// shows where the file is if they haven't set one.
+func commandCry(cfg *config, args []string) error {
+	legacy := cfg.Input.Flags["legacy"] != ""
+	pokemon, err := fetchPokemon(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	url := pokemon.Cries.Latest
+	if legacy && pokemon.Cries.Legacy != "" {
+		url = pokemon.Cries.Legacy
+	}
+	if url == "" {
+		fmt.Printf("PokeAPI has no cry for %s.\n", pokemon.Name)
+		return nil
+	}
+	path, err := cfg.Media.Fetch(url)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Settings.Player == "" {
+		fmt.Printf("%s's cry is at %s\n", pokemon.Name, path)
+		fmt.Println("Use `set player <command>` to play cries, e.g. `set player mpv --really-quiet`.")
+		return nil
+	}
+	fields := strings.Fields(cfg.Settings.Player)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		fmt.Printf("Could not play the cry with %s: %v\n", fields[0], err)
+		if len(out) > 0 {
+			fmt.Print(string(out))
+		}
+		fmt.Printf("The file is at %s\n", path)
+	}
+	return nil
+}
+
+func setPlayer(cfg *config, value string) error {
+	switch value {
+	case "":
+		fmt.Println("Give the command to play cries with, like `set player mpv --really-quiet`, or off.")
+		return nil
+	case "off":
+		cfg.Settings.Player = ""
+		fmt.Println("Cries are no longer played; `cry` shows where the file is.")
+	default:
+		cfg.Settings.Player = value
+		fmt.Printf("Cries are now played with `%s <file>`.\n", value)
+	}
+	return saveSettings(cfg)
+}
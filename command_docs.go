@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/docs"
+)
+
+// commandDocs shows a page of the docs. Long pages are paged at a
+// terminal like any long output; see paged.
+func commandDocs(cfg *config, args []string) error {
+	if len(args) < 1 {
+		fmt.Println("Available topics:")
+		for _, topic := range docs.Topics() {
+			fmt.Printf(" - %s\n", topic)
+		}
+		fmt.Println("Use `docs <topic>` to read one.")
+		return nil
+	}
+
+	page, err := docs.Page(args[0])
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	fmt.Println(strings.TrimRight(page, "\n"))
+	return nil
+}
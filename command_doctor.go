@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/schema"
+)
+
+// probe is a well-known resource doctor fetches, with the struct the game
+// decodes it into.
+type probe struct {
+	path string
+	into any
+	// skip lists fields PokeAPI may leave empty for this resource, and
+	// fields the game adds itself.
+	skip []string
+}
+
+var probes = []probe{
+	{"location-area/", &pokeapi.LocationAreaList{}, []string{"previous"}},
+	{"location-area/canalave-city-area/", &pokeapi.LocationArea{}, nil},
+	{"pokemon/pikachu/", &pokeapi.Pokemon{}, nil},
+	{"pokemon-species/pikachu/", &pokeapi.Species{}, []string{"evolution_chain.name"}},
+	// Machines are only linked by URL.
+	{"move/thunderbolt/", &moves.Move{}, []string{
+		"stat_changes", "meta.min_hits", "meta.max_hits", "machines[].machine.name",
+	}},
+}
+
+// commandDoctor checks PokeAPI still returns what the game expects, since
+// a renamed field would otherwise just look like missing data. It skips
+// the cache so it always sees what PokeAPI returns now.
+func commandDoctor(cfg *config, args []string) error {
+	client := &http.Client{Timeout: 15 * time.Second}
+	failed := 0
+	for _, p := range probes {
+		problems, err := checkProbe(client, cfg.API.BaseURL+"/"+p.path, p)
+		switch {
+		case err != nil:
+			fmt.Printf("  FAIL  %s: %v\n", p.path, err)
+			failed++
+		case len(problems) > 0:
+			fmt.Printf("  WARN  %s\n", p.path)
+			for _, problem := range problems {
+				fmt.Printf("          %s\n", problem)
+			}
+			failed++
+		default:
+			fmt.Printf("  ok    %s\n", p.path)
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d checks found problems; PokeAPI may have changed.\n", failed, len(probes))
+		return nil
+	}
+	fmt.Println("PokeAPI looks the way the game expects.")
+	return nil
+}
+
+func checkProbe(client *http.Client, url string, p probe) ([]schema.Problem, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got %s", response.Status)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Check(data, p.into, p.skip...)
+}
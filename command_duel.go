@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+	"github.com/eymardfreire/pokedexcli/internal/elo"
+	"github.com/eymardfreire/pokedexcli/internal/netplay"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+// hostTimeout is how long a host waits for someone to join.
+const hostTimeout = 5 * time.Minute
+
+func commandDuel(cfg *config, args []string) error {
+	port := cfg.Input.Flags["port"]
+	switch {
+	case len(args) >= 2 && args[0] == "host":
+		return duelHost(cfg, args[1], port)
+	case len(args) >= 3 && args[0] == "join":
+		return duelJoin(cfg, args[1], args[2])
+	case len(args) >= 2 && args[0] == "watch":
+		return duelWatch(cfg, args[1])
+	case len(args) >= 1 && args[0] == "record":
+		record := cfg.State.Duels
+		fmt.Printf("Rating: %d\n", record.Current())
+		fmt.Printf("Record: %d won, %d lost, %d drawn\n", record.Wins, record.Losses, record.Draws)
+		return nil
+	default:
+		fmt.Println("Usage: duel host <pokemon_name> [--port <port>] | duel join <host:port> <pokemon_name> | duel watch <host:port> | duel record")
+		return nil
+	}
+}
+
+// duelist returns the combatant for one of the player's Pokémon, or nil if
+// they haven't caught it.
+func duelist(cfg *config, name string) (*battle.Combatant, error) {
+	pokemon, exists := cfg.Caught[name]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil, nil
+	}
+	return combatantFor(cfg, pokemon, levelOf(pokemon))
+}
+
+func duelHello(cfg *config, player *battle.Combatant) netplay.Hello {
+	return netplay.Hello{
+		Trainer:   cfg.State.Trainer.Name,
+		TrainerID: cfg.State.Trainer.ID,
+		Rating:    cfg.State.Duels.Current(),
+		Fighter:   netplay.FighterOf(player),
+	}
+}
+
+func duelHost(cfg *config, name, port string) error {
+	player, err := duelist(cfg, name)
+	if player == nil {
+		return err
+	}
+	if port == "" {
+		port = strconv.Itoa(netplay.DefaultPort)
+	}
+	l, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		fmt.Printf("Could not host on port %s: %v\n", port, err)
+		return nil
+	}
+	defer l.Close()
+	fmt.Printf("Waiting for a challenger on port %s...\n", port)
+	_, _, err = hostDuel(cfg, player, l)
+	return err
+}
+
+// hostDuel waits on l for a guest and plays the duel with them. It reports
+// whether a duel was played and, if so, the player's score.
+func hostDuel(cfg *config, player *battle.Combatant, l net.Listener) (float64, bool, error) {
+	host := netplay.NewHost(l)
+	defer host.Relay.Close()
+
+	start := netplay.Start{Seed: cfg.RNG.Get(rng.World).Int63(), Generation: cfg.State.generation()}
+	me := duelHello(cfg, player)
+	conn, opponent, err := host.WaitForGuest(me, start, hostTimeout)
+	if errors.Is(err, netplay.ErrTimeout) {
+		fmt.Println("Nobody joined.")
+		return 0, false, nil
+	}
+	if err != nil {
+		fmt.Printf("Could not start the duel: %v\n", err)
+		return 0, false, nil
+	}
+	defer conn.Close()
+
+	host.Relay.Broadcast(netplay.Message{Type: netplay.TypeMatch, Match: &netplay.Match{Host: me, Guest: opponent}})
+	score, err := runDuel(cfg, conn, player, opponent, start, host.Relay)
+	return score, err == nil, err
+}
+
+func duelJoin(cfg *config, addr, name string) error {
+	player, err := duelist(cfg, name)
+	if player == nil {
+		return err
+	}
+	_, _, err = joinDuel(cfg, player, addr)
+	return err
+}
+
+// joinDuel plays a duel hosted at addr, reporting like hostDuel.
+func joinDuel(cfg *config, player *battle.Combatant, addr string) (float64, bool, error) {
+	if !strings.Contains(addr, ":") {
+		addr = fmt.Sprintf("%s:%d", addr, netplay.DefaultPort)
+	}
+	conn, err := netplay.Dial(addr)
+	if err != nil {
+		fmt.Printf("Could not connect to %s: %v\n", addr, err)
+		return 0, false, nil
+	}
+	defer conn.Close()
+
+	opponent, start, err := conn.Join(duelHello(cfg, player))
+	if err != nil {
+		fmt.Printf("Could not start the duel: %v\n", err)
+		return 0, false, nil
+	}
+	score, err := runDuel(cfg, conn, player, opponent, start, nil)
+	return score, err == nil, err
+}
+
+// duelWatch follows a duel hosted at addr as it happens.
+func duelWatch(cfg *config, addr string) error {
+	if !strings.Contains(addr, ":") {
+		addr = fmt.Sprintf("%s:%d", addr, netplay.DefaultPort)
+	}
+	conn, err := netplay.Watch(addr, netplay.Hello{Trainer: cfg.State.Trainer.Name, TrainerID: cfg.State.Trainer.ID})
+	if err != nil {
+		fmt.Printf("Could not connect to %s: %v\n", addr, err)
+		return nil
+	}
+	defer conn.Close()
+
+	fmt.Println("Waiting for the duel to start...")
+	printer := newTurnPrinter()
+	for {
+		// Turns can take as long as both players take to choose.
+		m, err := conn.Receive(2*netplay.TurnTimeout + netplay.HandshakeTimeout)
+		if err != nil {
+			fmt.Println("The duel ended unexpectedly.")
+			return nil
+		}
+		switch m.Type {
+		case netplay.TypeMatch:
+			host, guest := m.Match.Host, m.Match.Guest
+			fmt.Printf("%s's %s (Lv. %d) vs %s's %s (Lv. %d)\n", host.Trainer, host.Fighter.Name, host.Fighter.Level, guest.Trainer, guest.Fighter.Name, guest.Fighter.Level)
+		case netplay.TypeTurn:
+			printer.print(*m.Turn)
+		case netplay.TypeEnd:
+			fmt.Println(m.Outcome)
+			return nil
+		}
+	}
+}
+
+// runDuel plays out a duel in lockstep with the other instance. The host's
+// Pokémon always goes first in the simulation so both sides run it the same
+// way. Only the host has a relay, which spectators watch through. It returns
+// the player's score.
+func runDuel(cfg *config, conn *netplay.Conn, player *battle.Combatant, them netplay.Hello, start netplay.Start, relay *netplay.Relay) (float64, error) {
+	foe := them.Fighter.Combatant()
+	fmt.Printf("%s (rating %d) sent out %s (Lv. %d)!\n", them.Trainer, them.Rating, foe.Name, foe.Level)
+	// Both sides share the seed, so it names the duel's chat channel.
+	if server(cfg) != "" {
+		lobby := cfg.Chat.join(fmt.Sprintf("duel-%d", start.Seed))
+		defer cfg.Chat.join(lobby)
+		fmt.Printf("Type `say <message>` to chat with %s.\n", them.Trainer)
+	}
+
+	me := &netplay.Recorder{Controller: &prompt{cfg: cfg, conn: conn, printer: newTurnPrinter(), relay: relay}}
+	remote := &netplay.Recorder{Controller: netplay.Remote{Conn: conn, Timeout: netplay.TurnTimeout}}
+	replay := netplay.Replay{Played: time.Now(), Host: duelHello(cfg, player), Guest: them, Start: start}
+	a, b := player, foe
+	ca, cb := me, remote
+	if relay == nil {
+		replay.Host, replay.Guest = them, replay.Host
+		a, b = foe, player
+		ca, cb = remote, me
+	}
+	result, err := battle.FightWith(a, b, ca, cb, battle.Conditions{Generation: start.Generation}, rand.New(rand.NewSource(start.Seed)))
+	replay.HostActions, replay.GuestActions = ca.Actions, cb.Actions
+	replay.Summary = netplay.Summarize(result, a, b)
+
+	var score float64
+	var ending string
+	switch {
+	case errors.Is(err, netplay.ErrForfeit):
+		fmt.Println("You forfeited the duel.")
+		score, ending = elo.Loss, fmt.Sprintf("%s forfeited.", cfg.State.Trainer.Name)
+	case errors.Is(err, netplay.ErrOpponentForfeited), errors.Is(err, netplay.ErrTimeout), errors.Is(err, netplay.ErrDisconnected):
+		fmt.Printf("You win: %v.\n", errors.Unwrap(err))
+		score, ending = elo.Win, fmt.Sprintf("%s forfeited.", them.Trainer)
+	case err != nil:
+		return 0, err
+	default:
+		ending = outcome(result)
+		fmt.Println(ending)
+		reportBattle(cfg, player, foe, result)
+		switch {
+		case result.Winner == player:
+			score = elo.Win
+		case result.Winner == nil:
+			score = elo.Draw
+		default:
+			score = elo.Loss
+		}
+	}
+
+	if relay != nil {
+		relay.Broadcast(netplay.Message{Type: netplay.TypeEnd, Outcome: ending})
+	}
+	replay.Outcome = ending
+	if err := keepReplay(cfg, replay); err != nil {
+		return score, err
+	}
+
+	change := cfg.State.Duels.Add(them.Rating, score)
+	fmt.Printf("Your rating is now %d (%+d).\n", cfg.State.Duels.Current(), change)
+	return score, saveState(cfg)
+}
+
+// prompt asks the local player what to do each turn and tells the other
+// instance. On the host it also passes every turn on to spectators.
+type prompt struct {
+	cfg     *config
+	conn    *netplay.Conn
+	printer *turnPrinter
+	relay   *netplay.Relay
+}
+
+func (p *prompt) Observe(turn battle.Turn) {
+	p.printer.print(turn)
+	if p.relay != nil {
+		p.relay.Broadcast(netplay.Message{Type: netplay.TypeTurn, Turn: &turn})
+	}
+}
+
+func (p *prompt) Choose(s battle.Situation) (battle.Action, error) {
+	fmt.Printf("%s: %s vs %s: %s\n", s.Self.Name, hpText(p.cfg, s.Self.HP, s.Self.Stats.HP), s.Opponent.Name, hpText(p.cfg, s.Opponent.HP, s.Opponent.Stats.HP))
+	options := []battle.Action{battle.Physical, battle.Special}
+	if len(s.Self.Moves) > 0 {
+		options = nil
+		for _, m := range s.Self.Moves {
+			options = append(options, battle.Action(m.Name))
+		}
+	}
+	for i, option := range options {
+		fmt.Printf("  %d) %s\n", i+1, option)
+	}
+
+	asked := time.Now()
+	for {
+		p.cfg.Chat.waiting("What will you do? (number, name or forfeit) ")
+		line, err := p.cfg.In.ReadString('\n')
+		p.cfg.Chat.busy()
+		if err != nil {
+			p.conn.Forfeit()
+			return "", netplay.ErrForfeit
+		}
+		if time.Since(asked) > netplay.TurnTimeout {
+			return "", fmt.Errorf("you took too long: %w", netplay.ErrForfeit)
+		}
+
+		choice := strings.TrimSpace(line)
+		if text, ok := strings.CutPrefix(choice, "say "); ok {
+			if err := p.cfg.Chat.say(p.cfg, text); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if choice == "forfeit" {
+			p.conn.Forfeit()
+			return "", netplay.ErrForfeit
+		}
+		action := battle.Action(choice)
+		if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(options) {
+			action = options[n-1]
+		}
+		if s.Allows(action) {
+			return action, p.conn.SendAction(action)
+		}
+		fmt.Println("You can't do that.")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/spawn"
+)
+
+func commandEncounter(cfg *config, args []string) error {
+	areaName := cfg.Area
+	if areaName == "" {
+		areaName = cfg.State.Explored
+	}
+	if areaName == "" {
+		fmt.Println("Explore an area first; wild Pokémon come from the area you last explored.")
+		return nil
+	}
+	area, err := cfg.API.GetLocationArea(areaName)
+	if err != nil {
+		return err
+	}
+	wild, ok := spawn.Pick(encounterSlots(area), cfg.RNG.Get(rng.Encounter))
+	if !ok {
+		fmt.Printf("No wild Pokémon live in %s.\n", areaName)
+		return nil
+	}
+
+	// Catching it gets the Pokémon that appeared, at its level, met here.
+	if cfg.Area != areaName {
+		cfg.Area = areaName
+		cfg.Encounters = make(map[string]levelRange)
+		for _, encounter := range area.PokemonEncounters {
+			cfg.Encounters[encounter.Pokemon.Name] = encounterLevels(encounter)
+		}
+	}
+	cfg.Encounters[wild.Pokemon] = levelRange{Min: wild.Level, Max: wild.Level}
+
+	fmt.Printf("A wild %s (Lv. %d) appeared (%s)! Try `catch %s`.\n", wild.Pokemon, wild.Level, wild.Method, wild.Pokemon)
+	cfg.Events.Publish(events.Event{Kind: events.PokemonSeen, Subject: wild.Pokemon})
+	return nil
+}
+
+// encounterSlots are an area's encounter slots in one version: the one
+// with the most slots, as PokeAPI knows it best. Walking encounters are
+// used if there are any, since that is how the player gets around;
+// otherwise every method is.
+func encounterSlots(area pokeapi.LocationArea) []spawn.Slot {
+	byVersion := make(map[string][]spawn.Slot)
+	var versions []string
+	for _, encounter := range area.PokemonEncounters {
+		for _, version := range encounter.VersionDetails {
+			name := version.Version.Name
+			if _, ok := byVersion[name]; !ok {
+				versions = append(versions, name)
+			}
+			for _, detail := range version.EncounterDetails {
+				byVersion[name] = append(byVersion[name], spawn.Slot{
+					Pokemon:  encounter.Pokemon.Name,
+					Method:   detail.Method.Name,
+					Chance:   detail.Chance,
+					MinLevel: detail.MinLevel,
+					MaxLevel: detail.MaxLevel,
+				})
+			}
+		}
+	}
+	var slots []spawn.Slot
+	for _, version := range versions {
+		if len(byVersion[version]) > len(slots) {
+			slots = byVersion[version]
+		}
+	}
+
+	var walking []spawn.Slot
+	for _, slot := range slots {
+		if slot.Method == "walk" {
+			walking = append(walking, slot)
+		}
+	}
+	if len(walking) > 0 {
+		return walking
+	}
+	return slots
+}
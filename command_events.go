@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/seasons"
+)
+
+func commandEvents(cfg *config, args []string) error {
+	now := time.Now()
+	fmt.Println("Seasonal events:")
+	for _, e := range cfg.Seasons {
+		status := ""
+		if e.ActiveOn(now) {
+			status = " (active now)"
+		}
+		fmt.Printf(" - %s, %s to %s%s\n", e.Name, e.Start, e.End, status)
+	}
+	if running := communityEvents(cfg); len(running) > 0 {
+		fmt.Println("Community events on your server:")
+		for _, name := range running {
+			fmt.Printf(" - %s (active now)\n", name)
+		}
+	}
+	return nil
+}
+
+func announceSeasons(cfg *config) {
+	for _, e := range seasons.Active(cfg.Seasons, time.Now()) {
+		fmt.Printf("%s is on! %s\n", e.Name, e.Message)
+	}
+}
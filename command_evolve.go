@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/evolution"
+)
+
+func commandEvolve(cfg *config, args []string) error {
+	pokemon, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return errRefused
+	}
+	pokemon = syncFriendship(cfg, pokemon)
+	chain, err := fetchEvolutionChain(cfg, pokemon.Name)
+	if err != nil {
+		return err
+	}
+	next := chain.Next(pokemon.Name)
+	if len(args) > 1 {
+		next = onlyInto(next, args[1])
+		if len(next) == 0 {
+			fmt.Printf("%s doesn't evolve into %s.\n", pokemon.Name, args[1])
+			return errRefused
+		}
+	}
+	if len(next) == 0 {
+		fmt.Printf("%s doesn't evolve any further.\n", pokemon.Name)
+		return errRefused
+	}
+
+	status := evolution.Status{Level: levelOf(pokemon), Friendship: pokemon.Friendship, Items: cfg.State.Items}
+	var ready []evolution.Evolution
+	for _, evo := range next {
+		if len(evolution.Missing(evo.Detail, status)) == 0 {
+			ready = append(ready, evo)
+		}
+	}
+	switch {
+	case len(ready) == 0:
+		fmt.Printf("%s isn't ready to evolve:\n", pokemon.Name)
+		for _, evo := range next {
+			fmt.Printf(" - into %s, it needs %s\n", evo.Species, strings.Join(evolution.Missing(evo.Detail, status), " and "))
+		}
+		return errRefused
+	case len(ready) > 1 && !sameSpecies(ready):
+		fmt.Printf("%s can evolve into more than one Pokémon. Choose one with `evolve %s <species>`:\n", pokemon.Name, pokemon.Name)
+		for _, evo := range ready {
+			fmt.Printf(" - %s\n", evo.Species)
+		}
+		return errRefused
+	}
+
+	evo := ready[0]
+	if _, owned := cfg.Caught[evo.Species]; owned {
+		fmt.Printf("You already have a %s; release or trade it first.\n", evo.Species)
+		return errRefused
+	}
+	if _, err := evolvePokemon(cfg, pokemon, evo.Species); err != nil {
+		return err
+	}
+	if item := evo.Detail.Item; evo.Detail.Trigger.Name == evolution.TriggerUseItem && item != nil {
+		cfg.State.Items[item.Name]--
+		fmt.Printf("Used a %s.\n", item.Name)
+	}
+	return saveState(cfg)
+}
+
+// onlyInto keeps the evolutions into species.
+func onlyInto(next []evolution.Evolution, species string) []evolution.Evolution {
+	var into []evolution.Evolution
+	for _, evo := range next {
+		if evo.Species == species {
+			into = append(into, evo)
+		}
+	}
+	return into
+}
+
+// sameSpecies reports whether every evolution is into the same species, by
+// different means.
+func sameSpecies(evos []evolution.Evolution) bool {
+	for _, evo := range evos {
+		if evo.Species != evos[0].Species {
+			return false
+		}
+	}
+	return true
+}
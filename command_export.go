@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// exportFormat names the files export writes in JSON, and exportVersion
+// is their version; import reads that version and those before it.
+const (
+	exportFormat  = "pokedexcli"
+	exportVersion = 1
+)
+
+// exported is a Pokedex as export writes it in JSON: every caught Pokémon
+// whole, sorted by name, so the same Pokedex is always written the same
+// way and import can bring each back as it was.
+type exported struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	Trainer    trainerID `json:"trainer"`
+	ExportedAt time.Time `json:"exported_at"`
+	Pokemon    []Pokemon `json:"pokemon"`
+}
+
+// exportColumns head the CSV export. The first three are what import
+// reads back from a CSV file; the rest are for spreadsheets.
+var exportColumns = []string{"species", "level", "moves", "nickname", "shiny", "size", "friendship", "caught_at", "met_at", "original_trainer"}
+
+func commandExport(cfg *config, args []string) error {
+	format := cfg.Input.Flags["format"]
+	path := args[0]
+	if format == "" {
+		format = "json"
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = "csv"
+		}
+	}
+	var caught []Pokemon
+	for _, pokemon := range cfg.Caught {
+		caught = append(caught, pokemon)
+	}
+	sort.Slice(caught, func(i, j int) bool { return caught[i].Name < caught[j].Name })
+
+	var err error
+	switch format {
+	case "json":
+		err = storage.WriteJSON(path, exported{
+			Format:     exportFormat,
+			Version:    exportVersion,
+			Trainer:    cfg.State.Trainer,
+			ExportedAt: time.Now(),
+			Pokemon:    caught,
+		})
+	case "csv":
+		err = exportCSV(path, caught)
+	default:
+		fmt.Printf("Unknown format %s; try json or csv.\n", format)
+		return nil
+	}
+	if err != nil {
+		fmt.Printf("Could not write %s: %v\n", path, err)
+		return nil
+	}
+	fmt.Printf("Exported %d Pokémon to %s.\n", len(caught), path)
+	return nil
+}
+
+func exportCSV(path string, caught []Pokemon) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(exportColumns)
+	for _, p := range caught {
+		w.Write([]string{
+			p.Name,
+			strconv.Itoa(levelOf(p)),
+			strings.Join(knownMoves(p, levelOf(p)), ";"),
+			p.Nickname,
+			strconv.FormatBool(p.Shiny),
+			strconv.FormatFloat(p.Size, 'f', 2, 64),
+			strconv.Itoa(p.Friendship),
+			p.CaughtAt.Format(time.RFC3339),
+			p.MetAt,
+			p.OriginalTrainer.Name,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/farm"
+)
+
+func commandFarm(cfg *config, args []string) error {
+	now := time.Now()
+	switch args[0] {
+	case "status":
+		printFarmStatus(cfg.State.Farm, now)
+		return nil
+	case "plant":
+		if len(args) < 2 {
+			fmt.Println("Please specify a berry to plant. Seeds available:")
+			for _, name := range farm.BerryNames() {
+				berry := farm.Berries[name]
+				fmt.Printf(" - %s (%s, ready in %s)\n", name, berry.Description, berry.Growth)
+			}
+			return nil
+		}
+		if err := cfg.State.Farm.Plant(args[1], now); err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		fmt.Printf("Planted a %s. It will be ready in %s.\n", args[1], farm.Berries[args[1]].Growth)
+	case "harvest":
+		harvested := cfg.State.Farm.Harvest(now)
+		if len(harvested) == 0 {
+			fmt.Println("Nothing is ready to harvest yet.")
+			return nil
+		}
+		for _, name := range farm.BerryNames() {
+			if n := harvested[name]; n > 0 {
+				cfg.State.Items[name] += n
+				fmt.Printf("Harvested %d %s\n", n, name)
+			}
+		}
+	default:
+		fmt.Println("Unknown farm action:", args[0])
+		return nil
+	}
+	return saveState(cfg)
+}
+
+func printFarmStatus(f farm.Farm, now time.Time) {
+	fmt.Printf("Plots in use: %d of %d\n", len(f.Plots), farm.MaxPlots)
+	for _, plot := range f.Plots {
+		if plot.Ready(now) {
+			fmt.Printf(" - %s: ready to harvest\n", plot.Berry)
+			continue
+		}
+		left := plot.ReadyAt().Sub(now).Round(time.Minute)
+		fmt.Printf(" - %s: ready in %s\n", plot.Berry, left)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/friendship"
+)
+
+func commandFeed(cfg *config, args []string) error {
+	pokemonName, berry := args[0], args[1]
+	pokemon, exists := cfg.Caught[pokemonName]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	if !strings.HasSuffix(berry, "-berry") || cfg.State.Items[berry] == 0 {
+		fmt.Printf("You don't have any %s.\n", berry)
+		return nil
+	}
+
+	cfg.State.Items[berry]--
+	pokemon = syncFriendship(cfg, pokemon)
+	pokemon.Friendship = friendship.Adjust(pokemon.Friendship, friendship.Berry)
+	cfg.Caught[pokemonName] = pokemon
+	fmt.Printf("%s ate the %s. %s\n", pokemonName, berry, friendship.Describe(pokemon.Friendship))
+	return saveState(cfg)
+}
+
+// syncFriendship adds the friendship a caught Pokémon has earned just by
+// spending time with the trainer since it was last updated.
+func syncFriendship(cfg *config, pokemon Pokemon) Pokemon {
+	gained, since := friendship.Elapsed(pokemon.FriendshipAt, time.Now())
+	pokemon.Friendship = friendship.Adjust(pokemon.Friendship, gained)
+	pokemon.FriendshipAt = since
+	cfg.Caught[pokemon.Name] = pokemon
+	return pokemon
+}
+
+// watchFriendship applies the friendship changes caused by battles.
+func watchFriendship(cfg *config) {
+	adjust := func(delta int) events.Handler {
+		return func(e events.Event) {
+			pokemon, exists := cfg.Caught[e.Subject]
+			if !exists {
+				return
+			}
+			pokemon.Friendship = friendship.Adjust(pokemon.Friendship, delta)
+			cfg.Caught[e.Subject] = pokemon
+		}
+	}
+	cfg.Events.Subscribe(events.BattleWon, adjust(friendship.BattleWon))
+	cfg.Events.Subscribe(events.PokemonFainted, adjust(friendship.Fainted))
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/community"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+)
+
+func commandFriends(cfg *config, args []string) error {
+	client := communityClient(cfg, "Friends")
+	if client == nil {
+		return nil
+	}
+	code, err := friendCode(cfg, client)
+	if err != nil {
+		fmt.Printf("Could not sign up with the server: %v\n", err)
+		return nil
+	}
+	switch {
+	case len(args) == 0:
+		return listFriends(cfg, client, code)
+	case len(args) >= 2 && args[0] == "add":
+		friend, err := client.Link(code, args[1])
+		if err != nil {
+			fmt.Printf("Could not add %s: %v\n", args[1], err)
+			return nil
+		}
+		fmt.Printf("You and %s are now friends.\n", friend.Trainer)
+		return nil
+	case len(args) >= 2 && args[0] == "remove":
+		if err := client.Unlink(code, args[1]); err != nil {
+			fmt.Printf("Could not remove %s: %v\n", args[1], err)
+			return nil
+		}
+		delete(cfg.State.Gifted, args[1])
+		fmt.Printf("Removed %s from your friends.\n", args[1])
+		return saveState(cfg)
+	case len(args) >= 3 && args[0] == "gift":
+		return giftFriend(cfg, client, code, args[1], args[2])
+	default:
+		fmt.Println("Usage: friends | friends add <code> | friends remove <code> | friends gift <code|trainer> <item>")
+		return nil
+	}
+}
+
+func listFriends(cfg *config, client *community.Client, code string) error {
+	fmt.Printf("Your friend code: %s\n", code)
+	friends, err := client.Friends(code)
+	if err != nil {
+		fmt.Printf("Could not fetch your friends: %v\n", err)
+		return nil
+	}
+	if len(friends) == 0 {
+		fmt.Println("No friends yet. Share your code, or add someone else's with `friends add <code>`.")
+		return nil
+	}
+	today := time.Now().Format("2006-01-02")
+	for _, f := range friends {
+		status := "can be sent a gift today"
+		if cfg.State.Gifted[f.Code] == today {
+			status = "gift sent today"
+		}
+		fmt.Printf("  %-16s %s  (%s)\n", f.Trainer, f.Code, status)
+	}
+	return nil
+}
+
+// giftFriend sends one item from the bag to a friend, once a day per
+// friend.
+func giftFriend(cfg *config, client *community.Client, code, to, item string) error {
+	friends, err := client.Friends(code)
+	if err != nil {
+		fmt.Printf("Could not fetch your friends: %v\n", err)
+		return nil
+	}
+	var friend *community.Friend
+	for i, f := range friends {
+		if f.Code == to || f.Trainer == to {
+			friend = &friends[i]
+			break
+		}
+	}
+	if friend == nil {
+		fmt.Printf("%s isn't one of your friends.\n", to)
+		return nil
+	}
+	today := time.Now().Format("2006-01-02")
+	if cfg.State.Gifted[friend.Code] == today {
+		fmt.Printf("You've already sent %s a gift today.\n", friend.Trainer)
+		return nil
+	}
+	if cfg.State.Items[item] < 1 {
+		fmt.Printf("You don't have any %s.\n", item)
+		return nil
+	}
+
+	if err := client.SendGift(code, friend.Code, item, 1); err != nil {
+		fmt.Printf("Could not send the gift: %v\n", err)
+		return nil
+	}
+	cfg.State.Items[item]--
+	cfg.State.Gifted[friend.Code] = today
+	fmt.Printf("Sent %s a %s. They'll get it next time they play.\n", friend.Trainer, item)
+	return saveState(cfg)
+}
+
+// claimGiftsOnStartup collects the gifts friends sent while the player was
+// away.
+func claimGiftsOnStartup(cfg *config) {
+	if server(cfg) == "" || cfg.State.FriendCode == "" {
+		return
+	}
+	gifts, err := community.New(cfg.State.Server).ClaimGifts(cfg.State.FriendCode)
+	if err != nil {
+		fmt.Println("Could not collect gifts from friends:", err)
+		return
+	}
+	if len(gifts) == 0 {
+		return
+	}
+	for _, g := range gifts {
+		cfg.State.Items[g.Item] += g.Quantity
+		fmt.Printf("%s sent you %d %s!\n", g.From.Trainer, g.Quantity, g.Item)
+		cfg.Events.Publish(events.Event{Kind: events.GiftReceived, Subject: g.Item, Other: g.From.Trainer, Count: g.Quantity})
+	}
+	if err := saveState(cfg); err != nil {
+		fmt.Println("Error saving gifts from friends:", err)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "fmt"
+
+// commandGive gives a caught Pokémon an item from the bag to hold. Some
+// Pokémon only evolve when traded while holding the right item. Whatever
+// it held before goes back in the bag.
+func commandGive(cfg *config, args []string) error {
+	pokemonName, item := args[0], args[1]
+	pokemon, exists := cfg.Caught[pokemonName]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return errRefused
+	}
+	if pokemon.HeldItem == item {
+		fmt.Printf("%s is already holding the %s.\n", displayName(pokemon), item)
+		return errRefused
+	}
+	if cfg.State.Items[item] == 0 {
+		fmt.Printf("You don't have any %s.\n", item)
+		return errRefused
+	}
+
+	cfg.State.Items[item]--
+	if pokemon.HeldItem != "" {
+		cfg.State.Items[pokemon.HeldItem]++
+		fmt.Printf("You took the %s back from %s.\n", pokemon.HeldItem, displayName(pokemon))
+	}
+	pokemon.HeldItem = item
+	cfg.Caught[pokemonName] = pokemon
+	fmt.Printf("%s is now holding the %s.\n", displayName(pokemon), item)
+	return saveState(cfg)
+}
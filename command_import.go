@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/importer"
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+)
+
+// conflictRules are what import can do with a Pokémon of a species the
+// player already has.
+var conflictRules = []string{"keep", "replace", "higher"}
+
+func commandImport(cfg *config, args []string) error {
+	format, conflicts := cfg.Input.Flags["format"], cfg.Input.Flags["conflicts"]
+	if conflicts == "" {
+		conflicts = "keep"
+	}
+	if !slices.Contains(conflictRules, conflicts) {
+		fmt.Printf("--conflicts can be %s.\n", strings.Join(conflictRules, ", "))
+		return nil
+	}
+	if format == "" && strings.EqualFold(filepath.Ext(args[0]), ".json") {
+		format = "pokedex"
+	}
+	if format == "pokedex" {
+		return importExported(cfg, args[0], conflicts)
+	}
+	if format == "" {
+		format = importer.ForFile(args[0])
+	}
+	imp, ok := importer.Importers[format]
+	if !ok {
+		fmt.Printf("Unknown format %s; try pokedex, %s.\n", format, strings.Join(importer.Formats(), " or "))
+		return nil
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Printf("Could not open %s: %v\n", args[0], err)
+		return nil
+	}
+	defer f.Close()
+	records, err := imp.Import(f)
+	if err != nil {
+		fmt.Printf("Could not read %s as %s: %v\n", args[0], format, err)
+		return nil
+	}
+
+	imported := 0
+	for _, record := range records {
+		ok, err := importPokemon(cfg, record, conflicts)
+		if err != nil {
+			return err
+		}
+		if ok {
+			imported++
+		}
+	}
+	fmt.Printf("Imported %d of %d Pokémon.\n", imported, len(records))
+	return saveState(cfg)
+}
+
+// importPokemon adds one imported Pokémon to the Pokedex. Species the
+// player already has are settled by conflicts, and moves the species can't
+// learn are dropped.
+func importPokemon(cfg *config, record importer.Record, conflicts string) (bool, error) {
+	if mine, exists := cfg.Caught[record.Species]; exists && !replaces(mine, record.Level, conflicts) {
+		return false, nil
+	}
+	pokemon, err := fetchPokemon(cfg, record.Species)
+	if err != nil || pokemon.Name == "" {
+		fmt.Printf("Could not find a Pokémon called %s; skipped.\n", record.Species)
+		return false, nil
+	}
+	addToPokedex(cfg, pokemon)
+	pokemon = cfg.Caught[pokemon.Name]
+	pokemon.MetAt = ""
+	pokemon.Level = record.Level
+	if pokemon.Level == 0 {
+		pokemon.Level = defaultLevel
+	}
+
+	var dropped []string
+	for _, move := range record.Moves {
+		learnable := slices.ContainsFunc(pokemon.Moves, func(m moves.Learnable) bool { return m.Move.Name == move })
+		if !learnable || len(pokemon.Known) == moves.Limit || slices.Contains(pokemon.Known, move) {
+			dropped = append(dropped, move)
+			continue
+		}
+		pokemon.Known = append(pokemon.Known, move)
+	}
+	cfg.Caught[pokemon.Name] = pokemon
+
+	fmt.Printf("Imported %s (Lv. %d).\n", pokemon.Name, pokemon.Level)
+	if len(dropped) > 0 {
+		fmt.Printf("  %s can't know %s.\n", pokemon.Name, strings.Join(dropped, ", "))
+	}
+	return true, nil
+}
+
+// replaces settles an imported Pokémon of a species the player already
+// has, at level (0 if unknown), telling the player which is kept.
+func replaces(mine Pokemon, level int, conflicts string) bool {
+	if level == 0 {
+		level = defaultLevel
+	}
+	if conflicts == "replace" || conflicts == "higher" && level > levelOf(mine) {
+		fmt.Printf("Replaced your %s (Lv. %d) with the imported one (Lv. %d).\n", displayName(mine), levelOf(mine), level)
+		return true
+	}
+	fmt.Printf("You already have %s (Lv. %d); skipped the imported one (Lv. %d).\n", displayName(mine), levelOf(mine), level)
+	return false
+}
+
+// importExported merges a Pokedex written by export into the player's.
+// Each Pokémon comes back whole; those from another trainer's Pokedex note
+// the import in their provenance.
+func importExported(cfg *config, path, conflicts string) error {
+	var file exported
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &file)
+	}
+	switch {
+	case err != nil:
+		fmt.Printf("Could not read %s: %v\n", path, err)
+		return nil
+	case file.Format != exportFormat:
+		fmt.Printf("%s isn't a Pokedex written by export.\n", path)
+		return nil
+	case file.Version > exportVersion:
+		fmt.Printf("%s was written by a newer version of the game; update to import it.\n", path)
+		return nil
+	}
+
+	now := time.Now()
+	imported := 0
+	for _, pokemon := range file.Pokemon {
+		if pokemon.Name == "" {
+			continue
+		}
+		if mine, exists := cfg.Caught[pokemon.Name]; exists && !replaces(mine, pokemon.Level, conflicts) {
+			continue
+		}
+		if file.Trainer != cfg.State.Trainer {
+			pokemon.Provenance = append(pokemon.Provenance, transfer{From: file.Trainer, To: cfg.State.Trainer, Method: "import", At: now})
+		}
+		if pokemon.Shiny {
+			countShiny(cfg, pokemon.Name)
+		}
+		cfg.Caught[pokemon.Name] = pokemon
+		cfg.State.Dex.Catch(pokemon.Name, now)
+		recordSize(cfg, pokemon)
+		fmt.Printf("Imported %s (Lv. %d).\n", displayName(pokemon), levelOf(pokemon))
+		imported++
+	}
+	fmt.Printf("Imported %d of %d Pokémon from %s's Pokedex.\n", imported, len(file.Pokemon), file.Trainer.Name)
+	return saveState(cfg)
+}
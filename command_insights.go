@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func commandInsights(cfg *config, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "export":
+			if len(args) < 2 {
+				fmt.Println("Please specify a file to export to.")
+				return nil
+			}
+			if err := cfg.Insights.Export(args[1]); err != nil {
+				return err
+			}
+			fmt.Println("Report written to", args[1])
+			return nil
+		case "reset":
+			cfg.Insights.Reset()
+			fmt.Println("Usage insights cleared.")
+			return cfg.Insights.Save()
+		default:
+			fmt.Println("Usage: insights [export <file>|reset]")
+			return nil
+		}
+	}
+
+	rows := cfg.Insights.Rows()
+	if len(rows) == 0 {
+		fmt.Println("No commands recorded yet.")
+		return nil
+	}
+	fmt.Printf("Usage since %s (stored locally only):\n", cfg.Insights.Since.Format("2006-01-02"))
+	fmt.Printf("%-12s %6s %10s %10s %6s\n", "command", "uses", "avg", "max", "errors")
+	for _, row := range rows {
+		fmt.Printf("%-12s %6d %10s %10s %6d\n",
+			row.Name, row.Count,
+			row.Average().Round(time.Microsecond), row.Max.Round(time.Microsecond),
+			row.Errors)
+	}
+	return nil
+}
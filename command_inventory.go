@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+	"github.com/eymardfreire/pokedexcli/internal/fanout"
+	"github.com/eymardfreire/pokedexcli/internal/finds"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/stamina"
+)
+
+// bagItem is an item as `inventory` shows it.
+type bagItem struct {
+	Name        string `json:"name"`
+	Count       int    `json:"count"`
+	Description string `json:"description,omitempty"`
+	// Loading is set when the item was still loading once fetchBudget had
+	// passed, so its description is missing.
+	Loading bool `json:"loading,omitempty"`
+}
+
+func commandInventory(cfg *config, args []string) error {
+	var names []string
+	for name, n := range cfg.State.Items {
+		if n > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	// As text, each item is printed as soon as it comes.
+	text := textOutput(cfg)
+	if text && len(names) > 0 {
+		fmt.Println("Your bag:")
+	}
+	items := make([]bagItem, 0, len(names))
+	fetchEach(cfg, "item", names, fetchBudget, func(i int, r fanout.Result[pokeapi.ItemDetails]) {
+		item := bagItem{Name: names[i], Count: cfg.State.Items[names[i]], Loading: r.Late}
+		// Items PokeAPI doesn't know, or that can't be fetched offline, are
+		// listed without a description rather than not at all.
+		if r.Err != nil && cfg.Log != nil {
+			cfg.Log.Printf("item %s: %v", names[i], r.Err)
+		}
+		item.Description = r.Value.ShortEffect("en")
+		items = append(items, item)
+		if text {
+			printBagItem(item)
+		}
+	})
+	return show(cfg, items, func() {
+		if len(items) == 0 {
+			fmt.Println("Your bag is empty. Explore to find items, and more balls arrive each day you play.")
+		}
+		if cfg.State.Berry != "" {
+			fmt.Printf("A %s is held out for your next catch.\n", cfg.State.Berry)
+		}
+	})
+}
+
+func printBagItem(item bagItem) {
+	line := fmt.Sprintf(" - %s x%d", item.Name, item.Count)
+	switch {
+	case item.Loading:
+		line += fmt.Sprintf(": still loading after %s; run it again in a moment", fetchBudget)
+	case item.Description != "":
+		line += ": " + item.Description
+	}
+	fmt.Println(line)
+}
+
+func commandUse(cfg *config, args []string) error {
+	item := strings.ToLower(args[0])
+	if cfg.State.Items[item] == 0 {
+		fmt.Printf("You don't have any %s.\n", item)
+		return errRefused
+	}
+	switch {
+	case capture.Berries[item] > 0:
+		if cfg.State.Berry != "" {
+			fmt.Printf("You're already holding out a %s for your next catch.\n", cfg.State.Berry)
+			return errRefused
+		}
+		if cfg.DryRun {
+			return previewBerry(cfg, item)
+		}
+		cfg.State.Items[item]--
+		cfg.State.Berry = item
+		fmt.Printf("You hold out a %s: the next Pokémon you throw a ball at will be easier to catch.\n", item)
+		return saveState(cfg)
+	case stamina.Items[item] > 0:
+		return drink(cfg, item, time.Now())
+	case strings.HasSuffix(item, "-berry"):
+		fmt.Printf("Feed it to a Pokémon with `feed <pokemon_name> %s`.\n", item)
+	case strings.HasSuffix(item, "-ball"):
+		fmt.Printf("Throw it with `catch <pokemon_name> --ball %s`.\n", strings.TrimSuffix(item, "-ball"))
+	case item == "potion":
+		fmt.Println("Potions are used between rounds at the battle tower.")
+	default:
+		fmt.Printf("There's nothing to use the %s on here.\n", item)
+	}
+	// The item is used some other way, if at all.
+	return errRefused
+}
+
+// findItem rolls for an item lying around the area just explored, and
+// puts it in the bag if there is one.
+func findItem(cfg *config) {
+	find, ok := finds.Roll(cfg.RNG.Get(rng.Find), cfg.Balance.FindChance)
+	if !ok {
+		return
+	}
+	cfg.State.Items[find.Item] += find.Count
+	fmt.Printf("You found %d %s lying on the ground.\n", find.Count, find.Item)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+)
+
+// journalEntries say how each kind of event reads in the journal.
+var journalEntries = map[events.Kind]func(e events.Event) string{
+	events.PokemonSeen: func(e events.Event) string {
+		return fmt.Sprintf("Saw %s", e.Subject)
+	},
+	events.PokemonCaught: func(e events.Event) string {
+		return fmt.Sprintf("Caught %s", e.Subject)
+	},
+	events.PokemonEscaped: func(e events.Event) string {
+		return fmt.Sprintf("%s broke free and fled", e.Subject)
+	},
+	events.BattleWon: func(e events.Event) string {
+		return fmt.Sprintf("%s beat %s in battle", e.Subject, e.Other)
+	},
+	events.PokemonFainted: func(e events.Event) string {
+		return fmt.Sprintf("%s fainted battling %s", e.Subject, e.Other)
+	},
+	events.PokemonEvolved: func(e events.Event) string {
+		return fmt.Sprintf("%s evolved into %s", e.Other, e.Subject)
+	},
+}
+
+func watchJournal(cfg *config) {
+	for kind, describe := range journalEntries {
+		cfg.Events.Subscribe(kind, func(e events.Event) {
+			cfg.Journal.Add(time.Now(), describe(e))
+			if err := cfg.Journal.Save(); err != nil {
+				fmt.Println("Error saving the journal:", err)
+			}
+		})
+	}
+}
+
+func commandJournal(cfg *config, args []string) error {
+	n := 10
+	if len(args) > 0 {
+		if count, err := strconv.Atoi(args[0]); err == nil && count > 0 {
+			n = count
+			args = args[1:]
+		}
+	}
+	search := strings.Join(args, " ")
+
+	entries := cfg.Journal.Recent(n, search)
+	if len(entries) == 0 && search != "" {
+		fmt.Printf("Nothing in your journal mentions %q.\n", search)
+		return nil
+	}
+	if len(entries) == 0 {
+		fmt.Println("Nothing in your journal yet.")
+		return nil
+	}
+	for _, entry := range entries {
+		fmt.Printf("%s  %s\n", entry.At.Format("2006-01-02 15:04"), entry.Text)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/mail"
+)
+
+// mailLetters say how each kind of event reads in the inbox.
+var mailLetters = map[events.Kind]func(e events.Event) string{
+	events.GiftReceived: func(e events.Event) string {
+		return fmt.Sprintf("%s sent you %d %s.", e.Other, e.Count, e.Subject)
+	},
+	events.OfferAccepted: func(e events.Event) string {
+		return fmt.Sprintf("%s accepted your offer %d and sent you %s.", e.Other, e.Count, e.Subject)
+	},
+	events.EventStarted: func(e events.Event) string {
+		return fmt.Sprintf("%s has started.", e.Subject)
+	},
+	events.BerryReady: func(e events.Event) string {
+		return fmt.Sprintf("Your %s is ready to harvest.", e.Subject)
+	},
+}
+
+func watchMail(cfg *config) {
+	for kind, write := range mailLetters {
+		cfg.Events.Subscribe(kind, func(e events.Event) {
+			cfg.Mail.Add(time.Now(), write(e))
+			saveMail(cfg)
+		})
+	}
+}
+
+func saveMail(cfg *config) {
+	if cfg.ReadOnly {
+		return
+	}
+	if err := cfg.Mail.Save(); err != nil {
+		fmt.Println("Error saving your mail:", err)
+	}
+}
+
+// checkNews looks for what happened since the game last looked that
+// nothing announces by itself: berries ripening and seasonal events
+// starting. The first time, it only starts counting from now.
+func checkNews(cfg *config) {
+	now := time.Now()
+	since := cfg.Mail.Check(now)
+	if !since.IsZero() {
+		for _, plot := range cfg.State.Farm.Ripened(since, now) {
+			cfg.Events.Publish(events.Event{Kind: events.BerryReady, Subject: plot.Berry})
+		}
+		for _, e := range cfg.Seasons {
+			if e.ActiveOn(now) && !e.ActiveOn(since) {
+				cfg.Events.Publish(events.Event{Kind: events.EventStarted, Subject: e.Name})
+			}
+		}
+	}
+	saveMail(cfg)
+}
+
+// mailPrompt is prompt with the number of unread letters before it.
+func mailPrompt(cfg *config, prompt string) string {
+	if n := cfg.Mail.Unread(); n > 0 {
+		return fmt.Sprintf("(%d new) %s", n, prompt)
+	}
+	return prompt
+}
+
+func commandMail(cfg *config, args []string) error {
+	switch {
+	case len(args) > 0 && args[0] == "all":
+		all := cfg.Mail.Recent(20)
+		if len(all) == 0 {
+			fmt.Println("Your inbox is empty.")
+			return nil
+		}
+		printLetters(all)
+	case len(args) > 0 && args[0] == "clear":
+		fmt.Printf("Threw away %d read letter(s).\n", cfg.Mail.Clear())
+	default:
+		unread := cfg.Mail.Open()
+		if len(unread) == 0 {
+			fmt.Println("No new mail. `mail all` shows the letters you have read.")
+			return nil
+		}
+		printLetters(unread)
+	}
+	saveMail(cfg)
+	return nil
+}
+
+func printLetters(letters []mail.Letter) {
+	for _, l := range letters {
+		fmt.Printf("%s  %s\n", l.At.Format("2006-01-02 15:04"), l.Text)
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/bulk"
+	"github.com/eymardfreire/pokedexcli/internal/manifest"
+	"github.com/eymardfreire/pokedexcli/internal/pack"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/validate"
+)
+
+// mirrorRate is how many requests a second mirror makes by default, to stay
+// within PokeAPI's fair use.
+const mirrorRate = 5
+
+// commandMirror downloads every resource of one kind, like every Pokémon,
+// as JSON files for offline use.
+func commandMirror(cfg *config, args []string) error {
+	if len(args) > 0 && args[0] == "pack" {
+		return packSnapshot(cfg)
+	}
+	if cfg.API.Offline() {
+		fmt.Println("Mirroring downloads from PokeAPI; use `offline off` to go back online first.")
+		return nil
+	}
+	rateFlag := cfg.Input.Flags["rate"]
+	resume := cfg.Input.Flags["resume"] != ""
+	rate := mirrorRate
+	if rateFlag != "" {
+		n, err := strconv.Atoi(rateFlag)
+		if err != nil || n < 1 {
+			fmt.Println("The rate is a number of requests per second, like 5.")
+			return nil
+		}
+		rate = n
+	}
+	// Responses go straight to disk; caching thousands of them would only
+	// fill up memory.
+	client := &pokeapi.Client{BaseURL: cfg.API.BaseURL, HTTP: cfg.API.HTTP}
+	client.SetContext(cfg.Ctx)
+	m := mirror{client: client, plain: cfg.Settings.Accessible, root: snapshotDir(cfg), rate: rate, resume: resume}
+	if len(args) > 0 && args[0] == "update" {
+		return m.update(cfg, args[1:])
+	}
+
+	if len(args) > 1 {
+		m.root = args[1]
+	}
+	resource := args[0]
+	dir, err := validate.Within(m.root, resource)
+	if err != nil {
+		fmt.Println("That isn't a PokeAPI resource, like pokemon or location-area.")
+		return nil
+	}
+	if m.manifest, err = manifest.Load(filepath.Join(m.root, "manifest.json")); err != nil {
+		return err
+	}
+	var items []string
+	if saved, err := bulk.Load(filepath.Join(dir, ".checkpoint.json")); err != nil || saved == nil || !resume {
+		list, err := client.List(resource)
+		if err != nil {
+			return err
+		}
+		for _, r := range list {
+			items = append(items, r.Name)
+		}
+	}
+	if err := m.fetch(resource, dir, items); err != nil || !m.done {
+		return err
+	}
+	fmt.Printf("Mirrored %s into %s.\n", resource, dir)
+	return nil
+}
+
+// mirror downloads resources into root, rate a second, noting each in
+// the manifest there.
+type mirror struct {
+	client *pokeapi.Client
+	// plain reports progress a line at a time instead of redrawing one.
+	plain    bool
+	root     string
+	rate     int
+	resume   bool
+	manifest *manifest.Manifest
+	// done is set once fetch has fetched everything it was given.
+	done bool
+}
+
+// fetch downloads the named resources of a kind into dir, keeping a
+// checkpoint so that an interrupted run can be resumed.
+func (m *mirror) fetch(resource, dir string, names []string) error {
+	if len(names) == 0 && !m.resume {
+		m.done = true
+		return nil
+	}
+	m.done = false
+	checkpoint := filepath.Join(dir, ".checkpoint.json")
+	job := bulk.Job{
+		Checkpoint: checkpoint,
+		Every:      time.Second / time.Duration(m.rate),
+		Do: func(name string) error {
+			data, err := m.client.Get(m.client.URL(resource, name))
+			if err != nil {
+				return err
+			}
+			file, err := validate.Within(dir, name+".json")
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(file, data, 0o644); err != nil {
+				return err
+			}
+			m.manifest.Record(resource, name, data, time.Now())
+			return nil
+		},
+		Progress: func(done, total int, name string) {
+			if !m.plain {
+				fmt.Printf("\r%d/%d %s\033[K", done, total, name)
+			} else if done == total || done*10/total != (done-1)*10/total {
+				fmt.Printf("Fetched %d of %d.\n", done, total)
+			}
+		},
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	err := job.Run(names, m.resume)
+	if saveErr := m.manifest.Save(); err == nil {
+		err = saveErr
+	}
+	if errors.Is(err, bulk.ErrUnfinished) {
+		fmt.Printf("Mirroring %s into %s stopped part way (%v).\n", resource, dir, err)
+		fmt.Printf("Add --resume to carry on, or delete %s to start over.\n", checkpoint)
+		return nil
+	}
+	fmt.Println()
+	if err != nil {
+		fmt.Println("Stopped: run the same command with --resume to carry on.")
+		return err
+	}
+	m.done = true
+	return nil
+}
+
+// update brings the snapshot's resources of the kinds given, or of every
+// kind in it, up to date with PokeAPI. Only new and changed resources are
+// downloaded, and those PokeAPI no longer has are deleted.
+func (m *mirror) update(cfg *config, kinds []string) error {
+	var err error
+	if m.manifest, err = manifest.Load(filepath.Join(m.root, "manifest.json")); err != nil {
+		return err
+	}
+	if len(kinds) == 0 {
+		kinds = mirroredKinds(m.root, m.manifest)
+	}
+	if len(kinds) == 0 {
+		fmt.Println("Nothing is mirrored yet. Start with `mirror <resource>`, like `mirror pokemon`.")
+		return nil
+	}
+	changed := false
+	for _, kind := range kinds {
+		dir, err := validate.Within(m.root, kind)
+		if err != nil {
+			fmt.Println("That isn't a PokeAPI resource, like pokemon or location-area.")
+			return nil
+		}
+		list, err := m.client.List(kind)
+		if err != nil {
+			return err
+		}
+		// Resources mirrored before the manifest was kept are taken as
+		// they are.
+		for _, r := range list {
+			if m.manifest.Has(kind, r.Name) {
+				continue
+			}
+			file := filepath.Join(dir, r.Name+".json")
+			if info, err := os.Stat(file); err == nil {
+				if data, err := os.ReadFile(file); err == nil {
+					m.manifest.Record(kind, r.Name, data, info.ModTime())
+				}
+			}
+		}
+		diff := m.manifest.Diff(kind, list)
+		for _, name := range diff.Removed {
+			if err := os.Remove(filepath.Join(dir, name+".json")); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			m.manifest.Forget(kind, name)
+		}
+		if err := m.fetch(kind, dir, append(diff.Added, diff.Changed...)); err != nil || !m.done {
+			return err
+		}
+		if diff.Empty() {
+			fmt.Printf("%s is up to date.\n", kind)
+			continue
+		}
+		changed = true
+		fmt.Printf("%s: %d new, %d changed, %d removed.\n", kind, len(diff.Added), len(diff.Changed), len(diff.Removed))
+	}
+	if _, err := os.Stat(packPath(cfg)); changed && err == nil {
+		fmt.Println("Run `mirror pack` to pack the changes.")
+	}
+	return nil
+}
+
+// mirroredKinds are the kinds of resource in the manifest, and any
+// mirrored before there was one.
+func mirroredKinds(root string, m *manifest.Manifest) []string {
+	kinds := m.Kinds()
+	entries, _ := os.ReadDir(root)
+	for _, e := range entries {
+		if e.IsDir() && !slices.Contains(kinds, e.Name()) {
+			kinds = append(kinds, e.Name())
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// packSnapshot packs every resource in the snapshot directory into one
+// file, and reads from it from now on.
+func packSnapshot(cfg *config) error {
+	root := snapshotDir(cfg)
+	files, err := filepath.Glob(filepath.Join(root, "*", "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Printf("The snapshot is empty. Fill it with `mirror <resource>` first; it is kept in %s.\n", root)
+		return nil
+	}
+	w, err := pack.Create(packPath(cfg))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err == nil {
+			resource := filepath.Base(filepath.Dir(file))
+			err = w.Add(resource+"/"+strings.TrimSuffix(filepath.Base(file), ".json"), data)
+		}
+		if err != nil {
+			w.Abort()
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	// The pack it replaces stays mapped: what was read from it may still
+	// be in the cache.
+	if err := openPack(cfg); err != nil {
+		return err
+	}
+	info, err := os.Stat(packPath(cfg))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Packed %d resources into %s (%.1f MB).\n", len(files), packPath(cfg), float64(info.Size())/(1<<20))
+	return nil
+}
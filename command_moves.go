@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/fanout"
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// topMoves is how many of the strongest learnable moves `moves` lists.
+const topMoves = 10
+
+// moveInfo is a move as `moves` shows it.
+type moveInfo struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Class    string `json:"class"`
+	Power    int    `json:"power"`
+	Accuracy int    `json:"accuracy"`
+}
+
+// moveList is what `moves` shows: the moves a Pokémon knows, if it has
+// been caught, and the strongest it can learn by levelling up.
+type moveList struct {
+	Pokemon string     `json:"pokemon"`
+	Known   []moveInfo `json:"known,omitempty"`
+	Top     []moveInfo `json:"top"`
+	// Loading are the moves left out because they were still loading.
+	Loading []string `json:"loading,omitempty"`
+}
+
+// lookUpPokemon finds a caught Pokémon by name, or failing that the
+// species. It reports false, having told the player, if there is neither.
+func lookUpPokemon(cfg *config, name string) (Pokemon, bool, error) {
+	if pokemon, ok := cfg.Caught[name]; ok {
+		return pokemon, true, nil
+	}
+	pokemon, err := fetchPokemon(cfg, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no Pokémon called %s.%s\n", name, didYouMean(cfg, name))
+		return pokemon, false, nil
+	}
+	return pokemon, err == nil, err
+}
+
+// fetchMoves looks up moves, all at once so that a long learnset doesn't
+// wait on each in turn, and returns those still loading once budget has
+// passed; see fetchEach. each, if not nil, is called with each move in
+// turn as soon as it comes.
+func fetchMoves(cfg *config, names []string, budget time.Duration, each func(moves.Move)) ([]moves.Move, []string, error) {
+	return arrived(fetchEach(cfg, "move", names, budget, func(_ int, r fanout.Result[moves.Move]) {
+		if each != nil && r.Err == nil && !r.Late {
+			each(r.Value)
+		}
+	}))
+}
+
+func describeMoves(ms []moves.Move) []moveInfo {
+	var infos []moveInfo
+	for _, m := range ms {
+		infos = append(infos, moveInfo{m.Name, m.Type.Name, m.Class(), m.Power, m.Accuracy})
+	}
+	return infos
+}
+
+func commandMoves(cfg *config, args []string) error {
+	pokemon, ok, err := lookUpPokemon(cfg, args[0])
+	if !ok {
+		return err
+	}
+	result := moveList{Pokemon: args[0]}
+	if _, caught := cfg.Caught[args[0]]; caught {
+		// As text, the moves it knows are printed as soon as they come; the
+		// strongest it learns wait for the rest to be picked from.
+		var each func(moves.Move)
+		if textOutput(cfg) {
+			each = func(m moves.Move) {
+				if result.Known == nil {
+					result.Known = []moveInfo{}
+					fmt.Printf("%s knows:\n", result.Pokemon)
+				}
+				printMoves(describeMoves([]moves.Move{m}))
+			}
+		}
+		known, loading, err := fetchMoves(cfg, knownMoves(pokemon, levelOf(pokemon)), fetchBudget, each)
+		if err != nil {
+			return err
+		}
+		result.Known = describeMoves(known)
+		result.Loading = loading
+	}
+	learnable, loading, err := fetchMoves(cfg, moves.Learnset(pokemon.Moves, moves.ByLevelUp), fetchBudget, nil)
+	if err != nil {
+		return err
+	}
+	result.Top = describeMoves(moves.Best(learnable, topMoves))
+	for _, name := range loading {
+		if !slices.Contains(result.Loading, name) {
+			result.Loading = append(result.Loading, name)
+		}
+	}
+
+	return show(cfg, result, func() {
+		switch {
+		case len(result.Top) > 0:
+			fmt.Printf("Strongest moves %s learns by levelling up:\n", result.Pokemon)
+			printMoves(result.Top)
+		case len(result.Loading) == 0:
+			fmt.Printf("%s learns no damaging moves by levelling up.\n", result.Pokemon)
+		}
+		printLoading("moves", result.Loading)
+	})
+}
+
+func printMoves(ms []moveInfo) {
+	for _, m := range ms {
+		power, accuracy := "—", "—"
+		if m.Power > 0 {
+			power = fmt.Sprint(m.Power)
+		}
+		if m.Accuracy > 0 {
+			accuracy = fmt.Sprintf("%d%%", m.Accuracy)
+		}
+		fmt.Printf(" - %s (%s, %s; power %s, accuracy %s)\n", m.Name, m.Type, m.Class, power, accuracy)
+	}
+}
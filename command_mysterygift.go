@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/gift"
+)
+
+func commandMysteryGift(cfg *config, args []string) error {
+	if len(args) < 1 {
+		if len(cfg.State.Gifts) == 0 {
+			fmt.Println("You have not redeemed any mystery gifts. Use `mysterygift <code>` to redeem one.")
+			return nil
+		}
+		fmt.Println("Redeemed mystery gifts:")
+		for _, r := range cfg.State.Gifts {
+			fmt.Printf(" - %s on %s\n", r.Code, r.RedeemedAt.Format("2006-01-02"))
+		}
+		return nil
+	}
+
+	gifts, err := gift.Load(filepath.Join(cfg.Paths.Config, "gifts.json"))
+	if err != nil {
+		return err
+	}
+	g, ok := gift.Find(gifts, args[0])
+	if !ok {
+		fmt.Println("That code is not valid.")
+		return nil
+	}
+	if gift.Redeemed(cfg.State.Gifts, g.Code) {
+		fmt.Println("You have already redeemed that gift.")
+		return nil
+	}
+
+	if _, owned := cfg.Caught[g.Pokemon]; g.Pokemon != "" && owned {
+		fmt.Printf("This gift is a %s, and you already have one; release or trade it first.\n", g.Pokemon)
+		return nil
+	}
+	if g.Pokemon != "" {
+		pokemon, err := fetchPokemon(cfg, g.Pokemon)
+		if err != nil {
+			return err
+		}
+		addToPokedex(cfg, pokemon)
+	}
+
+	fmt.Printf("Mystery gift received: %s!\n", g.Description)
+	if g.Pokemon != "" {
+		fmt.Printf(" - %s was added to your Pokedex\n", g.Pokemon)
+	}
+	items := make([]string, 0, len(g.Items))
+	for item := range g.Items {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	for _, item := range items {
+		cfg.State.Items[item] += g.Items[item]
+		fmt.Printf(" - %d %s\n", g.Items[item], item)
+	}
+
+	cfg.State.Gifts = append(cfg.State.Gifts, gift.Redemption{Code: g.Code, RedeemedAt: time.Now()})
+	return saveState(cfg)
+}
@@ -0,0 +1,13 @@
+package main
+
+import "fmt"
+
+func commandName(cfg *config, args []string) error {
+	if len(args) < 1 {
+		fmt.Printf("You are %s.\n", cfg.State.Trainer)
+		return nil
+	}
+	cfg.State.Trainer.Name = args[0]
+	fmt.Printf("You are now known as %s.\n", cfg.State.Trainer.Name)
+	return saveState(cfg)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type areaNote struct {
+	Text string    `json:"text"`
+	At   time.Time `json:"at"`
+}
+
+func commandNote(cfg *config, args []string) error {
+	area := args[0]
+	text := strings.Trim(strings.Join(args[1:], " "), `"'`)
+	cfg.State.Notes[area] = append(cfg.State.Notes[area], areaNote{Text: text, At: time.Now()})
+	fmt.Printf("Noted for %s.\n", area)
+	return saveState(cfg)
+}
+
+func commandNotes(cfg *config, args []string) error {
+	search := ""
+	if len(args) >= 2 && args[0] == "search" {
+		search = strings.ToLower(strings.Join(args[1:], " "))
+	}
+
+	areas := make([]string, 0, len(cfg.State.Notes))
+	for area := range cfg.State.Notes {
+		areas = append(areas, area)
+	}
+	sort.Strings(areas)
+	found := false
+	for _, area := range areas {
+		var matches []areaNote
+		for _, note := range cfg.State.Notes[area] {
+			if strings.Contains(strings.ToLower(area+" "+note.Text), search) {
+				matches = append(matches, note)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		found = true
+		fmt.Printf("%s:\n", area)
+		for _, note := range matches {
+			fmt.Printf(" - %s (%s)\n", note.Text, note.At.Format("2006-01-02"))
+		}
+	}
+	if !found {
+		fmt.Println("No notes found.")
+	}
+	return nil
+}
+
+// printNotes shows the player's notes on an area they just arrived in.
+func printNotes(cfg *config, area string) {
+	notes := cfg.State.Notes[area]
+	if len(notes) == 0 {
+		return
+	}
+	fmt.Println("Your notes:")
+	for _, note := range notes {
+		fmt.Printf(" - %s\n", note.Text)
+	}
+}
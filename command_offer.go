@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/gts"
+)
+
+// openOffers opens the offer board. It lives in the data directory unless
+// POKEDEXCLI_OFFER_BOARD points to a file shared with other trainers.
+func openOffers(cfg *config) (*gts.Board, error) {
+	path := os.Getenv("POKEDEXCLI_OFFER_BOARD")
+	if path == "" {
+		path = filepath.Join(cfg.Paths.Data, "offers.json")
+	}
+	return gts.Open(path)
+}
+
+func gtsTrainer(t trainerID) gts.Trainer {
+	return gts.Trainer{Name: t.Name, ID: t.ID}
+}
+
+func commandOffer(cfg *config, args []string) error {
+	board, err := openOffers(cfg)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "put":
+		err = offerPut(cfg, board, args[1:])
+	case "browse":
+		offerBrowse(cfg, board)
+		return nil
+	case "accept":
+		err = offerAccept(cfg, board, args[1:])
+	case "cancel":
+		err = offerCancel(cfg, board, args[1:])
+	case "collect":
+		err = collectOffers(cfg, board)
+	default:
+		fmt.Println("Unknown offer action:", args[0])
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := board.Save(); err != nil {
+		return err
+	}
+	return saveState(cfg)
+}
+
+func offerPut(cfg *config, board *gts.Board, args []string) error {
+	name, want := args[0], cfg.Input.Flags["want"]
+	if want == "" {
+		fmt.Fprintln(os.Stderr, "offer put: missing --want <species>")
+		return errUsage
+	}
+	pokemon, exists := cfg.Caught[name]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+
+	data, err := json.Marshal(pokemon)
+	if err != nil {
+		return err
+	}
+	offer := board.Put(gtsTrainer(cfg.State.Trainer), pokemon.Name, data, want, time.Now())
+	removeCaught(cfg, name)
+	fmt.Printf("Offer %d: your %s is waiting for a %s.\n", offer.ID, offer.Species, offer.Want)
+	return nil
+}
+
+func offerBrowse(cfg *config, board *gts.Board) {
+	pending := board.Pending()
+	if len(pending) == 0 {
+		fmt.Println("There are no open offers.")
+		return
+	}
+	fmt.Println("Open offers:")
+	for _, o := range pending {
+		mine := ""
+		if o.Owner.ID == cfg.State.Trainer.ID {
+			mine = " (yours)"
+		}
+		fmt.Printf(" %d. %s offers %s, wants %s%s\n", o.ID, o.Owner.Name, o.Species, o.Want, mine)
+	}
+}
+
+func offerAccept(cfg *config, board *gts.Board, args []string) error {
+	id, ok := offerID(args)
+	if !ok {
+		return nil
+	}
+	var want, species string
+	for _, o := range board.Pending() {
+		if o.ID == id {
+			want, species = o.Want, o.Species
+		}
+	}
+	mine, exists := cfg.Caught[want]
+	if want == "" || !exists {
+		fmt.Println("You don't have the Pokémon that offer wants.")
+		return nil
+	}
+	// The Pokémon traded away makes room for one of its own species.
+	if _, owned := cfg.Caught[species]; owned && species != want {
+		fmt.Printf("You already have a %s; release or trade it first.\n", species)
+		return nil
+	}
+
+	data, err := json.Marshal(mine)
+	if err != nil {
+		return err
+	}
+	offer, err := board.Accept(id, gtsTrainer(cfg.State.Trainer), mine.Name, data, time.Now())
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	var received Pokemon
+	if err := json.Unmarshal(offer.Pokemon, &received); err != nil {
+		return err
+	}
+	removeCaught(cfg, mine.Name)
+	fmt.Printf("You traded your %s to %s for %s!\n", mine.Name, offer.Owner.Name, received.Name)
+	_, err = receiveTraded(cfg, &mine, received, trainerID{Name: offer.Owner.Name, ID: offer.Owner.ID}, "offer board")
+	return err
+}
+
+func offerCancel(cfg *config, board *gts.Board, args []string) error {
+	id, ok := offerID(args)
+	if !ok {
+		return nil
+	}
+	for _, o := range board.Pending() {
+		if _, owned := cfg.Caught[o.Species]; owned && o.ID == id && o.Owner.ID == cfg.State.Trainer.ID {
+			fmt.Printf("You already have a %s; release or trade it first.\n", o.Species)
+			return nil
+		}
+	}
+	offer, err := board.Cancel(id, gtsTrainer(cfg.State.Trainer))
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	var pokemon Pokemon
+	if err := json.Unmarshal(offer.Pokemon, &pokemon); err != nil {
+		return err
+	}
+	cfg.Caught[pokemon.Name] = pokemon
+	fmt.Printf("Offer %d withdrawn; %s is back in your Pokedex.\n", id, pokemon.Name)
+	return nil
+}
+
+// collectOffersOnStartup completes the trades that happened while the
+// player was away.
+func collectOffersOnStartup(cfg *config) {
+	board, err := openOffers(cfg)
+	if err == nil {
+		err = collectOffers(cfg, board)
+	}
+	if err == nil {
+		err = board.Save()
+	}
+	if err == nil {
+		err = saveState(cfg)
+	}
+	if err != nil {
+		fmt.Println("Error collecting offers:", err)
+	}
+}
+
+// collectOffers receives the Pokémon sent for the player's accepted offers.
+// One of a species the player already has waits on the board until they
+// release or trade theirs.
+func collectOffers(cfg *config, board *gts.Board) error {
+	collecting := make(map[string]bool)
+	offers := board.Collect(gtsTrainer(cfg.State.Trainer), func(offer gts.Offer) bool {
+		var received Pokemon
+		if err := json.Unmarshal(offer.Received, &received); err != nil {
+			// Collected, so the error is reported below.
+			return true
+		}
+		if _, owned := cfg.Caught[received.Name]; owned || collecting[received.Name] {
+			fmt.Printf("Your offer %d was accepted, but you already have a %s; release or trade it first to collect the one %s sent.\n",
+				offer.ID, received.Name, offer.Partner.Name)
+			return false
+		}
+		collecting[received.Name] = true
+		return true
+	})
+	for _, offer := range offers {
+		var received Pokemon
+		if err := json.Unmarshal(offer.Received, &received); err != nil {
+			return err
+		}
+		fmt.Printf("Your offer %d was accepted! %s sent you %s for your %s.\n",
+			offer.ID, offer.Partner.Name, received.Name, offer.Species)
+		cfg.Events.Publish(events.Event{Kind: events.OfferAccepted, Subject: received.Name, Other: offer.Partner.Name, Count: offer.ID})
+		var sent *Pokemon
+		if err := json.Unmarshal(offer.Pokemon, &sent); err != nil {
+			return err
+		}
+		partner := trainerID{Name: offer.Partner.Name, ID: offer.Partner.ID}
+		if _, err := receiveTraded(cfg, sent, received, partner, "offer board"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func offerID(args []string) (int, bool) {
+	if len(args) < 1 {
+		fmt.Println("Please specify an offer number.")
+		return 0, false
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Println("Offer numbers look like `3`.")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// firstBox is the box caught Pokémon go to until they are moved.
+const firstBox = "1"
+
+// maxNickname is the longest a nickname can be, as in the games.
+const maxNickname = 12
+
+// displayName is a Pokémon's nickname with its species, or just its species.
+func displayName(pokemon Pokemon) string {
+	if pokemon.Nickname == "" {
+		return pokemon.Name
+	}
+	return fmt.Sprintf("%s (%s)", pokemon.Nickname, pokemon.Name)
+}
+
+func commandParty(cfg *config, args []string) error {
+	switch {
+	case len(args) == 0 || args[0] == "list":
+		var party []Pokemon
+		for _, name := range cfg.State.Party {
+			if pokemon, exists := cfg.Caught[name]; exists {
+				party = append(party, pokemon)
+			}
+		}
+		if cfg.Where == nil {
+			return show(cfg, party, func() { printParty(cfg) })
+		}
+		party, err := where(cfg, party)
+		if err != nil {
+			return err
+		}
+		return show(cfg, party, func() {
+			if len(party) == 0 {
+				fmt.Println("None of your party match.")
+			}
+			for _, pokemon := range party {
+				fmt.Printf(" - %s\n", displayName(pokemon))
+			}
+		})
+	case len(args) >= 2 && args[0] == "add":
+		return partyAdd(cfg, args[1])
+	case len(args) >= 2 && args[0] == "remove":
+		return partyRemove(cfg, args[1])
+	case len(args) >= 1 && args[0] == "validate":
+		return partyValidate(cfg, cfg.Input.Flags["ruleset"])
+	default:
+		fmt.Println("Usage: party [list] | party add <pokemon_name> | party remove <pokemon_name> | party validate [--ruleset <name>]")
+		return nil
+	}
+}
+
+// inParty reports whether a caught Pokémon is in the party.
+func inParty(cfg *config, name string) bool {
+	for _, member := range cfg.State.Party {
+		if member == name {
+			return true
+		}
+	}
+	return false
+}
+
+// boxOf is the box a caught Pokémon is kept in when it isn't in the party.
+func boxOf(cfg *config, name string) string {
+	if box, exists := cfg.State.Boxes[name]; exists {
+		return box
+	}
+	return firstBox
+}
+
+func commandBox(cfg *config, args []string) error {
+	switch {
+	case len(args) == 0 || args[0] == "list":
+		box := ""
+		if len(args) >= 2 {
+			box = args[1]
+		}
+		return boxList(cfg, box)
+	case len(args) >= 3 && args[0] == "move":
+		return boxMove(cfg, args[1], args[2])
+	default:
+		fmt.Println("Usage: box [list [box]] | box move <pokemon_name> <box|party>")
+		return nil
+	}
+}
+
+// boxed is a Pokémon in a box, as `box list` shows it in other formats.
+type boxed struct {
+	Box string `json:"box"`
+	Pokemon
+}
+
+// boxList lists the Pokémon in one box, or in every box if box is empty.
+func boxList(cfg *config, box string) error {
+	var stored []boxed
+	for name, pokemon := range cfg.Caught {
+		if !inParty(cfg, name) && (box == "" || boxOf(cfg, name) == box) {
+			stored = append(stored, boxed{boxOf(cfg, name), pokemon})
+		}
+	}
+	sort.Slice(stored, func(i, j int) bool {
+		if stored[i].Box != stored[j].Box {
+			return stored[i].Box < stored[j].Box
+		}
+		return stored[i].Name < stored[j].Name
+	})
+	stored, err := where(cfg, stored)
+	if err != nil {
+		return err
+	}
+	return show(cfg, stored, func() {
+		switch {
+		case len(stored) == 0 && cfg.Where != nil:
+			fmt.Println("None of the Pokémon in your boxes match.")
+		case len(stored) == 0 && box != "":
+			fmt.Printf("Box %s is empty.\n", box)
+		case len(stored) == 0:
+			fmt.Println("Your boxes are empty; every Pokémon you have is in your party.")
+		}
+		for i, pokemon := range stored {
+			if i == 0 || stored[i-1].Box != pokemon.Box {
+				fmt.Printf("Box %s:\n", pokemon.Box)
+			}
+			fmt.Printf(" - %s\n", displayName(pokemon.Pokemon))
+		}
+	})
+}
+
+// boxMove puts a Pokémon in a box, taking it out of the party, or moves it
+// from its box into the party.
+func boxMove(cfg *config, name, to string) error {
+	if _, exists := cfg.Caught[name]; !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	if to == "party" {
+		return partyAdd(cfg, name)
+	}
+	if !inParty(cfg, name) && boxOf(cfg, name) == to {
+		fmt.Printf("%s is already in box %s.\n", name, to)
+		return nil
+	}
+	for i, member := range cfg.State.Party {
+		if member == name {
+			cfg.State.Party = append(cfg.State.Party[:i], cfg.State.Party[i+1:]...)
+			break
+		}
+	}
+	if to == firstBox {
+		delete(cfg.State.Boxes, name)
+	} else {
+		cfg.State.Boxes[name] = to
+	}
+	fmt.Printf("%s was put in box %s.\n", name, to)
+	return saveState(cfg)
+}
+
+// renameCaught follows a caught Pokémon to its new name, as when it
+// evolves, in the party, teams and boxes.
+func renameCaught(cfg *config, from, to string) {
+	for i, member := range cfg.State.Party {
+		if member == from {
+			cfg.State.Party[i] = to
+		}
+	}
+	for _, members := range cfg.State.Teams {
+		for i, member := range members {
+			if member == from {
+				members[i] = to
+			}
+		}
+	}
+	if box, exists := cfg.State.Boxes[from]; exists {
+		delete(cfg.State.Boxes, from)
+		cfg.State.Boxes[to] = box
+	}
+}
+
+// removeCaught takes a Pokémon out of the Pokedex, and out of the party,
+// teams and boxes with it, as when it is released or traded away.
+func removeCaught(cfg *config, name string) {
+	isName := func(member string) bool { return member == name }
+	delete(cfg.Caught, name)
+	cfg.State.Party = slices.DeleteFunc(cfg.State.Party, isName)
+	for team, members := range cfg.State.Teams {
+		cfg.State.Teams[team] = slices.DeleteFunc(members, isName)
+	}
+	delete(cfg.State.Boxes, name)
+}
+
+func commandNickname(cfg *config, args []string) error {
+	pokemon, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	nickname := strings.Join(args[1:], " ")
+	if utf8.RuneCountInString(nickname) > maxNickname || strings.IndexFunc(nickname, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+		fmt.Printf("Nicknames are up to %d letters, numbers and spaces.\n", maxNickname)
+		return nil
+	}
+	pokemon.Nickname = nickname
+	cfg.Caught[pokemon.Name] = pokemon
+	if nickname == "" {
+		fmt.Printf("Your %s no longer has a nickname.\n", pokemon.Name)
+	} else {
+		fmt.Printf("Your %s is now called %s.\n", pokemon.Name, nickname)
+	}
+	return savePokedex(cfg)
+}
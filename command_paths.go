@@ -0,0 +1,11 @@
+package main
+
+import "fmt"
+
+func commandPaths(cfg *config, args []string) error {
+	fmt.Printf("Config: %s\n", cfg.Paths.Config)
+	fmt.Printf("Data:   %s\n", cfg.Paths.Data)
+	fmt.Printf("Cache:  %s\n", cfg.Paths.Cache)
+	fmt.Printf("Logs:   %s\n", cfg.Paths.Logs)
+	return nil
+}
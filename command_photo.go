@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/photo"
+	"github.com/eymardfreire/pokedexcli/internal/ribbon"
+)
+
+func photosDir(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "photos")
+}
+
+func commandPhoto(cfg *config, args []string) error {
+	pokemon, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+
+	subject := photo.Subject{Name: pokemon.Name, CaughtAt: pokemon.CaughtAt, Ribbons: ribbon.Names(pokemon.Ribbons)}
+	for _, typ := range pokemon.Types {
+		subject.Types = append(subject.Types, typ.Type.Name)
+	}
+	for _, stat := range pokemon.Stats {
+		subject.Stats = append(subject.Stats, photo.Stat{Name: stat.Stat.Name, Value: stat.BaseStat})
+	}
+
+	now := time.Now()
+	card := photo.Card(subject, now)
+	file, err := photo.Save(photosDir(cfg), pokemon.Name, card, now)
+	if err != nil {
+		return err
+	}
+	fmt.Print(say(cfg, card))
+	fmt.Println("Saved to", filepath.Join(photosDir(cfg), file))
+	return nil
+}
+
+func commandAlbum(cfg *config, args []string) error {
+	files, err := photo.List(photosDir(cfg))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Println("Your album is empty. Use `photo <pokemon_name>` to take one.")
+		return nil
+	}
+
+	if len(args) < 1 {
+		fmt.Println("Your album:")
+		for i, file := range files {
+			fmt.Printf(" %d. %s\n", i+1, file)
+		}
+		fmt.Println("Use `album <n>` to show a photo.")
+		return nil
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(files) {
+		fmt.Printf("Please pick a photo between 1 and %d.\n", len(files))
+		return nil
+	}
+	card, err := os.ReadFile(filepath.Join(photosDir(cfg), files[n-1]))
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(card))
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/quest"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+// refreshQuests hands out the day's quests if they haven't been yet.
+// Catch-in quests are set in areas the player has explored.
+func refreshQuests(cfg *config) {
+	areas := make([]string, 0, len(cfg.State.Areas))
+	for area := range cfg.State.Areas {
+		areas = append(areas, area)
+	}
+	sort.Strings(areas)
+	if cfg.State.Quests.Refresh(time.Now(), areas, cfg.RNG.Get(rng.World)) {
+		saveQuests(cfg)
+	}
+}
+
+// watchQuests counts the player's catches and exploring towards their
+// quests, and hands out the rewards.
+func watchQuests(cfg *config) {
+	cfg.Events.Subscribe(events.PokemonCaught, func(e events.Event) {
+		refreshQuests(cfg)
+		finished := cfg.State.Quests.Advance(quest.CatchIn, cfg.Area)
+		if pokemon, ok := cfg.Caught[e.Subject]; ok {
+			finished = append(finished, cfg.State.Quests.Advance(quest.CatchType, typeNames(pokemon)...)...)
+		}
+		rewardQuests(cfg, finished)
+	})
+	cfg.Events.Subscribe(events.AreaExplored, func(e events.Event) {
+		refreshQuests(cfg)
+		rewardQuests(cfg, cfg.State.Quests.Advance(quest.Explore, e.Subject))
+	})
+}
+
+func rewardQuests(cfg *config, finished []quest.Quest) {
+	for _, q := range finished {
+		cfg.State.Items[q.Reward.Item] += q.Reward.Count
+		fmt.Printf("Quest complete: %s! You received %d %s.\n", q, q.Reward.Count, q.Reward.Item)
+	}
+	if len(finished) > 0 {
+		saveQuests(cfg)
+	}
+}
+
+func saveQuests(cfg *config) {
+	if err := saveState(cfg); err != nil {
+		fmt.Println("Error saving quests:", err)
+	}
+}
+
+func commandQuest(cfg *config, args []string) error {
+	refreshQuests(cfg)
+	quests := cfg.State.Quests.Quests
+	return show(cfg, quests, func() {
+		fmt.Println("Today's quests:")
+		for _, q := range quests {
+			mark := "☐"
+			if q.Done() {
+				mark = "☑"
+			}
+			fmt.Printf(say(cfg, " %s %s (%d/%d) — %d %s\n"), say(cfg, mark), say(cfg, q.String()), min(q.Progress, q.Goal), q.Goal, q.Reward.Count, q.Reward.Item)
+		}
+		fmt.Println("New quests arrive each day.")
+	})
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/community"
+	"github.com/eymardfreire/pokedexcli/internal/elo"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/netplay"
+)
+
+func commandRanked(cfg *config, args []string) error {
+	client := communityClient(cfg, "Ranked play")
+	if client == nil {
+		return nil
+	}
+	port, rulesetName := cfg.Input.Flags["port"], cfg.Input.Flags["ruleset"]
+	switch {
+	case len(args) >= 1 && args[0] == "queue":
+		return rankedQueue(cfg, client, args[1:], port, rulesetName)
+	case len(args) >= 1 && args[0] == "ladder":
+		return rankedLadder(cfg, client)
+	default:
+		fmt.Println("Usage: ranked queue [pokemon_name] [--port <port>] [--ruleset <name>] | ranked ladder")
+		return nil
+	}
+}
+
+// rankedQueue waits for the server to find an opponent, then duels them.
+// Every player listens while queued, since the server may pick either side
+// to host. Only Pokémon that follow the ruleset, standard unless another
+// is named, can queue.
+func rankedQueue(cfg *config, client *community.Client, args []string, port, rulesetName string) error {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	} else if len(cfg.State.Party) > 0 {
+		name = cfg.State.Party[0]
+	} else {
+		fmt.Println("Name a Pokémon to queue with, or put one in your party.")
+		return nil
+	}
+	ruleset, ok, err := loadRuleset(cfg, rulesetName)
+	if !ok {
+		return err
+	}
+	if !battleReady(cfg, ruleset, []string{name}) {
+		return nil
+	}
+	player, err := duelist(cfg, name)
+	if player == nil {
+		return err
+	}
+	if port == "" {
+		port = strconv.Itoa(netplay.DefaultPort)
+	}
+	l, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		fmt.Printf("Could not listen on port %s: %v\n", port, err)
+		return nil
+	}
+	defer l.Close()
+
+	ticket, err := client.Queue(community.Entry{
+		Trainer:   cfg.State.Trainer.Name,
+		TrainerID: cfg.State.Trainer.ID,
+		Rating:    cfg.State.Duels.Current(),
+		Pokemon:   player.Name,
+		Port:      l.Addr().(*net.TCPAddr).Port,
+	})
+	if err != nil {
+		fmt.Printf("Could not join the queue: %v\n", err)
+		return nil
+	}
+	fmt.Printf("Queued with %s. Looking for an opponent...\n", player.Name)
+	match, err := waitForMatch(client, ticket)
+	if err != nil {
+		fmt.Printf("Matchmaking failed: %v\n", err)
+		return nil
+	}
+	if match == nil {
+		client.Leave(ticket)
+		fmt.Println("Nobody was found. Try again later.")
+		return nil
+	}
+
+	var score float64
+	var played bool
+	switch match.Role {
+	case community.RoleHost:
+		fmt.Printf("Matched with %s. Waiting for them to connect...\n", match.Opponent)
+		score, played, err = hostDuel(cfg, player, l)
+	case community.RoleGuest:
+		l.Close()
+		score, played, err = joinDuel(cfg, player, match.Opponent)
+	default:
+		return fmt.Errorf("the server gave an unknown role %q", match.Role)
+	}
+	if !played {
+		return err
+	}
+	if score == elo.Win {
+		cfg.Events.Publish(events.Event{Kind: events.RankedWon, Subject: player.Name, Other: match.Opponent})
+	}
+	report := community.Report{TrainerID: cfg.State.Trainer.ID, Result: rankedResult(score)}
+	if err := client.Report(match.ID, report); err != nil {
+		fmt.Printf("Could not report the result to the ladder: %v\n", err)
+		return nil
+	}
+	fmt.Println("The result is on the ladder.")
+	return nil
+}
+
+// waitForMatch polls the server until the ticket is matched. It returns nil
+// if nobody turns up within hostTimeout.
+func waitForMatch(client *community.Client, ticket string) (*community.Match, error) {
+	deadline := time.Now().Add(hostTimeout)
+	for time.Now().Before(deadline) {
+		match, err := client.Poll(ticket)
+		if err != nil || match != nil {
+			return match, err
+		}
+		time.Sleep(community.PollEvery)
+	}
+	return nil, nil
+}
+
+func rankedResult(score float64) string {
+	switch score {
+	case elo.Win:
+		return community.Win
+	case elo.Loss:
+		return community.Loss
+	default:
+		return community.Draw
+	}
+}
+
+func rankedLadder(cfg *config, client *community.Client) error {
+	standings, err := client.Ladder()
+	if err != nil {
+		fmt.Printf("Could not fetch the ladder: %v\n", err)
+		return nil
+	}
+	if len(standings) == 0 {
+		fmt.Println("Nobody has played a ranked duel yet.")
+		return nil
+	}
+	for i, s := range standings {
+		marker := " "
+		if s.TrainerID == cfg.State.Trainer.ID {
+			marker = "*"
+		}
+		fmt.Printf("%s%3d. %-16s %5d  %d-%d-%d\n", marker, i+1, s.Trainer, s.Rating, s.Wins, s.Losses, s.Draws)
+	}
+	return nil
+}
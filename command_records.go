@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/eymardfreire/pokedexcli/internal/sizes"
+)
+
+func commandRecords(cfg *config, args []string) error {
+	if len(cfg.State.Records) == 0 {
+		fmt.Println("No size records yet. Catch some Pokémon first!")
+		return nil
+	}
+	species := make([]string, 0, len(cfg.State.Records))
+	for name := range cfg.State.Records {
+		species = append(species, name)
+	}
+	sort.Strings(species)
+
+	fmt.Println("Size records:")
+	for _, name := range species {
+		record := cfg.State.Records[name]
+		fmt.Printf(" - %s\n", name)
+		fmt.Printf("     biggest:  %s (%s)\n", record.Biggest, record.Biggest.At.Format("2006-01-02"))
+		fmt.Printf("     smallest: %s (%s)\n", record.Smallest, record.Smallest.At.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// recordSize checks a newly caught Pokémon against the species' records.
+func recordSize(cfg *config, pokemon Pokemon) {
+	record := cfg.State.Records[pokemon.Name]
+	biggest, smallest := record.Update(sizes.Measure(pokemon.Height, pokemon.Weight, pokemon.Size, pokemon.CaughtAt))
+	cfg.State.Records[pokemon.Name] = record
+	switch {
+	case biggest && smallest:
+		// The first of its species is trivially both.
+	case biggest:
+		fmt.Printf("New record! This is the biggest %s you have caught.\n", pokemon.Name)
+	case smallest:
+		fmt.Printf("New record! This is the smallest %s you have caught.\n", pokemon.Name)
+	}
+	if err := saveState(cfg); err != nil {
+		fmt.Println("Error saving records:", err)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/fanout"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// regionLocation is a location as `region` lists it.
+type regionLocation struct {
+	Name  string   `json:"name"`
+	Areas []string `json:"areas"`
+}
+
+// regionLocations looks up every location of a region, all at once, and
+// returns those still loading once budget has passed; see fetchEach. They
+// are cached, so it is only slow the first time. each, if not nil, is
+// called with each location in turn as soon as it comes.
+func regionLocations(cfg *config, name string, budget time.Duration, each func(pokeapi.Location)) ([]pokeapi.Location, []string, error) {
+	region, err := cfg.API.GetRegion(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return arrived(fetchEach(cfg, "location", resourceNames(region.Locations), budget, func(_ int, r fanout.Result[pokeapi.Location]) {
+		if each != nil && r.Err == nil && !r.Late {
+			each(r.Value)
+		}
+	}))
+}
+
+// commandRegion lists the regions, or a region's locations with the areas
+// in each, which can be explored straight away.
+func commandRegion(cfg *config, args []string) error {
+	if len(args) == 0 {
+		regions, err := cfg.API.List("region")
+		if err != nil {
+			return err
+		}
+		names := resourceNames(regions)
+		return show(cfg, names, func() {
+			fmt.Println("Regions:")
+			for _, name := range names {
+				fmt.Printf(" - %s\n", name)
+			}
+			fmt.Println("Use `region <name>` to list a region's areas.")
+		})
+	}
+
+	name := strings.ToLower(args[0])
+	// As text, each location is printed as soon as it comes.
+	text := textOutput(cfg)
+	var listed []regionLocation
+	others := 0
+	var filterErr error
+	_, loading, err := regionLocations(cfg, name, fetchBudget, func(location pokeapi.Location) {
+		if len(location.Areas) == 0 {
+			others++
+			return
+		}
+		if filterErr != nil {
+			return
+		}
+		kept, err := where(cfg, []regionLocation{{location.Name, resourceNames(location.Areas)}})
+		if err != nil {
+			filterErr = err
+			return
+		}
+		for _, l := range kept {
+			if text && len(listed) == 0 {
+				fmt.Printf("Locations in %s with areas to explore:\n", name)
+			}
+			listed = append(listed, l)
+			if text {
+				fmt.Printf(" - %s: %s\n", l.Name, strings.Join(l.Areas, ", "))
+			}
+		}
+	})
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no region called %s. Use `region` to list them.\n", name)
+		return nil
+	}
+	if err == nil {
+		err = filterErr
+	}
+	if err != nil {
+		return err
+	}
+	// The areas listed can be explored, and are offered when completing
+	// explore, like those on a map page.
+	cfg.Current = nil
+	for _, location := range listed {
+		cfg.Current = append(cfg.Current, location.Areas...)
+	}
+	return show(cfg, listed, func() {
+		defer printLoading("locations", loading)
+		if len(listed) == 0 {
+			fmt.Printf("None of %s's locations match.\n", name)
+			return
+		}
+		switch {
+		case others == 1:
+			fmt.Printf("1 more place in %s has nothing to explore.\n", name)
+		case others > 1:
+			fmt.Printf("%d more places in %s have nothing to explore.\n", others, name)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// releaseKeep is how long released Pokémon can be recovered for.
+const releaseKeep = 30 * 24 * time.Hour
+
+// released is a Pokémon the player let go, with where it was kept.
+type released struct {
+	Pokemon Pokemon   `json:"pokemon"`
+	At      time.Time `json:"at"`
+	Box     string    `json:"box,omitempty"`
+	InParty bool      `json:"in_party,omitempty"`
+}
+
+// gone reports whether a released Pokémon can no longer be recovered.
+func (r released) gone(now time.Time) bool {
+	return now.Sub(r.At) >= releaseKeep
+}
+
+func commandRelease(cfg *config, args []string) error {
+	pokemon, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return errRefused
+	}
+	if cfg.DryRun {
+		previewRelease(cfg, pokemon)
+		return nil
+	}
+	if !confirm(cfg, fmt.Sprintf("Release %s?", displayName(pokemon))) {
+		fmt.Printf("%s stays with you.\n", displayName(pokemon))
+		return nil
+	}
+	r := released{Pokemon: pokemon, At: time.Now(), Box: cfg.State.Boxes[pokemon.Name], InParty: inParty(cfg, pokemon.Name)}
+	removeCaught(cfg, pokemon.Name)
+	cfg.State.Released = append(cfg.State.Released, r)
+	fmt.Printf("You released %s. Changed your mind? `recover %s` brings it back within %d days.\n",
+		displayName(pokemon), pokemon.Name, int(releaseKeep.Hours()/24))
+	return saveState(cfg)
+}
+
+// commandRecover brings back a released Pokémon, or lists those that can
+// be.
+func commandRecover(cfg *config, args []string) error {
+	now := time.Now()
+	if len(args) == 0 {
+		listReleased(cfg, now)
+		return nil
+	}
+	i := slices.IndexFunc(cfg.State.Released, func(r released) bool { return r.Pokemon.Name == args[0] && !r.gone(now) })
+	if i < 0 {
+		fmt.Printf("You haven't released a %s in the last %d days.\n", args[0], int(releaseKeep.Hours()/24))
+		return nil
+	}
+	r := cfg.State.Released[i]
+	if _, exists := cfg.Caught[r.Pokemon.Name]; exists {
+		fmt.Printf("You already have a %s; release or trade it first.\n", r.Pokemon.Name)
+		return nil
+	}
+	cfg.Caught[r.Pokemon.Name] = r.Pokemon
+	if r.Box != "" {
+		cfg.State.Boxes[r.Pokemon.Name] = r.Box
+	}
+	if r.InParty && len(cfg.State.Party) < maxParty {
+		cfg.State.Party = append(cfg.State.Party, r.Pokemon.Name)
+	}
+	cfg.State.Released = slices.Delete(cfg.State.Released, i, i+1)
+	fmt.Printf("%s is back with you.\n", displayName(r.Pokemon))
+	return saveState(cfg)
+}
+
+func listReleased(cfg *config, now time.Time) {
+	var listed bool
+	for _, r := range cfg.State.Released {
+		if r.gone(now) {
+			continue
+		}
+		if !listed {
+			fmt.Println("Released Pokémon you can still recover:")
+			listed = true
+		}
+		left := int((releaseKeep-now.Sub(r.At)).Hours()/24) + 1
+		fmt.Printf(" - %s, released %s, %d days left\n", displayName(r.Pokemon), r.At.Format("2006-01-02"), left)
+	}
+	if !listed {
+		fmt.Printf("You haven't released any Pokémon in the last %d days.\n", int(releaseKeep.Hours()/24))
+	}
+}
+
+// emptyReleased deletes the released Pokémon that can no longer be
+// recovered, for good.
+func emptyReleased(cfg *config, _ time.Time) error {
+	now := time.Now()
+	before := len(cfg.State.Released)
+	cfg.State.Released = slices.DeleteFunc(cfg.State.Released, func(r released) bool { return r.gone(now) })
+	if len(cfg.State.Released) == before {
+		return nil
+	}
+	return saveState(cfg)
+}
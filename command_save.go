@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// savedPokedex is what save writes: the caught Pokémon and where the
+// player had got to in the location list.
+type savedPokedex struct {
+	SavedAt  time.Time          `json:"saved_at"`
+	Caught   map[string]Pokemon `json:"caught"`
+	Next     string             `json:"next"`
+	Previous string             `json:"previous"`
+}
+
+func pokedexPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "pokedex.json")
+}
+
+func savePokedex(cfg *config) error {
+	if cfg.ReadOnly {
+		return nil
+	}
+	return storage.WriteJSON(pokedexPath(cfg), savedPokedex{
+		SavedAt:  time.Now(),
+		Caught:   cfg.Caught,
+		Next:     cfg.Next,
+		Previous: cfg.Previous,
+	})
+}
+
+// loadPokedex replaces the session with the last save. Pokémon the save
+// doesn't have are taken out of the party and the boxes too. It returns
+// the zero time if nothing has been saved yet.
+func loadPokedex(cfg *config) (time.Time, error) {
+	var saved savedPokedex
+	if err := storage.ReadJSON(pokedexPath(cfg), &saved); err != nil || saved.SavedAt.IsZero() {
+		return time.Time{}, err
+	}
+	cfg.Caught = saved.Caught
+	if cfg.Caught == nil {
+		cfg.Caught = make(map[string]Pokemon)
+	}
+	cfg.Next, cfg.Previous = saved.Next, saved.Previous
+	cfg.State.Party = slices.DeleteFunc(cfg.State.Party, func(member string) bool {
+		_, ok := cfg.Caught[member]
+		return !ok
+	})
+	for name := range cfg.State.Boxes {
+		if _, ok := cfg.Caught[name]; !ok {
+			delete(cfg.State.Boxes, name)
+		}
+	}
+	return saved.SavedAt, nil
+}
+
+func commandSave(cfg *config, args []string) error {
+	if err := saveState(cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d Pokémon.\n", len(cfg.Caught))
+	return nil
+}
+
+func commandLoad(cfg *config, args []string) error {
+	savedAt, err := loadPokedex(cfg)
+	if err != nil {
+		return err
+	}
+	if savedAt.IsZero() {
+		fmt.Println("There is no saved Pokedex yet; use `save` to make one.")
+		return nil
+	}
+	fmt.Printf("Loaded %d Pokémon saved on %s.\n", len(cfg.Caught), savedAt.Format("2006-01-02 15:04"))
+	return nil
+}
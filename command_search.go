@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/fuzzy"
+)
+
+// maxResults is how many matches `search` lists.
+const maxResults = 20
+
+// pokemonIndex is the name of every Pokémon PokeAPI knows. The list is
+// cached like any other response, so it is only downloaded once.
+func pokemonIndex(cfg *config) ([]string, error) {
+	list, err := cfg.API.List("pokemon")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(list))
+	for i, pokemon := range list {
+		names[i] = pokemon.Name
+	}
+	return names, nil
+}
+
+func commandSearch(cfg *config, args []string) error {
+	index, err := pokemonIndex(cfg)
+	if err != nil {
+		return err
+	}
+	query := strings.ToLower(strings.Join(args, "-"))
+	found := fuzzy.Search(query, index)
+	if len(found) == 0 {
+		fmt.Printf("No Pokémon match %s.\n", query)
+		return nil
+	}
+	for _, name := range found[:min(len(found), maxResults)] {
+		if _, caught := cfg.Caught[name]; caught {
+			fmt.Printf(" - %s (caught)\n", name)
+		} else {
+			fmt.Printf(" - %s\n", name)
+		}
+	}
+	if len(found) > maxResults {
+		fmt.Printf("...and %d more.\n", len(found)-maxResults)
+	}
+	return nil
+}
+
+// didYouMean suggests the Pokémon a misspelt name was probably meant to be,
+// as a sentence to add to a message, or returns "".
+func didYouMean(cfg *config, name string) string {
+	index, err := pokemonIndex(cfg)
+	if err != nil {
+		return ""
+	}
+	if suggestion, ok := fuzzy.Closest(name, index); ok {
+		return fmt.Sprintf(" Did you mean %s?", suggestion)
+	}
+	return ""
+}
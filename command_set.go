@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+func commandSet(cfg *config, args []string) error {
+	if cfg.DryRun && args[0] != "dryrun" {
+		fmt.Printf("Dry run: %s would be set to %s.\n", args[0], strings.Join(args[1:], " "))
+		return nil
+	}
+	switch args[0] {
+	case "dryrun":
+		return setDryRun(cfg, args[1])
+	case "generation":
+		return setGeneration(cfg, args[1])
+	case "stamina":
+		return setStamina(cfg, args[1])
+	case "cache":
+		return setCache(cfg, args[1])
+	case "player":
+		return setPlayer(cfg, strings.Join(args[1:], " "))
+	case "server":
+		return setServer(cfg, args[1])
+	case "format":
+		return setFormat(cfg, args[1:])
+	case "tips":
+		return setTips(cfg, args[1])
+	case "sprites":
+		return setSprites(cfg, args[1])
+	case "timeout":
+		return setTimeout(cfg, args[1])
+	case "fakemon":
+		return setFakemon(cfg, args[1])
+	case "pagesize":
+		return setPageSize(cfg, args[1])
+	case "cachettl":
+		return setCacheTTL(cfg, args[1])
+	case "cachebackend":
+		return setCacheBackend(cfg, args[1])
+	case "color":
+		return setColor(cfg, args[1])
+	case "glyphs":
+		return setGlyphs(cfg, args[1])
+	case "api":
+		return setAPI(cfg, args[1])
+	case "accessible":
+		return setAccessible(cfg, args[1])
+	default:
+		fmt.Printf("Unknown setting %s.\n", args[0])
+		return nil
+	}
+}
+
+// maxTimeout is the longest a request to PokeAPI may be given.
+const maxTimeout = 10 * time.Minute
+
+func setTimeout(cfg *config, value string) error {
+	if value == "default" {
+		cfg.Settings.Timeout = ""
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 || d > maxTimeout {
+			fmt.Printf("The timeout must be a duration up to %s, like 10s, or default.\n", maxTimeout)
+			return nil
+		}
+		cfg.Settings.Timeout = d.String()
+	}
+	cfg.API.SetTimeout(cfg.Settings.requestTimeout())
+	fmt.Printf("Requests to PokeAPI now give up after %s.\n", cfg.Settings.requestTimeout())
+	return saveSettings(cfg)
+}
+
+func setGeneration(cfg *config, value string) error {
+	gen := types.Latest
+	if value != "latest" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < types.Gen1 || n > types.Latest {
+			fmt.Printf("Generation must be between %d and %d, or latest.\n", types.Gen1, types.Latest)
+			return nil
+		}
+		gen = n
+	}
+	cfg.State.Generation = gen
+	fmt.Printf("Battles now use generation %d rules.\n", gen)
+	return saveState(cfg)
+}
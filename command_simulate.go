@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+// maxSimulations keeps a mistyped --n from running for hours.
+const maxSimulations = 100000
+
+func commandSimulate(cfg *config, args []string) error {
+	count := cfg.Input.Flags["n"]
+	n := 100
+	if count != "" {
+		var err error
+		n, err = strconv.Atoi(count)
+		if err != nil || n < 1 || n > maxSimulations {
+			fmt.Printf("--n must be between 1 and %d.\n", maxSimulations)
+			return nil
+		}
+	}
+
+	var teams [2][]*battle.Combatant
+	for i, name := range []string{args[0], args[2]} {
+		team, err := simulatedTeam(cfg, name)
+		if err != nil {
+			return err
+		}
+		if len(team) == 0 {
+			fmt.Printf("%s has no Pokémon.\n", name)
+			return nil
+		}
+		teams[i] = team
+	}
+
+	conditions := battle.Conditions{Generation: cfg.State.generation()}
+	var wins [2]int
+	draws, turns := 0, 0
+	start := time.Now()
+	for run := 0; run < n; run++ {
+		if err := cfg.Ctx.Err(); err != nil {
+			return err
+		}
+		result, err := battle.FightTeams(fresh(teams[0]), fresh(teams[1]), battle.Auto{}, battle.Auto{}, conditions, cfg.RNG.Get(rng.Battle))
+		if err != nil {
+			return err
+		}
+		turns += result.Turns
+		if result.Winner == battle.Draw {
+			draws++
+		} else {
+			wins[result.Winner]++
+		}
+	}
+
+	percent := func(k int) float64 { return 100 * float64(k) / float64(n) }
+	fmt.Printf("%d battles in %s:\n", n, time.Since(start).Round(time.Millisecond))
+	fmt.Printf("  %s won %d (%.1f%%)\n", args[0], wins[0], percent(wins[0]))
+	fmt.Printf("  %s won %d (%.1f%%)\n", args[2], wins[1], percent(wins[1]))
+	if draws > 0 {
+		fmt.Printf("  %d draws (%.1f%%)\n", draws, percent(draws))
+	}
+	fmt.Printf("  %.1f turns on average\n", float64(turns)/float64(n))
+	return nil
+}
+
+// simulatedTeam is a saved team, or a comma-separated list of Pokémon.
+// Caught Pokémon battle as they are; other species battle at the default
+// level.
+func simulatedTeam(cfg *config, name string) ([]*battle.Combatant, error) {
+	members, saved := cfg.State.Teams[name]
+	if !saved {
+		members = strings.Split(name, ",")
+	}
+	var team []*battle.Combatant
+	for _, member := range members {
+		pokemon, caught := cfg.Caught[member]
+		if !caught && saved {
+			fmt.Printf("You no longer have %s; leaving it out of %s.\n", member, name)
+			continue
+		}
+		if !caught {
+			var err error
+			if pokemon, err = fetchPokemon(cfg, member); err != nil {
+				return nil, fmt.Errorf("fetching %s: %w", member, err)
+			}
+		}
+		c, err := combatantFor(cfg, pokemon, levelOf(pokemon))
+		if err != nil {
+			return nil, err
+		}
+		team = append(team, c)
+	}
+	return team, nil
+}
+
+func fresh(team []*battle.Combatant) []*battle.Combatant {
+	copies := make([]*battle.Combatant, len(team))
+	for i, c := range team {
+		copies[i] = c.Fresh()
+	}
+	return copies
+}
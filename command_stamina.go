@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/stamina"
+)
+
+// placeOf looks up which location and region an area is in.
+func placeOf(cfg *config, area pokeapi.LocationArea) (stamina.Place, error) {
+	place := stamina.Place{Area: area.Name, Location: area.Location.Name}
+	if place.Location == "" {
+		return place, nil
+	}
+	location, err := cfg.API.GetLocation(place.Location)
+	place.Region = location.Region.Name
+	return place, err
+}
+
+// travel moves the player to area, spending stamina for the trip. It
+// reports whether they got there.
+func travel(cfg *config, area pokeapi.LocationArea) (bool, error) {
+	if cfg.State.StaminaOff {
+		return true, nil
+	}
+	place, err := placeOf(cfg, area)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	cost := stamina.Cost(cfg.State.Place, place)
+	if !cfg.State.Stamina.Spend(cost, now) {
+		fmt.Printf("You're too tired to travel to %s: it takes %d stamina and you have %d.\n", area.Name, cost, cfg.State.Stamina.Current(now))
+		fmt.Println("Rest a while or try `stamina use <item>`.")
+		return false, nil
+	}
+	if cost > 0 {
+		fmt.Printf("Travelled to %s for %d stamina (%d/%d left).\n", area.Name, cost, cfg.State.Stamina.Current(now), stamina.Max)
+	}
+	cfg.State.Place = place
+	return true, saveState(cfg)
+}
+
+func commandStamina(cfg *config, args []string) error {
+	now := time.Now()
+	if len(args) >= 2 && args[0] == "use" {
+		return drink(cfg, args[1], now)
+	}
+	if cfg.State.StaminaOff {
+		fmt.Println("Stamina is turned off; travel is free. Turn it back on with `set stamina on`.")
+		return nil
+	}
+	s := cfg.State.Stamina
+	fmt.Printf("Stamina: %d/%d\n", s.Current(now), stamina.Max)
+	if full := s.Full(now); full > 0 {
+		fmt.Printf("Full again in %s.\n", full)
+	}
+	return nil
+}
+
+func drink(cfg *config, item string, now time.Time) error {
+	amount, ok := stamina.Items[item]
+	if !ok {
+		fmt.Printf("%s doesn't restore stamina.\n", item)
+		return errRefused
+	}
+	if cfg.State.Items[item] == 0 {
+		fmt.Printf("You don't have any %s.\n", item)
+		return errRefused
+	}
+	if cfg.State.Stamina.Current(now) == stamina.Max {
+		fmt.Println("Your stamina is already full.")
+		return errRefused
+	}
+	if cfg.DryRun {
+		previewDrink(cfg, item, now)
+		return nil
+	}
+	cfg.State.Items[item]--
+	restored := cfg.State.Stamina.Restore(amount, now)
+	fmt.Printf("You drank the %s and got %d stamina back (%d/%d).\n", item, restored, cfg.State.Stamina.Current(now), stamina.Max)
+	return saveState(cfg)
+}
+
+func setStamina(cfg *config, value string) error {
+	switch value {
+	case "on":
+		cfg.State.StaminaOff = false
+		fmt.Println("Travelling now costs stamina.")
+	case "off":
+		cfg.State.StaminaOff = true
+		// Where the player is isn't tracked while it is off.
+		cfg.State.Place = stamina.Place{}
+		fmt.Println("Travelling is now free.")
+	default:
+		fmt.Println("Stamina can be on or off.")
+		return nil
+	}
+	return saveState(cfg)
+}
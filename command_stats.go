@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/collection"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// catchTally counts balls thrown at wild Pokémon and how many caught one.
+type catchTally struct {
+	Throws  int `json:"throws"`
+	Catches int `json:"catches"`
+}
+
+// watchCatches counts catch attempts for the rest of the session.
+func watchCatches(cfg *config) {
+	cfg.Events.Subscribe(events.PokemonCaught, func(e events.Event) {
+		cfg.Session.Throws++
+		cfg.Session.Catches++
+	})
+	cfg.Events.Subscribe(events.PokemonEscaped, func(e events.Event) {
+		cfg.Session.Throws++
+	})
+}
+
+// collectionStats is the player's collection as `stats` sums it up.
+type collectionStats struct {
+	collection.Summary
+	// DexCaught is how many species the player has ever caught, out of
+	// DexTotal in the national Pokédex, which is 0 if PokeAPI couldn't
+	// be asked.
+	DexCaught int `json:"dex_caught"`
+	DexTotal  int `json:"dex_total,omitempty"`
+	// Shiny is how many of the Pokémon the player has are shiny, and
+	// ShiniesCaught how many shinies they ever caught.
+	Shiny         int        `json:"shiny"`
+	ShiniesCaught int        `json:"shinies_caught"`
+	Session       catchTally `json:"session"`
+}
+
+func commandStats(cfg *config, args []string) error {
+	var caught []pokeapi.Pokemon
+	for _, pokemon := range cfg.Caught {
+		caught = append(caught, pokemon.Pokemon)
+	}
+	stats := collectionStats{Summary: collection.Summarize(caught), Session: cfg.Session}
+	for _, pokemon := range cfg.Caught {
+		if pokemon.Shiny {
+			stats.Shiny++
+		}
+	}
+	for _, n := range cfg.State.Shinies {
+		stats.ShiniesCaught += n
+	}
+	_, stats.DexCaught = cfg.State.Dex.Counts()
+	species, err := cfg.API.List("pokemon-species")
+	if err != nil {
+		fmt.Println("Couldn't look up how many species there are:", err)
+	} else {
+		stats.DexTotal = len(species)
+	}
+	return show(cfg, stats, func() { printStats(stats) })
+}
+
+func printStats(stats collectionStats) {
+	fmt.Printf("Pokémon caught: %d\n", stats.Caught)
+	if stats.Caught > 0 {
+		var counts []string
+		for _, t := range stats.ByType {
+			counts = append(counts, fmt.Sprintf("%s %d", t.Type, t.Count))
+		}
+		if len(counts) == 0 {
+			counts = append(counts, missing.NoData)
+		}
+		fmt.Printf("By type: %s\n", strings.Join(counts, ", "))
+		average := missing.NoData
+		if stats.AverageBaseExperience > 0 {
+			average = fmt.Sprintf("%.0f", stats.AverageBaseExperience)
+		}
+		fmt.Printf("Average base experience: %s\n", average)
+		fmt.Printf("Heaviest: %s\n", record(stats.Heaviest, "kg"))
+		fmt.Printf("Tallest: %s\n", record(stats.Tallest, "m"))
+	}
+	if stats.DexTotal > 0 {
+		fmt.Printf("National Pokédex: %d of %d species caught (%.1f%%)\n",
+			stats.DexCaught, stats.DexTotal, 100*float64(stats.DexCaught)/float64(stats.DexTotal))
+	} else {
+		fmt.Printf("National Pokédex: %d species caught\n", stats.DexCaught)
+	}
+	if stats.ShiniesCaught > 0 {
+		fmt.Printf("Shiny: %d in your collection, %d caught in all\n", stats.Shiny, stats.ShiniesCaught)
+	}
+	if stats.Session.Throws == 0 {
+		fmt.Println("No balls thrown this session.")
+	} else {
+		fmt.Printf("This session: %d caught with %d throws (%.0f%%)\n",
+			stats.Session.Catches, stats.Session.Throws, 100*float64(stats.Session.Catches)/float64(stats.Session.Throws))
+	}
+}
+
+func record(r *collection.Record, unit string) string {
+	if r == nil {
+		return missing.NoData
+	}
+	return fmt.Sprintf("%s (%s)", r.Name, missing.Tenths(r.Value, unit))
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func commandTasks(cfg *config, args []string) error {
+	s, err := newScheduler(cfg)
+	if err != nil {
+		return err
+	}
+	for i, task := range s.Tasks {
+		status := s.Status[task.Name]
+		last := "never"
+		if !status.LastRun.IsZero() {
+			last = status.LastRun.Format(time.DateTime)
+		}
+		fmt.Printf(" - %s (%s): %s\n", task.Name, task.Schedule.Expr, daemonTasks[i].summary)
+		fmt.Printf("   last ran %s, next at %s\n", last, s.Next(task).Format(time.DateTime))
+		if status.Err != "" {
+			fmt.Printf("   failed: %s\n", status.Err)
+		}
+	}
+	fmt.Println("Tasks only run while `pokedexcli daemon` is running.")
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+)
+
+func commandTeach(cfg *config, args []string) error {
+	name, move := args[0], args[1]
+	pokemon, exists := cfg.Caught[name]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	known := knownMoves(pokemon, levelOf(pokemon))
+	if slices.Contains(known, move) {
+		fmt.Printf("%s already knows %s.\n", name, move)
+		return nil
+	}
+
+	byMachine := moves.Learns(pokemon.Moves, move, moves.ByMachine)
+	byTutor := moves.Learns(pokemon.Moves, move, moves.ByTutor)
+	if !byMachine && !byTutor {
+		fmt.Printf("%s can't be taught %s.\n", name, move)
+		return nil
+	}
+	// A TM in the bag is used if there is one; otherwise the move tutor
+	// teaches the move for free.
+	tm := ""
+	if byMachine {
+		item, err := machineItem(cfg, move)
+		if err != nil {
+			return err
+		}
+		if cfg.State.Items[item] > 0 {
+			tm = item
+		} else if !byTutor {
+			fmt.Printf("You need %s to teach %s.\n", item, move)
+			return nil
+		}
+	}
+
+	if len(known) >= moves.Limit {
+		forget := chooseForgotten(cfg, name, known)
+		if forget < 0 {
+			fmt.Printf("%s did not learn %s.\n", name, move)
+			return nil
+		}
+		fmt.Printf("1, 2 and... Poof! %s forgot %s.\n", name, known[forget])
+		known = slices.Delete(slices.Clone(known), forget, forget+1)
+	}
+	pokemon.Known = append(slices.Clone(known), move)
+	cfg.Caught[name] = pokemon
+
+	if tm != "" {
+		cfg.State.Items[tm]--
+		fmt.Printf("Used %s.\n", tm)
+	}
+	fmt.Printf("%s learned %s!\n", name, move)
+	return saveState(cfg)
+}
+
+// chooseForgotten asks which move to forget to make room, by number or
+// name. It returns -1 if the player gives up on the new move.
+func chooseForgotten(cfg *config, name string, known []string) int {
+	fmt.Printf("%s already knows %d moves:\n", name, len(known))
+	for i, move := range known {
+		fmt.Printf("  %d) %s\n", i+1, move)
+	}
+	for {
+		fmt.Print("Which should it forget? (number or name, blank to cancel) ")
+		answer, err := cfg.In.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" || err != nil {
+			return -1
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(known) {
+			return n - 1
+		}
+		if i := slices.Index(known, answer); i >= 0 {
+			return i
+		}
+		fmt.Printf("%s doesn't know %s.\n", name, answer)
+	}
+}
+
+// machineItem is the TM or HM that teaches move in the most recent games.
+func machineItem(cfg *config, name string) (string, error) {
+	move, err := cfg.API.GetMove(name)
+	if err != nil {
+		return "", err
+	}
+	if len(move.Machines) == 0 {
+		return "", fmt.Errorf("no machine teaches %s", name)
+	}
+	machine, err := cfg.API.GetMachine(move.Machines[len(move.Machines)-1].Machine.URL)
+	return machine.Item.Name, err
+}
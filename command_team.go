@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxParty is how many Pokémon can travel with the player.
+const maxParty = 6
+
+// partyMembers returns the Pokémon in the party, or every caught Pokémon if
+// the party is empty.
+func partyMembers(cfg *config) []Pokemon {
+	var members []Pokemon
+	for _, name := range cfg.State.Party {
+		if pokemon, exists := cfg.Caught[name]; exists {
+			members = append(members, pokemon)
+		}
+	}
+	if len(cfg.State.Party) == 0 {
+		for _, pokemon := range cfg.Caught {
+			members = append(members, pokemon)
+		}
+	}
+	return members
+}
+
+// commandTeam runs the team subcommand the cli framework picked.
+func commandTeam(cfg *config, args []string) error {
+	in := cfg.Input
+	switch strings.Join(in.Path, " ") {
+	case "add":
+		return partyAdd(cfg, in.Args[0])
+	case "remove":
+		return partyRemove(cfg, in.Args[0])
+	case "save":
+		cfg.State.Teams[in.Args[0]] = append([]string(nil), cfg.State.Party...)
+		fmt.Printf("Saved your party as %s.\n", in.Args[0])
+		return saveState(cfg)
+	case "load":
+		return teamLoad(cfg, in.Args[0])
+	case "list":
+		teamList(cfg)
+		return nil
+	default:
+		printParty(cfg)
+		return nil
+	}
+}
+
+func printParty(cfg *config) {
+	if len(cfg.State.Party) == 0 {
+		fmt.Println("Your party is empty. Add Pokémon with `party add <pokemon_name>`.")
+		return
+	}
+	fmt.Println("Your party:")
+	for _, name := range cfg.State.Party {
+		if pokemon, exists := cfg.Caught[name]; exists {
+			name = displayName(pokemon)
+		}
+		fmt.Printf(" - %s\n", name)
+	}
+}
+
+func partyAdd(cfg *config, name string) error {
+	if _, exists := cfg.Caught[name]; !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	for _, member := range cfg.State.Party {
+		if member == name {
+			fmt.Printf("%s is already in your party.\n", name)
+			return nil
+		}
+	}
+	if len(cfg.State.Party) == maxParty {
+		fmt.Printf("Your party is full; it holds %d Pokémon.\n", maxParty)
+		return nil
+	}
+	cfg.State.Party = append(cfg.State.Party, name)
+	fmt.Printf("%s joined your party.\n", name)
+	return saveState(cfg)
+}
+
+func partyRemove(cfg *config, name string) error {
+	for i, member := range cfg.State.Party {
+		if member == name {
+			cfg.State.Party = append(cfg.State.Party[:i], cfg.State.Party[i+1:]...)
+			fmt.Printf("%s left your party.\n", name)
+			return saveState(cfg)
+		}
+	}
+	fmt.Printf("%s is not in your party.\n", name)
+	return nil
+}
+
+// teamLoad makes a saved team the party, as long as the player still has
+// every Pokémon in it.
+func teamLoad(cfg *config, team string) error {
+	members, exists := cfg.State.Teams[team]
+	if !exists {
+		fmt.Printf("There is no team called %s.\n", team)
+		return nil
+	}
+	var missing []string
+	for _, name := range members {
+		if _, exists := cfg.Caught[name]; !exists {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Printf("You no longer have %s, so %s can't be loaded.\n", strings.Join(missing, ", "), team)
+		return nil
+	}
+	cfg.State.Party = append([]string(nil), members...)
+	fmt.Printf("Loaded %s.\n", team)
+	printParty(cfg)
+	return saveState(cfg)
+}
+
+func teamList(cfg *config) {
+	if len(cfg.State.Teams) == 0 {
+		fmt.Println("No saved teams yet.")
+		return
+	}
+	names := make([]string, 0, len(cfg.State.Teams))
+	for name := range cfg.State.Teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf(" - %s: %s\n", name, strings.Join(cfg.State.Teams[name], ", "))
+	}
+}
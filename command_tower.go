@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/rules"
+	"github.com/eymardfreire/pokedexcli/internal/tower"
+)
+
+func commandTower(cfg *config, args []string) error {
+	aiSpec, rulesetName := cfg.Input.Flags["ai"], cfg.Input.Flags["ruleset"]
+	if args[0] == "records" {
+		printTowerRecords(cfg)
+		return nil
+	}
+
+	pokemon, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	// The tower has no rules unless the player picks some.
+	var ruleset rules.Ruleset
+	if rulesetName != "" {
+		r, ok, err := loadRuleset(cfg, rulesetName)
+		if !ok {
+			return err
+		}
+		ruleset = r
+		if !battleReady(cfg, ruleset, []string{pokemon.Name}) {
+			return nil
+		}
+	}
+
+	ai, stopAI, err := startAI(aiSpec)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	defer stopAI()
+
+	player, err := combatantFor(cfg, pokemon, levelOf(pokemon))
+	if err != nil {
+		return err
+	}
+	streak := 0
+	fmt.Printf("Welcome to the Battle Tower! %s will battle until it faints. HP is not restored between rounds.\n", pokemon.Name)
+	for round := 1; ; round++ {
+		opponent := tower.Next(round, player.Level, cfg.RNG.Get(rng.Battle))
+		wild, err := fetchPokemon(cfg, opponent.Species)
+		if err != nil {
+			return err
+		}
+		foe, err := combatantFor(cfg, wild, opponent.Level)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Round %d: %s (Lv. %d) vs %s (Lv. %d)\n", round, player.Name, player.Level, foe.Name, foe.Level)
+		player.ResetStages()
+		result, err := battleWith(cfg, player, foe, ai)
+		if err != nil {
+			return err
+		}
+		if result.Winner != player {
+			fmt.Printf("Your streak ended at %d.\n", streak)
+			break
+		}
+
+		streak++
+		if item, ok := tower.Milestones[streak]; ok {
+			cfg.State.Items[item]++
+			fmt.Printf("Streak of %d! You received a %s.\n", streak, item)
+		}
+		if !towerContinue(cfg, player, ruleset) {
+			fmt.Printf("You left the tower with a streak of %d.\n", streak)
+			break
+		}
+	}
+
+	cfg.Events.Publish(events.Event{Kind: events.TowerStreak, Subject: pokemon.Name, Count: streak})
+	rank := cfg.State.Tower.Add(tower.Entry{
+		Trainer: cfg.State.Trainer.Name,
+		Pokemon: pokemon.Name,
+		Streak:  streak,
+		At:      time.Now(),
+	})
+	if rank > 0 {
+		fmt.Printf("That's #%d on your Battle Tower leaderboard!\n", rank)
+	}
+	return saveState(cfg)
+}
+
+// towerContinue asks what to do between rounds, letting the player use
+// potions from their bag before going on, if the ruleset allows them.
+func towerContinue(cfg *config, player *battle.Combatant, ruleset rules.Ruleset) bool {
+	for {
+		fmt.Printf("%s has %s. [c]ontinue, use a [p]otion (%d left) or [q]uit? ",
+			player.Name, hpText(cfg, player.HP, player.Stats.HP), cfg.State.Items["potion"])
+		answer, _ := cfg.In.ReadString('\n')
+		switch strings.TrimSpace(answer) {
+		case "c", "":
+			return true
+		case "q":
+			return false
+		case "p":
+			if !ruleset.AllowsItem("potion") {
+				fmt.Printf("Potions are banned under %s.\n", ruleset.Name)
+				continue
+			}
+			if cfg.State.Items["potion"] == 0 {
+				fmt.Println("You don't have any potions.")
+				continue
+			}
+			cfg.State.Items["potion"]--
+			fmt.Printf("%s recovered %d HP.\n", player.Name, player.Heal(tower.PotionHeal))
+		}
+	}
+}
+
+func printTowerRecords(cfg *config) {
+	if len(cfg.State.Tower) == 0 {
+		fmt.Println("No Battle Tower streaks yet. Use `tower <pokemon_name>` to take the challenge.")
+		return
+	}
+	fmt.Println("Battle Tower leaderboard:")
+	for i, e := range cfg.State.Tower {
+		fmt.Printf(" %2d. %-12s streak of %d (%s, %s)\n", i+1, e.Pokemon, e.Streak, e.Trainer, e.At.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/friendship"
+	"github.com/eymardfreire/pokedexcli/internal/npc"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/sizes"
+)
+
+// tradeBonus is how many levels above the Pokémon sent the one received in
+// an NPC trade comes at, as thanks.
+const tradeBonus = 1
+
+// commandTrade trades one of the player's Pokémon with a passing trainer
+// for a species of their choice.
+func commandTrade(cfg *config, args []string) error {
+	sent, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return errRefused
+	}
+	wanted := args[1]
+	if wanted == sent.Name {
+		fmt.Printf("Trading %s for another %s wouldn't change anything.\n", sent.Name, wanted)
+		return errRefused
+	}
+	if _, owned := cfg.Caught[wanted]; owned {
+		fmt.Printf("You already have a %s; release or trade it first.\n", wanted)
+		return errRefused
+	}
+	received, err := fetchPokemon(cfg, wanted)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no Pokémon called %s.%s\n", wanted, didYouMean(cfg, wanted))
+		return errRefused
+	}
+	if err != nil {
+		return err
+	}
+
+	t := npc.Generate(levelOf(sent), cfg.RNG.Get(rng.World))
+	partner := trainerID{Name: t.String(), ID: int(t.Seed % 100000)}
+	level := min(levelOf(sent)+tradeBonus, 100)
+	if cfg.DryRun {
+		fmt.Printf("Dry run: %s would get your %s and you would get %s at Lv. %d.\n", partner.Name, displayName(sent), received.Name, level)
+		return nil
+	}
+	if !confirm(cfg, fmt.Sprintf("%s offers a %s for your %s. Trade?", partner.Name, received.Name, displayName(sent))) {
+		fmt.Printf("%s stays with you.\n", displayName(sent))
+		return nil
+	}
+
+	now := time.Now()
+	received.Friendship = friendship.Base
+	received.FriendshipAt = now
+	received.CaughtAt = now
+	received.Size = sizes.Roll(cfg.RNG.Get(rng.World))
+	received.Level = level
+	received.OriginalTrainer = partner
+
+	removeCaught(cfg, sent.Name)
+	cfg.State.Dex.Catch(received.Name, now)
+	fmt.Printf("You sent %s to %s and received %s (Lv. %d)!\n", displayName(sent), partner.Name, received.Name, received.Level)
+	if _, err := receiveTraded(cfg, &sent, received, partner, "trade"); err != nil {
+		return err
+	}
+	return saveState(cfg)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/ledger"
+)
+
+func openTrades(cfg *config) (*ledger.Ledger, error) {
+	return ledger.Open(filepath.Join(cfg.Paths.Data, "trades.json"))
+}
+
+func ledgerTrainer(t trainerID) ledger.Trainer {
+	return ledger.Trainer{Name: t.Name, ID: t.ID}
+}
+
+// recordTrade writes a trade into the ledger, with both Pokémon as they
+// were when they changed hands. sent is nil when what the player gave is
+// no longer known.
+func recordTrade(cfg *config, sent *Pokemon, received Pokemon, partner trainerID, method string) error {
+	if cfg.ReadOnly {
+		return nil
+	}
+	trades, err := openTrades(cfg)
+	if err != nil {
+		return err
+	}
+	trade := ledger.Trade{
+		At:              time.Now(),
+		Method:          method,
+		Player:          ledgerTrainer(cfg.State.Trainer),
+		Partner:         ledgerTrainer(partner),
+		ReceivedSpecies: received.Name,
+	}
+	if trade.Received, err = json.Marshal(received); err != nil {
+		return err
+	}
+	if sent != nil {
+		trade.SentSpecies = sent.Name
+		if trade.Sent, err = json.Marshal(sent); err != nil {
+			return err
+		}
+	}
+	trades.Add(trade)
+	return trades.Save()
+}
+
+// tradeSummary is a trade as `trades list` shows it in other formats,
+// without the Pokémon's snapshots.
+type tradeSummary struct {
+	ID       int       `json:"id"`
+	At       time.Time `json:"at"`
+	Method   string    `json:"method"`
+	Partner  string    `json:"partner"`
+	Sent     string    `json:"sent,omitempty"`
+	Received string    `json:"received"`
+}
+
+func commandTrades(cfg *config, args []string) error {
+	trades, err := openTrades(cfg)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(args) == 0 || args[0] == "list":
+		return listTrades(cfg, trades.Search(""), "")
+	case len(args) >= 2 && args[0] == "search":
+		query := strings.Join(args[1:], " ")
+		return listTrades(cfg, trades.Search(query), query)
+	case len(args) >= 2 && args[0] == "show":
+		return showTrade(cfg, trades, args[1])
+	default:
+		fmt.Println("Usage: trades [list] | trades search <query> | trades show <id>")
+		return nil
+	}
+}
+
+func listTrades(cfg *config, found []ledger.Trade, query string) error {
+	var summaries []tradeSummary
+	for _, t := range found {
+		summaries = append(summaries, tradeSummary{t.ID, t.At, t.Method, t.Partner.Name, t.SentSpecies, t.ReceivedSpecies})
+	}
+	summaries, err := where(cfg, summaries)
+	if err != nil {
+		return err
+	}
+	return show(cfg, summaries, func() {
+		switch {
+		case len(summaries) == 0 && cfg.Where != nil:
+			fmt.Println("No trades match.")
+		case len(summaries) == 0 && query != "":
+			fmt.Printf("No trades mention %q.\n", query)
+		case len(summaries) == 0:
+			fmt.Println("No trades yet.")
+		}
+		for _, t := range summaries {
+			sent := t.Sent
+			if sent == "" {
+				sent = "?"
+			}
+			fmt.Printf("%3d  %s  %s: %s for %s from %s\n", t.ID, t.At.Format("2006-01-02 15:04"), t.Method, sent, t.Received, t.Partner)
+		}
+	})
+}
+
+// showTrade shows one trade in full, with both Pokémon as they were.
+func showTrade(cfg *config, trades *ledger.Ledger, arg string) error {
+	id, err := strconv.Atoi(arg)
+	trade, ok := trades.Get(id)
+	if err != nil || !ok {
+		fmt.Printf("There is no trade %s. See `trades list`.\n", arg)
+		return nil
+	}
+	var sent, received Pokemon
+	if len(trade.Sent) > 0 {
+		if err := json.Unmarshal(trade.Sent, &sent); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(trade.Received, &received); err != nil {
+		return err
+	}
+	return show(cfg, trade, func() {
+		fmt.Printf("Trade %d, %s by %s\n", trade.ID, trade.At.Format("2006-01-02 15:04"), trade.Method)
+		fmt.Printf("Between %s and %s\n", trainerID(trade.Player), trainerID(trade.Partner))
+		if sent.Name != "" {
+			fmt.Printf("Sent: %s\n", tradedDescription(sent))
+		}
+		fmt.Printf("Received: %s\n", tradedDescription(received))
+	})
+}
+
+// tradedDescription sums up a traded Pokémon as it was, like
+// "pikachu, Lv. 12, OT Ash (ID 00042)".
+func tradedDescription(pokemon Pokemon) string {
+	parts := []string{pokemon.Name}
+	if pokemon.Nickname != "" {
+		parts[0] += fmt.Sprintf(" (%s)", pokemon.Nickname)
+	}
+	if pokemon.Level > 0 {
+		parts = append(parts, fmt.Sprintf("Lv. %d", pokemon.Level))
+	}
+	if pokemon.Shiny {
+		parts = append(parts, "shiny")
+	}
+	if pokemon.OriginalTrainer.ID != 0 {
+		parts = append(parts, "OT "+pokemon.OriginalTrainer.String())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/tutorial"
+)
+
+func commandTutorial(cfg *config, args []string) error {
+	progress := &cfg.State.Tutorial
+	if len(args) > 0 && args[0] == "restart" {
+		*progress = tutorial.Progress{}
+	}
+	if progress.Completed {
+		fmt.Println("You have already completed the tutorial. Use `tutorial restart` to go through it again.")
+		return nil
+	}
+	if !progress.Started {
+		progress.Started = true
+		fmt.Println("Welcome, trainer! Let's learn the basics.")
+		if err := saveState(cfg); err != nil {
+			return err
+		}
+	}
+	step, _ := progress.Current()
+	fmt.Printf("Step %d of %d: %s\n", progress.Step+1, len(tutorial.Steps), step.Instruction)
+	return nil
+}
+
+// watchTutorial advances the tutorial as the player performs each step.
+func watchTutorial(cfg *config) {
+	for _, step := range tutorial.Steps {
+		cfg.Events.Subscribe(step.Event, func(e events.Event) {
+			progress := &cfg.State.Tutorial
+			if !progress.Advance(e) {
+				return
+			}
+			if next, ok := progress.Current(); ok {
+				fmt.Printf("Tutorial: well done! Next, step %d of %d: %s\n", progress.Step+1, len(tutorial.Steps), next.Instruction)
+			} else {
+				cfg.State.Items["poke-ball"] += cfg.Balance.TutorialReward
+				fmt.Printf("Tutorial complete! You received %d Poké Balls.\n", cfg.Balance.TutorialReward)
+			}
+			if err := saveState(cfg); err != nil {
+				fmt.Println("Error saving progress:", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/fuzzy"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+// typeInfo is a type's matchups as `type` shows them.
+type typeInfo struct {
+	Name             string   `json:"name"`
+	SuperEffective   []string `json:"super_effective_against"`
+	NotVeryEffective []string `json:"not_very_effective_against"`
+	NoEffect         []string `json:"no_effect_on"`
+	WeakTo           []string `json:"weak_to"`
+	Resists          []string `json:"resists"`
+	ImmuneTo         []string `json:"immune_to"`
+}
+
+func commandType(cfg *config, args []string) error {
+	name := strings.ToLower(args[0])
+	details, err := cfg.API.GetType(name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		hint := ""
+		if suggestion, ok := fuzzy.Closest(name, types.Names(types.Latest)); ok {
+			hint = fmt.Sprintf(" Did you mean %s?", suggestion)
+		}
+		fmt.Printf("There is no type called %s.%s\n", name, hint)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r := details.DamageRelations
+	info := typeInfo{
+		Name:             details.Name,
+		SuperEffective:   resourceNames(r.DoubleDamageTo),
+		NotVeryEffective: resourceNames(r.HalfDamageTo),
+		NoEffect:         resourceNames(r.NoDamageTo),
+		WeakTo:           resourceNames(r.DoubleDamageFrom),
+		Resists:          resourceNames(r.HalfDamageFrom),
+		ImmuneTo:         resourceNames(r.NoDamageFrom),
+	}
+	return show(cfg, info, func() {
+		fmt.Printf("%s attacks are\n", info.Name)
+		printMatchup("super effective against", info.SuperEffective)
+		printMatchup("not very effective against", info.NotVeryEffective)
+		printMatchup("no use against", info.NoEffect)
+		fmt.Printf("%s Pokémon are\n", info.Name)
+		printMatchup("weak to", info.WeakTo)
+		printMatchup("resistant to", info.Resists)
+		printMatchup("immune to", info.ImmuneTo)
+	})
+}
+
+func resourceNames(resources []pokeapi.NamedResource) []string {
+	names := []string{}
+	for _, r := range resources {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func printMatchup(label string, names []string) {
+	if len(names) > 0 {
+		fmt.Printf("  %s: %s\n", label, strings.Join(names, ", "))
+	}
+}
+
+// weakness is how hard an attack of one type hits a Pokémon.
+type weakness struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// commandWeakness shows how hard attacks of each type hit a Pokémon,
+// counting both its types, under the rules of the generation being played.
+func commandWeakness(cfg *config, args []string) error {
+	pokemon, ok, err := lookUpPokemon(cfg, args[0])
+	if !ok {
+		return err
+	}
+	defending := typeNames(pokemon)
+	if len(defending) == 0 {
+		fmt.Printf("PokeAPI doesn't know %s's types.\n", pokemon.Name)
+		return nil
+	}
+	gen := cfg.State.generation()
+	multipliers := types.Defending(gen, defending)
+	var table []weakness
+	for _, t := range types.Names(gen) {
+		table = append(table, weakness{t, multipliers[t]})
+	}
+	return show(cfg, table, func() {
+		fmt.Printf("Attacks against %s (%s):\n", pokemon.Name, strings.Join(defending, "/"))
+		for _, g := range types.Grouped(multipliers) {
+			fmt.Printf("  %-6s %s\n", strconv.FormatFloat(g.Multiplier, 'f', -1, 64)+"x", strings.Join(g.Types, ", "))
+		}
+		fmt.Println("Every other type hits it normally.")
+	})
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/eymardfreire/pokedexcli/internal/update"
+)
+
+// version is set when building a release, with
+// -ldflags "-X main.version=v1.2.3".
+var version = "dev"
+
+func commandUpdate(cfg *config, args []string) error {
+	checkOnly := cfg.Input.Flags["check-only"] != ""
+	source := update.GitHub("eymardfreire/pokedexcli")
+	release, err := source.Latest(cfg.Ctx)
+	if err != nil {
+		return fmt.Errorf("checking for updates: %w", err)
+	}
+
+	if version == "dev" {
+		fmt.Printf("The latest release is %s. This is a development build, so it can't update itself; build from source instead.\n", release.Tag)
+		return nil
+	}
+	if !update.Newer(release.Tag, version) {
+		fmt.Printf("You have the latest version, %s.\n", version)
+		return nil
+	}
+	fmt.Printf("%s is available (you have %s).\n", release.Tag, version)
+	if checkOnly {
+		return nil
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Downloading...")
+	data, err := source.Download(cfg.Ctx, release)
+	if err != nil {
+		return fmt.Errorf("downloading the update: %w", err)
+	}
+	if err := update.Replace(path, data); err != nil {
+		return fmt.Errorf("installing the update: %w", err)
+	}
+	fmt.Printf("Updated to %s. Restart the Pokedex to use it.\n", release.Tag)
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+	"github.com/eymardfreire/pokedexcli/internal/npc"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+)
+
+func commandChallenge(cfg *config, args []string) error {
+	aiSpec := cfg.Input.Flags["ai"]
+	pokemon, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+
+	ai, stopAI, err := startAI(aiSpec)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	defer stopAI()
+
+	trainer := npc.Generate(levelOf(pokemon), cfg.RNG.Get(rng.Battle))
+	fmt.Printf("%s wants to battle!\n", trainer)
+	if err := battleTrainer(cfg, pokemon, &trainer, ai); err != nil {
+		return err
+	}
+	if trainer.Wins == 0 {
+		return nil
+	}
+
+	registered := cfg.State.Trainers.Register(trainer)
+	fmt.Printf("%s was added to your VS Seeker. Use `vsseeker %d <pokemon_name>` for a rematch later.\n", registered, registered.ID)
+	return saveState(cfg)
+}
+
+func commandVSSeeker(cfg *config, args []string) error {
+	aiSpec := cfg.Input.Flags["ai"]
+	registry := &cfg.State.Trainers
+	if len(args) < 2 {
+		if len(registry.Trainers) == 0 {
+			fmt.Println("You have not battled any trainers yet. Use `challenge <pokemon_name>` to find one.")
+			return nil
+		}
+		now := time.Now()
+		fmt.Println("Trainers in your VS Seeker:")
+		for _, t := range registry.Trainers {
+			status := "ready for a rematch"
+			if ready := t.ReadyAt(); now.Before(ready) {
+				status = fmt.Sprintf("ready in %s", ready.Sub(now).Round(time.Minute))
+			}
+			fmt.Printf(" %d. %-20s won %d, lost %d, %s\n", t.ID, t.String(), t.Wins, t.Losses, status)
+		}
+		fmt.Println("Use `vsseeker <id> <pokemon_name>` to battle one again.")
+		return nil
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Println("Trainer ids look like `3`.")
+		return nil
+	}
+	trainer, ok := registry.Find(id)
+	if !ok {
+		fmt.Println("There is no trainer with that id in your VS Seeker.")
+		return nil
+	}
+	pokemon, exists := cfg.Caught[args[1]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	if ready := trainer.ReadyAt(); time.Now().Before(ready) {
+		fmt.Printf("%s isn't ready yet. Try again in %s.\n", trainer, time.Until(ready).Round(time.Minute))
+		return nil
+	}
+
+	ai, stopAI, err := startAI(aiSpec)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	defer stopAI()
+
+	trainer.Rematches++
+	fmt.Printf("%s wants a rematch! Their team has grown stronger.\n", trainer)
+	if err := battleTrainer(cfg, pokemon, trainer, ai); err != nil {
+		return err
+	}
+	return saveState(cfg)
+}
+
+// battleTrainer fights a trainer's whole team with one Pokémon, without
+// healing in between, and records the result against the trainer.
+func battleTrainer(cfg *config, pokemon Pokemon, trainer *npc.Trainer, ai battle.Controller) error {
+	player, err := combatantFor(cfg, pokemon, levelOf(pokemon))
+	if err != nil {
+		return err
+	}
+	won := true
+	side := &battle.Side{}
+	for _, member := range trainer.Team() {
+		opponent, err := fetchPokemon(cfg, member.Species)
+		if err != nil {
+			return err
+		}
+		foe, err := combatantFor(cfg, opponent, member.Level)
+		if err != nil {
+			return err
+		}
+		foe.Side = side
+		fmt.Printf("%s sent out %s (Lv. %d)!\n", trainer, foe.Name, foe.Level)
+		result, err := battleWith(cfg, player, foe, ai)
+		if err != nil {
+			return err
+		}
+		if result.Winner != player {
+			won = false
+			break
+		}
+	}
+
+	trainer.LastBattle = time.Now()
+	if won {
+		trainer.Wins++
+		fmt.Printf("You defeated %s!\n", trainer)
+	} else {
+		trainer.Losses++
+		fmt.Printf("You lost to %s.\n", trainer)
+	}
+	fmt.Printf("Head-to-head against %s: %d won, %d lost.\n", trainer, trainer.Wins, trainer.Losses)
+	return nil
+}
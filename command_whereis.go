@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/roamer"
+)
+
+func commandWhereis(cfg *config, args []string) error {
+	ro := &cfg.State.Roamer
+	if !ro.Active() {
+		fmt.Println("No roaming Pokémon are on the loose.")
+		return nil
+	}
+	fmt.Println(ro.Hint())
+	return nil
+}
+
+// setupRoamer releases a roamer for new saves and keeps it wandering as
+// commands are run.
+func setupRoamer(cfg *config) {
+	if cfg.State.Roamer.Species == "" {
+		cfg.State.Roamer = roamer.New(cfg.RNG.Get(rng.World))
+		saveState(cfg)
+	}
+	cfg.Events.Subscribe(events.PokemonCaught, func(e events.Event) {
+		if e.Subject == cfg.State.Roamer.Species {
+			cfg.State.Roamer.Caught = true
+			saveState(cfg)
+		}
+	})
+}
+
+// roamerHere reports whether the roamer is in the area the player last
+// explored.
+func roamerHere(cfg *config) bool {
+	ro := cfg.State.Roamer
+	return ro.Active() && cfg.Area != "" && ro.Area == cfg.Area
+}
+
+func tickRoamer(cfg *config) {
+	if cfg.State.Roamer.Tick(cfg.RNG.Get(rng.World)) {
+		saveState(cfg)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/friendship"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/sizes"
+	"github.com/eymardfreire/pokedexcli/internal/wondertrade"
+)
+
+// wonderBot is the trainer behind every wonder trade.
+var wonderBot = trainerID{Name: wondertrade.BotName, ID: 100000}
+
+func commandWonderTrade(cfg *config, args []string) error {
+	now := time.Now()
+	allowance := &cfg.State.WonderTrades
+	if len(args) < 1 {
+		fmt.Printf("Wonder trades left today: %d of %d\n", allowance.Remaining(now), wondertrade.DailyLimit)
+		return nil
+	}
+	offered, exists := cfg.Caught[args[0]]
+	if !exists {
+		fmt.Println("You have not caught that Pokémon.")
+		return nil
+	}
+	if allowance.Remaining(now) <= 0 {
+		fmt.Println("You have used all your wonder trades for today. Come back tomorrow!")
+		return nil
+	}
+	if cfg.DryRun {
+		previewWonderTrade(offered, allowance.Remaining(now)-1)
+		return nil
+	}
+
+	// Avoid sending back a species the player already has, since that would
+	// replace it in the Pokedex.
+	var species, rarity string
+	for i := 0; i < 10; i++ {
+		species, rarity = wondertrade.Pick(cfg.RNG.Get(rng.World))
+		if _, owned := cfg.Caught[species]; !owned || species == offered.Name {
+			break
+		}
+	}
+
+	received, err := fetchPokemon(cfg, species)
+	if err != nil {
+		return err
+	}
+	received.Friendship = friendship.Base
+	received.FriendshipAt = now
+	received.CaughtAt = now
+	received.Size = sizes.Roll(cfg.RNG.Get(rng.World))
+	received.Level = 1 + cfg.RNG.Get(rng.World).Intn(30)
+	received.OriginalTrainer = wonderBot
+
+	removeCaught(cfg, offered.Name)
+	allowance.Use(now)
+	fmt.Printf("You sent %s into the wonder trade...\n", offered.Name)
+	fmt.Printf("You received %s (%s) from %s!\n", received.Name, rarity, wonderBot.Name)
+	if _, err := receiveTraded(cfg, &offered, received, wonderBot, "wonder trade"); err != nil {
+		return err
+	}
+	return saveState(cfg)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/cli"
+	"github.com/eymardfreire/pokedexcli/internal/community"
+)
+
+// communityClient returns a client for the player's community server, or
+// nil after explaining that feature needs one.
+func communityClient(cfg *config, feature string) *community.Client {
+	switch {
+	case cfg.State.Server == "":
+		fmt.Printf("%s needs a community server; choose one with `set server <url>`.\n", feature)
+		return nil
+	case cfg.API.Offline():
+		fmt.Printf("%s needs the community server, and you're offline; use `offline off` to go back online.\n", feature)
+		return nil
+	}
+	return community.New(cfg.State.Server)
+}
+
+// server is the player's community server, or "" while they are offline.
+func server(cfg *config) string {
+	if cfg.API.Offline() {
+		return ""
+	}
+	return cfg.State.Server
+}
+
+// friendCode returns the player's friend code, signing up with the server
+// the first time.
+func friendCode(cfg *config, client *community.Client) (string, error) {
+	if cfg.State.FriendCode != "" {
+		return cfg.State.FriendCode, nil
+	}
+	code, err := client.Register(community.Player{Trainer: cfg.State.Trainer.Name, TrainerID: cfg.State.Trainer.ID})
+	if err != nil {
+		return "", err
+	}
+	cfg.State.FriendCode = code
+	return code, saveState(cfg)
+}
+
+// permissions are what commands need from the player's role on their
+// community server, by command or "command subcommand"; a command's entry
+// doesn't cover its named subcommands. Anything else, and everything
+// without a server, is open to everyone.
+var permissions = map[string]string{
+	"battle":       community.Battle,
+	"challenge":    community.Battle,
+	"vsseeker":     community.Battle,
+	"tower":        community.Battle,
+	"duel host":    community.Battle,
+	"duel join":    community.Battle,
+	"ranked queue": community.Battle,
+	"wondertrade":  community.Trade,
+	"offer put":    community.Trade,
+	"offer accept": community.Trade,
+	"friends gift": community.Trade,
+
+	"admin spawn":       community.Administer,
+	"admin grant":       community.Administer,
+	"admin event start": community.Administer,
+	"admin stats":       community.Administer,
+}
+
+// permission is what running cmd as typed in needs, if anything.
+func permission(cmd cliCommand, in cli.Input) string {
+	return permissions[strings.Join(append([]string{cmd.Name}, in.Path...), " ")]
+}
+
+// serverRole is the player's role on their community server, asked for once
+// a session.
+func serverRole(cfg *config) (string, error) {
+	if cfg.Role != "" {
+		return cfg.Role, nil
+	}
+	client := community.New(cfg.State.Server)
+	code, err := friendCode(cfg, client)
+	if err != nil {
+		return "", err
+	}
+	cfg.Role, err = client.Role(code)
+	return cfg.Role, err
+}
+
+func setServer(cfg *config, value string) error {
+	if value == "off" {
+		value = ""
+		fmt.Println("Online play is off.")
+	} else if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fmt.Println("The server must be an http:// or https:// URL, or off.")
+		return nil
+	} else {
+		fmt.Printf("Online play now uses %s.\n", value)
+	}
+	// Friend codes belong to a server.
+	if value != cfg.State.Server {
+		cfg.State.FriendCode = ""
+		clear(cfg.State.Gifted)
+	}
+	cfg.State.Server = value
+	cfg.Role = ""
+	cfg.Chat.connect(server(cfg))
+	return saveState(cfg)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/completion"
+	"github.com/eymardfreire/pokedexcli/internal/paths"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+// runCompletion handles `pokedexcli completion <shell>`, and the
+// `completion names <kind>` the scripts call back with. It returns the
+// exit code.
+func runCompletion(args []string) int {
+	if len(args) == 2 && args[0] == "names" {
+		return printNames(args[1])
+	}
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: pokedexcli completion %s\n", strings.Join(completion.Shells, "|"))
+		return 2
+	}
+	var commands []completion.Command
+	for _, c := range commandRegistry() {
+		commands = append(commands, completion.Command{Name: c.Name, Description: c.Summary, Flags: c.FlagNames(), Names: c.Kind()})
+	}
+	script, err := completion.Script(args[0], "pokedexcli", commands)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	fmt.Print(script)
+	return 0
+}
+
+// printNames lists the player's names of one kind, one per line: the
+// Pokémon they've caught, the species they've seen, the areas they know,
+// the types, or the items in their bag.
+func printNames(kind string) int {
+	switch kind {
+	case "pokemon", "species", "area", "type", "item":
+	default:
+		return 2
+	}
+	dirs, err := paths.Resolve()
+	if err != nil {
+		return 1
+	}
+	cfg := &config{Paths: dirs, Caught: make(map[string]Pokemon)}
+	if err := loadState(cfg); err != nil {
+		return 1
+	}
+	if _, err := loadPokedex(cfg); err != nil {
+		return 1
+	}
+
+	names := nameCandidates(cfg, kind)
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return 0
+}
+
+// nameCandidates lists the player's names of one kind, in no particular
+// order. Areas include those on the last `map` page.
+func nameCandidates(cfg *config, kind string) []string {
+	var names []string
+	switch kind {
+	case "pokemon":
+		for name := range cfg.Caught {
+			names = append(names, name)
+		}
+	case "species":
+		names = cfg.State.Dex.Species()
+	case "area":
+		known := make(map[string]bool)
+		for _, area := range cfg.Current {
+			known[area] = true
+		}
+		for area := range cfg.State.Areas {
+			known[area] = true
+		}
+		for bookmark := range cfg.State.Bookmarks {
+			known[bookmark] = true
+		}
+		for area := range cfg.State.Notes {
+			known[area] = true
+		}
+		for name := range known {
+			names = append(names, name)
+		}
+	case "type":
+		names = types.Names(cfg.State.generation())
+	case "item":
+		for item, n := range cfg.State.Items {
+			if n > 0 {
+				names = append(names, item)
+			}
+		}
+	}
+	return names
+}
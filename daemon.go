@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/schedule"
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// daemonTask is a task the daemon runs on a schedule. Its run is given
+// when it last ran, or the zero time.
+type daemonTask struct {
+	name     string
+	summary  string
+	schedule string
+	run      func(cfg *config, last time.Time) error
+}
+
+// daemonTasks are the daemon's tasks, in the order they run, with their
+// default schedules.
+var daemonTasks = []daemonTask{
+	{"cache", "Drop expired PokeAPI responses from the disk cache", "30 4 * * *", pruneCache},
+	{"roamer", "Move the roaming legendary to another area", "*/30 * * * *", moveRoamer},
+	{"farm", "Tell you about berries that have ripened", "*/10 * * * *", ripenBerries},
+	{"supply", "Hand out the day's balls", "@daily", func(cfg *config, _ time.Time) error { return collectSupply(cfg) }},
+	{"released", "Delete Pokémon released too long ago to recover", "0 5 * * *", emptyReleased},
+}
+
+// schedulesPath is where players change when tasks run, as a map of task
+// names to cron expressions.
+func schedulesPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Config, "tasks.json")
+}
+
+// taskRunsPath is where the daemon records how its tasks last went.
+func taskRunsPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "task-runs.json")
+}
+
+// newScheduler returns a scheduler for the daemon's tasks, on the schedules
+// in tasks.json, with how they last went.
+func newScheduler(cfg *config) (*schedule.Scheduler, error) {
+	schedules := make(map[string]string)
+	if err := storage.ReadJSON(schedulesPath(cfg), &schedules); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", schedulesPath(cfg), err)
+	}
+	s := &schedule.Scheduler{Since: time.Now()}
+	if err := storage.ReadJSON(taskRunsPath(cfg), &s.Status); err != nil {
+		return nil, err
+	}
+	for _, t := range daemonTasks {
+		expr, ok := schedules[t.name]
+		if !ok {
+			expr = t.schedule
+		}
+		delete(schedules, t.name)
+		when, err := schedule.Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("task %s: %w", t.name, err)
+		}
+		name, run := t.name, t.run
+		s.Tasks = append(s.Tasks, schedule.Task{Name: name, Schedule: when, Run: func() error {
+			// Another session may have played since the last task.
+			if err := loadState(cfg); err != nil {
+				return err
+			}
+			return run(cfg, s.Status[name].LastRun)
+		}})
+	}
+	for name := range schedules {
+		return nil, fmt.Errorf("%s: there is no %s task", schedulesPath(cfg), name)
+	}
+	return s, nil
+}
+
+// runDaemon runs the scheduled tasks until interrupted.
+func runDaemon(cfg *config) int {
+	s, err := newScheduler(cfg)
+	if err != nil {
+		fmt.Println("Error loading tasks:", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cfg.Ctx = ctx
+	cfg.API.SetContext(ctx)
+	fmt.Println("Running scheduled tasks; press Ctrl-C to stop.")
+	for _, task := range s.Tasks {
+		fmt.Printf("  %s (%s), next at %s\n", task.Name, task.Schedule.Expr, s.Next(task).Format(time.DateTime))
+	}
+	s.Run(ctx, func(ran []string) {
+		for _, name := range ran {
+			if msg := s.Status[name].Err; msg != "" {
+				fmt.Printf("%s Task %s failed: %s\n", time.Now().Format(time.DateTime), name, msg)
+			}
+		}
+		if err := storage.WriteJSON(taskRunsPath(cfg), s.Status); err != nil {
+			fmt.Println("Error saving task runs:", err)
+		}
+	})
+	return 0
+}
+
+func pruneCache(cfg *config, _ time.Time) error {
+	stored, err := storedCache(cfg)
+	if err != nil {
+		return err
+	}
+	// Backends that expire entries themselves have nothing to prune.
+	pruner, ok := stored.(interface{ Prune() (int, error) })
+	if !ok {
+		return nil
+	}
+	n, err := pruner.Prune()
+	if n > 0 {
+		fmt.Printf("Dropped %d expired PokeAPI responses.\n", n)
+	}
+	return err
+}
+
+func moveRoamer(cfg *config, _ time.Time) error {
+	if !cfg.State.Roamer.Active() {
+		return nil
+	}
+	cfg.State.Roamer.Move(cfg.RNG.Get(rng.World))
+	return saveState(cfg)
+}
+
+// ripenBerries tells the player about the berries that ripened since it
+// last ran.
+func ripenBerries(cfg *config, last time.Time) error {
+	now := time.Now()
+	var ripe []string
+	for _, plot := range cfg.State.Farm.Plots {
+		if plot.ReadyAt().After(last) && plot.Ready(now) {
+			ripe = append(ripe, plot.Berry)
+		}
+	}
+	if len(ripe) > 0 {
+		fmt.Printf("Ready to harvest in your farm: %s.\n", strings.Join(ripe, ", "))
+	}
+	return nil
+}
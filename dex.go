@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+)
+
+// watchDex registers species in the Pokédex as the player comes across
+// them. Catching is registered by addToPokedex.
+func watchDex(cfg *config) {
+	see := func(e events.Event) {
+		if cfg.State.Dex.See(e.Subject, time.Now()) {
+			if err := saveState(cfg); err != nil {
+				fmt.Println("Error saving progress:", err)
+			}
+		}
+	}
+	cfg.Events.Subscribe(events.PokemonSeen, see)
+	cfg.Events.Subscribe(events.PokemonEscaped, see)
+}
+
+// seen is a species in the Pokédex, as `pokedex --seen` shows it in other
+// formats.
+type seen struct {
+	Species string `json:"species"`
+	Caught  bool   `json:"caught"`
+}
+
+func seenSpecies(cfg *config) []seen {
+	var species []seen
+	for _, name := range cfg.State.Dex.Species() {
+		species = append(species, seen{name, cfg.State.Dex.Caught(name)})
+	}
+	return species
+}
+
+// printSeen lists species seen, marking the ones caught.
+func printSeen(species []seen) {
+	fmt.Println("Pokémon you have seen:")
+	for _, s := range species {
+		if s.Caught {
+			fmt.Printf(" - %s (caught)\n", s.Species)
+		} else {
+			fmt.Printf(" - %s\n", s.Species)
+		}
+	}
+}
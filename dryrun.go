@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+	"github.com/eymardfreire/pokedexcli/internal/stamina"
+	"github.com/eymardfreire/pokedexcli/internal/wondertrade"
+)
+
+// takeDryRun takes --dry-run out of a command line and reports whether it
+// was there.
+func takeDryRun(parts []string) ([]string, bool) {
+	var rest []string
+	found := false
+	for _, part := range parts {
+		if part == "--dry-run" {
+			found = true
+			continue
+		}
+		rest = append(rest, part)
+	}
+	return rest, found
+}
+
+func setDryRun(cfg *config, value string) error {
+	switch value {
+	case "on":
+		cfg.State.DryRun = true
+		fmt.Println("Commands now only show what they would do; nothing in your game changes.")
+	case "off":
+		cfg.State.DryRun = false
+		fmt.Println("Commands change your game again.")
+	default:
+		fmt.Println("Dry run can be on or off.")
+		return nil
+	}
+	return saveState(cfg)
+}
+
+// previewCatch describes a catch attempt that succeeds with chance.
+func previewCatch(cfg *config, pokemon Pokemon, ball string, chance float64) {
+	fmt.Printf("Dry run: a %s would catch %s %.0f%% of the time, leaving you %d.\n",
+		ballName(ball), pokemon.Name, chance*100, cfg.State.Items[ball]-1)
+	if levels, ok := cfg.Encounters[pokemon.Name]; ok && levels.Max > 0 {
+		fmt.Printf(say(cfg, "If caught, it would be Lv. %d–%d, met at %s.\n"), levels.Min, levels.Max, cfg.Area)
+	}
+	if _, owned := cfg.Caught[pokemon.Name]; owned {
+		fmt.Printf("It would take the place of the %s you have.\n", pokemon.Name)
+	}
+}
+
+// previewBerry describes holding out a berry, with the odds a poké ball
+// would have against each Pokémon in the area last explored.
+func previewBerry(cfg *config, item string) error {
+	fmt.Printf("Dry run: holding out a %s would make your next catch %.1f× as likely, leaving you %d.\n",
+		item, capture.Berries[item], cfg.State.Items[item]-1)
+	if len(cfg.Encounters) == 0 {
+		fmt.Println("Explore an area to see how it changes the odds there.")
+		return nil
+	}
+	fmt.Printf("With a %s in %s:\n", ballName("poke-ball"), cfg.Area)
+	names := make([]string, 0, len(cfg.Encounters))
+	for name := range cfg.Encounters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		rate, err := captureRate(cfg, name)
+		if err != nil {
+			return err
+		}
+		without := capture.Chance(rate, 1, capture.Throw{Ball: "poke-ball"})
+		with := capture.Chance(rate, 1, capture.Throw{Ball: "poke-ball", Berry: item})
+		fmt.Printf("  %s: %.0f%% → %.0f%%\n", name, without*100, with*100)
+	}
+	return nil
+}
+
+// previewDrink describes drinking item for stamina at now.
+func previewDrink(cfg *config, item string, now time.Time) {
+	current := cfg.State.Stamina.Current(now)
+	restored := min(stamina.Items[item], stamina.Max-current)
+	fmt.Printf("Dry run: drinking the %s would give you %d stamina back (%d/%d), leaving you %d.\n",
+		item, restored, current+restored, stamina.Max, cfg.State.Items[item]-1)
+}
+
+// previewRelease describes releasing a Pokémon, and everywhere it would
+// be taken out of.
+func previewRelease(cfg *config, pokemon Pokemon) {
+	fmt.Printf("Dry run: %s would be released; `recover %s` would bring it back within %d days.\n",
+		displayName(pokemon), pokemon.Name, int(releaseKeep.Hours()/24))
+	if inParty(cfg, pokemon.Name) {
+		fmt.Println("It would leave your party.")
+	}
+	if box, ok := cfg.State.Boxes[pokemon.Name]; ok {
+		fmt.Printf("It would leave box %s.\n", box)
+	}
+	var teams []string
+	for team, members := range cfg.State.Teams {
+		if slices.Contains(members, pokemon.Name) {
+			teams = append(teams, team)
+		}
+	}
+	if len(teams) > 0 {
+		slices.Sort(teams)
+		fmt.Printf("It would leave your teams: %s.\n", strings.Join(teams, ", "))
+	}
+}
+
+// previewWonderTrade describes a wonder trade that would leave left trades
+// for the day.
+func previewWonderTrade(offered Pokemon, left int) {
+	total := 0
+	for _, rarity := range wondertrade.Pool {
+		total += rarity.Weight
+	}
+	var odds []string
+	for _, rarity := range wondertrade.Pool {
+		odds = append(odds, fmt.Sprintf("%s %d%%", rarity.Name, rarity.Weight*100/total))
+	}
+	fmt.Printf("Dry run: %s would go into the wonder trade for a random Pokémon (%s).\n", offered.Name, strings.Join(odds, ", "))
+	fmt.Printf("You would have %d wonder trades left today.\n", left)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+// Rarities, by the best chance of meeting a Pokémon in any version.
+const (
+	common   = "common"
+	uncommon = "uncommon"
+	rare     = "rare"
+)
+
+// bestChance is the percent chance of meeting an encounter in the version
+// where it is likeliest.
+func bestChance(encounter pokeapi.PokemonEncounter) int {
+	chance := 0
+	for _, version := range encounter.VersionDetails {
+		chance = max(chance, version.MaxChance)
+	}
+	return chance
+}
+
+// rarity describes how often an encounter with a percent chance comes up:
+// common at 30% or more, rare under 10%.
+func rarity(chance int) string {
+	switch {
+	case chance >= 30:
+		return common
+	case chance >= 10:
+		return uncommon
+	}
+	return rare
+}
+
+// encounterFilter narrows down the Pokémon `explore` lists. Zero fields
+// don't filter.
+type encounterFilter struct {
+	Type     string
+	MinLevel int
+	MaxLevel int
+	Rarity   string
+	Method   string
+	// Version only counts encounters in one version of the games, like
+	// red.
+	Version string
+	// Sort orders the Pokémon by rarity or level rather than as PokeAPI
+	// lists them, and Details lists every way each is met.
+	Sort    string
+	Details bool
+}
+
+// exploreFilter is the filter explore's flags ask for. It returns a
+// message for the player if one of them is wrong.
+func exploreFilter(flags map[string]string) (encounterFilter, string) {
+	f := encounterFilter{
+		Type:    flags["type"],
+		Rarity:  flags["rarity"],
+		Method:  flags["method"],
+		Sort:    flags["sort"],
+		Version: flags["version"],
+		Details: flags["details"] != "",
+	}
+	minLevel, maxLevel := flags["min-level"], flags["max-level"]
+
+	if f.Type != "" && !types.Exists(types.Latest, f.Type) {
+		return f, fmt.Sprintf("There is no %s type.", f.Type)
+	}
+	if f.Rarity != "" && !slices.Contains([]string{common, uncommon, rare}, f.Rarity) {
+		return f, "Rarity is common, uncommon or rare."
+	}
+	if f.Sort != "" && f.Sort != "rarity" && f.Sort != "level" {
+		return f, "Pokémon can be sorted by rarity or level."
+	}
+	for _, level := range []struct {
+		value string
+		to    *int
+	}{{minLevel, &f.MinLevel}, {maxLevel, &f.MaxLevel}} {
+		if level.value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(level.value)
+		if err != nil || n < 1 {
+			return f, "Levels must be whole numbers from 1."
+		}
+		*level.to = n
+	}
+	return f, ""
+}
+
+func (f encounterFilter) active() bool {
+	return f != encounterFilter{Sort: f.Sort, Details: f.Details}
+}
+
+// keep reports whether a Pokémon found in an area passes the filter.
+// Filtering by type looks up the Pokémon.
+func (f encounterFilter) keep(cfg *config, found foundPokemon) (bool, error) {
+	levels := found.levels
+	if f.MinLevel > 0 && levels.Max < f.MinLevel || f.MaxLevel > 0 && levels.Min > f.MaxLevel {
+		return false, nil
+	}
+	if f.Rarity != "" && rarity(found.chance) != f.Rarity {
+		return false, nil
+	}
+	if f.Method != "" && !metBy(found.encounter, f.Method) {
+		return false, nil
+	}
+	if f.Type != "" {
+		pokemon, err := fetchPokemon(cfg, found.encounter.Pokemon.Name)
+		if err != nil {
+			return false, err
+		}
+		return slices.Contains(typeNames(pokemon), f.Type), nil
+	}
+	return true, nil
+}
+
+// sortEncounters orders the Pokémon found in an area: the most common
+// first, or the lowest levels first.
+func sortEncounters(found []foundPokemon, by string) {
+	switch by {
+	case "rarity":
+		slices.SortStableFunc(found, func(a, b foundPokemon) int {
+			return b.chance - a.chance
+		})
+	case "level":
+		slices.SortStableFunc(found, func(a, b foundPokemon) int {
+			if a.levels.Min != b.levels.Min {
+				return a.levels.Min - b.levels.Min
+			}
+			return a.levels.Max - b.levels.Max
+		})
+	}
+}
+
+// methods are the ways an encounter can happen, like walk or surf.
+func methods(encounter pokeapi.PokemonEncounter) []string {
+	var names []string
+	for _, version := range encounter.VersionDetails {
+		for _, detail := range version.EncounterDetails {
+			if !slices.Contains(names, detail.Method.Name) {
+				names = append(names, detail.Method.Name)
+			}
+		}
+	}
+	return names
+}
+
+// metBy reports whether an encounter can happen by method, like walk or
+// surf.
+func metBy(encounter pokeapi.PokemonEncounter, method string) bool {
+	for _, version := range encounter.VersionDetails {
+		for _, detail := range version.EncounterDetails {
+			if detail.Method.Name == method {
+				return true
+			}
+		}
+	}
+	return false
+}
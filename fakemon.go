@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/fakemon"
+	"github.com/eymardfreire/pokedexcli/internal/validate"
+)
+
+// fakemonDir holds the homebrew species packs the player installed.
+func fakemonDir(cfg *config) string {
+	return filepath.Join(cfg.Paths.Config, "fakemon")
+}
+
+// loadFakemon makes the installed packs' species part of the game if the
+// player opted in to them, or takes them out if not.
+func loadFakemon(cfg *config) error {
+	cfg.Fakemon = nil
+	cfg.API.SetLocal(nil)
+	if !cfg.State.Fakemon {
+		return nil
+	}
+	packs, err := fakemon.LoadDir(fakemonDir(cfg))
+	if err != nil {
+		return err
+	}
+	set, err := fakemon.NewSet(cfg.API.BaseURL, packs)
+	if err != nil {
+		return err
+	}
+	cfg.Fakemon = set
+	cfg.API.SetLocal(set)
+	return nil
+}
+
+func setFakemon(cfg *config, value string) error {
+	switch value {
+	case "on":
+		cfg.State.Fakemon = true
+	case "off":
+		cfg.State.Fakemon = false
+	default:
+		fmt.Println("Homebrew species can be on or off.")
+		return nil
+	}
+	if err := loadFakemon(cfg); err != nil {
+		fmt.Println("Error loading homebrew species:", err)
+		cfg.State.Fakemon = false
+		return nil
+	}
+	if cfg.State.Fakemon {
+		fmt.Printf("Homebrew species from %d pack(s) can be met in the wild.\n", len(cfg.Fakemon.Packs()))
+	} else {
+		fmt.Println("Only PokeAPI's species can be met from now on. Those you caught stay with you.")
+	}
+	return saveState(cfg)
+}
+
+func commandFakemon(cfg *config, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "import":
+			return importFakemon(cfg, args[1])
+		case "remove":
+			return removeFakemon(cfg, args[1])
+		}
+	}
+	packs, err := fakemon.LoadDir(fakemonDir(cfg))
+	if err != nil {
+		return err
+	}
+	if len(packs) == 0 {
+		fmt.Println("No homebrew species packs are installed. Add one with `fakemon import <file>`.")
+		return nil
+	}
+	return show(cfg, packs, func() {
+		for _, p := range packs {
+			by := ""
+			if p.Author != "" {
+				by = " by " + p.Author
+			}
+			var names []string
+			for _, s := range p.Species {
+				names = append(names, validate.Namespace+s.Name)
+			}
+			fmt.Printf("%s%s: %s\n", p.Name, by, strings.Join(names, ", "))
+		}
+		if !cfg.State.Fakemon {
+			fmt.Println("They are off; use `set fakemon on` to meet them in the wild.")
+		}
+	})
+}
+
+// importFakemon checks a pack and installs it, replacing any pack of the
+// same name.
+func importFakemon(cfg *config, path string) error {
+	p, err := fakemon.Load(path)
+	if err != nil {
+		fmt.Println("That pack can't be used:", err)
+		return nil
+	}
+	dir := fakemonDir(cfg)
+	installed, err := fakemon.LoadDir(dir)
+	if err != nil {
+		return err
+	}
+	others := []*fakemon.Pack{p}
+	for _, other := range installed {
+		if other.Name != p.Name {
+			others = append(others, other)
+		}
+	}
+	if _, err := fakemon.NewSet(cfg.API.BaseURL, others); err != nil {
+		fmt.Println("That pack can't be used with those installed:", err)
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, p.Name+".json"), data, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Installed %s with %d species.\n", p.Name, len(p.Species))
+	if !cfg.State.Fakemon {
+		fmt.Println("Use `set fakemon on` to meet them in the wild.")
+		return nil
+	}
+	return loadFakemon(cfg)
+}
+
+func removeFakemon(cfg *config, name string) error {
+	file, err := validate.Within(fakemonDir(cfg), name+".json")
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	if err := os.Remove(file); errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("There is no pack called %s. Use `fakemon` to list them.\n", name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	fmt.Printf("Removed %s. Its species you caught stay with you.\n", name)
+	return loadFakemon(cfg)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/fanout"
+)
+
+// fetchBudget is how long a command waits on the requests it makes all at
+// once before showing what it has, and noting what is still loading.
+const fetchBudget = 5 * time.Second
+
+// fetchEach looks up a resource of each name, like every move a Pokémon
+// learns, all at once. It waits for them up to budget, or for every one if
+// budget is 0. Requests it stops waiting for carry on into the cache, so
+// they are there the next time.
+//
+// each, if not nil, is called with the index and result of each name in
+// turn as soon as it is in, so a command can print what has come while
+// the rest loads.
+func fetchEach[T any](cfg *config, resource string, names []string, budget time.Duration, each func(int, fanout.Result[T])) []fanout.Result[T] {
+	done, progress := 0, terminal() && !cfg.Settings.Accessible && len(names) > 1
+	results := fanout.Fetch(cfg.Ctx, names, prefetchWorkers, budget, func(name string) (T, error) {
+		var v T
+		data, err := cfg.API.GetContext(context.Background(), cfg.API.URL(resource, name))
+		if err == nil {
+			err = json.Unmarshal(data, &v)
+		}
+		return v, err
+	}, func(r fanout.Result[T]) {
+		if each != nil {
+			if progress {
+				fmt.Print("\r\033[K")
+			}
+			each(done, r)
+		}
+		done++
+		if progress {
+			fmt.Printf("\rLoading %d of %d from PokeAPI...\033[K", done, len(names))
+		}
+	})
+	if progress {
+		fmt.Print("\r\033[K")
+	}
+	return results
+}
+
+// arrived splits results into the values that came and the names still
+// loading, or returns the first error.
+func arrived[T any](results []fanout.Result[T]) ([]T, []string, error) {
+	var values []T
+	var loading []string
+	for _, r := range results {
+		switch {
+		case r.Late:
+			loading = append(loading, r.Key)
+		case r.Err != nil:
+			return nil, nil, r.Err
+		default:
+			values = append(values, r.Value)
+		}
+	}
+	return values, loading, nil
+}
+
+// printLoading notes what a command left out because it was still
+// loading.
+func printLoading(what string, loading []string) {
+	if len(loading) > 0 {
+		fmt.Printf("Still loading %s after %s: %s. Run it again in a moment to see them.\n", what, fetchBudget, strings.Join(loading, ", "))
+	}
+}
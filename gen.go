@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/eymardfreire/pokedexcli/internal/cli"
+	"github.com/eymardfreire/pokedexcli/internal/docs"
+	"github.com/eymardfreire/pokedexcli/internal/refdocs"
+)
+
+// genCommand is how `pokedexcli gen` is typed.
+var genCommand = cli.Command{
+	Name:    "pokedexcli gen",
+	Summary: "Generate files from the command registry",
+	Subcommands: []cli.Command{{
+		Name:    "docs",
+		Summary: "Write the man page and Markdown reference",
+		Flags: []cli.Flag{
+			{Name: "dir", Value: "dir", Usage: "Where to write them; docs if not given"},
+			{Name: "check", Usage: "Only report whether they are up to date"},
+		},
+	}},
+}
+
+// runGen handles `pokedexcli gen docs [--dir <dir>] [--check]`, which writes
+// the man page and Markdown reference from the command registry. With
+// --check it only reports whether the files are up to date. It returns the
+// exit code.
+func runGen(args []string) int {
+	in, err := genCommand.Parse(args)
+	var usage *cli.UsageError
+	switch {
+	case errors.Is(err, cli.ErrHelp):
+		fmt.Print(genCommand.Help())
+		return 0
+	case errors.As(err, &usage):
+		fmt.Fprintf(os.Stderr, "%s\nUsage: %s\n", usage.Problem, usage.Usage)
+		return 2
+	}
+	dir, check := in.Flags["dir"], in.Flags["check"] != ""
+	if dir == "" {
+		dir = "docs"
+	}
+
+	var commands []refdocs.Command
+	for _, c := range commandRegistry() {
+		commands = append(commands, refdocs.Command{Name: c.Name, Description: c.Summary, Flags: c.FlagNames(), Names: c.Kind()})
+	}
+	files := refdocs.Files("pokedexcli", commands, docs.Topics())
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stale := 0
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if check {
+			if current, err := os.ReadFile(path); err != nil || !bytes.Equal(current, []byte(files[name])) {
+				fmt.Fprintf(os.Stderr, "%s is out of date; run `pokedexcli gen docs`.\n", path)
+				stale++
+			}
+			continue
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if err := os.WriteFile(path, []byte(files[name]), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Println("Wrote", path)
+	}
+	if stale > 0 {
+		return 1
+	}
+	return 0
+}
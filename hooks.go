@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/hooks"
+)
+
+// hooksPath is where players set commands of their own to run at points in
+// the game.
+func hooksPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Config, "hooks.json")
+}
+
+// runHooks runs the player's hooks at point. Dry runs change nothing, so
+// they run none.
+func runHooks(cfg *config, point hooks.Point, data hooks.Data) {
+	if cfg.DryRun || len(cfg.Hooks[point]) == 0 {
+		return
+	}
+	data.Area = cfg.Area
+	data.Caught = len(cfg.Caught)
+	for _, err := range cfg.Hooks.Run(cfg.Ctx, point, data) {
+		fmt.Printf("The %s hook failed: %v\n", point, err)
+		if cfg.Log != nil {
+			cfg.Log.Printf("%s hook: %v", point, err)
+		}
+	}
+}
+
+// watchHooks runs the catch and level-up hooks.
+func watchHooks(cfg *config) {
+	cfg.Events.Subscribe(events.PokemonCaught, func(e events.Event) {
+		runHooks(cfg, hooks.Catch, hooks.Data{Pokemon: e.Subject, Level: levelOf(cfg.Caught[e.Subject])})
+	})
+	cfg.Events.Subscribe(events.PokemonLevelled, func(e events.Event) {
+		runHooks(cfg, hooks.LevelUp, hooks.Data{Pokemon: e.Subject, Level: e.Count})
+	})
+}
+
+func commandHooks(cfg *config, args []string) error {
+	if len(cfg.Hooks) == 0 {
+		fmt.Printf("No hooks are set. Add them to %s.\n", hooksPath(cfg))
+		return nil
+	}
+	for _, point := range hooks.Points {
+		for _, h := range cfg.Hooks[point] {
+			timeout := h.Timeout
+			if timeout == "" {
+				timeout = hooks.DefaultTimeout.String()
+			}
+			fmt.Printf(" - %s: %s (up to %s)\n", point, strings.Join(h.Command, " "), timeout)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package balance holds the numbers that decide how hard the game is, like
+// the odds of catching a Pokémon and how much experience a battle is
+// worth, and reads changes to them from a JSON file, so players and
+// modders can tune the game without rebuilding it.
+package balance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+	"github.com/eymardfreire/pokedexcli/internal/finds"
+	"github.com/eymardfreire/pokedexcli/internal/tutorial"
+)
+
+// Balance is every number the game can be tuned with.
+type Balance struct {
+	// CatchRate multiplies every species' capture rate: 2 makes every
+	// Pokémon twice as easy to catch.
+	CatchRate float64 `json:"catch_rate"`
+	// Balls multiply the capture rate of the Pokémon they are thrown at.
+	// A master ball never fails, whatever it is set to.
+	Balls map[string]float64 `json:"balls"`
+	// ShinyOdds is one in how many Pokémon caught are shiny.
+	ShinyOdds int `json:"shiny_odds"`
+	// FleeChance is the chance a wild Pokémon runs off before a ball can
+	// be thrown at it.
+	FleeChance float64 `json:"flee_chance"`
+	// FindChance is the chance of finding an item each time an area is
+	// explored.
+	FindChance float64 `json:"find_chance"`
+	// BattleXP multiplies the experience Pokémon get from winning battles,
+	// and TrainerXP the trainer's experience from catching them.
+	BattleXP  float64 `json:"battle_xp"`
+	TrainerXP float64 `json:"trainer_xp"`
+	// DailySupply are the balls handed out each day the player plays, and
+	// TutorialReward the Poké Balls for finishing the tutorial.
+	DailySupply    map[string]int `json:"daily_supply"`
+	TutorialReward int            `json:"tutorial_reward"`
+	// Prices are what items cost in Pokédollars, which advise weighs ways
+	// of catching a Pokémon by.
+	Prices map[string]int `json:"prices"`
+}
+
+// defaults are taken before Apply can change the capture package's tables.
+var defaults = Balance{
+	CatchRate:      1,
+	Balls:          maps.Clone(capture.Balls),
+	ShinyOdds:      4096,
+	FindChance:     finds.Chance,
+	BattleXP:       1,
+	TrainerXP:      1,
+	DailySupply:    maps.Clone(capture.Supply),
+	TutorialReward: tutorial.Reward,
+	Prices:         maps.Clone(capture.Prices),
+}
+
+// Default is the game as it comes.
+func Default() Balance {
+	b := defaults
+	b.Balls = maps.Clone(b.Balls)
+	b.DailySupply = maps.Clone(b.DailySupply)
+	b.Prices = maps.Clone(b.Prices)
+	return b
+}
+
+// Load reads the balance file at path. What it leaves out keeps its
+// default, so it only needs what is changed; a missing file changes
+// nothing.
+func Load(path string) (Balance, error) {
+	b := Default()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return Balance{}, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// A misspelt setting would otherwise be ignored without a word.
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&b); err != nil {
+		return Balance{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := b.check(); err != nil {
+		return Balance{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+func (b Balance) check() error {
+	switch {
+	case b.CatchRate <= 0:
+		return fmt.Errorf("catch_rate is %g; it must be above 0", b.CatchRate)
+	case b.ShinyOdds < 1:
+		return fmt.Errorf("shiny_odds is %d; it is one in how many, so at least 1", b.ShinyOdds)
+	case b.FleeChance < 0 || b.FleeChance >= 1:
+		return fmt.Errorf("flee_chance is %g; it must be from 0 up to, but not, 1", b.FleeChance)
+	case b.FindChance < 0 || b.FindChance > 1:
+		return fmt.Errorf("find_chance is %g; it must be from 0 to 1", b.FindChance)
+	case b.BattleXP < 0 || b.TrainerXP < 0:
+		return fmt.Errorf("experience can't be multiplied by less than 0")
+	case b.TutorialReward < 0:
+		return fmt.Errorf("tutorial_reward is %d; it can't be below 0", b.TutorialReward)
+	}
+	for ball, m := range b.Balls {
+		if !strings.HasSuffix(ball, "-ball") {
+			return fmt.Errorf("%s isn't a ball; their names end in -ball", ball)
+		}
+		if m <= 0 {
+			return fmt.Errorf("%s multiplies the capture rate by %g; it must be above 0", ball, m)
+		}
+	}
+	for ball, n := range b.DailySupply {
+		if _, ok := b.Balls[ball]; !ok {
+			return fmt.Errorf("the daily supply has %s, which isn't in balls", ball)
+		}
+		if n < 0 {
+			return fmt.Errorf("the daily supply has %d %s; it can't be below 0", n, ball)
+		}
+	}
+	for item, price := range b.Prices {
+		if price < 0 {
+			return fmt.Errorf("%s costs %d; prices can't be below 0", item, price)
+		}
+	}
+	return nil
+}
+
+// Apply makes the capture package's tables follow the balance, for the
+// rest of the game.
+func (b Balance) Apply() {
+	capture.Balls = b.Balls
+	capture.Supply = b.DailySupply
+	capture.Prices = b.Prices
+}
+
+// Scale multiplies an amount, like experience, by m, rounding to the
+// nearest whole number.
+func Scale(n int, m float64) int {
+	return int(float64(n)*m + 0.5)
+}
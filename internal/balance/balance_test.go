@@ -0,0 +1,90 @@
+package balance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+)
+
+func write(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "balance.json")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMissing(t *testing.T) {
+	b, err := Load(filepath.Join(t.TempDir(), "balance.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.CatchRate != 1 || b.ShinyOdds != 4096 || b.Balls["great-ball"] != 1.5 || b.DailySupply["poke-ball"] != 10 {
+		t.Errorf("expected the defaults, got %+v", b)
+	}
+}
+
+func TestLoadOnlyChanges(t *testing.T) {
+	b, err := Load(write(t, `{"shiny_odds": 512, "balls": {"great-ball": 2, "dusk-ball": 3}, "daily_supply": {"dusk-ball": 2}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ShinyOdds != 512 || b.Balls["great-ball"] != 2 || b.Balls["dusk-ball"] != 3 || b.DailySupply["dusk-ball"] != 2 {
+		t.Errorf("expected the changes, got %+v", b)
+	}
+	if b.Balls["ultra-ball"] != 2 || b.DailySupply["poke-ball"] != 10 || b.CatchRate != 1 {
+		t.Errorf("expected what the file leaves out to keep its default, got %+v", b)
+	}
+	if Default().Balls["great-ball"] != 1.5 {
+		t.Error("loading a balance changed the defaults")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{"shiny_odd": 512}`,
+		`{"catch_rate": 0}`,
+		`{"shiny_odds": 0}`,
+		`{"flee_chance": 1}`,
+		`{"battle_xp": -1}`,
+		`{"balls": {"great": 2}}`,
+		`{"balls": {"great-ball": 0}}`,
+		`{"daily_supply": {"dusk-ball": 2}}`,
+		`{"prices": {"potion": -5}}`,
+		`not json`,
+	} {
+		_, err := Load(write(t, data))
+		if err == nil {
+			t.Errorf("%s: expected an error", data)
+		} else if !strings.Contains(err.Error(), "balance.json") {
+			t.Errorf("%s: expected the error to name the file, got %v", data, err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	balls, supply, prices := capture.Balls, capture.Supply, capture.Prices
+	t.Cleanup(func() { capture.Balls, capture.Supply, capture.Prices = balls, supply, prices })
+	b := Default()
+	b.Balls["dusk-ball"] = 3
+	b.Apply()
+	if _, ok := capture.Ball("dusk"); !ok {
+		t.Error("expected a ball added by the balance to be thrown like any other")
+	}
+	if Default().Balls["dusk-ball"] != 0 {
+		t.Error("applying a balance changed the defaults")
+	}
+}
+
+func TestScale(t *testing.T) {
+	if got := Scale(112, 1.5); got != 168 {
+		t.Errorf("got %d, want 168", got)
+	}
+	if got := Scale(5, 0.5); got != 3 {
+		t.Errorf("got %d, want 3, rounded to the nearest", got)
+	}
+}
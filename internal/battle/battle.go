@@ -0,0 +1,616 @@
+// Package battle simulates fights between two Pokémon.
+package battle
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+// MaxTurns stops battles between two Pokémon that cannot hurt each other.
+const MaxTurns = 100
+
+// Power is the base power of the attack every combatant uses.
+const Power = 50
+
+type Stats struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"special_attack"`
+	SpDefense int `json:"special_defense"`
+	Speed     int `json:"speed"`
+}
+
+// StatsAt computes stats at a level from base stats, keyed by their PokeAPI
+// names.
+func StatsAt(base map[string]int, level int) Stats {
+	other := func(name string) int {
+		return 2*base[name]*level/100 + 5
+	}
+	return Stats{
+		HP:        2*base["hp"]*level/100 + level + 10,
+		Attack:    other("attack"),
+		Defense:   other("defense"),
+		SpAttack:  other("special-attack"),
+		SpDefense: other("special-defense"),
+		Speed:     other("speed"),
+	}
+}
+
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Stats Stats
+	HP    int
+	// Ability may change the weather or terrain when the combatant enters
+	// the battle.
+	Ability string
+	// Moves are the moves the combatant knows. Combatants without moves use
+	// a plain attack of the class their controller asks for.
+	Moves []moves.Move
+	// Stages are in-battle stat stages, keyed by PokeAPI stat name.
+	Stages map[string]int
+	// Side is the combatant's side of the battlefield. Combatants that
+	// share a side, like a trainer's team, share its hazards and screens.
+	Side *Side
+
+	flinched bool
+	entered  bool
+}
+
+func NewCombatant(name string, level int, types []string, base map[string]int) *Combatant {
+	stats := StatsAt(base, level)
+	return &Combatant{
+		Name:   name,
+		Level:  level,
+		Types:  types,
+		Stats:  stats,
+		HP:     stats.HP,
+		Stages: make(map[string]int),
+	}
+}
+
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+// Heal restores up to amount HP and returns how much was restored.
+func (c *Combatant) Heal(amount int) int {
+	if c.HP+amount > c.Stats.HP {
+		amount = c.Stats.HP - c.HP
+	}
+	c.HP += amount
+	return amount
+}
+
+func (c *Combatant) hurt(amount int) {
+	c.HP -= amount
+	if c.HP < 0 {
+		c.HP = 0
+	}
+}
+
+// MaxStage is how far a stat stage can be raised or lowered.
+const MaxStage = 6
+
+// ChangeStage moves a stat stage by delta, staying within MaxStage, and
+// returns how much it actually changed.
+func (c *Combatant) ChangeStage(stat string, delta int) int {
+	if c.Stages == nil {
+		c.Stages = make(map[string]int)
+	}
+	before := c.Stages[stat]
+	after := before + delta
+	if after > MaxStage {
+		after = MaxStage
+	}
+	if after < -MaxStage {
+		after = -MaxStage
+	}
+	c.Stages[stat] = after
+	return after - before
+}
+
+// ResetStages clears stat stages and other volatile battle state, as when
+// a Pokémon is switched out.
+func (c *Combatant) ResetStages() {
+	c.Stages = make(map[string]int)
+	c.flinched = false
+}
+
+// StageMultiplier is how much a stat stage multiplies a stat: +1 is 1.5x
+// and -1 is 2/3.
+func StageMultiplier(stage int) float64 {
+	if stage >= 0 {
+		return float64(2+stage) / 2
+	}
+	return 2 / float64(2-stage)
+}
+
+// accuracyMultiplier is the chance multiplier for the attacker's accuracy
+// stage against the defender's evasion stage.
+func accuracyMultiplier(accuracy, evasion int) float64 {
+	stage := accuracy - evasion
+	if stage > MaxStage {
+		stage = MaxStage
+	}
+	if stage < -MaxStage {
+		stage = -MaxStage
+	}
+	if stage >= 0 {
+		return float64(3+stage) / 3
+	}
+	return 3 / float64(3-stage)
+}
+
+// stat returns a stat with its stage applied.
+func (c *Combatant) stat(name string, value int) int {
+	return int(float64(value) * StageMultiplier(c.Stages[name]))
+}
+
+func (c *Combatant) speed() int {
+	return c.stat("speed", c.Stats.Speed)
+}
+
+// stageOrder is the order stat stages are listed in.
+var stageOrder = []string{"attack", "defense", "special-attack", "special-defense", "speed", "accuracy", "evasion"}
+
+// FormatStages lists the stats whose stage is not 0, e.g.
+// "attack -1, speed +2".
+func FormatStages(stages map[string]int) string {
+	var parts []string
+	for _, stat := range stageOrder {
+		if stage := stages[stat]; stage != 0 {
+			parts = append(parts, fmt.Sprintf("%s %+d", strings.ReplaceAll(stat, "-", " "), stage))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// moveFor turns a controller's action into a move: either the known move
+// it names, or the strongest known move of the class it names.
+func (c *Combatant) moveFor(action Action) moves.Move {
+	best := -1
+	for i, m := range c.Moves {
+		if m.Name == string(action) {
+			return m
+		}
+		if m.Class() == string(action) && (best < 0 || m.Power > c.Moves[best].Power) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return c.Moves[best]
+	}
+	for i, m := range c.Moves {
+		if best < 0 || m.Power > c.Moves[best].Power {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return c.Moves[best]
+	}
+	class := Physical
+	if action == Special {
+		class = Special
+	}
+	return moves.Move{Power: Power, DamageClass: moves.NamedResource{Name: string(class)}}
+}
+
+// Hit describes the damage done by one attack.
+type Hit struct {
+	Type          string
+	Damage        int
+	Critical      bool
+	Effectiveness float64
+}
+
+// attackType picks the attacker's own type that works best against the
+// defender in gen. It returns "" if none of the attacker's types exist in
+// that generation.
+func attackType(attacker, defender *Combatant, gen int) (string, float64) {
+	best, multiplier := "", 1.0
+	for _, t := range attacker.Types {
+		if !types.Exists(gen, t) {
+			continue
+		}
+		if m := types.Effectiveness(gen, t, defender.Types); best == "" || m > multiplier {
+			best, multiplier = t, m
+		}
+	}
+	return best, multiplier
+}
+
+func hasType(c *Combatant, t string) bool {
+	for _, own := range c.Types {
+		if own == t {
+			return true
+		}
+	}
+	return false
+}
+
+// critOdds are the modern 1 in n odds of a critical hit by crit stage.
+var critOdds = []int{16, 8, 2, 1}
+
+// Damage returns what one hit of move does under the given conditions,
+// using the damage formula and type chart of their generation. Moves
+// without a type take the attacker's best type.
+func Damage(attacker, defender *Combatant, move moves.Move, c Conditions, r *rand.Rand) Hit {
+	gen := c.Generation
+	attackStat, defenseStat := "attack", "defense"
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if move.Class() == moves.Special {
+		attackStat, defenseStat = "special-attack", "special-defense"
+		attack, defense = attacker.Stats.SpAttack, defender.Stats.SpDefense
+	}
+
+	hit := Hit{Type: move.Type.Name}
+	if hit.Type == "" {
+		hit.Type, hit.Effectiveness = attackType(attacker, defender, gen)
+	} else {
+		hit.Effectiveness = types.Effectiveness(gen, hit.Type, defender.Types)
+	}
+	if hit.Effectiveness == 0 {
+		return hit
+	}
+
+	level, critical := attacker.Level, 1.0
+	if gen == types.Gen1 {
+		// Generation 1 bases critical hits on speed and doubles the
+		// attacker's level instead of multiplying the damage.
+		odds := attacker.Stats.Speed
+		if move.CritStage() > 0 {
+			odds *= 8
+		}
+		hit.Critical = r.Intn(512) < odds
+		if hit.Critical {
+			level *= 2
+		}
+	} else {
+		stage := move.CritStage()
+		if stage >= len(critOdds) {
+			stage = len(critOdds) - 1
+		}
+		hit.Critical = r.Intn(critOdds[stage]) == 0
+		if hit.Critical {
+			critical = 1.5
+		}
+	}
+	// Critical hits ignore stages that would make them weaker.
+	attackStage, defenseStage := attacker.Stages[attackStat], defender.Stages[defenseStat]
+	if hit.Critical {
+		attackStage, defenseStage = max(attackStage, 0), min(defenseStage, 0)
+	}
+	attack = int(float64(attack) * StageMultiplier(attackStage))
+	defense = int(float64(defense) * StageMultiplier(defenseStage))
+	if c.Weather == Sandstorm && move.Class() == moves.Special && hasType(defender, "rock") {
+		defense = defense * 3 / 2
+	}
+	if defense < 1 {
+		defense = 1
+	}
+	damage := (float64((2*level/5+2)*move.Power*attack/defense)/50 + 2) * critical
+	if hit.Type != "" && hasType(attacker, hit.Type) {
+		damage *= 1.5
+	}
+	damage *= hit.Effectiveness * c.modifier(attacker, defender, hit.Type)
+	if defender.Side != nil {
+		damage *= defender.Side.screen(move.Class(), hit.Critical)
+	}
+	if gen == types.Gen1 {
+		damage = damage * float64(217+r.Intn(39)) / 255
+	} else {
+		damage *= 0.85 + r.Float64()*0.15
+	}
+	if damage < 1 {
+		damage = 1
+	}
+	hit.Damage = int(damage)
+	return hit
+}
+
+// Turn is one combatant's turn. Turns without an Attacker only carry Notes
+// about the field.
+type Turn struct {
+	Attacker string
+	Defender string
+	Action   Action
+	// Move is empty for plain attacks.
+	Move     string
+	Flinched bool
+	Missed   bool
+	Hits     int
+	Hit
+	HPLeft int
+	// Notes describe the move's secondary effects and changes to the field.
+	Notes []string
+	// AttackerStages and DefenderStages are the stat stages of both sides
+	// after the turn.
+	AttackerStages map[string]int
+	DefenderStages map[string]int
+}
+
+func (t Turn) String() string {
+	switch {
+	case t.Attacker == "":
+		return ""
+	case t.Flinched:
+		return fmt.Sprintf("%s flinched and couldn't move", t.Attacker)
+	}
+	var s string
+	if t.Move == "" {
+		kind := string(t.Action)
+		if t.Type != "" {
+			kind += ", " + t.Type
+		}
+		s = fmt.Sprintf("%s hits %s with an attack (%s)", t.Attacker, t.Defender, kind)
+	} else {
+		s = fmt.Sprintf("%s used %s", t.Attacker, t.Move)
+	}
+	switch {
+	case t.Missed:
+		return s + ", but it missed"
+	case t.Hits == 0:
+		return s
+	}
+	s += fmt.Sprintf(" for %d damage", t.Damage)
+	if t.Hits > 1 {
+		s += fmt.Sprintf(" in %d hits", t.Hits)
+	}
+	if t.Critical {
+		s += " (critical hit!)"
+	}
+	s = fmt.Sprintf("%s, %s has %d HP left", s, t.Defender, t.HPLeft)
+	if msg := types.Describe(t.Effectiveness); msg != "" {
+		s += ". " + msg
+	}
+	return s
+}
+
+// use makes attacker use move on defender and applies its effects.
+func use(attacker, defender *Combatant, move moves.Move, f *field, r *rand.Rand) (turn Turn) {
+	turn = Turn{Attacker: attacker.Name, Defender: defender.Name, Move: move.Name}
+	turn.Effectiveness = 1
+	defer func() { turn.HPLeft = defender.HP }()
+
+	accuracy := float64(move.Accuracy) * accuracyMultiplier(attacker.Stages["accuracy"], defender.Stages["evasion"])
+	if move.Accuracy > 0 && r.Float64()*100 >= accuracy {
+		turn.Missed = true
+		return turn
+	}
+	effects := move.Effects()
+	if move.Power > 0 {
+		hits := 1
+		for _, e := range effects {
+			if e.Kind == moves.MultiHit {
+				hits = e.MinHits + r.Intn(e.MaxHits-e.MinHits+1)
+			}
+		}
+		for turn.Hits < hits && !defender.Fainted() {
+			hit := Damage(attacker, defender, move, f.Conditions, r)
+			defender.hurt(hit.Damage)
+			turn.Hits++
+			turn.Type, turn.Effectiveness = hit.Type, hit.Effectiveness
+			turn.Damage += hit.Damage
+			turn.Critical = turn.Critical || hit.Critical
+			if hit.Effectiveness == 0 {
+				return turn
+			}
+		}
+	}
+	for _, e := range effects {
+		if note := apply(e, attacker, defender, turn.Damage, r); note != "" {
+			turn.Notes = append(turn.Notes, note)
+		}
+	}
+	if kind, ok := fieldMoves[move.Name]; ok {
+		turn.Notes = append(turn.Notes, f.set(kind))
+	}
+	if _, ok := sideMoves[move.Name]; ok {
+		side := defender.Side
+		if move.Name == Reflect || move.Name == LightScreen {
+			side = attacker.Side
+		}
+		turn.Notes = append(turn.Notes, side.set(move.Name, attacker, defender, f.Generation))
+	}
+	return turn
+}
+
+// apply applies one secondary effect after the move has dealt damage and
+// describes what happened, or returns "" if nothing worth telling did.
+func apply(e moves.Effect, attacker, defender *Combatant, damage int, r *rand.Rand) string {
+	if e.Kind == moves.MultiHit || r.Intn(100) >= e.Chance {
+		return ""
+	}
+	target := defender
+	if e.Self {
+		target = attacker
+	}
+	if target.Fainted() {
+		return ""
+	}
+	switch e.Kind {
+	case moves.Drain:
+		if healed := attacker.Heal(atLeastOne(damage * e.Amount / 100)); damage > 0 && healed > 0 {
+			return fmt.Sprintf("%s drained %d HP", attacker.Name, healed)
+		}
+	case moves.Recoil:
+		if damage > 0 {
+			recoil := atLeastOne(damage * e.Amount / 100)
+			attacker.hurt(recoil)
+			return fmt.Sprintf("%s is hit with recoil for %d damage", attacker.Name, recoil)
+		}
+	case moves.Heal:
+		if healed := attacker.Heal(attacker.Stats.HP * e.Amount / 100); healed > 0 {
+			return fmt.Sprintf("%s restored %d HP", attacker.Name, healed)
+		}
+		return fmt.Sprintf("%s's HP is already full", attacker.Name)
+	case moves.Flinch:
+		target.flinched = true
+	case moves.StatStage:
+		return describeStage(target.Name, e.Stat, target.ChangeStage(e.Stat, e.Amount), e.Amount)
+	}
+	return ""
+}
+
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+func describeStage(name, stat string, changed, wanted int) string {
+	stat = strings.ReplaceAll(stat, "-", " ")
+	switch {
+	case changed == 0 && wanted > 0:
+		return fmt.Sprintf("%s's %s won't go any higher", name, stat)
+	case changed == 0:
+		return fmt.Sprintf("%s's %s won't go any lower", name, stat)
+	case changed >= 3:
+		return fmt.Sprintf("%s's %s rose drastically", name, stat)
+	case changed == 2:
+		return fmt.Sprintf("%s's %s rose sharply", name, stat)
+	case changed == 1:
+		return fmt.Sprintf("%s's %s rose", name, stat)
+	case changed == -1:
+		return fmt.Sprintf("%s's %s fell", name, stat)
+	case changed == -2:
+		return fmt.Sprintf("%s's %s harshly fell", name, stat)
+	default:
+		return fmt.Sprintf("%s's %s severely fell", name, stat)
+	}
+}
+
+type Result struct {
+	Winner *Combatant
+	Loser  *Combatant
+	Turns  []Turn
+}
+
+// Fight runs a battle under the latest rules where both sides are
+// controlled by Auto.
+func Fight(a, b *Combatant, r *rand.Rand) Result {
+	result, _ := FightWith(a, b, Auto{}, Auto{}, Conditions{Generation: types.Latest}, r)
+	return result
+}
+
+// FightWith runs until one combatant faints, asking each side's controller
+// what to do on its turn. The faster one attacks first each round; ties are
+// broken at random. Weather and terrain wear off at the end of a round.
+// Controllers that are Observers are shown every turn as it happens. Winner
+// is nil if nobody faints within MaxTurns.
+func FightWith(a, b *Combatant, ca, cb Controller, c Conditions, r *rand.Rand) (Result, error) {
+	var result Result
+	controllers := map[*Combatant]Controller{a: ca, b: cb}
+	var observers []Observer
+	for _, controller := range []Controller{ca, cb} {
+		if o, ok := controller.(Observer); ok {
+			observers = append(observers, o)
+		}
+	}
+	record := func(turn Turn) {
+		result.Turns = append(result.Turns, turn)
+		for _, o := range observers {
+			o.Observe(turn)
+		}
+	}
+	f := &field{Conditions: c}
+	var entry []string
+	for _, combatant := range []*Combatant{a, b} {
+		if combatant.Side == nil {
+			combatant.Side = &Side{}
+		}
+		if !combatant.entered {
+			combatant.entered = true
+			entry = append(entry, combatant.Side.switchIn(combatant, f.Generation)...)
+		}
+		if kind, ok := abilityFields[combatant.Ability]; ok {
+			entry = append(entry, fmt.Sprintf("%s's %s: %s", combatant.Name, combatant.Ability, f.set(kind)))
+		}
+	}
+	if len(entry) > 0 {
+		record(Turn{Notes: entry})
+	}
+	if done := decide(&result, a, b); done {
+		return result, nil
+	}
+	for round := 1; len(result.Turns) < MaxTurns; round++ {
+		first, second := a, b
+		if b.speed() > a.speed() || (b.speed() == a.speed() && r.Intn(2) == 0) {
+			first, second = b, a
+		}
+		for _, pair := range [][2]*Combatant{{first, second}, {second, first}} {
+			attacker, defender := pair[0], pair[1]
+			if attacker.flinched {
+				record(Turn{Attacker: attacker.Name, Defender: defender.Name, Flinched: true, HPLeft: defender.HP})
+				continue
+			}
+			action, err := controllers[attacker].Choose(Situation{
+				Round:      round,
+				Generation: f.Generation,
+				Weather:    f.Weather,
+				Terrain:    f.Terrain,
+				Self:       attacker.view(),
+				Opponent:   defender.view(),
+			})
+			if err != nil {
+				return result, fmt.Errorf("%s's controller: %w", attacker.Name, err)
+			}
+			turn := use(attacker, defender, attacker.moveFor(action), f, r)
+			turn.Action = action
+			turn.AttackerStages, turn.DefenderStages = copyStages(attacker), copyStages(defender)
+			record(turn)
+			switch {
+			case defender.Fainted():
+				result.Winner, result.Loser = attacker, defender
+				return result, nil
+			case attacker.Fainted():
+				result.Winner, result.Loser = defender, attacker
+				return result, nil
+			}
+		}
+		a.flinched, b.flinched = false, false
+
+		notes := f.endOfRound(first, second)
+		notes = append(notes, first.Side.endOfRound(first.Name)...)
+		notes = append(notes, second.Side.endOfRound(second.Name)...)
+		if len(notes) > 0 {
+			record(Turn{Notes: notes})
+		}
+		if done := decide(&result, a, b); done {
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+// decide records the winner if either combatant fainted outside of an
+// attack, and reports whether the battle is over.
+func decide(result *Result, a, b *Combatant) bool {
+	switch {
+	case a.Fainted() && b.Fainted():
+		return true
+	case a.Fainted():
+		result.Winner, result.Loser = b, a
+		return true
+	case b.Fainted():
+		result.Winner, result.Loser = a, b
+		return true
+	}
+	return false
+}
+
+func copyStages(c *Combatant) map[string]int {
+	stages := make(map[string]int, len(c.Stages))
+	for stat, stage := range c.Stages {
+		stages[stat] = stage
+	}
+	return stages
+}
@@ -0,0 +1,306 @@
+package battle
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+var pikachuBase = map[string]int{
+	"hp": 35, "attack": 55, "defense": 40,
+	"special-attack": 50, "special-defense": 50, "speed": 90,
+}
+
+var rattataBase = map[string]int{
+	"hp": 30, "attack": 56, "defense": 35,
+	"special-attack": 25, "special-defense": 35, "speed": 72,
+}
+
+func TestStatsAt(t *testing.T) {
+	got := StatsAt(pikachuBase, 50)
+	want := Stats{HP: 95, Attack: 60, Defense: 45, SpAttack: 55, SpDefense: 55, Speed: 95}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestFight(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	strong := NewCombatant("pikachu", 50, []string{"electric"}, pikachuBase)
+	weak := NewCombatant("rattata", 5, []string{"normal"}, rattataBase)
+
+	result := Fight(weak, strong, r)
+	if result.Winner != strong || !weak.Fainted() {
+		t.Fatalf("expected the level 50 pikachu to win, got %+v", result.Winner)
+	}
+	if result.Turns[0].Attacker != "pikachu" {
+		t.Errorf("expected the faster pokemon to attack first")
+	}
+	last := result.Turns[len(result.Turns)-1]
+	if last.HPLeft != 0 || last.Defender != "rattata" {
+		t.Errorf("expected the last turn to knock out rattata, got %+v", last)
+	}
+}
+
+func TestHeal(t *testing.T) {
+	c := NewCombatant("pikachu", 50, nil, pikachuBase)
+	c.HP = c.Stats.HP - 10
+	if healed := c.Heal(20); healed != 10 || c.HP != c.Stats.HP {
+		t.Errorf("expected healing to stop at max HP, healed %d to %d", healed, c.HP)
+	}
+}
+
+func TestDamageByGeneration(t *testing.T) {
+	ghost := NewCombatant("gastly", 30, []string{"ghost"}, pikachuBase)
+	psychic := NewCombatant("abra", 30, []string{"psychic"}, rattataBase)
+	plain := ghost.moveFor(Physical)
+
+	if hit := Damage(ghost, psychic, plain, Conditions{Generation: types.Gen1}, rand.New(rand.NewSource(1))); hit.Damage != 0 || hit.Effectiveness != 0 {
+		t.Errorf("expected ghost attacks to miss psychic types in gen 1, got %+v", hit)
+	}
+	hit := Damage(ghost, psychic, plain, Conditions{Generation: types.Latest}, rand.New(rand.NewSource(1)))
+	if hit.Type != "ghost" || hit.Effectiveness != 2 || hit.Damage == 0 {
+		t.Errorf("expected a super effective ghost attack, got %+v", hit)
+	}
+}
+
+func intp(n int) *int { return &n }
+
+func move(name string, power int, meta moves.Meta, changes ...moves.StatChange) moves.Move {
+	return moves.Move{
+		Name:        name,
+		Power:       power,
+		Type:        moves.NamedResource{Name: "normal"},
+		DamageClass: moves.NamedResource{Name: moves.Physical},
+		Target:      moves.NamedResource{Name: "selected-pokemon"},
+		Meta:        &meta,
+		StatChanges: changes,
+	}
+}
+
+func TestMoveEffects(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	attacker := NewCombatant("rattata", 50, []string{"normal"}, rattataBase)
+	defender := NewCombatant("pikachu", 50, []string{"electric"}, pikachuBase)
+	defender.Stats.HP, defender.HP = 1000, 1000
+
+	attacker.HP = 10
+	turn := use(attacker, defender, move("absorb", 40, moves.Meta{Drain: 50}), latest(), r)
+	if attacker.HP <= 10 || len(turn.Notes) != 1 {
+		t.Errorf("expected draining to heal the attacker, got %d HP and %v", attacker.HP, turn.Notes)
+	}
+
+	before := attacker.HP
+	use(attacker, defender, move("take-down", 90, moves.Meta{Drain: -25}), latest(), r)
+	if attacker.HP >= before {
+		t.Errorf("expected recoil damage")
+	}
+
+	turn = use(attacker, defender, move("fury-attack", 15, moves.Meta{MinHits: intp(2), MaxHits: intp(5)}), latest(), r)
+	if turn.Hits < 2 || turn.Hits > 5 {
+		t.Errorf("expected 2 to 5 hits, got %d", turn.Hits)
+	}
+
+	use(attacker, defender, move("bite", 60, moves.Meta{FlinchChance: 100}), latest(), r)
+	if !defender.flinched {
+		t.Errorf("expected the defender to flinch")
+	}
+
+	growl := move("growl", 0, moves.Meta{}, moves.StatChange{Change: -1, Stat: moves.NamedResource{Name: "attack"}})
+	for i := 0; i < 8; i++ {
+		use(attacker, defender, growl, latest(), r)
+	}
+	if defender.Stages["attack"] != -MaxStage {
+		t.Errorf("expected attack to bottom out at -%d, got %d", MaxStage, defender.Stages["attack"])
+	}
+}
+
+func TestFightWithMoves(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a := NewCombatant("pikachu", 20, []string{"electric"}, pikachuBase)
+	b := NewCombatant("rattata", 20, []string{"normal"}, rattataBase)
+	shock := move("thunder-shock", 40, moves.Meta{})
+	shock.Type.Name = "electric"
+	shock.DamageClass.Name = moves.Special
+	a.Moves = []moves.Move{move("growl", 0, moves.Meta{}), shock}
+	b.Moves = []moves.Move{move("tackle", 40, moves.Meta{})}
+
+	result := Fight(a, b, r)
+	if result.Winner == nil {
+		t.Fatalf("expected a winner")
+	}
+	for _, turn := range result.Turns {
+		if turn.Attacker == "pikachu" && turn.Move != "thunder-shock" {
+			t.Errorf("expected Auto to pick the damaging move, got %q", turn.Move)
+		}
+	}
+}
+
+func TestStages(t *testing.T) {
+	for stage, want := range map[int]float64{0: 1, 1: 1.5, 6: 4, -1: 2.0 / 3, -6: 0.25} {
+		if got := StageMultiplier(stage); got != want {
+			t.Errorf("stage %d: expected %v, got %v", stage, want, got)
+		}
+	}
+
+	c := NewCombatant("pikachu", 50, []string{"electric"}, pikachuBase)
+	if changed := c.ChangeStage("attack", 4); changed != 4 {
+		t.Errorf("expected attack to rise by 4, got %d", changed)
+	}
+	if changed := c.ChangeStage("attack", 4); changed != 2 {
+		t.Errorf("expected attack to stop at +6, rose by %d", changed)
+	}
+	c.ChangeStage("special-defense", -1)
+	if got := FormatStages(c.Stages); got != "attack +6, special defense -1" {
+		t.Errorf("unexpected stage summary %q", got)
+	}
+	c.ResetStages()
+	if got := FormatStages(c.Stages); got != "" {
+		t.Errorf("expected stages to reset, got %q", got)
+	}
+}
+
+func TestStagesAffectDamage(t *testing.T) {
+	attacker := NewCombatant("rattata", 50, []string{"normal"}, rattataBase)
+	defender := NewCombatant("pikachu", 50, []string{"electric"}, pikachuBase)
+	tackle := move("tackle", 40, moves.Meta{})
+
+	// Compare damage without critical hits, which ignore lowered stats.
+	damage := func() int {
+		for seed := int64(0); ; seed++ {
+			if hit := Damage(attacker, defender, tackle, Conditions{Generation: types.Latest}, rand.New(rand.NewSource(seed))); !hit.Critical {
+				return hit.Damage
+			}
+		}
+	}
+	normal := damage()
+	attacker.ChangeStage("attack", 2)
+	if boosted := damage(); boosted <= normal {
+		t.Errorf("expected +2 attack to do more than %d damage, got %d", normal, boosted)
+	}
+}
+
+func latest() *field {
+	return &field{Conditions: Conditions{Generation: types.Latest}}
+}
+
+func TestWeather(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	attacker := NewCombatant("squirtle", 50, []string{"water"}, rattataBase)
+	defender := NewCombatant("rattata", 50, []string{"normal"}, rattataBase)
+	defender.Stats.HP, defender.HP = 1000, 1000
+	water := move("water-gun", 40, moves.Meta{})
+	water.Type.Name = "water"
+
+	damage := func(c Conditions) int {
+		return Damage(attacker, defender, water, c, rand.New(rand.NewSource(2))).Damage
+	}
+	clear := damage(Conditions{Generation: types.Latest})
+	if rain := damage(Conditions{Generation: types.Latest, Weather: Rain}); rain <= clear {
+		t.Errorf("expected rain to boost water attacks, got %d vs %d", rain, clear)
+	}
+	if sun := damage(Conditions{Generation: types.Latest, Weather: Sun}); sun >= clear {
+		t.Errorf("expected sun to weaken water attacks, got %d vs %d", sun, clear)
+	}
+
+	f := latest()
+	turn := use(attacker, defender, move("sandstorm", 0, moves.Meta{}), f, r)
+	if f.Weather != Sandstorm || len(turn.Notes) != 1 {
+		t.Fatalf("expected the sandstorm move to start a sandstorm, got %q %v", f.Weather, turn.Notes)
+	}
+	for round := 1; round <= FieldTurns; round++ {
+		before := defender.HP
+		f.endOfRound(attacker, defender)
+		if defender.HP != before-1000/16 {
+			t.Errorf("round %d: expected sandstorm damage, HP went from %d to %d", round, before, defender.HP)
+		}
+	}
+	if f.Weather != "" {
+		t.Errorf("expected the sandstorm to end after %d rounds", FieldTurns)
+	}
+}
+
+func TestTerrainNeedsGeneration6(t *testing.T) {
+	f := &field{Conditions: Conditions{Generation: 5}}
+	if f.set(ElectricTerrain); f.Terrain != "" {
+		t.Errorf("expected terrain to fail before generation 6")
+	}
+	f.Generation = types.Latest
+	if f.set(ElectricTerrain); f.Terrain != ElectricTerrain {
+		t.Errorf("expected electric terrain to start")
+	}
+}
+
+func TestHazards(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	user := NewCombatant("geodude", 30, []string{"rock"}, rattataBase)
+	side := &Side{}
+	first := NewCombatant("charizard", 30, []string{"fire", "flying"}, pikachuBase)
+	first.Side = side
+
+	use(user, first, move(StealthRock, 0, moves.Meta{}), latest(), r)
+	use(user, first, move(Spikes, 0, moves.Meta{}), latest(), r)
+	if !side.StealthRock || side.Spikes != 1 {
+		t.Fatalf("expected hazards on the opponent's side, got %+v", side)
+	}
+
+	// A fire/flying type takes 4x from stealth rock and floats over spikes.
+	second := NewCombatant("charizard", 30, []string{"fire", "flying"}, pikachuBase)
+	second.Side = side
+	notes := side.switchIn(second, types.Latest)
+	if want := second.Stats.HP - second.Stats.HP/2; second.HP != want || len(notes) != 1 {
+		t.Errorf("expected stealth rock to take half its HP, got %d/%d and %v", second.HP, second.Stats.HP, notes)
+	}
+}
+
+func TestScreens(t *testing.T) {
+	attacker := NewCombatant("rattata", 50, []string{"normal"}, rattataBase)
+	defender := NewCombatant("pikachu", 50, []string{"electric"}, pikachuBase)
+	defender.Side = &Side{}
+	tackle := move("tackle", 40, moves.Meta{})
+
+	damage := func() int {
+		for seed := int64(0); ; seed++ {
+			if hit := Damage(attacker, defender, tackle, Conditions{Generation: types.Latest}, rand.New(rand.NewSource(seed))); !hit.Critical {
+				return hit.Damage
+			}
+		}
+	}
+	normal := damage()
+	defender.Side.set(Reflect, defender, attacker, types.Latest)
+	if screened := damage(); screened >= normal {
+		t.Errorf("expected reflect to reduce %d damage, got %d", normal, screened)
+	}
+	for i := 0; i < ScreenTurns; i++ {
+		defender.Side.endOfRound(defender.Name)
+	}
+	if defender.Side.Reflect != 0 {
+		t.Errorf("expected reflect to wear off after %d rounds", ScreenTurns)
+	}
+}
+
+func TestFightTeams(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	strong := NewCombatant("pikachu", 50, []string{"electric"}, pikachuBase)
+	weak := []*Combatant{
+		NewCombatant("rattata", 5, []string{"normal"}, rattataBase),
+		NewCombatant("raticate", 6, []string{"normal"}, rattataBase),
+	}
+	result, err := FightTeams(weak, []*Combatant{strong}, Auto{}, Auto{}, latest().Conditions, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Winner != 1 || !weak[0].Fainted() || !weak[1].Fainted() {
+		t.Errorf("expected pikachu to beat both, got %+v", result)
+	}
+	if result.Turns < 2 {
+		t.Errorf("expected the turns of both fights to be counted, got %d", result.Turns)
+	}
+
+	fresh := weak[0].Fresh()
+	if fresh.HP != fresh.Stats.HP || fresh.Side != nil {
+		t.Errorf("expected a fresh copy at full HP and off the field, got %+v", fresh)
+	}
+}
@@ -0,0 +1,160 @@
+package battle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+type Action string
+
+const (
+	Physical Action = "physical"
+	Special  Action = "special"
+)
+
+// View is what a controller is told about a combatant.
+type View struct {
+	Name  string     `json:"name"`
+	Level int        `json:"level"`
+	Types []string   `json:"types"`
+	Stats Stats      `json:"stats"`
+	HP    int        `json:"hp"`
+	Moves []MoveView `json:"moves,omitempty"`
+	// Stages are the stat stages that are not 0.
+	Stages map[string]int `json:"stages,omitempty"`
+	Side   Side           `json:"side"`
+}
+
+type MoveView struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Class    string `json:"class"`
+	Power    int    `json:"power"`
+	Accuracy int    `json:"accuracy"`
+}
+
+func (c *Combatant) view() View {
+	v := View{Name: c.Name, Level: c.Level, Types: c.Types, Stats: c.Stats, HP: c.HP}
+	if c.Side != nil {
+		v.Side = *c.Side
+	}
+	for stat, stage := range c.Stages {
+		if stage != 0 {
+			if v.Stages == nil {
+				v.Stages = make(map[string]int)
+			}
+			v.Stages[stat] = stage
+		}
+	}
+	for _, m := range c.Moves {
+		v.Moves = append(v.Moves, MoveView{
+			Name:     m.Name,
+			Type:     m.Type.Name,
+			Class:    m.Class(),
+			Power:    m.Power,
+			Accuracy: m.Accuracy,
+		})
+	}
+	return v
+}
+
+// Situation is everything a controller gets to decide on.
+type Situation struct {
+	Round      int    `json:"round"`
+	Generation int    `json:"generation"`
+	Weather    string `json:"weather,omitempty"`
+	Terrain    string `json:"terrain,omitempty"`
+	Self       View   `json:"self"`
+	Opponent   View   `json:"opponent"`
+}
+
+// Allows reports whether a controller may answer the situation with action:
+// a class of attack, or the name of one of its moves.
+func (s Situation) Allows(action Action) bool {
+	if action == Physical || action == Special {
+		return true
+	}
+	for _, m := range s.Self.Moves {
+		if m.Name == string(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// Controller decides what a combatant does on its turn.
+type Controller interface {
+	Choose(Situation) (Action, error)
+}
+
+// Observer is implemented by controllers that follow the battle as it
+// happens.
+type Observer interface {
+	Observe(Turn)
+}
+
+// Auto uses the move it expects to do the most damage, or attacks with
+// whichever of its stats does more damage against the opponent's matching
+// defense if it knows no moves.
+type Auto struct{}
+
+func (Auto) Choose(s Situation) (Action, error) {
+	if len(s.Self.Moves) > 0 {
+		return Action(bestMove(s).Name), nil
+	}
+	physical := s.Self.Stats.Attack * s.Opponent.Stats.SpDefense
+	special := s.Self.Stats.SpAttack * s.Opponent.Stats.Defense
+	if special > physical {
+		return Special, nil
+	}
+	return Physical, nil
+}
+
+// NewController parses an AI spec: "auto" (or empty) for the built-in AI,
+// or "external:<command>" to run another program as the AI.
+func NewController(spec string) (Controller, error) {
+	switch {
+	case spec == "" || spec == "auto":
+		return Auto{}, nil
+	case strings.HasPrefix(spec, "external:"):
+		fields := strings.Fields(strings.TrimPrefix(spec, "external:"))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("external AI needs a command, e.g. external:./mybot")
+		}
+		return StartExternal(fields[0], fields[1:]...)
+	default:
+		return nil, fmt.Errorf("unknown AI %q, expected auto or external:<command>", spec)
+	}
+}
+
+// bestMove picks the move with the highest expected damage. If none of the
+// moves deal damage it goes through them in turn.
+func bestMove(s Situation) MoveView {
+	best, bestScore := s.Self.Moves[(s.Round-1)%len(s.Self.Moves)], 0.0
+	for _, m := range s.Self.Moves {
+		attack := float64(s.Self.Stats.Attack) * StageMultiplier(s.Self.Stages["attack"])
+		defense := float64(s.Opponent.Stats.Defense) * StageMultiplier(s.Opponent.Stages["defense"])
+		if m.Class == moves.Special {
+			attack = float64(s.Self.Stats.SpAttack) * StageMultiplier(s.Self.Stages["special-attack"])
+			defense = float64(s.Opponent.Stats.SpDefense) * StageMultiplier(s.Opponent.Stages["special-defense"])
+		}
+		accuracy := m.Accuracy
+		if accuracy == 0 {
+			accuracy = 100
+		}
+		score := float64(m.Power*accuracy) * attack / (defense + 1)
+		score *= types.Effectiveness(s.Generation, m.Type, s.Opponent.Types)
+		for _, t := range s.Self.Types {
+			if t == m.Type {
+				score *= 1.5
+			}
+		}
+		if score > bestScore {
+			best, bestScore = m, score
+		}
+	}
+	return best
+}
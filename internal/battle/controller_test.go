@@ -0,0 +1,117 @@
+package battle
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+// TestHelperProcess is not a real test; it is the external AI started by the
+// tests below. It always answers with the action in AI_ACTION. With
+// AI_LATE set, each answer comes after one to the request before, as if
+// that had come too late, and with AI_CHATTY, it goes on writing after.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var request struct {
+			ID int `json:"id"`
+		}
+		json.Unmarshal(scanner.Bytes(), &request)
+		if os.Getenv("AI_LATE") != "" && request.ID > 1 {
+			fmt.Printf("{\"id\":%d,\"action\":\"splash\"}\n", request.ID-1)
+		}
+		fmt.Printf("{\"id\":%d,\"action\":%q}\n", request.ID, os.Getenv("AI_ACTION"))
+		if os.Getenv("AI_CHATTY") != "" {
+			for i := 0; i < 2*pendingLines; i++ {
+				fmt.Println(`{"action":"splash"}`)
+			}
+		}
+	}
+	os.Exit(0)
+}
+
+func startHelper(t *testing.T, action string, env ...string) *External {
+	t.Helper()
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	t.Setenv("AI_ACTION", action)
+	for _, name := range env {
+		t.Setenv(name, "1")
+	}
+	ai, err := StartExternal(os.Args[0], "-test.run=TestHelperProcess")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { ai.Close() })
+	return ai
+}
+
+func TestExternal(t *testing.T) {
+	ai := startHelper(t, "special")
+	r := rand.New(rand.NewSource(1))
+	a := NewCombatant("pikachu", 20, nil, pikachuBase)
+	b := NewCombatant("rattata", 20, nil, rattataBase)
+
+	result, err := FightWith(a, b, Auto{}, ai, Conditions{Generation: types.Latest}, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, turn := range result.Turns {
+		if turn.Attacker == "rattata" && turn.Action != Special {
+			t.Errorf("expected the external AI's choice to be used, got %s", turn.Action)
+		}
+	}
+}
+
+func TestExternalInvalidAction(t *testing.T) {
+	ai := startHelper(t, "splash")
+	_, err := ai.Choose(Situation{Round: 1})
+	if err == nil || !strings.Contains(err.Error(), "splash") {
+		t.Errorf("expected an unknown action error, got %v", err)
+	}
+}
+
+func TestExternalLateAnswer(t *testing.T) {
+	ai := startHelper(t, "special", "AI_LATE")
+	for round := 1; round <= 3; round++ {
+		if action, err := ai.Choose(Situation{Round: round}); err != nil || action != Special {
+			t.Errorf("round %d: expected the answer to this request, got %q, %v", round, action, err)
+		}
+	}
+}
+
+func TestExternalClose(t *testing.T) {
+	ai := startHelper(t, "special", "AI_CHATTY")
+	if action, err := ai.Choose(Situation{Round: 1}); err != nil || action != Special {
+		t.Fatalf("expected the AI's answer, got %q, %v", action, err)
+	}
+	// More is written than is kept waiting to be read, after the battle is
+	// over.
+	ai.Close()
+	select {
+	case <-ai.read:
+	case <-time.After(time.Second):
+		t.Error("expected Close to stop reading what the AI writes")
+	}
+}
+
+func TestNewController(t *testing.T) {
+	if c, err := NewController("auto"); err != nil || c != (Auto{}) {
+		t.Errorf("expected the built-in AI, got %v, %v", c, err)
+	}
+	if _, err := NewController("external:"); err == nil {
+		t.Errorf("expected a missing command to fail")
+	}
+	if _, err := NewController("smart"); err == nil {
+		t.Errorf("expected an unknown AI to fail")
+	}
+}
@@ -0,0 +1,148 @@
+package battle
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// DecisionTimeout is how long an external AI may think about a turn.
+const DecisionTimeout = 5 * time.Second
+
+// External is a Controller backed by another program. For every decision the
+// program is sent a Situation as a single line of JSON on its stdin, with
+// the id of the request, e.g.
+//
+//	{"id":1,"round":1,"self":{"name":"pikachu",...},"opponent":{"name":"onix",...}}
+//
+// and must answer with a single line of JSON on its stdout:
+//
+//	{"id":1,"action":"special"}
+//
+// where action is "physical", "special" or the name of one of the moves in
+// self.moves. Echoing the id is optional, but without it an answer that
+// comes after DecisionTimeout can't be told from the next one. The
+// program's stdin is closed when the battle is over.
+type External struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string
+	// asked is the id of the last request sent.
+	asked int
+	// done is closed by Close, after which lines are no longer read, and
+	// read once the goroutine reading them has stopped.
+	done, read chan struct{}
+}
+
+// pendingLines is how many lines the AI can write ahead of being asked
+// before it has to wait.
+const pendingLines = 16
+
+func StartExternal(name string, args ...string) (*External, error) {
+	cmd := exec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting AI: %w", err)
+	}
+
+	e := &External{
+		cmd:   cmd,
+		stdin: stdin,
+		lines: make(chan string, pendingLines),
+		done:  make(chan struct{}),
+		read:  make(chan struct{}),
+	}
+	go func() {
+		defer close(e.read)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			select {
+			case e.lines <- scanner.Text():
+			case <-e.done:
+				// Nothing reads the lines any more, but the AI is kept from
+				// blocking on writing them until it exits.
+			}
+		}
+		close(e.lines)
+	}()
+	return e, nil
+}
+
+func (e *External) Choose(s Situation) (Action, error) {
+	// Answers that came too late for earlier requests are thrown away.
+	for len(e.lines) > 0 {
+		<-e.lines
+	}
+	e.asked++
+	request, err := json.Marshal(struct {
+		ID int `json:"id"`
+		Situation
+	}{e.asked, s})
+	if err != nil {
+		return "", err
+	}
+	if _, err := fmt.Fprintf(e.stdin, "%s\n", request); err != nil {
+		return "", fmt.Errorf("sending to AI: %w", err)
+	}
+
+	timeout := time.After(DecisionTimeout)
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return "", errors.New("AI exited without answering")
+			}
+			var response struct {
+				ID     int    `json:"id"`
+				Action Action `json:"action"`
+			}
+			if err := json.Unmarshal([]byte(line), &response); err != nil {
+				return "", fmt.Errorf("AI answered %q: %w", line, err)
+			}
+			if response.ID != 0 && response.ID != e.asked {
+				// A late answer to an earlier request.
+				continue
+			}
+			if !s.Allows(response.Action) {
+				return "", fmt.Errorf("AI chose unknown action %q", response.Action)
+			}
+			return response.Action, nil
+		case <-timeout:
+			return "", fmt.Errorf("AI took longer than %s to answer", DecisionTimeout)
+		}
+	}
+}
+
+// Close ends the AI program, killing it if it does not exit on its own, and
+// stops reading what it writes. Closing it again does nothing.
+func (e *External) Close() error {
+	select {
+	case <-e.done:
+		return nil
+	default:
+	}
+	close(e.done)
+	e.stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- e.cmd.Wait() }()
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(time.Second):
+		e.cmd.Process.Kill()
+		err = <-done
+	}
+	<-e.read
+	return err
+}
@@ -0,0 +1,168 @@
+package battle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+// FieldTurns is how many rounds weather and terrain last when a move or
+// ability sets them.
+const FieldTurns = 5
+
+const (
+	Rain      = "rain"
+	Sun       = "sun"
+	Sandstorm = "sandstorm"
+	Hail      = "hail"
+
+	ElectricTerrain = "electric-terrain"
+	GrassyTerrain   = "grassy-terrain"
+	MistyTerrain    = "misty-terrain"
+	PsychicTerrain  = "psychic-terrain"
+)
+
+// Conditions are the rules and the field a battle is fought under. Weather
+// and terrain given at the start, such as the overworld's weather, last the
+// whole battle.
+type Conditions struct {
+	Generation int
+	Weather    string
+	Terrain    string
+}
+
+// fieldMoves are the moves that change the weather or terrain. PokeAPI's
+// move meta data does not say which, so they are listed by name.
+var fieldMoves = map[string]string{
+	"rain-dance":       Rain,
+	"sunny-day":        Sun,
+	"sandstorm":        Sandstorm,
+	"hail":             Hail,
+	"snowscape":        Hail,
+	"electric-terrain": ElectricTerrain,
+	"grassy-terrain":   GrassyTerrain,
+	"misty-terrain":    MistyTerrain,
+	"psychic-terrain":  PsychicTerrain,
+}
+
+// abilityFields are the abilities that change the weather or terrain when
+// their Pokémon enters the battle.
+var abilityFields = map[string]string{
+	"drizzle":        Rain,
+	"drought":        Sun,
+	"sand-stream":    Sandstorm,
+	"snow-warning":   Hail,
+	"electric-surge": ElectricTerrain,
+	"grassy-surge":   GrassyTerrain,
+	"misty-surge":    MistyTerrain,
+	"psychic-surge":  PsychicTerrain,
+}
+
+// fieldMessages are what is said when weather or terrain starts and ends.
+var fieldMessages = map[string][2]string{
+	Rain:            {"It started to rain!", "The rain stopped."},
+	Sun:             {"The sunlight turned harsh!", "The harsh sunlight faded."},
+	Sandstorm:       {"A sandstorm kicked up!", "The sandstorm subsided."},
+	Hail:            {"It started to hail!", "The hail stopped."},
+	ElectricTerrain: {"An electric current ran across the battlefield!", "The electricity disappeared from the battlefield."},
+	GrassyTerrain:   {"Grass grew to cover the battlefield!", "The grass disappeared from the battlefield."},
+	MistyTerrain:    {"Mist swirled around the battlefield!", "The mist disappeared from the battlefield."},
+	PsychicTerrain:  {"The battlefield got weird!", "The weirdness disappeared from the battlefield."},
+}
+
+// terrainBoosts are the attack types each terrain powers up.
+var terrainBoosts = map[string]string{
+	ElectricTerrain: "electric",
+	GrassyTerrain:   "grass",
+	PsychicTerrain:  "psychic",
+}
+
+// field tracks the Conditions as they change during a battle. Turns left
+// of 0 means the weather or terrain lasts until it is replaced.
+type field struct {
+	Conditions
+	weatherTurns int
+	terrainTurns int
+}
+
+func isTerrain(kind string) bool {
+	return strings.HasSuffix(kind, "-terrain")
+}
+
+// set starts weather or terrain for FieldTurns rounds and says so.
+func (f *field) set(kind string) string {
+	if isTerrain(kind) {
+		if f.Generation < types.Gen6 || f.Terrain == kind {
+			return "But it failed"
+		}
+		f.Terrain, f.terrainTurns = kind, FieldTurns
+	} else {
+		if f.Weather == kind {
+			return "But it failed"
+		}
+		f.Weather, f.weatherTurns = kind, FieldTurns
+	}
+	return fieldMessages[kind][0]
+}
+
+// grounded Pokémon are affected by terrain.
+func grounded(c *Combatant) bool {
+	return !hasType(c, "flying")
+}
+
+// modifier is how much the weather and terrain multiply the damage of an
+// attack of type attackType.
+func (c Conditions) modifier(attacker, defender *Combatant, attackType string) float64 {
+	m := 1.0
+	switch {
+	case c.Weather == Rain && attackType == "water", c.Weather == Sun && attackType == "fire":
+		m *= 1.5
+	case c.Weather == Rain && attackType == "fire", c.Weather == Sun && attackType == "water":
+		m *= 0.5
+	}
+	switch {
+	case grounded(attacker) && terrainBoosts[c.Terrain] == attackType:
+		m *= 1.3
+	case c.Terrain == MistyTerrain && grounded(defender) && attackType == "dragon":
+		m *= 0.5
+	}
+	return m
+}
+
+// endOfRound applies weather damage and terrain healing, then counts down
+// the weather and terrain. It describes what happened.
+func (f *field) endOfRound(combatants ...*Combatant) []string {
+	var notes []string
+	for _, c := range combatants {
+		if c.Fainted() {
+			continue
+		}
+		switch {
+		case f.Weather == Sandstorm && !hasType(c, "rock") && !hasType(c, "ground") && !hasType(c, "steel"):
+			c.hurt(atLeastOne(c.Stats.HP / 16))
+			notes = append(notes, fmt.Sprintf("%s is buffeted by the sandstorm", c.Name))
+		case f.Weather == Hail && !hasType(c, "ice"):
+			c.hurt(atLeastOne(c.Stats.HP / 16))
+			notes = append(notes, fmt.Sprintf("%s is pelted by hail", c.Name))
+		}
+		if f.Terrain == GrassyTerrain && grounded(c) && !c.Fainted() {
+			if healed := c.Heal(atLeastOne(c.Stats.HP / 16)); healed > 0 {
+				notes = append(notes, fmt.Sprintf("%s is healed by the grassy terrain", c.Name))
+			}
+		}
+	}
+	if f.weatherTurns > 0 {
+		if f.weatherTurns--; f.weatherTurns == 0 {
+			notes = append(notes, fieldMessages[f.Weather][1])
+			f.Weather = ""
+		}
+	}
+	if f.terrainTurns > 0 {
+		if f.terrainTurns--; f.terrainTurns == 0 {
+			notes = append(notes, fieldMessages[f.Terrain][1])
+			f.Terrain = ""
+		}
+	}
+	return notes
+}
@@ -0,0 +1,125 @@
+package battle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+)
+
+// ScreenTurns is how many rounds reflect and light screen last.
+const ScreenTurns = 5
+
+// MaxSpikes is how many layers of spikes a side can have.
+const MaxSpikes = 3
+
+// Side is one side of the battlefield. A side outlasts a single fight, so
+// hazards laid against a trainer hurt every Pokémon they send out.
+type Side struct {
+	StealthRock bool `json:"stealth_rock,omitempty"`
+	Spikes      int  `json:"spikes,omitempty"`
+	// Reflect and LightScreen are the rounds left of each screen.
+	Reflect     int `json:"reflect,omitempty"`
+	LightScreen int `json:"light_screen,omitempty"`
+}
+
+const (
+	StealthRock = "stealth-rock"
+	Spikes      = "spikes"
+	Reflect     = "reflect"
+	LightScreen = "light-screen"
+)
+
+// sideMoves are the moves that lay hazards on the opponent's side or put up
+// screens on the user's, with the generation they were introduced in.
+var sideMoves = map[string]int{
+	StealthRock: 4,
+	Spikes:      types.Gen2,
+	Reflect:     types.Gen1,
+	LightScreen: types.Gen1,
+}
+
+// set applies a side move used by user against opponent and says what
+// happened.
+func (s *Side) set(move string, user, opponent *Combatant, gen int) string {
+	if gen < sideMoves[move] {
+		return "But it failed"
+	}
+	switch move {
+	case StealthRock:
+		if s.StealthRock {
+			return "But it failed"
+		}
+		s.StealthRock = true
+		return fmt.Sprintf("Pointed stones float in the air around %s!", opponent.Name)
+	case Spikes:
+		if s.Spikes == MaxSpikes {
+			return "But it failed"
+		}
+		s.Spikes++
+		return fmt.Sprintf("Spikes were scattered on the ground around %s!", opponent.Name)
+	case Reflect:
+		if s.Reflect > 0 {
+			return "But it failed"
+		}
+		s.Reflect = ScreenTurns
+		return fmt.Sprintf("Reflect made %s stronger against physical moves!", user.Name)
+	case LightScreen:
+		if s.LightScreen > 0 {
+			return "But it failed"
+		}
+		s.LightScreen = ScreenTurns
+		return fmt.Sprintf("Light screen made %s stronger against special moves!", user.Name)
+	}
+	return ""
+}
+
+// spikesDamage is the fraction of max HP spikes take by layers.
+var spikesDamage = []int{0, 8, 6, 4}
+
+// switchIn hurts a combatant entering the battle with the hazards on its
+// side and describes what happened.
+func (s *Side) switchIn(c *Combatant, gen int) []string {
+	var notes []string
+	if s.StealthRock {
+		// Stealth rock hurts like a rock attack: 1/8 of max HP, scaled by
+		// how weak the combatant is to rock.
+		damage := int(float64(c.Stats.HP) / 8 * types.Effectiveness(gen, "rock", c.Types))
+		c.hurt(atLeastOne(damage))
+		notes = append(notes, fmt.Sprintf("Pointed stones dug into %s", c.Name))
+	}
+	if s.Spikes > 0 && grounded(c) && !c.Fainted() {
+		c.hurt(atLeastOne(c.Stats.HP / spikesDamage[s.Spikes]))
+		notes = append(notes, fmt.Sprintf("%s is hurt by the spikes", c.Name))
+	}
+	return notes
+}
+
+// screen is how much the side's screens reduce a hit. Critical hits go
+// through screens.
+func (s *Side) screen(class string, critical bool) float64 {
+	switch {
+	case critical:
+		return 1
+	case class == moves.Physical && s.Reflect > 0, class == moves.Special && s.LightScreen > 0:
+		return 0.5
+	}
+	return 1
+}
+
+// endOfRound counts down the side's screens and says which wore off.
+func (s *Side) endOfRound(owner string) []string {
+	var notes []string
+	for _, screen := range []struct {
+		name  string
+		turns *int
+	}{{Reflect, &s.Reflect}, {LightScreen, &s.LightScreen}} {
+		if *screen.turns > 0 {
+			if *screen.turns--; *screen.turns == 0 {
+				notes = append(notes, fmt.Sprintf("%s's %s wore off", owner, strings.ReplaceAll(screen.name, "-", " ")))
+			}
+		}
+	}
+	return notes
+}
@@ -0,0 +1,68 @@
+package battle
+
+import "math/rand"
+
+// Draw is TeamResult.Winner when neither team won.
+const Draw = -1
+
+// TeamResult is how a battle between two teams went. Winner is 0 if the
+// first team won and 1 if the second did.
+type TeamResult struct {
+	Winner int
+	Turns  int
+}
+
+// Fresh returns a copy of the combatant as it was before battling: full
+// HP, no stat stages and not on a side.
+func (c *Combatant) Fresh() *Combatant {
+	return &Combatant{
+		Name:    c.Name,
+		Level:   c.Level,
+		Types:   c.Types,
+		Stats:   c.Stats,
+		HP:      c.Stats.HP,
+		Ability: c.Ability,
+		Moves:   c.Moves,
+		Stages:  make(map[string]int),
+	}
+}
+
+// FightTeams battles two teams. Each sends out its members in order, and
+// the Pokémon left standing stays in against the next one. A fight that
+// runs out of turns with both still standing is a draw.
+func FightTeams(a, b []*Combatant, ca, cb Controller, c Conditions, r *rand.Rand) (TeamResult, error) {
+	result := TeamResult{Winner: Draw}
+	sideA, sideB := &Side{}, &Side{}
+	for _, member := range a {
+		member.Side = sideA
+	}
+	for _, member := range b {
+		member.Side = sideB
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		x, y := a[i], b[j]
+		fight, err := FightWith(x, y, ca, cb, c, r)
+		result.Turns += len(fight.Turns)
+		if err != nil {
+			return result, err
+		}
+		if !x.Fainted() && !y.Fainted() {
+			return result, nil
+		}
+		if x.Fainted() {
+			i++
+		}
+		if y.Fainted() {
+			j++
+		}
+	}
+	switch {
+	case i < len(a):
+		result.Winner = 0
+	case j < len(b):
+		result.Winner = 1
+	}
+	return result, nil
+}
@@ -0,0 +1,154 @@
+// Package biome sorts location areas into biomes, like caves and forests,
+// from their names and the ways their Pokémon are met, and says how each
+// biome changes which Pokémon turn up.
+package biome
+
+import (
+	"math/rand"
+	"strings"
+)
+
+type Biome string
+
+// The biomes. An area that is none of these has no biome.
+const (
+	Cave   Biome = "cave"
+	Forest Biome = "forest"
+	Water  Biome = "water"
+	Urban  Biome = "urban"
+)
+
+// All are the biomes, in the order they are listed.
+var All = []Biome{Cave, Forest, Water, Urban}
+
+// Parse returns the biome called name, if there is one.
+func Parse(name string) (Biome, bool) {
+	for _, b := range All {
+		if string(b) == name {
+			return b, true
+		}
+	}
+	return "", false
+}
+
+// words are the parts of area names that give their biome away. They are
+// checked in the order of All, so "mt-moon-1f" is a cave however its
+// Pokémon are met.
+var words = map[Biome][]string{
+	Cave:   {"cave", "cavern", "tunnel", "mt", "mount", "mountain", "grotto", "chamber", "ruins", "underground", "icefall"},
+	Forest: {"forest", "woods", "wood", "grove", "jungle", "marsh", "meadow", "garden", "safari"},
+	Water:  {"sea", "ocean", "lake", "river", "bay", "pond", "beach", "island", "cape", "falls", "harbor", "strait"},
+	Urban:  {"city", "town", "tower", "mansion", "building", "plant", "lab", "house", "gym", "hotel", "store", "station", "ship", "village"},
+}
+
+// waterMethods are the ways of meeting Pokémon that mean water.
+var waterMethods = map[string]bool{"surf": true, "old-rod": true, "good-rod": true, "super-rod": true}
+
+// Classify returns the biome of an area from its name, like
+// "mt-moon-1f", and the ways its Pokémon are met, like "walk" and
+// "surf". An area its name doesn't place is water if most of its
+// Pokémon are met on the water, and has no biome otherwise.
+func Classify(area string, methods []string) Biome {
+	parts := strings.Split(area, "-")
+	for _, b := range All {
+		for _, word := range words[b] {
+			for _, part := range parts {
+				if part == word {
+					return b
+				}
+			}
+		}
+	}
+	wet := 0
+	for _, method := range methods {
+		if waterMethods[method] {
+			wet++
+		}
+	}
+	if len(methods) > 0 && wet*2 > len(methods) {
+		return Water
+	}
+	return ""
+}
+
+// Types are the Pokémon types each biome favours.
+var Types = map[Biome][]string{
+	Cave:   {"rock", "ground", "dark", "poison"},
+	Forest: {"grass", "bug", "fairy"},
+	Water:  {"water", "ice"},
+	Urban:  {"electric", "normal", "ghost", "steel"},
+}
+
+// Boost is how many times likelier a Pokémon of a favoured type is to be
+// met in its biome.
+const Boost = 2
+
+// Modify adjusts the percent chance of meeting a Pokémon of types in a
+// biome: favoured types are Boost times likelier, up to 100%.
+func Modify(b Biome, chance int, types []string) int {
+	for _, favoured := range Types[b] {
+		for _, t := range types {
+			if t == favoured {
+				return min(chance*Boost, 100)
+			}
+		}
+	}
+	return chance
+}
+
+// Candidate is an area that could be picked, and how heavily.
+type Candidate struct {
+	Area   string
+	Weight int
+}
+
+// Pick returns one of the candidates at random, weighted by their
+// weights, or "" if none has any weight.
+func Pick(candidates []Candidate, r *rand.Rand) string {
+	total := 0
+	for _, c := range candidates {
+		total += max(c.Weight, 0)
+	}
+	if total == 0 {
+		return ""
+	}
+	n := r.Intn(total)
+	for _, c := range candidates {
+		if n < max(c.Weight, 0) {
+			return c.Area
+		}
+		n -= max(c.Weight, 0)
+	}
+	panic("unreachable")
+}
+
+// Achievement is earned by catching Catches Pokémon in a biome.
+type Achievement struct {
+	Name    string
+	Biome   Biome
+	Catches int
+}
+
+// Achievements are the biome achievements, easiest first.
+var Achievements = []Achievement{
+	{Name: "Spelunker", Biome: Cave, Catches: 5},
+	{Name: "Deep Delver", Biome: Cave, Catches: 25},
+	{Name: "Forest Ranger", Biome: Forest, Catches: 5},
+	{Name: "Canopy Keeper", Biome: Forest, Catches: 25},
+	{Name: "Angler", Biome: Water, Catches: 5},
+	{Name: "Sea Captain", Biome: Water, Catches: 25},
+	{Name: "Street Smart", Biome: Urban, Catches: 5},
+	{Name: "City Slicker", Biome: Urban, Catches: 25},
+}
+
+// Earned returns the achievements that catches, the Pokémon caught in each
+// biome, have earned.
+func Earned(catches map[Biome]int) []Achievement {
+	var earned []Achievement
+	for _, a := range Achievements {
+		if catches[a.Biome] >= a.Catches {
+			earned = append(earned, a)
+		}
+	}
+	return earned
+}
@@ -0,0 +1,65 @@
+package biome
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		area    string
+		methods []string
+		want    Biome
+	}{
+		{"mt-moon-1f", []string{"walk"}, Cave},
+		{"rock-tunnel-b1f", nil, Cave},
+		{"viridian-forest-area", []string{"walk"}, Forest},
+		{"lake-verity-before-galactic-intervention", nil, Water},
+		{"celadon-city-area", []string{"surf", "old-rod"}, Urban},
+		{"kanto-route-19-area", []string{"surf", "old-rod", "good-rod"}, Water},
+		{"kanto-route-1-area", []string{"walk", "surf"}, ""},
+		{"kanto-route-1-area", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.area, tt.methods); got != tt.want {
+			t.Errorf("Classify(%q, %v) = %q, want %q", tt.area, tt.methods, got, tt.want)
+		}
+	}
+}
+
+func TestModify(t *testing.T) {
+	if got := Modify(Cave, 20, []string{"rock", "ground"}); got != 40 {
+		t.Errorf("expected rock types to be twice as likely in caves, got %d", got)
+	}
+	if got := Modify(Cave, 60, []string{"rock"}); got != 100 {
+		t.Errorf("expected chances to stop at 100, got %d", got)
+	}
+	if got := Modify(Water, 20, []string{"rock"}); got != 20 {
+		t.Errorf("expected rock types to be unchanged on water, got %d", got)
+	}
+	if got := Modify("", 20, []string{"water"}); got != 20 {
+		t.Errorf("expected no change outside a biome, got %d", got)
+	}
+}
+
+func TestPick(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	candidates := []Candidate{{"easy", 3}, {"hard", 1}, {"never", 0}}
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		counts[Pick(candidates, r)]++
+	}
+	if counts["never"] != 0 || counts["easy"] <= counts["hard"] {
+		t.Errorf("expected picks to follow weights, got %v", counts)
+	}
+	if got := Pick(nil, r); got != "" {
+		t.Errorf("expected nothing to pick from no candidates, got %q", got)
+	}
+}
+
+func TestEarned(t *testing.T) {
+	earned := Earned(map[Biome]int{Cave: 5, Water: 4})
+	if len(earned) != 1 || earned[0].Name != "Spelunker" {
+		t.Errorf("expected only Spelunker, got %v", earned)
+	}
+}
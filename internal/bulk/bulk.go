@@ -0,0 +1,80 @@
+// Package bulk works through long lists of items at a limited rate, keeping
+// a checkpoint on disk so an interrupted run can carry on where it stopped.
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// ErrUnfinished means a checkpoint from an earlier run exists and the run
+// wasn't asked to resume it.
+var ErrUnfinished = errors.New("an earlier run was interrupted")
+
+// Checkpoint is how far a run got through its items.
+type Checkpoint struct {
+	Items []string `json:"items"`
+	Done  int      `json:"done"`
+}
+
+// Job is a run over items, doing one at most every Every.
+type Job struct {
+	// Checkpoint is where progress is kept. It is removed once every item
+	// is done.
+	Checkpoint string
+	Every      time.Duration
+	Do         func(item string) error
+	// Progress, if set, is called after each item.
+	Progress func(done, total int, item string)
+}
+
+// Load returns the checkpoint at path, or nil if there isn't one.
+func Load(path string) (*Checkpoint, error) {
+	var c *Checkpoint
+	err := storage.ReadJSON(path, &c)
+	return c, err
+}
+
+// Run does every item, or with resume, the ones an interrupted run didn't
+// get to; its items are used instead of the ones given. Without resume an
+// existing checkpoint is ErrUnfinished. Run stops at the first error,
+// which the next resumed run starts with.
+func (j Job) Run(items []string, resume bool) error {
+	c, err := Load(j.Checkpoint)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c != nil && !resume:
+		return fmt.Errorf("%w at %d of %d", ErrUnfinished, c.Done, len(c.Items))
+	case c == nil:
+		c = &Checkpoint{Items: items}
+	}
+
+	var last time.Time
+	for c.Done < len(c.Items) {
+		if wait := j.Every - time.Since(last); wait > 0 {
+			time.Sleep(wait)
+		}
+		last = time.Now()
+		item := c.Items[c.Done]
+		if err := j.Do(item); err != nil {
+			if saveErr := storage.WriteJSON(j.Checkpoint, c); saveErr != nil {
+				return saveErr
+			}
+			return fmt.Errorf("%s: %w", item, err)
+		}
+		c.Done++
+		if err := storage.WriteJSON(j.Checkpoint, c); err != nil {
+			return err
+		}
+		if j.Progress != nil {
+			j.Progress(c.Done, len(c.Items), item)
+		}
+	}
+	return os.Remove(j.Checkpoint)
+}
@@ -0,0 +1,43 @@
+package bulk
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	var done []string
+	fail := "c"
+	job := Job{Checkpoint: path, Do: func(item string) error {
+		if item == fail {
+			return errors.New("offline")
+		}
+		done = append(done, item)
+		return nil
+	}}
+
+	if err := job.Run([]string{"a", "b", "c", "d"}, false); err == nil {
+		t.Fatal("expected the run to stop at c")
+	}
+	if err := job.Run([]string{"x"}, false); !errors.Is(err, ErrUnfinished) {
+		t.Fatalf("expected ErrUnfinished, got %v", err)
+	}
+	c, err := Load(path)
+	if err != nil || c == nil || c.Done != 2 {
+		t.Fatalf("got checkpoint %+v, %v", c, err)
+	}
+
+	fail = ""
+	if err := job.Run([]string{"x"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(done, want) {
+		t.Errorf("did %v, want %v", done, want)
+	}
+	if c, _ := Load(path); c != nil {
+		t.Errorf("expected the checkpoint to be removed, got %+v", c)
+	}
+}
@@ -0,0 +1,210 @@
+// Package capture models the odds of catching a wild Pokémon with the
+// formula the main games use from generation 3 on, and simulates sequences
+// of throws to compare ways of catching one.
+package capture
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// MaxRate is the highest capture rate a species can have.
+const MaxRate = 255
+
+// Balls multiply the capture rate. The master ball never fails.
+var Balls = map[string]float64{
+	"poke-ball":   1,
+	"great-ball":  1.5,
+	"ultra-ball":  2,
+	"master-ball": MaxRate,
+}
+
+// Supply is the balls a trainer receives each day they play.
+var Supply = map[string]int{
+	"poke-ball":  10,
+	"great-ball": 3,
+	"ultra-ball": 1,
+}
+
+// Ball turns a ball as the player types it, like "great" or "great-ball",
+// into its item name.
+func Ball(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, "-ball") {
+		name += "-ball"
+	}
+	_, ok := Balls[name]
+	return name, ok
+}
+
+// Berries multiply the capture rate of the next throw.
+var Berries = map[string]float64{
+	"razz-berry": 1.5,
+}
+
+// Statuses multiply the capture rate while the Pokémon is afflicted.
+var Statuses = map[string]float64{
+	"sleep":     2.5,
+	"freeze":    2.5,
+	"paralysis": 1.5,
+	"poison":    1.5,
+	"burn":      1.5,
+}
+
+// Prices are what each item costs at a Poké Mart, to compare strategies.
+var Prices = map[string]int{
+	"poke-ball":   200,
+	"great-ball":  600,
+	"ultra-ball":  800,
+	"master-ball": 100000,
+	"razz-berry":  100,
+}
+
+// Estimate guesses a capture rate from a species' base experience, for
+// when its capture rate isn't known: the rarer and stronger the species,
+// the harder it is to catch.
+func Estimate(baseExperience int) int {
+	rate := MaxRate - baseExperience
+	if rate < 3 {
+		return 3
+	}
+	if rate > MaxRate {
+		return MaxRate
+	}
+	return rate
+}
+
+// Throw is one attempt at catching a Pokémon.
+type Throw struct {
+	Ball   string
+	Berry  string
+	Status string
+}
+
+// Chance is the probability a throw catches a Pokémon with the given
+// capture rate and fraction of its HP left.
+func Chance(rate int, hpLeft float64, t Throw) float64 {
+	if t.Ball == "master-ball" {
+		return 1
+	}
+	a := (3 - 2*hpLeft) / 3 * float64(rate) * Balls[t.Ball]
+	if m, ok := Berries[t.Berry]; ok {
+		a *= m
+	}
+	if m, ok := Statuses[t.Status]; ok {
+		a *= m
+	}
+	if a >= MaxRate {
+		return 1
+	}
+	return a / MaxRate
+}
+
+// Strategy is a way of catching a Pokémon: throw Balls in order, each until
+// it runs out, with a berry before every throw while they last.
+type Strategy struct {
+	Balls  []string
+	Berry  string
+	Status string
+}
+
+// Outcome is how a strategy did over many simulated attempts.
+type Outcome struct {
+	Strategy Strategy
+	// Success is the fraction of attempts that caught the Pokémon before
+	// running out of balls.
+	Success float64
+	// Cost is the average price of the items used, and Throws the average
+	// number of balls thrown.
+	Cost   float64
+	Throws float64
+}
+
+// Simulate tries the strategy n times against a Pokémon at full HP with
+// the given capture rate, using only the items in the inventory.
+func Simulate(s Strategy, rate int, inventory map[string]int, n int, r *rand.Rand) Outcome {
+	out := Outcome{Strategy: s}
+	caught, cost, throws := 0, 0, 0
+	for i := 0; i < n; i++ {
+		bag := make(map[string]int, len(inventory))
+		for item, count := range inventory {
+			bag[item] = count
+		}
+	attempt:
+		for _, ball := range s.Balls {
+			for bag[ball] > 0 {
+				t := Throw{Ball: ball, Status: s.Status}
+				if s.Berry != "" && bag[s.Berry] > 0 {
+					bag[s.Berry]--
+					cost += Prices[s.Berry]
+					t.Berry = s.Berry
+				}
+				bag[ball]--
+				cost += Prices[ball]
+				throws++
+				if r.Float64() < Chance(rate, 1, t) {
+					caught++
+					break attempt
+				}
+			}
+		}
+	}
+	out.Success = float64(caught) / float64(n)
+	out.Cost = float64(cost) / float64(n)
+	out.Throws = float64(throws) / float64(n)
+	return out
+}
+
+// Strategies lists the strategies worth trying with an inventory: each ball
+// on its own, and every ball from cheapest to dearest, each with and without
+// berries.
+func Strategies(inventory map[string]int, status string) []Strategy {
+	var balls []string
+	for ball := range Balls {
+		if inventory[ball] > 0 {
+			balls = append(balls, ball)
+		}
+	}
+	sort.Slice(balls, func(i, j int) bool { return Prices[balls[i]] < Prices[balls[j]] })
+
+	var plans [][]string
+	for _, ball := range balls {
+		plans = append(plans, []string{ball})
+	}
+	if len(balls) > 1 {
+		plans = append(plans, balls)
+	}
+	berries := []string{""}
+	for berry := range Berries {
+		if inventory[berry] > 0 {
+			berries = append(berries, berry)
+		}
+	}
+	sort.Strings(berries)
+
+	var strategies []Strategy
+	for _, plan := range plans {
+		for _, berry := range berries {
+			strategies = append(strategies, Strategy{Balls: plan, Berry: berry, Status: status})
+		}
+	}
+	return strategies
+}
+
+// Best picks the outcome that catches the Pokémon most often, and among
+// those within a percentage point of it, the cheapest.
+func Best(outcomes []Outcome) Outcome {
+	best := outcomes[0]
+	for _, o := range outcomes {
+		if o.Success > best.Success {
+			best = o
+		}
+	}
+	for _, o := range outcomes {
+		if o.Success >= best.Success-0.01 && o.Cost < best.Cost {
+			best = o
+		}
+	}
+	return best
+}
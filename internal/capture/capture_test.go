@@ -0,0 +1,65 @@
+package capture
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestChance(t *testing.T) {
+	// A full-HP pidgey (rate 255) in a poke ball: a = 85, so 1 in 3.
+	if got := Chance(255, 1, Throw{Ball: "poke-ball"}); math.Abs(got-1.0/3) > 1e-9 {
+		t.Errorf("expected 1/3, got %v", got)
+	}
+	if got := Chance(3, 1, Throw{Ball: "master-ball"}); got != 1 {
+		t.Errorf("expected the master ball to always work, got %v", got)
+	}
+	plain := Chance(45, 1, Throw{Ball: "poke-ball"})
+	better := Chance(45, 1, Throw{Ball: "ultra-ball", Berry: "razz-berry", Status: "sleep"})
+	if math.Abs(better-plain*2*1.5*2.5) > 1e-9 {
+		t.Errorf("expected ball, berry and status to multiply, got %v from %v", better, plain)
+	}
+	if got := Chance(255, 0, Throw{Ball: "ultra-ball"}); got != 1 {
+		t.Errorf("expected the chance to stop at 1, got %v", got)
+	}
+}
+
+func TestBall(t *testing.T) {
+	for _, name := range []string{"great", "Great-Ball"} {
+		if ball, ok := Ball(name); !ok || ball != "great-ball" {
+			t.Errorf("Ball(%q) = %q, %t", name, ball, ok)
+		}
+	}
+	if _, ok := Ball("net"); ok {
+		t.Errorf("expected an unknown ball to be refused")
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	if Estimate(50) != 205 || Estimate(340) != 3 {
+		t.Errorf("unexpected estimates %d and %d", Estimate(50), Estimate(340))
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	inventory := map[string]int{"poke-ball": 1, "ultra-ball": 1, "razz-berry": 5}
+	strategies := Strategies(inventory, "")
+	if len(strategies) != 6 {
+		t.Fatalf("expected 3 ball plans with and without berries, got %v", strategies)
+	}
+
+	var outcomes []Outcome
+	for _, s := range strategies {
+		outcomes = append(outcomes, Simulate(s, 45, inventory, 5000, r))
+	}
+	best := Best(outcomes)
+	if len(best.Strategy.Balls) != 2 || best.Strategy.Berry != "razz-berry" {
+		t.Errorf("expected throwing everything with berries to catch it most often, got %+v", best)
+	}
+	for _, o := range outcomes {
+		if o.Throws > 2 || o.Success <= 0 || o.Success >= 1 {
+			t.Errorf("unexpected outcome %+v", o)
+		}
+	}
+}
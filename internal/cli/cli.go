@@ -0,0 +1,327 @@
+// Package cli describes how commands are typed: their arguments, flags and
+// subcommands. From that it checks what the player typed and writes usage
+// lines and help.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Command describes a command, or one of a command's subcommands.
+type Command struct {
+	Name    string
+	Summary string
+	// Args are the positional arguments, in order.
+	Args  []Arg
+	Flags []Flag
+	// Subcommands are picked by the first argument, in place of Args. One
+	// named "" is what the command does when no other is picked.
+	Subcommands []Command
+	// Details is a longer explanation, shown by --help.
+	Details string
+}
+
+// Arg is a positional argument.
+type Arg struct {
+	Name string
+	// Kind is the kind of name the argument is, for completion: pokemon,
+	// species, area, type or item.
+	Kind     string
+	Optional bool
+	// Rest takes this argument and all those after it, like a chat message.
+	Rest bool
+	// Literal arguments are typed as their name, like the "vs" in
+	// "simulate <team> vs <team>".
+	Literal bool
+	// File arguments are paths to files on the player's computer.
+	File bool
+}
+
+// Flag is a --flag.
+type Flag struct {
+	Name string
+	// Value names the flag's value; flags without one are switches.
+	Value string
+	Usage string
+}
+
+// ErrHelp is returned by Parse when the arguments ask for help.
+var ErrHelp = errors.New("help requested")
+
+// UsageError is a mistake in a command's arguments.
+type UsageError struct {
+	// Usage is how the command, or the subcommand picked, is typed.
+	Usage   string
+	Problem string
+}
+
+func (e *UsageError) Error() string {
+	return e.Problem
+}
+
+// Input is what Parse found in a command's arguments.
+type Input struct {
+	// Path is the subcommands picked, if any.
+	Path  []string
+	Args  []string
+	Flags map[string]string
+	// Of is the Arg each of Args was typed for, and At where each of Args
+	// is in the arguments given to Parse.
+	Of []Arg
+	At []int
+}
+
+// Parse checks args against the command: its flags, its subcommands and the
+// number of arguments. Switches are set to "true" in Flags.
+func (c Command) Parse(args []string) (Input, error) {
+	return c.parse(c.Name, c.Flags, args)
+}
+
+// parse parses args for c, whose full name is name, given the flags of the
+// commands it is a subcommand of too.
+func (c Command) parse(name string, flags []Flag, args []string) (Input, error) {
+	if len(c.Subcommands) > 0 {
+		for _, sub := range c.Subcommands {
+			if sub.Name != "" && len(args) > 0 && args[0] == sub.Name {
+				in, err := sub.parse(name+" "+sub.Name, append(slices.Clip(flags), sub.Flags...), args[1:])
+				in.Path = append([]string{sub.Name}, in.Path...)
+				for i := range in.At {
+					in.At[i]++
+				}
+				return in, err
+			}
+		}
+		if sub, ok := c.sub(""); ok {
+			return sub.parse(name, append(slices.Clip(flags), sub.Flags...), args)
+		}
+		if slices.Contains(args, "--help") || slices.Contains(args, "-h") {
+			return Input{}, ErrHelp
+		}
+		var names []string
+		for _, sub := range c.Subcommands {
+			names = append(names, sub.Name)
+		}
+		problem := "missing " + strings.Join(names, ", ")
+		if len(args) > 0 {
+			problem = fmt.Sprintf("%s isn't one of %s", args[0], strings.Join(names, ", "))
+		}
+		return Input{}, &UsageError{Usage: c.usage(name), Problem: problem}
+	}
+
+	in := Input{Flags: make(map[string]string)}
+	fail := func(format string, a ...any) (Input, error) {
+		return in, &UsageError{Usage: c.usage(name), Problem: fmt.Sprintf(format, a...)}
+	}
+	rest := len(c.Args) > 0 && c.Args[len(c.Args)-1].Rest
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--help" || arg == "-h" {
+			return in, ErrHelp
+		}
+		// Whatever follows the start of a rest argument belongs to it.
+		if rest && len(in.Args) >= len(c.Args)-1 || !strings.HasPrefix(arg, "--") || arg == "--" {
+			in.Args, in.At = append(in.Args, arg), append(in.At, i)
+			continue
+		}
+		flagName, value, hasValue := strings.Cut(arg[2:], "=")
+		at := slices.IndexFunc(flags, func(f Flag) bool { return f.Name == flagName })
+		switch {
+		case at < 0:
+			return fail("there is no --%s flag", flagName)
+		case flags[at].Value == "" && hasValue:
+			return fail("--%s doesn't take a value", flagName)
+		case flags[at].Value == "":
+			value = "true"
+		case !hasValue:
+			if i+1 >= len(args) {
+				return fail("--%s needs a <%s>", flagName, flags[at].Value)
+			}
+			i++
+			value = args[i]
+		}
+		in.Flags[flagName] = value
+	}
+
+	for i, arg := range c.Args {
+		switch {
+		case i >= len(in.Args):
+			if !arg.Optional {
+				return fail("missing <%s>", arg.Name)
+			}
+		case arg.Literal && in.Args[i] != arg.Name:
+			return fail("expected %s, not %s", arg.Name, in.Args[i])
+		}
+	}
+	if !rest && len(in.Args) > len(c.Args) {
+		return fail("unexpected %s", in.Args[len(c.Args)])
+	}
+	for i := range in.Args {
+		in.Of = append(in.Of, c.Args[min(i, len(c.Args)-1)])
+	}
+	return in, nil
+}
+
+func (c Command) sub(name string) (Command, bool) {
+	for _, sub := range c.Subcommands {
+		if sub.Name == name {
+			return sub, true
+		}
+	}
+	return Command{}, false
+}
+
+// FlagNames are the names of every flag the command and its subcommands
+// take.
+func (c Command) FlagNames() []string {
+	var names []string
+	for _, f := range c.Flags {
+		names = append(names, f.Name)
+	}
+	for _, sub := range c.Subcommands {
+		for _, name := range sub.FlagNames() {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Kind is the kind of name the command's first argument is, if any.
+func (c Command) Kind() string {
+	if len(c.Args) > 0 {
+		return c.Args[0].Kind
+	}
+	return ""
+}
+
+// Usage is a one-line summary of how the command is typed, like
+// "catch <pokemon_name> [--ball <ball>]" or "party [list|add|remove]".
+func (c Command) Usage() string {
+	return c.usage(c.Name)
+}
+
+func (c Command) usage(name string) string {
+	parts := []string{name}
+	if len(c.Subcommands) > 0 {
+		var alternatives []string
+		optional := false
+		for _, sub := range c.Subcommands {
+			switch {
+			case sub.Name != "":
+				alternatives = append(alternatives, sub.Name)
+			case len(sub.Args) > 0:
+				alternatives = append(alternatives, argsUsage(sub.Args))
+			default:
+				optional = true
+			}
+		}
+		if optional {
+			parts = append(parts, "["+strings.Join(alternatives, "|")+"]")
+		} else {
+			parts = append(parts, strings.Join(alternatives, "|"))
+		}
+	}
+	if len(c.Args) > 0 {
+		parts = append(parts, argsUsage(c.Args))
+	}
+	for _, f := range c.Flags {
+		if f.Value == "" {
+			parts = append(parts, "[--"+f.Name+"]")
+		} else {
+			parts = append(parts, "[--"+f.Name+" <"+f.Value+">]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func argsUsage(args []Arg) string {
+	var parts []string
+	for _, arg := range args {
+		switch {
+		case arg.Literal:
+			parts = append(parts, arg.Name)
+		case arg.Optional:
+			parts = append(parts, "["+arg.Name+"]")
+		default:
+			parts = append(parts, "<"+arg.Name+">")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Synopses are every way the command is typed, one for each subcommand,
+// with what each does.
+func (c Command) Synopses() []Synopsis {
+	return c.synopses(c.Name)
+}
+
+// Synopsis is one way to type a command.
+type Synopsis struct {
+	Usage   string
+	Summary string
+}
+
+func (c Command) synopses(name string) []Synopsis {
+	if len(c.Subcommands) == 0 {
+		return []Synopsis{{c.usage(name), c.Summary}}
+	}
+	var all []Synopsis
+	for _, sub := range c.Subcommands {
+		subName := name
+		if sub.Name != "" {
+			subName += " " + sub.Name
+		}
+		all = append(all, sub.synopses(subName)...)
+	}
+	return all
+}
+
+// Help is the command's full help: its summary, every way it is typed,
+// its flags and any details.
+func (c Command) Help() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\nUsage:\n", c.Summary)
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, s := range c.Synopses() {
+		if len(c.Subcommands) > 0 {
+			fmt.Fprintf(w, "  %s\t%s\n", s.Usage, s.Summary)
+		} else {
+			fmt.Fprintf(w, "  %s\n", s.Usage)
+		}
+	}
+	w.Flush()
+
+	var flags []Flag
+	var collect func(Command)
+	collect = func(c Command) {
+		for _, f := range c.Flags {
+			if !slices.ContainsFunc(flags, func(seen Flag) bool { return seen.Name == f.Name }) {
+				flags = append(flags, f)
+			}
+		}
+		for _, sub := range c.Subcommands {
+			collect(sub)
+		}
+	}
+	collect(c)
+	if len(flags) > 0 {
+		b.WriteString("\nFlags:\n")
+		for _, f := range flags {
+			name := "--" + f.Name
+			if f.Value != "" {
+				name += " <" + f.Value + ">"
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", name, f.Usage)
+		}
+		w.Flush()
+	}
+	if c.Details != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(c.Details))
+	}
+	return b.String()
+}
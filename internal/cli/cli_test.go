@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var catch = Command{
+	Name:    "catch",
+	Summary: "Try to catch a Pokémon",
+	Args:    []Arg{{Name: "pokemon_name", Kind: "species"}},
+	Flags:   []Flag{{Name: "ball", Value: "ball", Usage: "The ball to throw"}, {Name: "quiet"}},
+}
+
+var party = Command{
+	Name:    "party",
+	Summary: "Manage your party",
+	Flags:   []Flag{{Name: "format", Value: "format"}},
+	Subcommands: []Command{
+		{Summary: "Show your party"},
+		{Name: "add", Summary: "Add a Pokémon", Args: []Arg{{Name: "pokemon_name"}}},
+		{Name: "note", Summary: "Write about it", Args: []Arg{{Name: "pokemon_name"}, {Name: "text", Rest: true}}},
+	},
+}
+
+func TestParse(t *testing.T) {
+	in, err := catch.Parse([]string{"--ball", "great", "pikachu", "--quiet"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(in.Args, []string{"pikachu"}) || in.Flags["ball"] != "great" || in.Flags["quiet"] != "true" {
+		t.Errorf("got %+v", in)
+	}
+	if !slices.Equal(in.At, []int{2}) || in.Of[0].Kind != "species" {
+		t.Errorf("expected pikachu to be the species at 2, got %+v", in)
+	}
+
+	in, err = party.Parse([]string{"note", "zubat", "likes --caves", "a", "lot", "--format=json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(in.Path, []string{"note"}) || len(in.Args) != 5 {
+		t.Errorf("got %+v", in)
+	}
+	if in.At[0] != 1 || in.At[4] != 5 || in.Of[4].Name != "text" {
+		t.Errorf("expected positions in the arguments with the subcommand, got %+v", in)
+	}
+	if in, err := party.Parse([]string{"--format", "json"}); err != nil || in.Flags["format"] != "json" {
+		t.Errorf("got %+v, %v", in, err)
+	}
+}
+
+func TestParseProblems(t *testing.T) {
+	cases := []struct {
+		cmd     Command
+		args    []string
+		problem string
+	}{
+		{catch, nil, "missing <pokemon_name>"},
+		{catch, []string{"pikachu", "zubat"}, "unexpected zubat"},
+		{catch, []string{"pikachu", "--net"}, "there is no --net flag"},
+		{catch, []string{"pikachu", "--ball"}, "--ball needs a <ball>"},
+		{catch, []string{"pikachu", "--quiet=yes"}, "--quiet doesn't take a value"},
+		{party, []string{"add"}, "missing <pokemon_name>"},
+		{party, []string{"remove", "zubat"}, "unexpected remove"},
+	}
+	for _, c := range cases {
+		_, err := c.cmd.Parse(c.args)
+		var usage *UsageError
+		if !errors.As(err, &usage) || usage.Problem != c.problem {
+			t.Errorf("%s %v: got %v, want %q", c.cmd.Name, c.args, err, c.problem)
+		}
+	}
+	if _, err := party.Parse([]string{"add", "--help"}); err != ErrHelp {
+		t.Errorf("got %v, want ErrHelp", err)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	if got := catch.Usage(); got != "catch <pokemon_name> [--ball <ball>] [--quiet]" {
+		t.Errorf("got %q", got)
+	}
+	if got := party.Usage(); got != "party [add|note] [--format <format>]" {
+		t.Errorf("got %q", got)
+	}
+	var usages []string
+	for _, s := range party.Synopses() {
+		usages = append(usages, s.Usage)
+	}
+	if want := []string{"party", "party add <pokemon_name>", "party note <pokemon_name> <text>"}; !slices.Equal(usages, want) {
+		t.Errorf("got %q, want %q", usages, want)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	help := party.Help()
+	for _, want := range []string{"Manage your party\n", "  party add <pokemon_name>          Add a Pokémon\n", "--format <format>"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help is missing %q:\n%s", want, help)
+		}
+	}
+}
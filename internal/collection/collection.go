@@ -0,0 +1,76 @@
+// Package collection sums up the Pokémon a player has caught: how many of
+// each type, and which stand out.
+package collection
+
+import (
+	"sort"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// TypeCount is how many Pokémon of a type were caught. A Pokémon with two
+// types counts towards both.
+type TypeCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// Record is the Pokémon that stands out for something, and by how much,
+// in PokeAPI's units: tenths of a metre or kilogram.
+type Record struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// Summary sums up a collection. Pokémon PokeAPI leaves data out for are
+// left out of what needs it.
+type Summary struct {
+	Caught int         `json:"caught"`
+	ByType []TypeCount `json:"by_type"`
+	// AverageBaseExperience is 0 if no Pokémon's base experience is known.
+	AverageBaseExperience float64 `json:"average_base_experience"`
+	Heaviest              *Record `json:"heaviest,omitempty"`
+	Tallest               *Record `json:"tallest,omitempty"`
+}
+
+// Summarize sums up the Pokémon given. Ties go to the first name in
+// alphabetical order.
+func Summarize(pokemon []pokeapi.Pokemon) Summary {
+	pokemon = append([]pokeapi.Pokemon(nil), pokemon...)
+	sort.Slice(pokemon, func(i, j int) bool { return pokemon[i].Name < pokemon[j].Name })
+
+	s := Summary{Caught: len(pokemon), ByType: []TypeCount{}}
+	counts := make(map[string]int)
+	experience, known := 0, 0
+	for _, p := range pokemon {
+		for _, t := range p.Types {
+			counts[t.Type.Name]++
+		}
+		if p.BaseExperience > 0 {
+			experience += p.BaseExperience
+			known++
+		}
+		s.Heaviest = most(s.Heaviest, p.Name, p.Weight)
+		s.Tallest = most(s.Tallest, p.Name, p.Height)
+	}
+	if known > 0 {
+		s.AverageBaseExperience = float64(experience) / float64(known)
+	}
+	for t, n := range counts {
+		s.ByType = append(s.ByType, TypeCount{t, n})
+	}
+	sort.Slice(s.ByType, func(i, j int) bool {
+		if s.ByType[i].Count != s.ByType[j].Count {
+			return s.ByType[i].Count > s.ByType[j].Count
+		}
+		return s.ByType[i].Type < s.ByType[j].Type
+	})
+	return s
+}
+
+func most(record *Record, name string, value int) *Record {
+	if value <= 0 || record != nil && record.Value >= value {
+		return record
+	}
+	return &Record{name, value}
+}
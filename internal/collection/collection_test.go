@@ -0,0 +1,52 @@
+package collection
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+func pokemon(name string, experience, height, weight int, types ...string) pokeapi.Pokemon {
+	p := pokeapi.Pokemon{Name: name, BaseExperience: experience, Height: height, Weight: weight}
+	for _, t := range types {
+		p.Types = append(p.Types, pokeapi.Type{Type: pokeapi.NamedResource{Name: t}})
+	}
+	return p
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]pokeapi.Pokemon{
+		pokemon("pikachu", 112, 4, 60, "electric"),
+		pokemon("onix", 77, 88, 2100, "rock", "ground"),
+		pokemon("geodude", 60, 4, 200, "rock", "ground"),
+		pokemon("new-form", 0, 0, 0),
+	})
+	if s.Caught != 4 {
+		t.Errorf("expected 4 caught, got %d", s.Caught)
+	}
+	want := []TypeCount{{"ground", 2}, {"rock", 2}, {"electric", 1}}
+	if !reflect.DeepEqual(s.ByType, want) {
+		t.Errorf("got %v, want %v", s.ByType, want)
+	}
+	if got := s.AverageBaseExperience; got != 83 {
+		t.Errorf("expected an average of 83, counting only those known, got %v", got)
+	}
+	if *s.Heaviest != (Record{"onix", 2100}) || *s.Tallest != (Record{"onix", 88}) {
+		t.Errorf("expected onix to be heaviest and tallest, got %v and %v", *s.Heaviest, *s.Tallest)
+	}
+}
+
+func TestSummarizeTies(t *testing.T) {
+	s := Summarize([]pokeapi.Pokemon{pokemon("pikachu", 0, 4, 60), pokemon("geodude", 0, 4, 200)})
+	if s.Tallest.Name != "geodude" {
+		t.Errorf("expected the tie to go to geodude, got %s", s.Tallest.Name)
+	}
+}
+
+func TestSummarizeNothing(t *testing.T) {
+	s := Summarize(nil)
+	if s.Caught != 0 || len(s.ByType) != 0 || s.AverageBaseExperience != 0 || s.Heaviest != nil || s.Tallest != nil {
+		t.Errorf("expected an empty summary, got %+v", s)
+	}
+}
@@ -0,0 +1,69 @@
+// Package color colors text for terminals with ANSI escape codes, including
+// the colors the games give each Pokémon type.
+package color
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Style is an ANSI display attribute, like bold or a color.
+type Style string
+
+const (
+	Bold  Style = "1"
+	Dim   Style = "2"
+	Red   Style = "31"
+	Green Style = "32"
+	Gold  Style = "33"
+)
+
+// Paint wraps s in styles, resetting them afterwards.
+func Paint(s string, styles ...Style) string {
+	if s == "" || len(styles) == 0 {
+		return s
+	}
+	codes := make([]string, len(styles))
+	for i, style := range styles {
+		codes[i] = string(style)
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m" + s + "\x1b[0m"
+}
+
+// RGB is a color as terminals with 24-bit color show it.
+type RGB struct{ R, G, B uint8 }
+
+func (c RGB) Style() Style {
+	return Style(fmt.Sprintf("38;2;%d;%d;%d", c.R, c.G, c.B))
+}
+
+// Types are the colors each type is shown in across the games.
+var Types = map[string]RGB{
+	"normal":   {168, 167, 122},
+	"fire":     {238, 129, 48},
+	"water":    {99, 144, 240},
+	"electric": {247, 208, 44},
+	"grass":    {122, 199, 76},
+	"ice":      {150, 217, 214},
+	"fighting": {194, 46, 40},
+	"poison":   {163, 62, 161},
+	"ground":   {226, 191, 101},
+	"flying":   {169, 143, 243},
+	"psychic":  {249, 85, 135},
+	"bug":      {166, 185, 26},
+	"rock":     {182, 161, 54},
+	"ghost":    {115, 87, 151},
+	"dragon":   {111, 53, 252},
+	"dark":     {112, 87, 70},
+	"steel":    {183, 183, 206},
+	"fairy":    {214, 133, 173},
+}
+
+// Type is a type's name in its color, or as it is for a type without one.
+func Type(name string) string {
+	c, ok := Types[name]
+	if !ok {
+		return name
+	}
+	return Paint(name, c.Style())
+}
@@ -0,0 +1,24 @@
+package color
+
+import "testing"
+
+func TestPaint(t *testing.T) {
+	if got := Paint("caught", Bold, Green); got != "\x1b[1;32mcaught\x1b[0m" {
+		t.Errorf("got %q", got)
+	}
+	if got := Paint("caught"); got != "caught" {
+		t.Errorf("expected no styles to leave the text alone, got %q", got)
+	}
+	if got := Paint("", Red); got != "" {
+		t.Errorf("expected nothing to stay nothing, got %q", got)
+	}
+}
+
+func TestType(t *testing.T) {
+	if got := Type("fire"); got != "\x1b[38;2;238;129;48mfire\x1b[0m" {
+		t.Errorf("got %q", got)
+	}
+	if got := Type("shadow"); got != "shadow" {
+		t.Errorf("expected a type without a color to be left alone, got %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+package community
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Operators run community events through these endpoints, which need an
+// admin role and the server's admin token:
+//
+//	POST /admin/spawns  put a Pokémon in an area
+//	POST /admin/grants  give a player an item, claimed like a gift
+//	POST /admin/events  start a community event
+//	GET  /admin/stats   how busy the server is
+//
+// Players see what operators started through:
+//
+//	GET  /spawns?area={area}  the Pokémon put in an area
+//	GET  /events              the community events running
+
+type Spawn struct {
+	Pokemon string `json:"pokemon"`
+	Area    string `json:"area"`
+}
+
+type Grant struct {
+	// To is a friend code or trainer name.
+	To   string `json:"to"`
+	Item string `json:"item"`
+}
+
+type Stats struct {
+	Players int      `json:"players"`
+	Queued  int      `json:"queued"`
+	Matches int      `json:"matches"`
+	Events  []string `json:"events"`
+}
+
+// Authorize makes the client send token with every request, as operators
+// must.
+func (c *Client) Authorize(token string) {
+	c.token = token
+}
+
+func (c *Client) Spawn(s Spawn) error {
+	return c.do(http.MethodPost, "/admin/spawns", s, nil)
+}
+
+func (c *Client) Grant(g Grant) error {
+	return c.do(http.MethodPost, "/admin/grants", g, nil)
+}
+
+func (c *Client) StartEvent(name string) error {
+	return c.do(http.MethodPost, "/admin/events", map[string]string{"name": name}, nil)
+}
+
+func (c *Client) Stats() (Stats, error) {
+	var s Stats
+	err := c.do(http.MethodGet, "/admin/stats", nil, &s)
+	return s, err
+}
+
+// Spawns lists the Pokémon operators put in an area.
+func (c *Client) Spawns(area string) ([]Spawn, error) {
+	var spawns []Spawn
+	err := c.do(http.MethodGet, "/spawns?area="+url.QueryEscape(area), nil, &spawns)
+	return spawns, err
+}
+
+// Events lists the names of the community events running.
+func (c *Client) Events() ([]string, error) {
+	var events []string
+	err := c.do(http.MethodGet, "/events", nil, &events)
+	return events, err
+}
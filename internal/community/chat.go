@@ -0,0 +1,67 @@
+package community
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Chat goes through these endpoints, one channel per lobby or session:
+//
+//	POST /channels/{channel}/messages             say something
+//	GET  /channels/{channel}/messages?after={id}  what was said since
+
+// Lobby is the channel everyone on the server shares.
+const Lobby = "lobby"
+
+// MaxMessage is the longest message, in characters, that is sent or shown.
+const MaxMessage = 200
+
+type Message struct {
+	ID        int       `json:"id"`
+	Trainer   string    `json:"trainer"`
+	TrainerID int       `json:"trainer_id"`
+	Text      string    `json:"text"`
+	At        time.Time `json:"at"`
+}
+
+func messagesPath(channel string) string {
+	return "/channels/" + url.PathEscape(channel) + "/messages"
+}
+
+// Say posts to a channel and returns the message as the server stored it.
+func (c *Client) Say(channel string, from Player, text string) (Message, error) {
+	m := Message{Trainer: from.Trainer, TrainerID: from.TrainerID, Text: Clean(text)}
+	err := c.do(http.MethodPost, messagesPath(channel), m, &m)
+	return m, err
+}
+
+// Messages returns what was said in a channel after the message with the
+// given ID, oldest first.
+func (c *Client) Messages(channel string, after int) ([]Message, error) {
+	var messages []Message
+	err := c.do(http.MethodGet, messagesPath(channel)+"?after="+strconv.Itoa(after), nil, &messages)
+	return messages, err
+}
+
+// Clean makes text from other players safe to print: control characters,
+// which could move the cursor or recolour the terminal, are dropped along
+// with invisible direction overrides, and the text is cut to MaxMessage.
+func Clean(text string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range text {
+		if unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r) {
+			continue
+		}
+		if n == MaxMessage {
+			break
+		}
+		b.WriteRune(r)
+		n++
+	}
+	return strings.TrimSpace(b.String())
+}
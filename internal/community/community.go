@@ -0,0 +1,60 @@
+// Package community is the client for a community server, which players
+// opt in to for online play: ranked matchmaking, friends and gifts. Matches
+// themselves are played directly between the two players. The server speaks
+// JSON over HTTP.
+package community
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Client struct {
+	base string
+	http *http.Client
+	// token authorizes admin requests; see Authorize.
+	token string
+}
+
+func New(server string) *Client {
+	return &Client{base: strings.TrimRight(server, "/"), http: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// do sends in as JSON, if there is any, and decodes the answer into out, if
+// wanted.
+func (c *Client) do(method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.base+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("the server said %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,295 @@
+package community
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// server is a tiny matchmaker that pairs the first two players to queue.
+type server struct {
+	mu      sync.Mutex
+	queued  []Entry
+	results []Report
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/queue":
+		var e Entry
+		json.NewDecoder(r.Body).Decode(&e)
+		s.queued = append(s.queued, e)
+		json.NewEncoder(w).Encode(map[string]string{"ticket": e.Trainer})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/queue/"):
+		if len(s.queued) < 2 {
+			json.NewEncoder(w).Encode(map[string]any{})
+			return
+		}
+		m := Match{ID: "m1", Role: RoleHost, Opponent: s.queued[1].Trainer}
+		if strings.TrimPrefix(r.URL.Path, "/queue/") == s.queued[1].Trainer {
+			m = Match{ID: "m1", Role: RoleGuest, Opponent: "127.0.0.1:7777"}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"match": m})
+	case r.Method == http.MethodPost && r.URL.Path == "/matches/m1/result":
+		var report Report
+		json.NewDecoder(r.Body).Decode(&report)
+		s.results = append(s.results, report)
+	case r.URL.Path == "/ladder":
+		json.NewEncoder(w).Encode([]Standing{{Trainer: "red", Rating: 1016, Wins: 1}})
+	default:
+		http.Error(w, "no such queue", http.StatusNotFound)
+	}
+}
+
+func TestMatchmaking(t *testing.T) {
+	s := &server{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	c := New(ts.URL + "/")
+
+	red, err := c.Queue(Entry{Trainer: "red", Port: 7777})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, err := c.Poll(red); err != nil || m != nil {
+		t.Fatalf("expected to wait for an opponent, got %+v, %v", m, err)
+	}
+	blue, _ := c.Queue(Entry{Trainer: "blue"})
+	host, err := c.Poll(red)
+	if err != nil || host == nil || host.Role != RoleHost || host.Opponent != "blue" {
+		t.Fatalf("expected red to host blue, got %+v, %v", host, err)
+	}
+	guest, _ := c.Poll(blue)
+	if guest == nil || guest.Role != RoleGuest || guest.Opponent != "127.0.0.1:7777" {
+		t.Fatalf("expected blue to join red, got %+v", guest)
+	}
+
+	if err := c.Report(host.ID, Report{Result: Win, Turns: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.results) != 1 || s.results[0].Result != Win {
+		t.Errorf("expected the result to reach the server, got %+v", s.results)
+	}
+	ladder, err := c.Ladder()
+	if err != nil || len(ladder) != 1 || ladder[0].Trainer != "red" {
+		t.Errorf("unexpected ladder %+v, %v", ladder, err)
+	}
+	if err := c.Leave("nobody"); err == nil || !strings.Contains(err.Error(), "no such queue") {
+		t.Errorf("expected the server's error, got %v", err)
+	}
+}
+
+// friendServer keeps one friendship and the gifts sent along it.
+type friendServer struct {
+	mu     sync.Mutex
+	linked bool
+	gifts  []Gift
+}
+
+func (s *friendServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/players":
+		var p Player
+		json.NewDecoder(r.Body).Decode(&p)
+		json.NewEncoder(w).Encode(map[string]string{"code": p.Trainer + "-code"})
+	case r.Method == http.MethodPost && r.URL.Path == "/players/red-code/friends":
+		s.linked = true
+		json.NewEncoder(w).Encode(Friend{Code: "blue-code", Trainer: "blue"})
+	case r.Method == http.MethodGet && r.URL.Path == "/players/red-code/friends":
+		friends := []Friend{}
+		if s.linked {
+			friends = append(friends, Friend{Code: "blue-code", Trainer: "blue"})
+		}
+		json.NewEncoder(w).Encode(friends)
+	case r.Method == http.MethodDelete && r.URL.Path == "/players/red-code/friends/blue-code":
+		s.linked = false
+	case r.Method == http.MethodPost && r.URL.Path == "/players/red-code/gifts":
+		var sent struct {
+			To       string `json:"to"`
+			Item     string `json:"item"`
+			Quantity int    `json:"quantity"`
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		if !s.linked || sent.To != "blue-code" {
+			http.Error(w, "not friends", http.StatusForbidden)
+			return
+		}
+		s.gifts = append(s.gifts, Gift{From: Friend{Code: "red-code", Trainer: "red"}, Item: sent.Item, Quantity: sent.Quantity})
+	case r.Method == http.MethodPost && r.URL.Path == "/players/blue-code/gifts/claim":
+		json.NewEncoder(w).Encode(s.gifts)
+		s.gifts = nil
+	case r.Method == http.MethodGet && r.URL.Path == "/players/red-code":
+		json.NewEncoder(w).Encode(map[string]string{"trainer": "red", "role": Spectator})
+	case r.Method == http.MethodGet && r.URL.Path == "/players/blue-code":
+		json.NewEncoder(w).Encode(map[string]string{"trainer": "blue"})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestFriends(t *testing.T) {
+	ts := httptest.NewServer(&friendServer{})
+	defer ts.Close()
+	c := New(ts.URL)
+
+	code, err := c.Register(Player{Trainer: "red", TrainerID: 1})
+	if err != nil || code != "red-code" {
+		t.Fatalf("expected a friend code, got %q, %v", code, err)
+	}
+	if err := c.SendGift(code, "blue-code", "potion", 1); err == nil {
+		t.Error("expected gifts to strangers to be refused")
+	}
+	friend, err := c.Link(code, "blue-code")
+	if err != nil || friend.Trainer != "blue" {
+		t.Fatalf("expected to link with blue, got %+v, %v", friend, err)
+	}
+	if friends, _ := c.Friends(code); len(friends) != 1 {
+		t.Errorf("expected one friend, got %+v", friends)
+	}
+
+	if err := c.SendGift(code, "blue-code", "potion", 1); err != nil {
+		t.Fatal(err)
+	}
+	gifts, err := c.ClaimGifts("blue-code")
+	if err != nil || len(gifts) != 1 || gifts[0].Item != "potion" || gifts[0].From.Trainer != "red" {
+		t.Fatalf("expected blue to claim red's potion, got %+v, %v", gifts, err)
+	}
+	if gifts, _ := c.ClaimGifts("blue-code"); len(gifts) != 0 {
+		t.Errorf("expected gifts to be claimed only once, got %+v", gifts)
+	}
+
+	if err := c.Unlink(code, "blue-code"); err != nil {
+		t.Fatal(err)
+	}
+	if friends, _ := c.Friends(code); len(friends) != 0 {
+		t.Errorf("expected no friends after unlinking, got %+v", friends)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	ts := httptest.NewServer(&friendServer{})
+	defer ts.Close()
+	c := New(ts.URL)
+
+	if role, err := c.Role("red-code"); err != nil || role != Spectator {
+		t.Errorf("expected red to be a spectator, got %q, %v", role, err)
+	}
+	if role, err := c.Role("blue-code"); err != nil || role != Trainer {
+		t.Errorf("expected players without a role to be trainers, got %q, %v", role, err)
+	}
+	if _, err := c.Role("nobody"); err == nil {
+		t.Error("expected an error for an unknown player")
+	}
+
+	cases := []struct {
+		role, permission string
+		want             bool
+	}{
+		{Admin, Administer, true},
+		{Trainer, Battle, true},
+		{Trainer, Administer, false},
+		{Spectator, Trade, false},
+		{"moderator", Battle, false},
+	}
+	for _, c := range cases {
+		if got := Allows(c.role, c.permission); got != c.want {
+			t.Errorf("Allows(%q, %q): expected %v", c.role, c.permission, c.want)
+		}
+	}
+}
+
+// adminServer takes admin requests with the token "secret".
+type adminServer struct {
+	mu     sync.Mutex
+	spawns []Spawn
+	events []string
+}
+
+func (s *adminServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strings.HasPrefix(r.URL.Path, "/admin/") && r.Header.Get("Authorization") != "Bearer secret" {
+		http.Error(w, "operators only", http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/admin/spawns":
+		var spawn Spawn
+		json.NewDecoder(r.Body).Decode(&spawn)
+		s.spawns = append(s.spawns, spawn)
+	case r.Method == http.MethodPost && r.URL.Path == "/admin/events":
+		var event struct {
+			Name string `json:"name"`
+		}
+		json.NewDecoder(r.Body).Decode(&event)
+		s.events = append(s.events, event.Name)
+	case r.URL.Path == "/admin/stats":
+		json.NewEncoder(w).Encode(Stats{Players: 2, Events: s.events})
+	case r.URL.Path == "/spawns":
+		spawns := []Spawn{}
+		for _, spawn := range s.spawns {
+			if spawn.Area == r.URL.Query().Get("area") {
+				spawns = append(spawns, spawn)
+			}
+		}
+		json.NewEncoder(w).Encode(spawns)
+	case r.URL.Path == "/events":
+		json.NewEncoder(w).Encode(s.events)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestAdmin(t *testing.T) {
+	ts := httptest.NewServer(&adminServer{})
+	defer ts.Close()
+	c := New(ts.URL)
+
+	if err := c.Spawn(Spawn{Pokemon: "mew", Area: "pallet-town-area"}); err == nil || !strings.Contains(err.Error(), "operators only") {
+		t.Fatalf("expected spawning without the token to be refused, got %v", err)
+	}
+	c.Authorize("secret")
+	if err := c.Spawn(Spawn{Pokemon: "mew", Area: "pallet-town-area"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.StartEvent("mew-week"); err != nil {
+		t.Fatal(err)
+	}
+	if stats, err := c.Stats(); err != nil || stats.Players != 2 || len(stats.Events) != 1 {
+		t.Errorf("unexpected stats %+v, %v", stats, err)
+	}
+
+	player := New(ts.URL)
+	if spawns, err := player.Spawns("pallet-town-area"); err != nil || len(spawns) != 1 || spawns[0].Pokemon != "mew" {
+		t.Errorf("expected mew in pallet town, got %+v, %v", spawns, err)
+	}
+	if spawns, _ := player.Spawns("viridian-forest-area"); len(spawns) != 0 {
+		t.Errorf("expected nothing in viridian forest, got %+v", spawns)
+	}
+	if events, err := player.Events(); err != nil || len(events) != 1 || events[0] != "mew-week" {
+		t.Errorf("expected mew-week to be running, got %v, %v", events, err)
+	}
+}
+
+func TestClean(t *testing.T) {
+	cases := map[string]string{
+		"hello there":               "hello there",
+		"\x1b[2J\x1b[31mred\x1b[0m": "[2J[31mred[0m",
+		"line\nbreak\r":             "linebreak",
+		"\u202eevil":                "evil",
+		strings.Repeat("é", 300):    strings.Repeat("é", MaxMessage),
+		"  padded  ":                "padded",
+	}
+	for in, want := range cases {
+		if got := Clean(in); got != want {
+			t.Errorf("Clean(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
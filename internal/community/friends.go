@@ -0,0 +1,79 @@
+package community
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Friends and gifts go through these endpoints, under the player's friend
+// code:
+//
+//	POST   /players                        register, returns a friend code
+//	GET    /players/{code}/friends         the player's friends
+//	POST   /players/{code}/friends         link with another player
+//	DELETE /players/{code}/friends/{code}  unlink
+//	POST   /players/{code}/gifts           send a friend a gift
+//	POST   /players/{code}/gifts/claim     collect the gifts waiting
+
+type Player struct {
+	Trainer   string `json:"trainer"`
+	TrainerID int    `json:"trainer_id"`
+}
+
+type Friend struct {
+	Code    string `json:"code"`
+	Trainer string `json:"trainer"`
+}
+
+// Gift is an item sent by a friend, held by the server until it is claimed.
+type Gift struct {
+	From     Friend    `json:"from"`
+	Item     string    `json:"item"`
+	Quantity int       `json:"quantity"`
+	Sent     time.Time `json:"sent"`
+}
+
+// Register signs the player up and returns the friend code others can use
+// to link with them.
+func (c *Client) Register(p Player) (string, error) {
+	var registered struct {
+		Code string `json:"code"`
+	}
+	err := c.do(http.MethodPost, "/players", p, &registered)
+	return registered.Code, err
+}
+
+func friendsPath(code string) string {
+	return "/players/" + url.PathEscape(code) + "/friends"
+}
+
+func (c *Client) Friends(code string) ([]Friend, error) {
+	var friends []Friend
+	err := c.do(http.MethodGet, friendsPath(code), nil, &friends)
+	return friends, err
+}
+
+// Link makes the two players friends and returns the other player.
+func (c *Client) Link(code, theirs string) (Friend, error) {
+	var friend Friend
+	err := c.do(http.MethodPost, friendsPath(code), map[string]string{"code": theirs}, &friend)
+	return friend, err
+}
+
+func (c *Client) Unlink(code, theirs string) error {
+	return c.do(http.MethodDelete, friendsPath(code)+"/"+url.PathEscape(theirs), nil, nil)
+}
+
+func (c *Client) SendGift(code, to, item string, quantity int) error {
+	gift := map[string]any{"to": to, "item": item, "quantity": quantity}
+	return c.do(http.MethodPost, "/players/"+url.PathEscape(code)+"/gifts", gift, nil)
+}
+
+// ClaimGifts returns the gifts waiting for the player. The server forgets
+// them once they are claimed.
+func (c *Client) ClaimGifts(code string) ([]Gift, error) {
+	var gifts []Gift
+	err := c.do(http.MethodPost, "/players/"+url.PathEscape(code)+"/gifts/claim", nil, &gifts)
+	return gifts, err
+}
@@ -0,0 +1,98 @@
+package community
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Ranked play goes through these endpoints:
+//
+//	POST   /queue                join the queue, returns a ticket
+//	GET    /queue/{ticket}       poll for a match
+//	DELETE /queue/{ticket}       leave the queue
+//	POST   /matches/{id}/result  report how a match went
+//	GET    /ladder               the standings
+
+// PollEvery is how often a queued player asks the server for a match.
+const PollEvery = 2 * time.Second
+
+// Roles in a match. The host listens for the guest to join.
+const (
+	RoleHost  = "host"
+	RoleGuest = "guest"
+)
+
+// Results a player reports.
+const (
+	Win  = "win"
+	Loss = "loss"
+	Draw = "draw"
+)
+
+// Entry is what a player queues with. Port is where they will listen if
+// they end up hosting.
+type Entry struct {
+	Trainer   string `json:"trainer"`
+	TrainerID int    `json:"trainer_id"`
+	Rating    int    `json:"rating"`
+	Pokemon   string `json:"pokemon"`
+	Port      int    `json:"port"`
+}
+
+// Match pairs the player with an opponent. Opponent is the host's address
+// for the guest to join, and the guest's name for the host.
+type Match struct {
+	ID       string `json:"id"`
+	Role     string `json:"role"`
+	Opponent string `json:"opponent"`
+}
+
+type Report struct {
+	TrainerID int    `json:"trainer_id"`
+	Result    string `json:"result"`
+	Turns     int    `json:"turns"`
+}
+
+type Standing struct {
+	Trainer   string `json:"trainer"`
+	TrainerID int    `json:"trainer_id"`
+	Rating    int    `json:"rating"`
+	Wins      int    `json:"wins"`
+	Losses    int    `json:"losses"`
+	Draws     int    `json:"draws"`
+}
+
+// Queue joins the queue for a ranked duel and returns the ticket to poll
+// with.
+func (c *Client) Queue(e Entry) (string, error) {
+	var ticket struct {
+		Ticket string `json:"ticket"`
+	}
+	err := c.do(http.MethodPost, "/queue", e, &ticket)
+	return ticket.Ticket, err
+}
+
+// Poll asks whether the ticket has been matched yet. It returns nil while
+// the player is still waiting.
+func (c *Client) Poll(ticket string) (*Match, error) {
+	var status struct {
+		Match *Match `json:"match"`
+	}
+	err := c.do(http.MethodGet, "/queue/"+url.PathEscape(ticket), nil, &status)
+	return status.Match, err
+}
+
+func (c *Client) Leave(ticket string) error {
+	return c.do(http.MethodDelete, "/queue/"+url.PathEscape(ticket), nil, nil)
+}
+
+func (c *Client) Report(match string, r Report) error {
+	return c.do(http.MethodPost, "/matches/"+url.PathEscape(match)+"/result", r, nil)
+}
+
+func (c *Client) Ladder() ([]Standing, error) {
+	var standings []Standing
+	err := c.do(http.MethodGet, "/ladder", nil, &standings)
+	return standings, err
+}
@@ -0,0 +1,52 @@
+package community
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// A player's role on the server decides what they may do there:
+//
+//	GET /players/{code}  the player, with their role
+
+// Roles a player can have on a server. Servers that don't hand out roles
+// make everyone a trainer.
+const (
+	Admin     = "admin"
+	Trainer   = "trainer"
+	Spectator = "spectator"
+)
+
+// Permissions that roles grant.
+const (
+	Trade  = "trade"
+	Battle = "battle"
+	// Administer is for running the server's admin commands.
+	Administer = "administer"
+)
+
+// Allows reports whether a role grants a permission. Roles the client
+// doesn't know grant nothing, like spectators.
+func Allows(role, permission string) bool {
+	switch role {
+	case Admin:
+		return true
+	case Trainer:
+		return permission != Administer
+	}
+	return false
+}
+
+// Role asks the server for the role of the player with the friend code.
+func (c *Client) Role(code string) (string, error) {
+	var player struct {
+		Role string `json:"role"`
+	}
+	if err := c.do(http.MethodGet, "/players/"+url.PathEscape(code), nil, &player); err != nil {
+		return "", err
+	}
+	if player.Role == "" {
+		return Trainer, nil
+	}
+	return player.Role, nil
+}
@@ -0,0 +1,117 @@
+// Package compare lines two Pokémon up side by side, row by row, and says
+// which of them is ahead in each row.
+package compare
+
+import (
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// Side is which of the two Pokémon a row favours.
+type Side int
+
+const (
+	Neither Side = iota
+	Left
+	Right
+)
+
+// Row is one thing the Pokémon are compared on, as text for each of them.
+type Row struct {
+	Label       string
+	Left, Right string
+	Better      Side
+}
+
+// Rows compares left and right on their types, size, base experience and
+// each base stat and the total of them. Higher stats and base experience
+// are better; types and size favour neither. What PokeAPI doesn't have
+// shows as missing.NoData and favours neither, and so does the total of a
+// Pokémon missing a stat the other has.
+func Rows(left, right pokeapi.Pokemon) []Row {
+	rows := []Row{
+		{"types", typeList(left), typeList(right), Neither},
+		{"height", missing.Tenths(left.Height, "m"), missing.Tenths(right.Height, "m"), Neither},
+		{"weight", missing.Tenths(left.Weight, "kg"), missing.Tenths(right.Weight, "kg"), Neither},
+		number("base experience", left.BaseExperience, right.BaseExperience),
+	}
+	var leftTotal, rightTotal int
+	leftComplete, rightComplete := true, true
+	for _, stat := range statNames(left, right) {
+		l, r := baseStat(left, stat), baseStat(right, stat)
+		leftTotal, rightTotal = leftTotal+l, rightTotal+r
+		leftComplete, rightComplete = leftComplete && l > 0, rightComplete && r > 0
+		rows = append(rows, number(stat, l, r))
+	}
+	if !leftComplete {
+		leftTotal = 0
+	}
+	if !rightComplete {
+		rightTotal = 0
+	}
+	return append(rows, number("total", leftTotal, rightTotal))
+}
+
+// Wins counts the rows each side is better in.
+func Wins(rows []Row) (left, right int) {
+	for _, r := range rows {
+		switch r.Better {
+		case Left:
+			left++
+		case Right:
+			right++
+		}
+	}
+	return left, right
+}
+
+// number compares numbers that are better higher, and missing if 0.
+func number(label string, l, r int) Row {
+	row := Row{Label: label, Left: missing.Number(l), Right: missing.Number(r)}
+	switch {
+	case l <= 0 || r <= 0:
+	case l > r:
+		row.Better = Left
+	case r > l:
+		row.Better = Right
+	}
+	return row
+}
+
+// statNames are the stats either Pokémon has, in the order PokeAPI gives
+// them.
+func statNames(pokemon ...pokeapi.Pokemon) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, p := range pokemon {
+		for _, s := range p.Stats {
+			if !seen[s.Stat.Name] {
+				seen[s.Stat.Name] = true
+				names = append(names, s.Stat.Name)
+			}
+		}
+	}
+	return names
+}
+
+func baseStat(p pokeapi.Pokemon, name string) int {
+	for _, s := range p.Stats {
+		if s.Stat.Name == name {
+			return s.BaseStat
+		}
+	}
+	return 0
+}
+
+func typeList(p pokeapi.Pokemon) string {
+	var names []string
+	for _, t := range p.Types {
+		names = append(names, t.Type.Name)
+	}
+	if len(names) == 0 {
+		return missing.NoData
+	}
+	return strings.Join(names, "/")
+}
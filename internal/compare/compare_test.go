@@ -0,0 +1,67 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+func species(name string, xp int, types []string, stats map[string]int) pokeapi.Pokemon {
+	p := pokeapi.Pokemon{Name: name, BaseExperience: xp, Height: 4, Weight: 60}
+	for _, t := range types {
+		p.Types = append(p.Types, pokeapi.Type{Type: pokeapi.NamedResource{Name: t}})
+	}
+	for _, s := range []string{"hp", "attack", "speed"} {
+		if v, ok := stats[s]; ok {
+			p.Stats = append(p.Stats, pokeapi.Stat{BaseStat: v, Stat: pokeapi.NamedResource{Name: s}})
+		}
+	}
+	return p
+}
+
+func TestRows(t *testing.T) {
+	pikachu := species("pikachu", 112, []string{"electric"}, map[string]int{"hp": 35, "attack": 55, "speed": 90})
+	dragonite := species("dragonite", 270, []string{"dragon", "flying"}, map[string]int{"hp": 91, "attack": 134, "speed": 80})
+	rows := Rows(pikachu, dragonite)
+	want := map[string]Row{
+		"types":           {"types", "electric", "dragon/flying", Neither},
+		"height":          {"height", "0.4 m", "0.4 m", Neither},
+		"base experience": {"base experience", "112", "270", Right},
+		"speed":           {"speed", "90", "80", Left},
+		"total":           {"total", "180", "305", Right},
+	}
+	for _, r := range rows {
+		if w, ok := want[r.Label]; ok && r != w {
+			t.Errorf("got %+v, want %+v", r, w)
+		}
+	}
+	if l, r := Wins(rows); l != 1 || r != 4 {
+		t.Errorf("expected pikachu ahead in 1 row and dragonite in 4, got %d and %d", l, r)
+	}
+}
+
+func TestMissingStat(t *testing.T) {
+	rows := Rows(species("a", 1, nil, map[string]int{"hp": 10}), species("b", 1, nil, map[string]int{"speed": 5}))
+	var labels []string
+	for _, r := range rows {
+		labels = append(labels, r.Label)
+	}
+	if len(rows) != 7 || labels[4] != "hp" || labels[5] != "speed" {
+		t.Errorf("expected a row for each stat either has, got %v", labels)
+	}
+}
+
+func TestMissingData(t *testing.T) {
+	rows := Rows(species("a", 0, nil, map[string]int{"hp": 10}), species("b", 50, []string{"normal"}, map[string]int{"hp": 20, "speed": 5}))
+	want := map[string]Row{
+		"types":           {"types", "no data", "normal", Neither},
+		"base experience": {"base experience", "no data", "50", Neither},
+		"speed":           {"speed", "no data", "5", Neither},
+		"total":           {"total", "no data", "25", Neither},
+	}
+	for _, r := range rows {
+		if w, ok := want[r.Label]; ok && r != w {
+			t.Errorf("got %+v, want %+v", r, w)
+		}
+	}
+}
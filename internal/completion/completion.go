@@ -0,0 +1,154 @@
+// Package completion writes shell completion scripts for running single
+// commands from the shell, like `pokedexcli catch pikachu`.
+package completion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Shells are the shells there are scripts for.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// Command describes one command to complete.
+type Command struct {
+	Name        string
+	Description string
+	// Flags are the command's flags, without the leading dashes.
+	Flags []string
+	// Names is the kind of name the command's first argument is, if any.
+	// Scripts complete it by running `<program> completion names <kind>`,
+	// so the candidates are whatever the player has at the time.
+	Names string
+}
+
+// Script returns the completion script for shell.
+func Script(shell, program string, commands []Command) (string, error) {
+	commands = append([]Command{{
+		Name:        "completion",
+		Description: "Print a shell completion script",
+		Names:       "shells",
+	}}, commands...)
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	switch shell {
+	case "bash":
+		return bash(program, commands), nil
+	case "zsh":
+		return zsh(program, commands), nil
+	case "fish":
+		return fish(program, commands), nil
+	}
+	return "", fmt.Errorf("no completion for %s; try %s", shell, strings.Join(Shells, ", "))
+}
+
+// identifier makes program usable in a shell function name.
+func identifier(program string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, program)
+}
+
+// quote single-quotes s for any of the shells.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func names(program, kind string) string {
+	if kind == "shells" {
+		return strings.Join(Shells, " ")
+	}
+	return fmt.Sprintf(`$(%s completion names %s 2>/dev/null)`, program, kind)
+}
+
+func bash(program string, commands []Command) string {
+	var b strings.Builder
+	fn := "_" + identifier(program)
+	var all []string
+	for _, c := range commands {
+		all = append(all, c.Name)
+	}
+	fmt.Fprintf(&b, "# bash completion for %s\n", program)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("    local cur=${COMP_WORDS[COMP_CWORD]}\n")
+	b.WriteString("    if [ \"$COMP_CWORD\" -eq 1 ]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W %s -- \"$cur\"))\n", quote(strings.Join(all, " ")))
+	b.WriteString("        return\n    fi\n")
+	b.WriteString("    case \"$cur\" in\n    --*)\n        case \"${COMP_WORDS[1]}\" in\n")
+	for _, c := range commands {
+		if len(c.Flags) == 0 {
+			continue
+		}
+		var flags []string
+		for _, f := range c.Flags {
+			flags = append(flags, "--"+f)
+		}
+		fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W %s -- \"$cur\")) ;;\n", c.Name, quote(strings.Join(flags, " ")))
+	}
+	b.WriteString("        esac\n        return ;;\n    esac\n")
+	b.WriteString("    if [ \"$COMP_CWORD\" -eq 2 ]; then\n        case \"${COMP_WORDS[1]}\" in\n")
+	for _, c := range commands {
+		if c.Names == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", c.Name, names(program, c.Names))
+	}
+	b.WriteString("        esac\n    fi\n}\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", fn, program)
+	return b.String()
+}
+
+func zsh(program string, commands []Command) string {
+	var b strings.Builder
+	fn := "_" + identifier(program)
+	fmt.Fprintf(&b, "#compdef %s\n\n", program)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("    local -a commands\n    commands=(\n")
+	for _, c := range commands {
+		fmt.Fprintf(&b, "        %s\n", quote(c.Name+":"+strings.ReplaceAll(c.Description, ":", `\:`)))
+	}
+	b.WriteString("    )\n")
+	b.WriteString("    if (( CURRENT == 2 )); then\n        _describe command commands\n        return\n    fi\n")
+	b.WriteString("    shift words\n    (( CURRENT-- ))\n    case $words[1] in\n")
+	for _, c := range commands {
+		if len(c.Flags) == 0 && c.Names == "" {
+			continue
+		}
+		var specs []string
+		for _, f := range c.Flags {
+			specs = append(specs, quote("--"+f+"[]:value: "))
+		}
+		if c.Names != "" {
+			specs = append(specs, `"1:`+c.Names+`:(`+names(program, c.Names)+`)"`)
+		}
+		fmt.Fprintf(&b, "    %s) _arguments %s ;;\n", c.Name, strings.Join(specs, " "))
+	}
+	b.WriteString("    esac\n}\n\n")
+	fmt.Fprintf(&b, "compdef %s %s\n", fn, program)
+	return b.String()
+}
+
+func fish(program string, commands []Command) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s\n", program)
+	fmt.Fprintf(&b, "complete -c %s -f\n", program)
+	for _, c := range commands {
+		fmt.Fprintf(&b, "complete -c %s -n __fish_use_subcommand -a %s -d %s\n", program, c.Name, quote(c.Description))
+		seen := quote("__fish_seen_subcommand_from " + c.Name)
+		for _, f := range c.Flags {
+			fmt.Fprintf(&b, "complete -c %s -n %s -l %s -r\n", program, seen, f)
+		}
+		if c.Names != "" {
+			// Quoted, so fish runs it when completing rather than now.
+			candidates := quote("(" + program + " completion names " + c.Names + " 2>/dev/null)")
+			if c.Names == "shells" {
+				candidates = quote(strings.Join(Shells, " "))
+			}
+			fmt.Fprintf(&b, "complete -c %s -n %s -a %s\n", program, seen, candidates)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package completion
+
+import (
+	"strings"
+	"testing"
+)
+
+var commands = []Command{
+	{Name: "catch", Description: "Try to catch a Pokémon", Names: "species"},
+	{Name: "duel", Description: "Battle another player: over the network", Flags: []string{"port"}},
+	{Name: "help", Description: "Displays a help message"},
+}
+
+func TestScript(t *testing.T) {
+	cases := map[string][]string{
+		"bash": {
+			`compgen -W 'catch completion duel help'`,
+			`duel) COMPREPLY=($(compgen -W '--port' -- "$cur")) ;;`,
+			`catch) COMPREPLY=($(compgen -W "$(pokedexcli completion names species 2>/dev/null)" -- "$cur")) ;;`,
+			`completion) COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur")) ;;`,
+			`complete -F _pokedexcli pokedexcli`,
+		},
+		"zsh": {
+			`'duel:Battle another player\: over the network'`,
+			`duel) _arguments '--port[]:value: ' ;;`,
+			`catch) _arguments "1:species:($(pokedexcli completion names species 2>/dev/null))" ;;`,
+		},
+		"fish": {
+			`complete -c pokedexcli -n __fish_use_subcommand -a catch -d 'Try to catch a Pokémon'`,
+			`complete -c pokedexcli -n '__fish_seen_subcommand_from duel' -l port -r`,
+			`complete -c pokedexcli -n '__fish_seen_subcommand_from catch' -a '(pokedexcli completion names species 2>/dev/null)'`,
+		},
+	}
+	for shell, lines := range cases {
+		script, err := Script(shell, "pokedexcli", commands)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range lines {
+			if !strings.Contains(script, line) {
+				t.Errorf("%s script is missing %s\n%s", shell, line, script)
+			}
+		}
+	}
+	if _, err := Script("powershell", "pokedexcli", commands); err == nil {
+		t.Error("expected an unknown shell to be refused")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got := quote("Pokémon's moves"); got != `'Pokémon'\''s moves'` {
+		t.Errorf("unexpected quoting %s", got)
+	}
+}
@@ -0,0 +1,66 @@
+// Package danger rates how tough an area is for the player's party, from
+// the levels wild Pokémon are found at there.
+package danger
+
+import "fmt"
+
+type Rating int
+
+const (
+	// Unknown is the rating when the player has no Pokémon to compare.
+	Unknown Rating = iota
+	Below
+	Around
+	Above
+)
+
+func (r Rating) String() string {
+	switch r {
+	case Below:
+		return "below your party average"
+	case Around:
+		return "around your party average"
+	case Above:
+		return "⚠ above your party average"
+	}
+	return ""
+}
+
+// Average is the party's average level, or 0 for an empty party.
+func Average(party []int) float64 {
+	if len(party) == 0 {
+		return 0
+	}
+	total := 0
+	for _, level := range party {
+		total += level
+	}
+	return float64(total) / float64(len(party))
+}
+
+// Rate compares an area where wild Pokémon are levels min to max with the
+// levels of the party.
+func Rate(min, max int, party []int) Rating {
+	average := Average(party)
+	switch {
+	case len(party) == 0:
+		return Unknown
+	case float64(min) > average:
+		return Above
+	case float64(max) < average:
+		return Below
+	}
+	return Around
+}
+
+// Describe reads like "Lv. 18–22, ⚠ above your party average".
+func Describe(min, max int, party []int) string {
+	levels := fmt.Sprintf("Lv. %d–%d", min, max)
+	if min == max {
+		levels = fmt.Sprintf("Lv. %d", min)
+	}
+	if rating := Rate(min, max, party); rating != Unknown {
+		return levels + ", " + rating.String()
+	}
+	return levels
+}
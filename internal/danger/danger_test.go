@@ -0,0 +1,21 @@
+package danger
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		min, max int
+		party    []int
+		want     string
+	}{
+		{18, 22, []int{10, 14}, "Lv. 18–22, ⚠ above your party average"},
+		{18, 22, []int{15, 25}, "Lv. 18–22, around your party average"},
+		{2, 4, []int{30}, "Lv. 2–4, below your party average"},
+		{5, 5, nil, "Lv. 5"},
+	}
+	for _, test := range tests {
+		if got := Describe(test.min, test.max, test.party); got != test.want {
+			t.Errorf("Describe(%d, %d, %v) = %q, want %q", test.min, test.max, test.party, got, test.want)
+		}
+	}
+}
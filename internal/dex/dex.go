@@ -0,0 +1,65 @@
+// Package dex keeps the Pokédex: every species the player has seen, and
+// which of them they have caught.
+package dex
+
+import (
+	"sort"
+	"time"
+)
+
+type Entry struct {
+	Seen time.Time `json:"seen"`
+	// Caught is zero until the species is caught.
+	Caught time.Time `json:"caught,omitempty"`
+}
+
+// Dex maps species to when they were first seen and caught.
+type Dex map[string]Entry
+
+// See registers a species as seen and reports whether it is new.
+func (d Dex) See(species string, at time.Time) bool {
+	if _, ok := d[species]; ok {
+		return false
+	}
+	d[species] = Entry{Seen: at}
+	return true
+}
+
+// Catch registers a species as caught, and seen if it wasn't already. It
+// reports whether this is the first of the species caught.
+func (d Dex) Catch(species string, at time.Time) bool {
+	d.See(species, at)
+	entry := d[species]
+	if !entry.Caught.IsZero() {
+		return false
+	}
+	entry.Caught = at
+	d[species] = entry
+	return true
+}
+
+func (d Dex) Caught(species string) bool {
+	return !d[species].Caught.IsZero()
+}
+
+// Counts returns how many species have been seen, including those caught,
+// and how many have been caught.
+func (d Dex) Counts() (seen, caught int) {
+	for _, entry := range d {
+		seen++
+		if !entry.Caught.IsZero() {
+			caught++
+		}
+	}
+	return seen, caught
+}
+
+// Species lists every species seen in alphabetical order.
+func (d Dex) Species() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
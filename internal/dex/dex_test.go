@@ -0,0 +1,30 @@
+package dex
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSeenAndCaught(t *testing.T) {
+	d := make(Dex)
+	now := time.Now()
+	if !d.See("zubat", now) || d.See("zubat", now.Add(time.Hour)) {
+		t.Errorf("expected only the first sighting to be new")
+	}
+	if !d.Catch("pidgey", now) || d.Catch("pidgey", now) {
+		t.Errorf("expected only the first catch to be new")
+	}
+	if !d["zubat"].Seen.Equal(now) {
+		t.Errorf("expected the first sighting to be kept")
+	}
+	if d.Caught("zubat") || !d.Caught("pidgey") {
+		t.Errorf("expected pidgey caught and zubat only seen")
+	}
+	if seen, caught := d.Counts(); seen != 2 || caught != 1 {
+		t.Errorf("expected 2 seen and 1 caught, got %d and %d", seen, caught)
+	}
+	if got := d.Species(); !reflect.DeepEqual(got, []string{"pidgey", "zubat"}) {
+		t.Errorf("unexpected species %v", got)
+	}
+}
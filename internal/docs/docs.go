@@ -0,0 +1,32 @@
+// Package docs holds the reference pages shown by the docs command.
+package docs
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed pages/*.txt
+var pages embed.FS
+
+// Topics lists every available page name in alphabetical order.
+func Topics() []string {
+	entries, _ := fs.ReadDir(pages, "pages")
+	topics := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		topics = append(topics, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func Page(topic string) (string, error) {
+	data, err := pages.ReadFile("pages/" + topic + ".txt")
+	if err != nil {
+		return "", fmt.Errorf("no documentation for %q", topic)
+	}
+	return string(data), nil
+}
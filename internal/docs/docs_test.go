@@ -0,0 +1,22 @@
+package docs
+
+import "testing"
+
+func TestPages(t *testing.T) {
+	topics := Topics()
+	if len(topics) == 0 {
+		t.Fatalf("expected embedded pages")
+	}
+	for _, topic := range topics {
+		page, err := Page(topic)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", topic, err)
+		}
+		if page == "" {
+			t.Errorf("expected %s to have content", topic)
+		}
+	}
+	if _, err := Page("../docs"); err == nil {
+		t.Errorf("expected unknown topic to fail")
+	}
+}
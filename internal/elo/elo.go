@@ -0,0 +1,54 @@
+// Package elo keeps Elo ratings for battles between players.
+package elo
+
+import "math"
+
+// Initial is the rating of a player who has not played yet.
+const Initial = 1000
+
+// K is how much a single game can move a rating.
+const K = 32
+
+// Scores for Record.Add.
+const (
+	Loss = 0
+	Draw = 0.5
+	Win  = 1
+)
+
+// Expected is the score a player is expected to get against an opponent.
+func Expected(rating, opponent int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponent-rating)/400))
+}
+
+// Record is a player's rating and results.
+type Record struct {
+	Rating int `json:"rating"`
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"`
+}
+
+// Current returns the rating, starting at Initial.
+func (r Record) Current() int {
+	if r.Rating == 0 {
+		return Initial
+	}
+	return r.Rating
+}
+
+// Add records a game against an opponent rated opponent and returns how
+// much the rating changed.
+func (r *Record) Add(opponent int, score float64) int {
+	switch score {
+	case Win:
+		r.Wins++
+	case Loss:
+		r.Losses++
+	default:
+		r.Draws++
+	}
+	before := r.Current()
+	r.Rating = before + int(math.Round(K*(score-Expected(before, opponent))))
+	return r.Rating - before
+}
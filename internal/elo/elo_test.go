@@ -0,0 +1,26 @@
+package elo
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	var r Record
+	if change := r.Add(Initial, Win); change != K/2 || r.Rating != Initial+K/2 || r.Wins != 1 {
+		t.Errorf("expected an even win to gain %d, got %d (%+v)", K/2, change, r)
+	}
+	if change := r.Add(r.Rating, Draw); change != 0 || r.Draws != 1 {
+		t.Errorf("expected an even draw to change nothing, got %d (%+v)", change, r)
+	}
+	before := r.Rating
+	if change := r.Add(before+400, Loss); change >= 0 || change < -K/2 || r.Losses != 1 {
+		t.Errorf("expected a small loss against a stronger player, got %d", change)
+	}
+}
+
+func TestExpected(t *testing.T) {
+	if e := Expected(1000, 1000); e != 0.5 {
+		t.Errorf("expected 0.5 for equal ratings, got %v", e)
+	}
+	if e := Expected(1400, 1000); e < 0.9 {
+		t.Errorf("expected a 400 point favourite to score over 0.9, got %v", e)
+	}
+}
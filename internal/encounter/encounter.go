@@ -0,0 +1,114 @@
+// Package encounter walks a wild encounter through its phases: the Pokémon
+// appears, the player engages it, throws a ball, and it is caught or flees.
+package encounter
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+)
+
+type Phase int
+
+const (
+	Spawned Phase = iota
+	Engaged
+	Thrown
+	Caught
+	Fled
+)
+
+var phaseNames = []string{"spawned", "engaged", "thrown", "caught", "fled"}
+
+func (p Phase) String() string {
+	return phaseNames[p]
+}
+
+// next are the phases each phase can move on to.
+var next = map[Phase][]Phase{
+	Spawned: {Engaged, Fled},
+	Engaged: {Thrown, Fled},
+	Thrown:  {Caught, Fled},
+}
+
+// Encounter is one wild Pokémon met by the player.
+type Encounter struct {
+	Species string
+	// Rate is the species' capture rate.
+	Rate  int
+	Phase Phase
+	// Throw is the last throw at the Pokémon, and Chance the odds it
+	// catches it.
+	Throw  capture.Throw
+	Chance float64
+	// OnChange, if set, is called after every change of phase, so the UI
+	// can show it.
+	OnChange func(e *Encounter)
+}
+
+// New starts an encounter with a Pokémon that has just appeared.
+func New(species string, rate int) *Encounter {
+	return &Encounter{Species: species, Rate: rate}
+}
+
+// can returns an error unless the encounter can move on to phase to.
+func (e *Encounter) can(to Phase) error {
+	for _, allowed := range next[e.Phase] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s can't be %s once %s", e.Species, to, e.Phase)
+}
+
+func (e *Encounter) move(to Phase) error {
+	if err := e.can(to); err != nil {
+		return err
+	}
+	e.Phase = to
+	if e.OnChange != nil {
+		e.OnChange(e)
+	}
+	return nil
+}
+
+// Done reports whether the encounter is over.
+func (e *Encounter) Done() bool {
+	return e.Phase == Caught || e.Phase == Fled
+}
+
+// Engage starts the player's turn with the Pokémon.
+func (e *Encounter) Engage() error {
+	return e.move(Engaged)
+}
+
+// Odds is the chance a throw would catch the Pokémon, at full HP.
+func (e *Encounter) Odds(t capture.Throw) float64 {
+	return capture.Chance(e.Rate, 1, t)
+}
+
+// ThrowBall throws at the engaged Pokémon.
+func (e *Encounter) ThrowBall(t capture.Throw) error {
+	if err := e.can(Thrown); err != nil {
+		return err
+	}
+	e.Throw, e.Chance = t, e.Odds(t)
+	return e.move(Thrown)
+}
+
+// Resolve settles a throw with roll, a random number in [0, 1): below the
+// chance, the Pokémon is caught, otherwise it breaks free and flees.
+func (e *Encounter) Resolve(roll float64) error {
+	if e.Phase != Thrown {
+		return fmt.Errorf("no ball was thrown at %s", e.Species)
+	}
+	if roll < e.Chance {
+		return e.move(Caught)
+	}
+	return e.move(Fled)
+}
+
+// Flee ends the encounter before it is caught.
+func (e *Encounter) Flee() error {
+	return e.move(Fled)
+}
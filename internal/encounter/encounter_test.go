@@ -0,0 +1,64 @@
+package encounter
+
+import (
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+)
+
+func TestCatch(t *testing.T) {
+	e := New("pikachu", 190)
+	var seen []Phase
+	e.OnChange = func(e *Encounter) { seen = append(seen, e.Phase) }
+
+	if err := e.Engage(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := e.ThrowBall(capture.Throw{Ball: "master-ball"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Chance != 1 {
+		t.Errorf("expected a master ball to be sure, got %v", e.Chance)
+	}
+	if err := e.Resolve(0.99); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Phase{Engaged, Thrown, Caught}
+	if len(seen) != len(want) {
+		t.Fatalf("expected phases %v, got %v", want, seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("expected phases %v, got %v", want, seen)
+		}
+	}
+	if !e.Done() {
+		t.Errorf("expected a caught Pokémon to end the encounter")
+	}
+}
+
+func TestFled(t *testing.T) {
+	e := New("mewtwo", 3)
+	e.Engage()
+	e.ThrowBall(capture.Throw{Ball: "poke-ball"})
+	if err := e.Resolve(e.Chance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Phase != Fled {
+		t.Errorf("expected a roll at the chance to fail, got %s", e.Phase)
+	}
+}
+
+func TestInvalidMoves(t *testing.T) {
+	e := New("pikachu", 190)
+	if err := e.ThrowBall(capture.Throw{Ball: "poke-ball"}); err == nil {
+		t.Errorf("expected throwing before engaging to fail")
+	}
+	if err := e.Resolve(0); err == nil {
+		t.Errorf("expected resolving without a throw to fail")
+	}
+	e.Flee()
+	if err := e.Engage(); err == nil || e.Phase != Fled {
+		t.Errorf("expected a fled Pokémon to stay fled, got %s", e.Phase)
+	}
+}
@@ -0,0 +1,66 @@
+// Package events is a small synchronous publish/subscribe bus that lets
+// features react to what happens in commands without the commands knowing
+// about them.
+package events
+
+import "sync"
+
+type Kind string
+
+const (
+	MapViewed        Kind = "map_viewed"
+	AreaExplored     Kind = "area_explored"
+	PokemonCaught    Kind = "pokemon_caught"
+	PokemonEscaped   Kind = "pokemon_escaped"
+	PokemonSeen      Kind = "pokemon_seen"
+	PokemonInspected Kind = "pokemon_inspected"
+	BattleWon        Kind = "battle_won"
+	PokemonFainted   Kind = "pokemon_fainted"
+	PokemonEvolved   Kind = "pokemon_evolved"
+	PokemonLevelled  Kind = "pokemon_levelled"
+	TowerStreak      Kind = "tower_streak"
+	RankedWon        Kind = "ranked_won"
+	GiftReceived     Kind = "gift_received"
+	OfferAccepted    Kind = "offer_accepted"
+	EventStarted     Kind = "event_started"
+	BerryReady       Kind = "berry_ready"
+)
+
+// Event describes something that happened. Subject is the area or Pokémon
+// the event is about, when there is one. Other is anyone else involved,
+// such as the opponent in a battle. Count is a number the event is about,
+// like the length of a Battle Tower streak.
+type Event struct {
+	Kind    Kind
+	Subject string
+	Other   string
+	Count   int
+}
+
+type Handler func(Event)
+
+type Bus struct {
+	mu       sync.Mutex
+	handlers map[Kind][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[Kind][]Handler)}
+}
+
+func (b *Bus) Subscribe(kind Kind, fn Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[kind] = append(b.handlers[kind], fn)
+}
+
+// Publish calls every handler subscribed to the event's kind, in the order
+// they subscribed.
+func (b *Bus) Publish(e Event) {
+	b.mu.Lock()
+	handlers := append([]Handler(nil), b.handlers[e.Kind]...)
+	b.mu.Unlock()
+	for _, fn := range handlers {
+		fn(e)
+	}
+}
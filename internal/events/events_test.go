@@ -0,0 +1,17 @@
+package events
+
+import "testing"
+
+func TestPublish(t *testing.T) {
+	bus := NewBus()
+	var got []string
+	bus.Subscribe(PokemonCaught, func(e Event) { got = append(got, "first:"+e.Subject) })
+	bus.Subscribe(PokemonCaught, func(e Event) { got = append(got, "second:"+e.Subject) })
+	bus.Subscribe(MapViewed, func(e Event) { t.Errorf("unexpected %s event", e.Kind) })
+
+	bus.Publish(Event{Kind: PokemonCaught, Subject: "pidgey"})
+
+	if len(got) != 2 || got[0] != "first:pidgey" || got[1] != "second:pidgey" {
+		t.Errorf("unexpected handler calls %v", got)
+	}
+}
@@ -0,0 +1,126 @@
+// Package evolution parses PokeAPI evolution chains and works out which
+// evolutions a Pokémon is eligible for.
+package evolution
+
+import "fmt"
+
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Chain is the payload of /evolution-chain/{id}.
+type Chain struct {
+	ID    int  `json:"id"`
+	Chain Link `json:"chain"`
+}
+
+type Link struct {
+	Species          NamedResource `json:"species"`
+	EvolutionDetails []Detail      `json:"evolution_details"`
+	EvolvesTo        []Link        `json:"evolves_to"`
+}
+
+// Detail is one way of evolving into a Link's species. Optional
+// requirements are nil when the API returns null.
+type Detail struct {
+	Trigger      NamedResource  `json:"trigger"`
+	MinLevel     *int           `json:"min_level"`
+	MinHappiness *int           `json:"min_happiness"`
+	Item         *NamedResource `json:"item"`
+	HeldItem     *NamedResource `json:"held_item"`
+	TradeSpecies *NamedResource `json:"trade_species"`
+}
+
+const (
+	TriggerLevelUp = "level-up"
+	TriggerTrade   = "trade"
+	TriggerUseItem = "use-item"
+)
+
+type Evolution struct {
+	Species string
+	Detail  Detail
+}
+
+// Next returns every way species can evolve within the chain.
+func (c Chain) Next(species string) []Evolution {
+	link, ok := find(c.Chain, species)
+	if !ok {
+		return nil
+	}
+	var next []Evolution
+	for _, to := range link.EvolvesTo {
+		for _, detail := range to.EvolutionDetails {
+			next = append(next, Evolution{Species: to.Species.Name, Detail: detail})
+		}
+	}
+	return next
+}
+
+func find(link Link, species string) (Link, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, to := range link.EvolvesTo {
+		if found, ok := find(to, species); ok {
+			return found, true
+		}
+	}
+	return Link{}, false
+}
+
+// TradeEvolution returns the species a Pokémon evolves into when it is traded
+// while holding heldItem (empty for none) in exchange for partner.
+func TradeEvolution(c Chain, species, heldItem, partner string) (Evolution, bool) {
+	for _, evo := range c.Next(species) {
+		d := evo.Detail
+		if d.Trigger.Name != TriggerTrade {
+			continue
+		}
+		if d.HeldItem != nil && d.HeldItem.Name != heldItem {
+			continue
+		}
+		if d.TradeSpecies != nil && d.TradeSpecies.Name != partner {
+			continue
+		}
+		return evo, true
+	}
+	return Evolution{}, false
+}
+
+// Status is what a Pokémon and its trainer bring to an evolution.
+type Status struct {
+	Level      int
+	Friendship int
+	// Items is the trainer's bag, by item name.
+	Items map[string]int
+}
+
+// Missing lists what a Pokémon still needs for an evolution, like "level
+// 16" or "a thunder-stone". It is empty when the Pokémon can evolve now.
+// Trades can't be met this way, nor can triggers this package doesn't know.
+func Missing(d Detail, s Status) []string {
+	var missing []string
+	switch d.Trigger.Name {
+	case TriggerLevelUp:
+		if d.MinLevel != nil && s.Level < *d.MinLevel {
+			missing = append(missing, fmt.Sprintf("level %d", *d.MinLevel))
+		}
+		if d.MinHappiness != nil && s.Friendship < *d.MinHappiness {
+			missing = append(missing, fmt.Sprintf("friendship %d", *d.MinHappiness))
+		}
+		if d.HeldItem != nil {
+			missing = append(missing, "to hold a "+d.HeldItem.Name)
+		}
+	case TriggerUseItem:
+		if d.Item != nil && s.Items[d.Item.Name] == 0 {
+			missing = append(missing, "a "+d.Item.Name)
+		}
+	case TriggerTrade:
+		missing = append(missing, "to be traded")
+	default:
+		missing = append(missing, "a "+d.Trigger.Name+" evolution")
+	}
+	return missing
+}
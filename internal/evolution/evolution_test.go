@@ -0,0 +1,107 @@
+package evolution
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// onixChain is a trimmed /evolution-chain/36 response.
+const onixChain = `{
+	"id": 36,
+	"chain": {
+		"species": {"name": "onix"},
+		"evolution_details": [],
+		"evolves_to": [{
+			"species": {"name": "steelix"},
+			"evolution_details": [{
+				"trigger": {"name": "trade"},
+				"held_item": {"name": "metal-coat"},
+				"min_level": null,
+				"trade_species": null
+			}],
+			"evolves_to": []
+		}]
+	}
+}`
+
+// abraChain is a trimmed /evolution-chain/26 response.
+const abraChain = `{
+	"id": 26,
+	"chain": {
+		"species": {"name": "abra"},
+		"evolves_to": [{
+			"species": {"name": "kadabra"},
+			"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 16}],
+			"evolves_to": [{
+				"species": {"name": "alakazam"},
+				"evolution_details": [{"trigger": {"name": "trade"}}],
+				"evolves_to": []
+			}]
+		}]
+	}
+}`
+
+func parse(t *testing.T, data string) Chain {
+	t.Helper()
+	var c Chain
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestNext(t *testing.T) {
+	c := parse(t, abraChain)
+	next := c.Next("abra")
+	if len(next) != 1 || next[0].Species != "kadabra" {
+		t.Fatalf("expected abra to evolve into kadabra, got %+v", next)
+	}
+	if next[0].Detail.MinLevel == nil || *next[0].Detail.MinLevel != 16 {
+		t.Errorf("expected min level 16")
+	}
+	if got := c.Next("alakazam"); len(got) != 0 {
+		t.Errorf("expected no evolutions for alakazam, got %+v", got)
+	}
+}
+
+func TestTradeEvolution(t *testing.T) {
+	cases := []struct {
+		chain    string
+		species  string
+		heldItem string
+		want     string
+	}{
+		{onixChain, "onix", "metal-coat", "steelix"},
+		{onixChain, "onix", "", ""},
+		{abraChain, "kadabra", "", "alakazam"},
+		{abraChain, "abra", "", ""},
+	}
+	for _, c := range cases {
+		evo, ok := TradeEvolution(parse(t, c.chain), c.species, c.heldItem, "")
+		if ok != (c.want != "") || evo.Species != c.want {
+			t.Errorf("%s holding %q: expected %q, got %q", c.species, c.heldItem, c.want, evo.Species)
+		}
+	}
+}
+
+func TestMissing(t *testing.T) {
+	level16 := parse(t, abraChain).Next("abra")[0].Detail
+	stone := Detail{Trigger: NamedResource{Name: TriggerUseItem}, Item: &NamedResource{Name: "thunder-stone"}}
+	cases := []struct {
+		detail Detail
+		status Status
+		want   string
+	}{
+		{level16, Status{Level: 15}, "level 16"},
+		{level16, Status{Level: 16}, ""},
+		{stone, Status{}, "a thunder-stone"},
+		{stone, Status{Items: map[string]int{"thunder-stone": 1}}, ""},
+		{Detail{Trigger: NamedResource{Name: TriggerTrade}}, Status{Level: 100}, "to be traded"},
+	}
+	for _, c := range cases {
+		if got := strings.Join(Missing(c.detail, c.status), ", "); got != c.want {
+			t.Errorf("%+v: expected %q, got %q", c.status, c.want, got)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+// Package fakemon loads packs of homebrew species, made by players rather
+// than Nintendo, and serves them as PokeAPI would, so catching, battling
+// and evolving them works like any other Pokémon. Their names all start
+// with validate.Namespace, like custom/voltorbeast, so they can't clash
+// with PokeAPI's.
+//
+// A pack is a JSON file:
+//
+//	{
+//	  "name": "sparks",
+//	  "author": "Ash",
+//	  "species": [
+//	    {
+//	      "name": "voltorbeast",
+//	      "types": ["electric", "steel"],
+//	      "stats": {"hp": 70, "attack": 85, "defense": 90,
+//	                "special-attack": 95, "special-defense": 80, "speed": 110},
+//	      "base_experience": 170, "height": 15, "weight": 420,
+//	      "capture_rate": 60,
+//	      "genus": "Bomb Beast Pokémon",
+//	      "description": "It rolls downhill at full speed, crackling.",
+//	      "moves": [{"name": "tackle", "level": 1}, {"name": "spark", "level": 12}],
+//	      "areas": ["viridian-forest-area"], "min_level": 20, "max_level": 25,
+//	      "evolves_to": [{"species": "megavolt", "min_level": 40}],
+//	      "art": ["  .--.  ", " ( ** ) ", "  '--'  "]
+//	    }
+//	  ]
+//	}
+//
+// Only name and types are needed; what is left out is treated as missing
+// from PokeAPI. Species are met in the areas listed, or in every area if
+// none are, chance percent of the time, 10 if not given.
+package fakemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/evolution"
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+	"github.com/eymardfreire/pokedexcli/internal/validate"
+)
+
+// DefaultChance is the percent chance of meeting a species whose pack
+// doesn't say.
+const DefaultChance = 10
+
+// Version is the version of the games homebrew species are met in, as
+// encounters list it.
+const Version = "custom"
+
+// Pack is a bundle of homebrew species.
+type Pack struct {
+	Name    string    `json:"name"`
+	Author  string    `json:"author,omitempty"`
+	Species []Species `json:"species"`
+}
+
+// Species is a homebrew species. Its name is without the namespace.
+type Species struct {
+	Name           string         `json:"name"`
+	Types          []string       `json:"types"`
+	Stats          map[string]int `json:"stats,omitempty"`
+	BaseExperience int            `json:"base_experience,omitempty"`
+	// Height and Weight are in tenths of a metre and kilogram, as PokeAPI
+	// has them.
+	Height      int         `json:"height,omitempty"`
+	Weight      int         `json:"weight,omitempty"`
+	CaptureRate int         `json:"capture_rate,omitempty"`
+	Genus       string      `json:"genus,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Moves       []Move      `json:"moves,omitempty"`
+	EvolvesTo   []Evolution `json:"evolves_to,omitempty"`
+	Areas       []string    `json:"areas,omitempty"`
+	MinLevel    int         `json:"min_level,omitempty"`
+	MaxLevel    int         `json:"max_level,omitempty"`
+	Chance      int         `json:"chance,omitempty"`
+	// Art is drawn in place of a sprite, a line at a time.
+	Art []string `json:"art,omitempty"`
+}
+
+// Move is a move a species learns by levelling up.
+type Move struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+// Evolution is a species from the same pack a species evolves into, on
+// reaching MinLevel or when given Item.
+type Evolution struct {
+	Species  string `json:"species"`
+	MinLevel int    `json:"min_level,omitempty"`
+	Item     string `json:"item,omitempty"`
+}
+
+// Load reads and checks the pack at path.
+func Load(path string) (*Pack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Pack
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := p.check(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// LoadDir reads every pack in dir, in order of file name. A missing dir
+// has none.
+func LoadDir(dir string) ([]*Pack, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var packs []*Pack
+	for _, file := range files {
+		p, err := Load(file)
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, p)
+	}
+	return packs, nil
+}
+
+func (p *Pack) check() error {
+	if _, err := bareName(p.Name); err != nil {
+		return fmt.Errorf("the pack's name: %w", err)
+	}
+	if len(p.Species) == 0 {
+		return fmt.Errorf("the pack has no species")
+	}
+	known := types.Names(types.Latest)
+	byName := make(map[string]*Species)
+	for i := range p.Species {
+		s := &p.Species[i]
+		if _, err := bareName(s.Name); err != nil {
+			return err
+		}
+		if byName[s.Name] != nil {
+			return fmt.Errorf("%s is in the pack twice", s.Name)
+		}
+		byName[s.Name] = s
+		if len(s.Types) == 0 || len(s.Types) > 2 {
+			return fmt.Errorf("%s has %d types; Pokémon have one or two", s.Name, len(s.Types))
+		}
+		for _, t := range s.Types {
+			if !slices.Contains(known, t) {
+				return fmt.Errorf("%s's type %s isn't one; they are %s", s.Name, t, strings.Join(known, ", "))
+			}
+		}
+		for stat, value := range s.Stats {
+			if !slices.Contains(missing.StatNames, stat) {
+				return fmt.Errorf("%s's stat %s isn't one; they are %s", s.Name, stat, strings.Join(missing.StatNames, ", "))
+			}
+			if value < 1 || value > 255 {
+				return fmt.Errorf("%s's %s is %d; stats are from 1 to 255", s.Name, stat, value)
+			}
+		}
+		if s.CaptureRate < 0 || s.CaptureRate > 255 {
+			return fmt.Errorf("%s's capture rate is %d; it is from 1 to 255", s.Name, s.CaptureRate)
+		}
+		if s.MinLevel < 0 || s.MaxLevel > 100 || s.MinLevel > s.MaxLevel {
+			return fmt.Errorf("%s is met from level %d to %d; levels are from 1 to 100, lowest first", s.Name, s.MinLevel, s.MaxLevel)
+		}
+		if s.Chance < 0 || s.Chance > 100 {
+			return fmt.Errorf("%s's chance is %d%%; it is from 1 to 100", s.Name, s.Chance)
+		}
+		for _, m := range s.Moves {
+			if _, err := bareName(m.Name); err != nil {
+				return fmt.Errorf("%s's move: %w", s.Name, err)
+			}
+			if m.Level < 0 || m.Level > 100 {
+				return fmt.Errorf("%s learns %s at level %d; levels are from 1 to 100", s.Name, m.Name, m.Level)
+			}
+		}
+	}
+	// Evolutions stay within the pack, and each species evolves from one
+	// other at most, so the species make a family tree.
+	from := make(map[string]string)
+	for _, s := range p.Species {
+		for _, evo := range s.EvolvesTo {
+			if byName[evo.Species] == nil {
+				return fmt.Errorf("%s evolves into %s, which isn't in the pack", s.Name, evo.Species)
+			}
+			if other, ok := from[evo.Species]; ok && other != s.Name {
+				return fmt.Errorf("%s evolves from both %s and %s", evo.Species, other, s.Name)
+			}
+			from[evo.Species] = s.Name
+			if evo.MinLevel == 0 && evo.Item == "" {
+				return fmt.Errorf("%s evolves into %s with neither a level nor an item", s.Name, evo.Species)
+			}
+		}
+	}
+	for _, s := range p.Species {
+		seen := map[string]bool{s.Name: true}
+		for name := from[s.Name]; name != ""; name = from[name] {
+			if seen[name] {
+				return fmt.Errorf("%s evolves into itself", name)
+			}
+			seen[name] = true
+		}
+	}
+	return nil
+}
+
+func bareName(name string) (string, error) {
+	if strings.Contains(name, "/") {
+		return "", fmt.Errorf("%q isn't a name; leave out the %s", name, validate.Namespace)
+	}
+	tidy, err := validate.Name("species", name)
+	if err == nil && tidy != name {
+		err = fmt.Errorf("%q should be written %q", name, tidy)
+	}
+	return tidy, err
+}
+
+// Set is every species of some packs, served as PokeAPI resources. A nil
+// Set has none.
+type Set struct {
+	packs   []*Pack
+	species map[string]*Species
+	// roots are the species each evolves from first, by full name.
+	roots     map[string]string
+	resources map[string][]byte
+}
+
+// NewSet serves the species of packs, linking resources under baseURL.
+// The same species can't come in two packs.
+func NewSet(baseURL string, packs []*Pack) (*Set, error) {
+	s := &Set{
+		packs:     packs,
+		species:   make(map[string]*Species),
+		roots:     make(map[string]string),
+		resources: make(map[string][]byte),
+	}
+	in := make(map[string]string)
+	for _, p := range packs {
+		if err := p.check(); err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		for i := range p.Species {
+			sp := &p.Species[i]
+			name := validate.Namespace + sp.Name
+			if other, ok := in[name]; ok {
+				return nil, fmt.Errorf("%s is in both %s and %s", name, other, p.Name)
+			}
+			in[name] = p.Name
+			s.species[name] = sp
+		}
+		from := make(map[string]string)
+		for _, sp := range p.Species {
+			for _, evo := range sp.EvolvesTo {
+				from[evo.Species] = sp.Name
+			}
+		}
+		for _, sp := range p.Species {
+			root := sp.Name
+			for from[root] != "" {
+				root = from[root]
+			}
+			s.roots[validate.Namespace+sp.Name] = validate.Namespace + root
+		}
+	}
+	for name, sp := range s.species {
+		if err := s.add("pokemon/"+name, pokemon(name, sp)); err != nil {
+			return nil, err
+		}
+		if err := s.add("pokemon-species/"+name, s.speciesOf(baseURL, name, sp)); err != nil {
+			return nil, err
+		}
+		if root := s.roots[name]; root == name {
+			if err := s.add("evolution-chain/"+name, evolution.Chain{Chain: s.link(baseURL, name, nil)}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *Set) add(key string, v any) error {
+	data, err := json.Marshal(v)
+	s.resources[key] = data
+	return err
+}
+
+func pokemon(name string, sp *Species) pokeapi.Pokemon {
+	p := pokeapi.Pokemon{
+		Name:           name,
+		BaseExperience: sp.BaseExperience,
+		Height:         sp.Height,
+		Weight:         sp.Weight,
+	}
+	for _, stat := range missing.StatNames {
+		if value, ok := sp.Stats[stat]; ok {
+			p.Stats = append(p.Stats, pokeapi.Stat{BaseStat: value, Stat: pokeapi.NamedResource{Name: stat}})
+		}
+	}
+	for _, t := range sp.Types {
+		p.Types = append(p.Types, pokeapi.Type{Type: pokeapi.NamedResource{Name: t}})
+	}
+	for _, m := range sp.Moves {
+		p.Moves = append(p.Moves, moves.Learnable{
+			Move: moves.NamedResource{Name: m.Name},
+			VersionGroupDetails: []moves.LearnDetails{{
+				LevelLearnedAt:  m.Level,
+				MoveLearnMethod: moves.NamedResource{Name: moves.ByLevelUp},
+			}},
+		})
+	}
+	return p
+}
+
+func (s *Set) speciesOf(baseURL, name string, sp *Species) pokeapi.Species {
+	english := pokeapi.NamedResource{Name: "en"}
+	species := pokeapi.Species{
+		Name:        name,
+		CaptureRate: sp.CaptureRate,
+		EvolutionChain: pokeapi.NamedResource{
+			URL: fmt.Sprintf("%s/evolution-chain/%s/", baseURL, s.roots[name]),
+		},
+	}
+	if sp.Genus != "" {
+		species.Genera = []pokeapi.Genus{{Genus: sp.Genus, Language: english}}
+	}
+	if sp.Description != "" {
+		species.FlavorTextEntries = []pokeapi.FlavorText{{FlavorText: sp.Description, Language: english}}
+	}
+	return species
+}
+
+// link is the evolution chain from name on, reached by details.
+func (s *Set) link(baseURL, name string, details []evolution.Detail) evolution.Link {
+	l := evolution.Link{
+		Species:          evolution.NamedResource{Name: name, URL: fmt.Sprintf("%s/pokemon-species/%s/", baseURL, name)},
+		EvolutionDetails: details,
+		EvolvesTo:        []evolution.Link{},
+	}
+	for _, evo := range s.species[name].EvolvesTo {
+		d := evolution.Detail{Trigger: evolution.NamedResource{Name: evolution.TriggerLevelUp}}
+		if evo.MinLevel > 0 {
+			level := evo.MinLevel
+			d.MinLevel = &level
+		}
+		if evo.Item != "" {
+			d.Trigger.Name = evolution.TriggerUseItem
+			d.Item = &evolution.NamedResource{Name: evo.Item}
+		}
+		l.EvolvesTo = append(l.EvolvesTo, s.link(baseURL, validate.Namespace+evo.Species, []evolution.Detail{d}))
+	}
+	return l
+}
+
+// Get returns a resource by "<resource>/<name>", like
+// "pokemon/custom/voltorbeast", as PokeAPI would.
+func (s *Set) Get(key string) ([]byte, bool) {
+	if s == nil {
+		return nil, false
+	}
+	data, ok := s.resources[key]
+	return data, ok
+}
+
+// Packs are the packs in the set.
+func (s *Set) Packs() []*Pack {
+	if s == nil {
+		return nil
+	}
+	return s.packs
+}
+
+// Art is a species' art, as lines, if it has any.
+func (s *Set) Art(name string) ([]string, bool) {
+	if s == nil || s.species[name] == nil {
+		return nil, false
+	}
+	art := s.species[name].Art
+	return art, len(art) > 0
+}
+
+// Encounters are the species met in an area, as PokeAPI lists them, in
+// order of name.
+func (s *Set) Encounters(area string) []pokeapi.PokemonEncounter {
+	if s == nil {
+		return nil
+	}
+	var names []string
+	for name, sp := range s.species {
+		if len(sp.Areas) == 0 || slices.Contains(sp.Areas, area) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var encounters []pokeapi.PokemonEncounter
+	for _, name := range names {
+		sp := s.species[name]
+		chance := sp.Chance
+		if chance == 0 {
+			chance = DefaultChance
+		}
+		encounters = append(encounters, pokeapi.PokemonEncounter{
+			Pokemon: pokeapi.NamedResource{Name: name},
+			VersionDetails: []pokeapi.EncounterVersion{{
+				Version:   pokeapi.NamedResource{Name: Version},
+				MaxChance: chance,
+				EncounterDetails: []pokeapi.EncounterDetail{{
+					MinLevel: sp.MinLevel,
+					MaxLevel: sp.MaxLevel,
+					Chance:   chance,
+					Method:   pokeapi.NamedResource{Name: "walk"},
+				}},
+			}},
+		})
+	}
+	return encounters
+}
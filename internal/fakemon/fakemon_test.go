@@ -0,0 +1,135 @@
+package fakemon
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/evolution"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+func sparks() *Pack {
+	return &Pack{Name: "sparks", Species: []Species{
+		{
+			Name:      "voltpup",
+			Types:     []string{"electric"},
+			Stats:     map[string]int{"hp": 40, "speed": 90},
+			Moves:     []Move{{"tackle", 1}, {"spark", 12}},
+			Areas:     []string{"viridian-forest-area"},
+			MinLevel:  3,
+			MaxLevel:  6,
+			EvolvesTo: []Evolution{{Species: "voltorbeast", MinLevel: 30}},
+			Art:       []string{"(o)"},
+		},
+		{Name: "voltorbeast", Types: []string{"electric", "steel"}, Genus: "Bomb Beast Pokémon"},
+	}}
+}
+
+func TestSet(t *testing.T) {
+	s, err := NewSet("https://pokeapi.example", []*Pack{sparks()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p pokeapi.Pokemon
+	data, ok := s.Get("pokemon/custom/voltpup")
+	if !ok {
+		t.Fatal("expected custom/voltpup to be served")
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "custom/voltpup" || len(p.Stats) != 2 || p.Types[0].Type.Name != "electric" || len(p.Moves) != 2 {
+		t.Errorf("got %+v", p)
+	}
+
+	var species pokeapi.Species
+	data, _ = s.Get("pokemon-species/custom/voltorbeast")
+	json.Unmarshal(data, &species)
+	if species.Genus("en") != "Bomb Beast Pokémon" {
+		t.Errorf("expected voltorbeast's genus, got %q", species.Genus("en"))
+	}
+	if want := "https://pokeapi.example/evolution-chain/custom/voltpup/"; species.EvolutionChain.URL != want {
+		t.Errorf("expected voltorbeast's chain to start at voltpup, got %s", species.EvolutionChain.URL)
+	}
+	var chain evolution.Chain
+	data, ok = s.Get("evolution-chain/custom/voltpup")
+	if !ok {
+		t.Fatal("expected voltpup's evolution chain to be served")
+	}
+	json.Unmarshal(data, &chain)
+	next := chain.Next("custom/voltpup")
+	if len(next) != 1 || next[0].Species != "custom/voltorbeast" || *next[0].Detail.MinLevel != 30 {
+		t.Errorf("expected voltpup to evolve into voltorbeast at level 30, got %+v", next)
+	}
+
+	if art, ok := s.Art("custom/voltpup"); !ok || art[0] != "(o)" {
+		t.Errorf("expected voltpup's art, got %q", art)
+	}
+	if _, ok := s.Art("custom/voltorbeast"); ok {
+		t.Error("voltorbeast has no art")
+	}
+}
+
+func TestEncounters(t *testing.T) {
+	s, _ := NewSet("", []*Pack{sparks()})
+	got := s.Encounters("viridian-forest-area")
+	if len(got) != 2 || got[0].Pokemon.Name != "custom/voltorbeast" || got[1].Pokemon.Name != "custom/voltpup" {
+		t.Fatalf("expected both species in the forest, got %+v", got)
+	}
+	if d := got[1].VersionDetails[0].EncounterDetails[0]; d.MinLevel != 3 || d.MaxLevel != 6 || d.Chance != DefaultChance {
+		t.Errorf("got %+v", d)
+	}
+	if got := s.Encounters("pallet-town-area"); len(got) != 1 {
+		t.Errorf("expected only voltorbeast, met everywhere, in Pallet Town, got %+v", got)
+	}
+	var none *Set
+	if none.Encounters("pallet-town-area") != nil {
+		t.Error("expected no encounters from a nil set")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for problem, change := range map[string]func(p *Pack){
+		"no types":        func(p *Pack) { p.Species[1].Types = nil },
+		"unknown type":    func(p *Pack) { p.Species[1].Types = []string{"sound"} },
+		"unknown stat":    func(p *Pack) { p.Species[0].Stats["luck"] = 5 },
+		"stat too high":   func(p *Pack) { p.Species[0].Stats["hp"] = 300 },
+		"namespaced name": func(p *Pack) { p.Species[1].Name = "custom/voltorbeast" },
+		"capital letters": func(p *Pack) { p.Species[0].Name = "Voltpup" },
+		"duplicate":       func(p *Pack) { p.Species[1].Name = "voltpup" },
+		"evolves outside": func(p *Pack) { p.Species[0].EvolvesTo[0].Species = "pikachu" },
+		"evolves into itself": func(p *Pack) {
+			p.Species[1].EvolvesTo = []Evolution{{Species: "voltpup", MinLevel: 50}}
+		},
+		"levels backwards": func(p *Pack) { p.Species[0].MinLevel = 10 },
+	} {
+		p := sparks()
+		change(p)
+		if _, err := NewSet("", []*Pack{p}); err == nil {
+			t.Errorf("%s: expected an error", problem)
+		}
+	}
+	if _, err := NewSet("", []*Pack{sparks(), sparks()}); err == nil || !strings.Contains(err.Error(), "both") {
+		t.Errorf("expected species in two packs to clash, got %v", err)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	if packs, err := LoadDir(filepath.Join(dir, "missing")); err != nil || len(packs) != 0 {
+		t.Errorf("expected no packs, got %v, %v", packs, err)
+	}
+	data, _ := json.Marshal(sparks())
+	os.WriteFile(filepath.Join(dir, "sparks.json"), data, 0o644)
+	packs, err := LoadDir(dir)
+	if err != nil || len(packs) != 1 || packs[0].Name != "sparks" {
+		t.Errorf("expected the sparks pack, got %v, %v", packs, err)
+	}
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"name": "broken"}`), 0o644)
+	if _, err := LoadDir(dir); err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Errorf("expected the broken pack to be named, got %v", err)
+	}
+}
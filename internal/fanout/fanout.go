@@ -0,0 +1,94 @@
+// Package fanout asks for many things at once and waits for them only as
+// long as a budget allows, so that one slow answer holds up its own part
+// of a command's output instead of all of it.
+package fanout
+
+import (
+	"context"
+	"time"
+)
+
+// Result is the answer for one key. Late is set when the budget ran out
+// before the answer came, and then Value and Err are zero.
+type Result[T any] struct {
+	Key   string
+	Value T
+	Err   error
+	Late  bool
+}
+
+// Fetch calls fetch for every key, workers at a time, and returns the
+// results in the order of keys. It stops waiting once budget has passed,
+// or ctx is done, and returns the results still to come as Late; a budget
+// of 0 waits for every one. Fetches it stops waiting for are left to
+// finish, so what they fetch can still be cached for next time.
+//
+// arrived, if not nil, is called with each result in the order of keys as
+// soon as it and those before it are in, for output written as it comes.
+func Fetch[T any](ctx context.Context, keys []string, workers int, budget time.Duration, fetch func(key string) (T, error), arrived func(Result[T])) []Result[T] {
+	results := make([]Result[T], len(keys))
+	if len(keys) == 0 {
+		return results
+	}
+	type answer struct {
+		i      int
+		result Result[T]
+	}
+	// Buffered for every key, so fetches finishing late never block.
+	answers := make(chan answer, len(keys))
+	queue := make(chan int, len(keys))
+	for i := range keys {
+		queue <- i
+	}
+	close(queue)
+	for w := 0; w < max(1, min(workers, len(keys))); w++ {
+		go func() {
+			for i := range queue {
+				value, err := fetch(keys[i])
+				answers <- answer{i, Result[T]{Key: keys[i], Value: value, Err: err}}
+			}
+		}()
+	}
+
+	var deadline <-chan time.Time
+	if budget > 0 {
+		timer := time.NewTimer(budget)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	in := make([]bool, len(keys))
+	next := 0
+	for received := 0; received < len(keys); received++ {
+		select {
+		case a := <-answers:
+			results[a.i], in[a.i] = a.result, true
+		case <-deadline:
+			return late(results, in, keys, next, arrived)
+		case <-ctx.Done():
+			return late(results, in, keys, next, arrived)
+		}
+		for next < len(keys) && in[next] {
+			if arrived != nil {
+				arrived(results[next])
+			}
+			next++
+		}
+	}
+	return results
+}
+
+// late marks the results not in as Late, and passes on those from next
+// that haven't been yet.
+func late[T any](results []Result[T], in []bool, keys []string, next int, arrived func(Result[T])) []Result[T] {
+	for i := range results {
+		if !in[i] {
+			results[i] = Result[T]{Key: keys[i], Late: true}
+		}
+	}
+	if arrived != nil {
+		for _, r := range results[next:] {
+			arrived(r)
+		}
+	}
+	return results
+}
@@ -0,0 +1,64 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFetch(t *testing.T) {
+	keys := []string{"slow", "fast", "broken"}
+	delays := map[string]time.Duration{"slow": 20 * time.Millisecond}
+	var order []string
+	got := Fetch(context.Background(), keys, 3, 0, func(key string) (int, error) {
+		time.Sleep(delays[key])
+		if key == "broken" {
+			return 0, errors.New("broken")
+		}
+		return len(key), nil
+	}, func(r Result[int]) { order = append(order, r.Key) })
+
+	if got[0].Value != 4 || got[1].Value != 4 || got[2].Err == nil {
+		t.Errorf("unexpected results %+v", got)
+	}
+	if len(order) != 3 || order[0] != "slow" || order[2] != "broken" {
+		t.Errorf("expected results to arrive in the order of keys, got %v", order)
+	}
+}
+
+func TestFetchBudget(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var arrived []Result[string]
+	got := Fetch(context.Background(), []string{"quick", "stuck", "quick"}, 2, 20*time.Millisecond, func(key string) (string, error) {
+		if key == "stuck" {
+			<-release
+		}
+		return key, nil
+	}, func(r Result[string]) { arrived = append(arrived, r) })
+
+	if got[0].Late || got[0].Value != "quick" || got[2].Late {
+		t.Errorf("expected the quick answers to be in, got %+v", got)
+	}
+	if !got[1].Late || got[1].Key != "stuck" {
+		t.Errorf("expected the stuck answer to be late, got %+v", got[1])
+	}
+	if len(arrived) != 3 || !arrived[1].Late {
+		t.Errorf("expected every result to be passed on once the budget ran out, got %+v", arrived)
+	}
+}
+
+func TestFetchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	release := make(chan struct{})
+	defer close(release)
+	got := Fetch(ctx, []string{"a"}, 1, time.Hour, func(key string) (string, error) {
+		<-release
+		return key, nil
+	}, nil)
+	if !got[0].Late {
+		t.Errorf("expected a cancelled fetch to be late, got %+v", got[0])
+	}
+}
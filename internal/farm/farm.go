@@ -0,0 +1,92 @@
+// Package farm grows berries over wall-clock time.
+package farm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxPlots is how many berries can grow at once.
+const MaxPlots = 4
+
+type Berry struct {
+	Name        string
+	Growth      time.Duration
+	Yield       int
+	Description string
+}
+
+// Berries are the seeds that can be planted, keyed by item name.
+var Berries = map[string]Berry{
+	"oran-berry":  {Name: "oran-berry", Growth: time.Hour, Yield: 3, Description: "a common berry, quick to grow"},
+	"razz-berry":  {Name: "razz-berry", Growth: 2 * time.Hour, Yield: 2, Description: "makes the next catch easier"},
+	"nanab-berry": {Name: "nanab-berry", Growth: 4 * time.Hour, Yield: 2, Description: "calms wild Pokémon down"},
+	"pinap-berry": {Name: "pinap-berry", Growth: 8 * time.Hour, Yield: 2, Description: "a rare berry that grows slowly"},
+}
+
+type Plot struct {
+	Berry     string    `json:"berry"`
+	PlantedAt time.Time `json:"planted_at"`
+}
+
+// Ready reports whether the berry in the plot can be harvested at now.
+func (p Plot) Ready(now time.Time) bool {
+	return !now.Before(p.ReadyAt())
+}
+
+func (p Plot) ReadyAt() time.Time {
+	return p.PlantedAt.Add(Berries[p.Berry].Growth)
+}
+
+type Farm struct {
+	Plots []Plot `json:"plots"`
+}
+
+func BerryNames() []string {
+	names := make([]string, 0, len(Berries))
+	for name := range Berries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *Farm) Plant(berry string, now time.Time) error {
+	if _, ok := Berries[berry]; !ok {
+		return fmt.Errorf("%s cannot be planted", berry)
+	}
+	if len(f.Plots) >= MaxPlots {
+		return fmt.Errorf("all %d plots are in use", MaxPlots)
+	}
+	f.Plots = append(f.Plots, Plot{Berry: berry, PlantedAt: now})
+	return nil
+}
+
+// Ripened returns the plots whose berries became ready after since and by
+// now.
+func (f *Farm) Ripened(since, now time.Time) []Plot {
+	var ripe []Plot
+	for _, plot := range f.Plots {
+		if plot.ReadyAt().After(since) && plot.Ready(now) {
+			ripe = append(ripe, plot)
+		}
+	}
+	return ripe
+}
+
+// Harvest removes every ripe plot and returns how many of each berry it
+// produced.
+func (f *Farm) Harvest(now time.Time) map[string]int {
+	harvested := make(map[string]int)
+	growing := f.Plots[:0]
+	for _, plot := range f.Plots {
+		if plot.Ready(now) {
+			harvested[plot.Berry] += Berries[plot.Berry].Yield
+			continue
+		}
+		growing = append(growing, plot)
+	}
+	f.Plots = growing
+	return harvested
+}
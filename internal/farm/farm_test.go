@@ -0,0 +1,60 @@
+package farm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlantAndHarvest(t *testing.T) {
+	start := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	var f Farm
+	if err := f.Plant("oran-berry", start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := f.Plant("pinap-berry", start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := f.Plant("rock", start); err == nil {
+		t.Errorf("expected unknown berry to fail")
+	}
+
+	if got := f.Harvest(start.Add(30 * time.Minute)); len(got) != 0 {
+		t.Errorf("expected nothing ripe yet, got %v", got)
+	}
+
+	got := f.Harvest(start.Add(time.Hour))
+	if got["oran-berry"] != 3 || len(got) != 1 {
+		t.Errorf("expected 3 oran berries, got %v", got)
+	}
+	if len(f.Plots) != 1 || f.Plots[0].Berry != "pinap-berry" {
+		t.Errorf("expected pinap berry to keep growing, got %+v", f.Plots)
+	}
+}
+
+func TestRipened(t *testing.T) {
+	var f Farm
+	start := time.Now()
+	f.Plant("oran-berry", start)
+	f.Plant("razz-berry", start)
+	f.Plant("pinap-berry", start)
+
+	got := f.Ripened(start.Add(30*time.Minute), start.Add(3*time.Hour))
+	if len(got) != 2 || got[0].Berry != "oran-berry" || got[1].Berry != "razz-berry" {
+		t.Errorf("expected the oran and razz berries to ripen, got %+v", got)
+	}
+	if got := f.Ripened(start.Add(3*time.Hour), start.Add(4*time.Hour)); len(got) != 0 {
+		t.Errorf("expected berries already ripe to be left out, got %+v", got)
+	}
+}
+
+func TestPlotLimit(t *testing.T) {
+	var f Farm
+	for i := 0; i < MaxPlots; i++ {
+		if err := f.Plant("oran-berry", time.Now()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := f.Plant("oran-berry", time.Now()); err == nil {
+		t.Errorf("expected planting beyond %d plots to fail", MaxPlots)
+	}
+}
@@ -0,0 +1,475 @@
+// Package filter is the small expression language list commands filter
+// their results with, like
+//
+//	base_experience > 200 && types contains 'dragon'
+//
+// An expression compares fields with values using ==, !=, <, <=, >, >= and
+// contains, and combines comparisons with &&, || and !, grouped by
+// parentheses. A field on its own is true when it is set and not zero.
+//
+// Fields are named by their JSON keys, or paths of them like
+// "stats.base_stat", as render.Count names them: objects stand for their
+// name, the s of a plural key may be left out, and a comparison with a
+// list is true if it is true of any of its items.
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is a parsed expression.
+type Filter struct {
+	src  string
+	root node
+}
+
+// Parse parses an expression.
+func Parse(src string) (*Filter, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != end {
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return &Filter{src: src, root: root}, nil
+}
+
+func (f *Filter) String() string {
+	return f.src
+}
+
+// Match reports whether v, one of a list command's results, matches.
+func (f *Filter) Match(v any) (bool, error) {
+	item, err := decode(v)
+	if err != nil {
+		return false, err
+	}
+	return f.root.eval(item), nil
+}
+
+// Apply returns the items that match. It is an error for the expression to
+// name a field the items don't have, which is most likely a typo.
+func Apply[T any](f *Filter, items []T) ([]T, error) {
+	for _, field := range f.root.fields(nil) {
+		if !known(reflect.TypeFor[T](), strings.Split(field, ".")) {
+			return nil, fmt.Errorf("there is no %s to filter on", field)
+		}
+	}
+	var kept []T
+	for _, item := range items {
+		decoded, err := decode(item)
+		if err != nil {
+			return nil, err
+		}
+		if f.root.eval(decoded) {
+			kept = append(kept, item)
+		}
+	}
+	return kept, nil
+}
+
+// known reports whether values of type t can have a field at path, going
+// by their JSON keys. Maps and interfaces could have anything.
+func known(t reflect.Type, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return known(t.Elem(), path)
+	case reflect.Map, reflect.Interface:
+		return true
+	case reflect.Struct:
+		for _, field := range reflect.VisibleFields(t) {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			switch {
+			case !field.IsExported() || name == "-":
+				continue
+			case field.Anonymous && name == "":
+				// Its fields are among t's visible fields.
+				continue
+			case name == "":
+				name = field.Name
+			}
+			if name == path[0] || name == path[0]+"s" {
+				return known(field.Type, path[1:])
+			}
+		}
+	}
+	return false
+}
+
+// decode turns v into what JSON makes of it, so fields can be found by
+// their JSON keys.
+func decode(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	return decoded, json.Unmarshal(data, &decoded)
+}
+
+type node interface {
+	eval(item any) bool
+	// fields adds the fields the node looks at to into.
+	fields(into []string) []string
+}
+
+type and struct{ left, right node }
+type or struct{ left, right node }
+type not struct{ operand node }
+
+func (n and) eval(item any) bool { return n.left.eval(item) && n.right.eval(item) }
+func (n or) eval(item any) bool  { return n.left.eval(item) || n.right.eval(item) }
+func (n not) eval(item any) bool { return !n.operand.eval(item) }
+
+func (n and) fields(into []string) []string { return n.right.fields(n.left.fields(into)) }
+func (n or) fields(into []string) []string  { return n.right.fields(n.left.fields(into)) }
+func (n not) fields(into []string) []string { return n.operand.fields(into) }
+
+// comparison compares a field with a value, or with op empty, checks the
+// field is set.
+type comparison struct {
+	field string
+	op    string
+	value any
+}
+
+func (c comparison) fields(into []string) []string {
+	if slices.Contains(into, c.field) {
+		return into
+	}
+	return append(into, c.field)
+}
+
+func (c comparison) eval(item any) bool {
+	vals, isList := lookup(item, "", strings.Split(c.field, "."))
+	if len(vals) == 0 {
+		// Fields left out, like false ones with omitempty, are zero.
+		vals = []any{nil}
+	}
+	switch c.op {
+	case "":
+		return slices.ContainsFunc(vals, truthy)
+	case "!=":
+		return !slices.ContainsFunc(vals, func(v any) bool { return equal(v, c.value) })
+	case "contains":
+		if isList {
+			return slices.ContainsFunc(vals, func(v any) bool { return equal(v, c.value) })
+		}
+		return slices.ContainsFunc(vals, func(v any) bool {
+			return strings.Contains(strings.ToLower(text(v)), strings.ToLower(text(c.value)))
+		})
+	}
+	return slices.ContainsFunc(vals, func(v any) bool {
+		cmp, ok := compare(v, c.value)
+		switch c.op {
+		case "==":
+			return ok && cmp == 0
+		case "<":
+			return ok && cmp < 0
+		case "<=":
+			return ok && cmp <= 0
+		case ">":
+			return ok && cmp > 0
+		case ">=":
+			return ok && cmp >= 0
+		}
+		return false
+	})
+}
+
+func equal(a, b any) bool {
+	cmp, ok := compare(a, b)
+	return ok && cmp == 0
+}
+
+// compare compares a field's value with a value from the expression:
+// numbers as numbers, true and false only with each other, and anything
+// else as text, regardless of case. Dates compare as they should, being
+// written year first. Fields that aren't set compare as zero.
+func compare(v, value any) (int, bool) {
+	if v == nil {
+		v = zero(value)
+	}
+	switch value := value.(type) {
+	case float64:
+		n, ok := v.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case n < value:
+			return -1, true
+		case n > value:
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		b, ok := v.(bool)
+		if !ok || b != value {
+			return 1, ok
+		}
+		return 0, true
+	}
+	return strings.Compare(strings.ToLower(text(v)), strings.ToLower(text(value))), true
+}
+
+// zero is the zero value of value's type.
+func zero(value any) any {
+	switch value.(type) {
+	case float64:
+		return 0.0
+	case bool:
+		return false
+	}
+	return ""
+}
+
+func text(v any) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+// lookup finds the values at path in v, which was found under key, and
+// reports whether any of them came from a list.
+func lookup(v any, key string, path []string) (vals []any, isList bool) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			more, _ := lookup(item, key, path)
+			vals = append(vals, more...)
+		}
+		return vals, true
+	case map[string]any:
+		if len(path) == 0 {
+			if name, ok := v["name"]; ok {
+				return []any{name}, false
+			}
+			// An item of a list like types, as {"slot": 1, "type": {...}}.
+			if inner, ok := v[singular(key)]; ok {
+				return lookup(inner, "", nil)
+			}
+			return nil, false
+		}
+		for _, k := range []string{path[0], path[0] + "s"} {
+			if next, ok := v[k]; ok {
+				return lookup(next, k, path[1:])
+			}
+		}
+		return nil, false
+	}
+	if v == nil || len(path) > 0 {
+		return nil, false
+	}
+	return []any{v}, false
+}
+
+// singular is the singular of a plural key, like type for types.
+func singular(key string) string {
+	if base, ok := strings.CutSuffix(key, "ies"); ok {
+		return base + "y"
+	}
+	return strings.TrimSuffix(key, "s")
+}
+
+type kind int
+
+const (
+	end kind = iota
+	word
+	number
+	str
+	op
+)
+
+type token struct {
+	kind kind
+	text string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case end:
+		return "end of filter"
+	case str:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+	return t.text
+}
+
+// operators are the operators, longest first so that <= isn't read as <.
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")"}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'' || c == '"':
+			j := strings.IndexRune(src[i+1:], c)
+			if j < 0 {
+				return nil, fmt.Errorf("%c at %d is never closed", c, i+1)
+			}
+			tokens = append(tokens, token{str, src[i+1 : i+1+j]})
+			i += j + 2
+		case unicode.IsDigit(c) || c == '-' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1])):
+			j := i + 1
+			for j < len(src) && (unicode.IsDigit(rune(src[j])) || src[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{number, src[i:j]})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(src) && (unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j])) || strings.ContainsRune("_-.", rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, token{word, src[i:j]})
+			i = j
+		default:
+			at := slices.IndexFunc(operators, func(o string) bool { return strings.HasPrefix(src[i:], o) })
+			if at < 0 {
+				return nil, fmt.Errorf("unexpected %q at %d", c, i+1)
+			}
+			tokens = append(tokens, token{op, operators[at]})
+			i += len(operators[at])
+		}
+	}
+	return append(tokens, token{kind: end}), nil
+}
+
+type parser struct {
+	tokens []token
+	at     int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.at]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.at]
+	if t.kind != end {
+		p.at++
+	}
+	return t
+}
+
+func (p *parser) accept(text string) bool {
+	if t := p.peek(); (t.kind == op || t.kind == word) && t.text == text {
+		p.at++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	for err == nil && p.accept("||") {
+		var right node
+		if right, err = p.and(); err == nil {
+			left = or{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	for err == nil && p.accept("&&") {
+		var right node
+		if right, err = p.unary(); err == nil {
+			left = and{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) unary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.unary()
+		return not{operand}, err
+	}
+	if p.accept("(") {
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("expected ), got %s", p.peek())
+		}
+		return inner, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	field := p.next()
+	if field.kind != word {
+		return nil, fmt.Errorf("expected a field, got %s", field)
+	}
+	c := comparison{field: field.text}
+	t := p.peek()
+	switch {
+	case t.kind == op && slices.Contains([]string{"==", "!=", "<", "<=", ">", ">="}, t.text),
+		t.kind == word && t.text == "contains":
+		c.op = p.next().text
+	default:
+		return c, nil
+	}
+	value := p.next()
+	switch value.kind {
+	case number:
+		n, err := strconv.ParseFloat(value.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s isn't a number", value.text)
+		}
+		c.value = n
+	case str:
+		c.value = value.text
+	case word:
+		// Unquoted words are text, as in types contains dragon, except
+		// true and false.
+		switch value.text {
+		case "true", "false":
+			c.value = value.text == "true"
+		default:
+			c.value = value.text
+		}
+	default:
+		return nil, fmt.Errorf("expected a value after %s, got %s", c.op, value)
+	}
+	return c, nil
+}
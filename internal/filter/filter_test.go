@@ -0,0 +1,85 @@
+package filter
+
+import "testing"
+
+type pokemon struct {
+	Name           string `json:"name"`
+	BaseExperience int    `json:"base_experience"`
+	Shiny          bool   `json:"shiny,omitempty"`
+	MetAt          string `json:"met_at"`
+	Types          []struct {
+		Type struct {
+			Name string `json:"name"`
+		} `json:"type"`
+	} `json:"types"`
+}
+
+func newPokemon(name string, xp int, shiny bool, types ...string) pokemon {
+	p := pokemon{Name: name, BaseExperience: xp, Shiny: shiny, MetAt: "kanto-route-1-area"}
+	for _, t := range types {
+		p.Types = append(p.Types, struct {
+			Type struct {
+				Name string `json:"name"`
+			} `json:"type"`
+		}{Type: struct {
+			Name string `json:"name"`
+		}{t}})
+	}
+	return p
+}
+
+func TestMatch(t *testing.T) {
+	dragonite := newPokemon("dragonite", 270, false, "dragon", "flying")
+	pikachu := newPokemon("pikachu", 112, true, "electric")
+	tests := []struct {
+		expr string
+		want [2]bool
+	}{
+		{"base_experience > 200 && types contains 'dragon'", [2]bool{true, false}},
+		{"base_experience >= 112 && base_experience < 200", [2]bool{false, true}},
+		{"type == electric || name == 'DRAGONITE'", [2]bool{true, true}},
+		{"!(types contains dragon)", [2]bool{false, true}},
+		{"types != flying", [2]bool{false, true}},
+		{"name contains chu", [2]bool{false, true}},
+		{"shiny", [2]bool{false, true}},
+		{"shiny == false", [2]bool{true, false}},
+		{"met_at contains route-1", [2]bool{true, true}},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		for i, p := range []pokemon{dragonite, pikachu} {
+			if got, _ := f.Match(p); got != tt.want[i] {
+				t.Errorf("%q on %s = %v, want %v", tt.expr, p.Name, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "name ==", "(name", "name == 'pikachu", "> 3", "name == pikachu extra", "level ~ 3"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("expected %q not to parse", expr)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	all := []pokemon{newPokemon("dragonite", 270, false, "dragon"), newPokemon("pikachu", 112, true, "electric")}
+	f, _ := Parse("base_experience > 200")
+	kept, err := Apply(f, all)
+	if err != nil || len(kept) != 1 || kept[0].Name != "dragonite" {
+		t.Errorf("got %v, %v", kept, err)
+	}
+	f, _ = Parse("base_exp > 200")
+	if _, err := Apply(f, all); err == nil {
+		t.Error("expected a field the items don't have to be an error")
+	}
+	// Fields left out of every item's JSON are still fields.
+	f, _ = Parse("shiny || types.type == dragon")
+	if kept, err := Apply(f, all[:1]); err != nil || len(kept) != 1 {
+		t.Errorf("got %v, %v", kept, err)
+	}
+}
@@ -0,0 +1,48 @@
+// Package finds decides what a trainer picks up while exploring: now and
+// then a ball, a berry or a potion someone left lying in the grass.
+package finds
+
+import "math/rand"
+
+// Chance is the chance of finding something each time an area is
+// explored.
+const Chance = 0.2
+
+// Find is something that can be found, Count at a time. Weight is how
+// often it turns up compared to the other finds.
+type Find struct {
+	Item   string
+	Count  int
+	Weight int
+}
+
+// Table is everything that can be found.
+var Table = []Find{
+	{Item: "poke-ball", Count: 2, Weight: 30},
+	{Item: "great-ball", Count: 1, Weight: 10},
+	{Item: "razz-berry", Count: 1, Weight: 25},
+	{Item: "oran-berry", Count: 2, Weight: 20},
+	{Item: "potion", Count: 1, Weight: 15},
+}
+
+// Roll decides whether anything is found, with the given chance, and
+// what. It always draws from r the same number of times whatever it
+// finds, so one find doesn't change the next.
+func Roll(r *rand.Rand, chance float64) (Find, bool) {
+	found := r.Float64() < chance
+	total := 0
+	for _, f := range Table {
+		total += f.Weight
+	}
+	pick := r.Intn(total)
+	if !found {
+		return Find{}, false
+	}
+	for _, f := range Table {
+		if pick < f.Weight {
+			return f, true
+		}
+		pick -= f.Weight
+	}
+	return Find{}, false
+}
@@ -0,0 +1,36 @@
+package finds
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRoll(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	const n = 10000
+	for i := 0; i < n; i++ {
+		if f, ok := Roll(r, 0.5); ok {
+			counts[f.Item]++
+		}
+	}
+	found := 0
+	for _, c := range counts {
+		found += c
+	}
+	if found < n*45/100 || found > n*55/100 {
+		t.Errorf("expected about half the rolls to find something, got %d of %d", found, n)
+	}
+	if counts["poke-ball"] <= counts["great-ball"] {
+		t.Errorf("expected poke balls to turn up more than great balls, got %v", counts)
+	}
+}
+
+func TestRollNever(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if f, ok := Roll(r, 0); ok {
+			t.Fatalf("expected nothing with no chance, found %+v", f)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package friendship implements how attached a Pokémon is to its trainer.
+package friendship
+
+import "time"
+
+const (
+	Base = 70
+	Max  = 255
+
+	// EvolutionThreshold is the friendship needed for friendship-based
+	// evolutions such as golbat into crobat.
+	EvolutionThreshold = 220
+
+	Berry     = 10
+	BattleWon = 3
+	Fainted   = -5
+
+	// PerHour is gained for every full hour the Pokémon spends with the
+	// trainer.
+	PerHour = 1
+)
+
+// Adjust returns v changed by delta, kept within 0 and Max.
+func Adjust(v, delta int) int {
+	v += delta
+	if v < 0 {
+		return 0
+	}
+	if v > Max {
+		return Max
+	}
+	return v
+}
+
+// Elapsed returns the friendship gained over the full hours between since
+// and now, and the time from which the next hour should be counted.
+func Elapsed(since, now time.Time) (int, time.Time) {
+	hours := int(now.Sub(since) / time.Hour)
+	if hours <= 0 {
+		return 0, since
+	}
+	return hours * PerHour, since.Add(time.Duration(hours) * time.Hour)
+}
+
+func CanEvolve(v int) bool {
+	return v >= EvolutionThreshold
+}
+
+// Describe returns the flavour text shown for a friendship value.
+func Describe(v int) string {
+	switch {
+	case v >= Max:
+		return "It looks really happy! It must love you a lot."
+	case v >= EvolutionThreshold:
+		return "It seems to be very happy."
+	case v >= 150:
+		return "It's quite friendly toward you."
+	case v >= Base:
+		return "It's getting used to you."
+	case v > 0:
+		return "It's not very used to you yet."
+	default:
+		return "It doesn't seem to like you at all."
+	}
+}
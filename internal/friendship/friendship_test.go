@@ -0,0 +1,37 @@
+package friendship
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdjust(t *testing.T) {
+	cases := []struct {
+		v, delta, want int
+	}{
+		{Base, Berry, Base + Berry},
+		{2, Fainted, 0},
+		{Max - 1, Berry, Max},
+	}
+	for _, c := range cases {
+		if got := Adjust(c.v, c.delta); got != c.want {
+			t.Errorf("Adjust(%d, %d): expected %d, got %d", c.v, c.delta, c.want, got)
+		}
+	}
+}
+
+func TestElapsed(t *testing.T) {
+	since := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+	gained, next := Elapsed(since, since.Add(150*time.Minute))
+	if gained != 2*PerHour {
+		t.Errorf("expected %d, got %d", 2*PerHour, gained)
+	}
+	if !next.Equal(since.Add(2 * time.Hour)) {
+		t.Errorf("expected the partial hour to carry over, got %v", next)
+	}
+
+	gained, next = Elapsed(since, since.Add(time.Minute))
+	if gained != 0 || !next.Equal(since) {
+		t.Errorf("expected no gain within the first hour")
+	}
+}
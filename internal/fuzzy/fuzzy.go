@@ -0,0 +1,115 @@
+// Package fuzzy finds names from a partial or misspelt query.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+)
+
+// How well a name matches a query, best first.
+const (
+	exact = iota
+	prefix
+	substring
+	subsequence
+	typo
+)
+
+// Search returns the names that match query, best first: the name itself,
+// names starting with it, names containing it, names with its letters in
+// order, then names a few typos away. Names that match equally well keep
+// their order.
+func Search(query string, names []string) []string {
+	query = strings.ToLower(query)
+	type match struct {
+		name  string
+		score int
+	}
+	var matches []match
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		score := -1
+		switch {
+		case lower == query:
+			score = exact
+		case strings.HasPrefix(lower, query):
+			score = prefix
+		case strings.Contains(lower, query):
+			score = substring
+		case inOrder(query, lower):
+			score = subsequence
+		default:
+			if d := Distance(query, lower); d <= allowed(query) {
+				score = typo + d
+			}
+		}
+		if score >= 0 {
+			matches = append(matches, match{name, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+	found := make([]string, len(matches))
+	for i, m := range matches {
+		found[i] = m.name
+	}
+	return found
+}
+
+// Closest returns the name query most likely meant, if one is close enough
+// to be a typo of it.
+func Closest(query string, names []string) (string, bool) {
+	query = strings.ToLower(query)
+	best, bestDistance := "", allowed(query)+1
+	for _, name := range names {
+		if d := Distance(query, strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best, best != ""
+}
+
+// allowed is how many typos a query of its length may have.
+func allowed(query string) int {
+	return max(1, len([]rune(query))/3)
+}
+
+// inOrder reports whether every letter of query appears in s, in order.
+func inOrder(query, s string) bool {
+	for _, r := range query {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// Distance is how many letters have to be inserted, deleted, changed or
+// swapped with their neighbour to turn a into b.
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// d[i][j] is the distance between the first i letters of s and the
+	// first j of t.
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
@@ -0,0 +1,54 @@
+package fuzzy
+
+import (
+	"slices"
+	"testing"
+)
+
+var index = []string{"charmander", "charmeleon", "charizard", "pikachu", "raichu", "chatot", "pichu"}
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"char", []string{"charmander", "charmeleon", "charizard"}},
+		{"CHU", []string{"pikachu", "raichu", "pichu"}},
+		{"pichu", []string{"pichu", "pikachu"}},
+		{"chrzrd", []string{"charizard"}},
+		{"charizrad", []string{"charizard"}},
+		{"mew", nil},
+	}
+	for _, c := range cases {
+		if got := Search(c.query, index); !slices.Equal(got, c.want) {
+			t.Errorf("Search(%q) = %v, want %v", c.query, got, c.want)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	if got, ok := Closest("charzard", index); !ok || got != "charizard" {
+		t.Errorf("got %q, %v; want charizard", got, ok)
+	}
+	if got, ok := Closest("bulbasaur", index); ok {
+		t.Errorf("expected no suggestion, got %q", got)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"pikachu", "pikachu", 0},
+		{"pikachu", "pikahcu", 1},
+		{"pikachu", "pikachuu", 1},
+		{"", "zubat", 5},
+		{"kitten", "sitting", 3},
+	}
+	for _, c := range cases {
+		if got := Distance(c.a, c.b); got != c.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Package gift defines mystery gift codes and tracks which ones were
+// redeemed.
+package gift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+type Gift struct {
+	Code        string         `json:"code"`
+	Description string         `json:"description"`
+	Items       map[string]int `json:"items,omitempty"`
+	Pokemon     string         `json:"pokemon,omitempty"`
+}
+
+// Builtin are the codes every copy of the Pokedex knows about.
+var Builtin = []Gift{
+	{
+		Code:        "WELCOME",
+		Description: "A starter pack of Poké Balls",
+		Items:       map[string]int{"poke-ball": 5},
+	},
+	{
+		Code:        "BERRYBUNDLE",
+		Description: "A bundle of berries for your farm",
+		Items:       map[string]int{"oran-berry": 3, "razz-berry": 2},
+	},
+	{
+		Code:        "MYTHICALMEW",
+		Description: "A Mew, distributed to celebrate the Pokedex",
+		Pokemon:     "mew",
+	},
+}
+
+type Redemption struct {
+	Code       string    `json:"code"`
+	RedeemedAt time.Time `json:"redeemed_at"`
+}
+
+// Load returns the built-in gifts plus any defined in the file at path.
+func Load(path string) ([]Gift, error) {
+	var local []Gift
+	if err := storage.ReadJSON(path, &local); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return append(append([]Gift(nil), Builtin...), local...), nil
+}
+
+// Normalize makes codes case-insensitive and ignores dashes and spaces, so
+// "mythical-mew" matches MYTHICALMEW.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func Find(gifts []Gift, code string) (Gift, bool) {
+	code = Normalize(code)
+	for _, g := range gifts {
+		if Normalize(g.Code) == code {
+			return g, true
+		}
+	}
+	return Gift{}, false
+}
+
+func Redeemed(history []Redemption, code string) bool {
+	code = Normalize(code)
+	for _, r := range history {
+		if Normalize(r.Code) == code {
+			return true
+		}
+	}
+	return false
+}
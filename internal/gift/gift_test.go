@@ -0,0 +1,40 @@
+package gift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gifts.json")
+	err := os.WriteFile(path, []byte(`[{"code": "LOCAL-1", "items": {"master-ball": 1}}]`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gifts, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if g, ok := Find(gifts, "mythical-mew"); !ok || g.Pokemon != "mew" {
+		t.Errorf("expected built-in code to match, got %+v", g)
+	}
+	if g, ok := Find(gifts, "local1"); !ok || g.Items["master-ball"] != 1 {
+		t.Errorf("expected local code to match, got %+v", g)
+	}
+	if _, ok := Find(gifts, "NOPE"); ok {
+		t.Errorf("expected unknown code not to match")
+	}
+}
+
+func TestRedeemed(t *testing.T) {
+	history := []Redemption{{Code: "WELCOME", RedeemedAt: time.Now()}}
+	if !Redeemed(history, "welcome") {
+		t.Errorf("expected welcome to be redeemed")
+	}
+	if Redeemed(history, "BERRYBUNDLE") {
+		t.Errorf("expected berrybundle not to be redeemed")
+	}
+}
@@ -0,0 +1,95 @@
+// Package glyph decides which characters output can use: emoji, other
+// Unicode symbols like box-drawing lines, or only ASCII, for terminals and
+// connections that can't show more.
+package glyph
+
+import "strings"
+
+// Level is how much beyond ASCII a terminal can show.
+type Level int
+
+const (
+	ASCII Level = iota
+	Unicode
+	Emoji
+)
+
+var names = []string{"ascii", "unicode", "emoji"}
+
+func (l Level) String() string {
+	return names[l]
+}
+
+// Parse reads a level from its name.
+func Parse(s string) (Level, bool) {
+	for i, name := range names {
+		if s == name {
+			return Level(i), true
+		}
+	}
+	return ASCII, false
+}
+
+// emojiTerminals are the TERM_PROGRAMs known to draw emoji.
+var emojiTerminals = []string{"iTerm.app", "Apple_Terminal", "WezTerm", "vscode", "ghostty"}
+
+// Detect guesses what the terminal described by getenv can show. With no
+// locale set it assumes Unicode, which almost every terminal shows today;
+// a locale that isn't UTF-8, or the Linux console, limits it to ASCII.
+// Emoji are only assumed on terminals known to draw them, and never over
+// SSH, where the terminal at the other end is unknown.
+func Detect(getenv func(string) string) Level {
+	locale := getenv("LC_ALL")
+	if locale == "" {
+		locale = getenv("LC_CTYPE")
+	}
+	if locale == "" {
+		locale = getenv("LANG")
+	}
+	lower := strings.ToLower(locale)
+	if locale != "" && !strings.Contains(lower, "utf-8") && !strings.Contains(lower, "utf8") {
+		return ASCII
+	}
+	switch getenv("TERM") {
+	case "linux", "dumb", "vt100":
+		return ASCII
+	}
+	if getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != "" {
+		return Unicode
+	}
+	for _, t := range emojiTerminals {
+		if getenv("TERM_PROGRAM") == t {
+			return Emoji
+		}
+	}
+	// Windows Terminal sets WT_SESSION rather than TERM_PROGRAM.
+	if getenv("WT_SESSION") != "" {
+		return Emoji
+	}
+	return Unicode
+}
+
+// Shiny marks a shiny Pokémon.
+const Shiny = "✨"
+
+// Types are the emoji shown beside each type.
+var Types = map[string]string{
+	"normal":   "⚪",
+	"fire":     "🔥",
+	"water":    "💧",
+	"electric": "⚡",
+	"grass":    "🌿",
+	"ice":      "❄️",
+	"fighting": "🥊",
+	"poison":   "☠️",
+	"ground":   "⛰️",
+	"flying":   "🪶",
+	"psychic":  "🔮",
+	"bug":      "🐛",
+	"rock":     "🪨",
+	"ghost":    "👻",
+	"dragon":   "🐉",
+	"dark":     "🌑",
+	"steel":    "⚙️",
+	"fairy":    "🧚",
+}
@@ -0,0 +1,36 @@
+package glyph
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		want Level
+	}{
+		{"nothing set", nil, Unicode},
+		{"utf-8 locale", map[string]string{"LANG": "en_GB.UTF-8"}, Unicode},
+		{"latin-1 locale", map[string]string{"LANG": "de_DE.ISO-8859-1"}, ASCII},
+		{"C locale", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, ASCII},
+		{"linux console", map[string]string{"TERM": "linux"}, ASCII},
+		{"iterm", map[string]string{"TERM_PROGRAM": "iTerm.app"}, Emoji},
+		{"windows terminal", map[string]string{"WT_SESSION": "1"}, Emoji},
+		{"ssh", map[string]string{"TERM_PROGRAM": "iTerm.app", "SSH_CONNECTION": "10.0.0.1 22"}, Unicode},
+	}
+	for _, c := range cases {
+		if got := Detect(func(k string) string { return c.env[k] }); got != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, l := range []Level{ASCII, Unicode, Emoji} {
+		if got, ok := Parse(l.String()); !ok || got != l {
+			t.Errorf("%s: got %s, %v", l, got, ok)
+		}
+	}
+	if _, ok := Parse("auto"); ok {
+		t.Errorf("expected auto not to be a level")
+	}
+}
@@ -0,0 +1,30 @@
+// Package growth is how Pokémon gain experience from battles and level up.
+// Every species grows at the medium-fast rate, since PokeAPI's growth
+// rates would cost another request per species.
+package growth
+
+const MaxLevel = 100
+
+// Total is the experience a Pokémon has on reaching level.
+func Total(level int) int {
+	return level * level * level
+}
+
+// Gain is the experience for defeating a wild Pokémon with the given base
+// experience and level.
+func Gain(baseExperience, level int) int {
+	gain := baseExperience * level / 7
+	if gain < 1 {
+		return 1
+	}
+	return gain
+}
+
+// Level is the level a Pokémon with total experience has reached.
+func Level(total int) int {
+	level := 1
+	for level < MaxLevel && Total(level+1) <= total {
+		level++
+	}
+	return level
+}
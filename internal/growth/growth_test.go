@@ -0,0 +1,27 @@
+package growth
+
+import "testing"
+
+func TestLevel(t *testing.T) {
+	cases := []struct{ total, want int }{
+		{0, 1},
+		{Total(5), 5},
+		{Total(6) - 1, 5},
+		{Total(MaxLevel) * 2, MaxLevel},
+	}
+	for _, c := range cases {
+		if got := Level(c.total); got != c.want {
+			t.Errorf("Level(%d) = %d, want %d", c.total, got, c.want)
+		}
+	}
+}
+
+func TestGain(t *testing.T) {
+	// A level 5 pidgey (base experience 50).
+	if got := Gain(50, 5); got != 35 {
+		t.Errorf("got %d, want 35", got)
+	}
+	if got := Gain(0, 1); got != 1 {
+		t.Errorf("expected at least 1 experience, got %d", got)
+	}
+}
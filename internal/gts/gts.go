@@ -0,0 +1,148 @@
+// Package gts is an offer board for asynchronous trades. Trainers deposit a
+// Pokémon and name the species they want; anyone holding that species can
+// accept, and the depositor collects the Pokémon they were sent later.
+package gts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+type Trainer struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+type Offer struct {
+	ID        int             `json:"id"`
+	Owner     Trainer         `json:"owner"`
+	Species   string          `json:"species"`
+	Pokemon   json.RawMessage `json:"pokemon"`
+	Want      string          `json:"want"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	// Set once the offer has been accepted.
+	Partner     *Trainer        `json:"partner,omitempty"`
+	Received    json.RawMessage `json:"received,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+func (o Offer) Completed() bool {
+	return o.CompletedAt != nil
+}
+
+var ErrNotFound = errors.New("no such offer")
+
+// Board is the set of offers stored in a single file.
+type Board struct {
+	path   string
+	NextID int     `json:"next_id"`
+	Offers []Offer `json:"offers"`
+}
+
+func Open(path string) (*Board, error) {
+	b := &Board{path: path, NextID: 1}
+	if err := storage.ReadJSON(path, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *Board) Save() error {
+	return storage.WriteJSON(b.path, b)
+}
+
+func (b *Board) Put(owner Trainer, species string, pokemon json.RawMessage, want string, now time.Time) Offer {
+	offer := Offer{
+		ID:        b.NextID,
+		Owner:     owner,
+		Species:   species,
+		Pokemon:   pokemon,
+		Want:      want,
+		CreatedAt: now,
+	}
+	b.NextID++
+	b.Offers = append(b.Offers, offer)
+	return offer
+}
+
+// Pending returns the offers still waiting for a partner.
+func (b *Board) Pending() []Offer {
+	var pending []Offer
+	for _, o := range b.Offers {
+		if !o.Completed() {
+			pending = append(pending, o)
+		}
+	}
+	return pending
+}
+
+func (b *Board) find(id int) (int, error) {
+	for i, o := range b.Offers {
+		if o.ID == id {
+			return i, nil
+		}
+	}
+	return 0, ErrNotFound
+}
+
+// Accept completes an offer with the partner's Pokémon and returns the offer,
+// whose Pokemon now belongs to the partner.
+func (b *Board) Accept(id int, partner Trainer, species string, pokemon json.RawMessage, now time.Time) (Offer, error) {
+	i, err := b.find(id)
+	if err != nil {
+		return Offer{}, err
+	}
+	offer := b.Offers[i]
+	switch {
+	case offer.Completed():
+		return Offer{}, fmt.Errorf("offer %d has already been accepted", id)
+	case offer.Owner.ID == partner.ID:
+		return Offer{}, fmt.Errorf("you cannot accept your own offer")
+	case offer.Want != species:
+		return Offer{}, fmt.Errorf("offer %d wants a %s, not a %s", id, offer.Want, species)
+	}
+	offer.Partner = &partner
+	offer.Received = pokemon
+	offer.CompletedAt = &now
+	b.Offers[i] = offer
+	return offer, nil
+}
+
+// Cancel withdraws one of the owner's pending offers and returns it.
+func (b *Board) Cancel(id int, owner Trainer) (Offer, error) {
+	i, err := b.find(id)
+	if err != nil {
+		return Offer{}, err
+	}
+	offer := b.Offers[i]
+	if offer.Owner.ID != owner.ID {
+		return Offer{}, fmt.Errorf("offer %d is not yours", id)
+	}
+	if offer.Completed() {
+		return Offer{}, fmt.Errorf("offer %d has already been accepted", id)
+	}
+	b.Offers = append(b.Offers[:i], b.Offers[i+1:]...)
+	return offer, nil
+}
+
+// Collect removes and returns the owner's completed offers that ok allows,
+// whose Received Pokémon are now theirs. The rest stay to be collected
+// later.
+func (b *Board) Collect(owner Trainer, ok func(Offer) bool) []Offer {
+	var collected []Offer
+	kept := b.Offers[:0]
+	for _, o := range b.Offers {
+		if o.Completed() && o.Owner.ID == owner.ID && ok(o) {
+			collected = append(collected, o)
+			continue
+		}
+		kept = append(kept, o)
+	}
+	b.Offers = kept
+	return collected
+}
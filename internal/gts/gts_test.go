@@ -0,0 +1,92 @@
+package gts
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func nameOf(t *testing.T, data json.RawMessage) string {
+	t.Helper()
+	var pokemon struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &pokemon); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return pokemon.Name
+}
+
+func TestTrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offers.json")
+	misty := Trainer{Name: "Misty", ID: 1}
+	brock := Trainer{Name: "Brock", ID: 2}
+	now := time.Now()
+
+	board, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	offer := board.Put(misty, "staryu", json.RawMessage(`{"name":"staryu"}`), "onix", now)
+	if err := board.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	board, err = Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(board.Pending()) != 1 {
+		t.Fatalf("expected one pending offer")
+	}
+	if _, err := board.Accept(offer.ID, misty, "onix", nil, now); err == nil {
+		t.Errorf("expected accepting your own offer to fail")
+	}
+	if _, err := board.Accept(offer.ID, brock, "geodude", nil, now); err == nil {
+		t.Errorf("expected the wrong species to be refused")
+	}
+	accepted, err := board.Accept(offer.ID, brock, "onix", json.RawMessage(`{"name":"onix"}`), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := nameOf(t, accepted.Pokemon); name != "staryu" {
+		t.Errorf("expected brock to receive staryu, got %s", name)
+	}
+	if len(board.Pending()) != 0 {
+		t.Errorf("expected no pending offers after acceptance")
+	}
+
+	all := func(Offer) bool { return true }
+	if got := board.Collect(brock, all); len(got) != 0 {
+		t.Errorf("expected nothing for brock to collect")
+	}
+	if got := board.Collect(misty, func(Offer) bool { return false }); len(got) != 0 || len(board.Offers) != 1 {
+		t.Errorf("expected an offer misty can't take yet to stay on the board, got %+v", got)
+	}
+	got := board.Collect(misty, all)
+	if len(got) != 1 || nameOf(t, got[0].Received) != "onix" {
+		t.Errorf("expected misty to collect onix, got %+v", got)
+	}
+	if len(board.Offers) != 0 {
+		t.Errorf("expected collected offers to be removed")
+	}
+}
+
+func TestCancel(t *testing.T) {
+	board, err := Open(filepath.Join(t.TempDir(), "offers.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	misty := Trainer{Name: "Misty", ID: 1}
+	offer := board.Put(misty, "staryu", nil, "onix", time.Now())
+	if _, err := board.Cancel(offer.ID, Trainer{Name: "Brock", ID: 2}); err == nil {
+		t.Errorf("expected others not to be able to cancel")
+	}
+	if _, err := board.Cancel(offer.ID, misty); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := board.Cancel(offer.ID, misty); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
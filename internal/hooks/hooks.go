@@ -0,0 +1,172 @@
+// Package hooks runs the player's own commands at points in the game, like
+// adding each catch to a spreadsheet.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// Point is when a hook runs.
+type Point string
+
+const (
+	Start   Point = "on-start"
+	Exit    Point = "on-exit"
+	Catch   Point = "on-catch"
+	LevelUp Point = "on-levelup"
+)
+
+var Points = []Point{Start, Exit, Catch, LevelUp}
+
+// DefaultTimeout is how long a hook may run unless it says otherwise.
+const DefaultTimeout = 10 * time.Second
+
+// Hook is a program to run and its arguments. Each argument is a template
+// filled in from Data, like "{{.Pokemon}}", and is passed to the program
+// as it is, never through a shell, so nothing in a name can run commands.
+type Hook struct {
+	Command []string `json:"command"`
+	// Timeout is how long the hook may run, like "5s"; DefaultTimeout if
+	// empty.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Data is what a hook's arguments can refer to. Pokemon and Level are the
+// Pokémon caught or levelled up, and Area where the player is exploring.
+type Data struct {
+	Event   Point
+	Pokemon string
+	Level   int
+	Area    string
+	// Caught is how many Pokémon the player has.
+	Caught int
+	Time   time.Time
+}
+
+// Hooks are the hooks to run at each point, in order.
+type Hooks map[Point][]Hook
+
+// Load reads hooks from the JSON file at path; a missing file means none.
+func Load(path string) (Hooks, error) {
+	hooks := make(Hooks)
+	if err := storage.ReadJSON(path, &hooks); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	for point, list := range hooks {
+		if !slices.Contains(Points, point) {
+			return nil, fmt.Errorf("unknown hook point %q, expected one of %s", point, pointNames())
+		}
+		for i, h := range list {
+			if err := h.validate(); err != nil {
+				return nil, fmt.Errorf("%s hook %d: %w", point, i+1, err)
+			}
+		}
+	}
+	return hooks, nil
+}
+
+func pointNames() string {
+	var names []string
+	for _, p := range Points {
+		names = append(names, string(p))
+	}
+	return strings.Join(names, ", ")
+}
+
+func (h Hook) validate() error {
+	if len(h.Command) == 0 || h.Command[0] == "" {
+		return fmt.Errorf("no command")
+	}
+	if _, err := h.timeout(); err != nil {
+		return err
+	}
+	// Filling the arguments in once catches typos in them now rather than
+	// when the hook first runs.
+	_, err := h.args(Data{})
+	return err
+}
+
+func (h Hook) timeout() (time.Duration, error) {
+	if h.Timeout == "" {
+		return DefaultTimeout, nil
+	}
+	d, err := time.ParseDuration(h.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q, expected a duration like 5s", h.Timeout)
+	}
+	return d, nil
+}
+
+// args fills in the hook's arguments from data.
+func (h Hook) args(data Data) ([]string, error) {
+	var args []string
+	for _, arg := range h.Command {
+		t, err := template.New("arg").Parse(arg)
+		if err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		if err := t.Execute(&b, data); err != nil {
+			return nil, err
+		}
+		args = append(args, b.String())
+	}
+	return args, nil
+}
+
+// Run runs a hook and waits for it, stopping it if it takes longer than
+// its timeout or ctx is done. A hook that fails returns an error with
+// what it printed.
+func (h Hook) Run(ctx context.Context, data Data) error {
+	args, err := h.args(data)
+	if err != nil {
+		return err
+	}
+	timeout, err := h.timeout()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	// Don't wait on anything the hook left running with its output.
+	cmd.WaitDelay = time.Second
+	var output bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &output, &output
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s took longer than %s", args[0], timeout)
+	}
+	if err != nil {
+		if out := strings.TrimSpace(output.String()); out != "" {
+			return fmt.Errorf("%s: %w: %s", args[0], err, out)
+		}
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
+
+// Run runs the hooks at point in order, and returns the errors of those
+// that failed.
+func (hs Hooks) Run(ctx context.Context, point Point, data Data) []error {
+	data.Event = point
+	if data.Time.IsZero() {
+		data.Time = time.Now()
+	}
+	var errs []error
+	for _, h := range hs[point] {
+		if err := h.Run(ctx, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
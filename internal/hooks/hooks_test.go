@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeHooks(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "hooks.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	hooks, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(hooks) != 0 {
+		t.Fatalf("expected no hooks without a file, got %v, %v", hooks, err)
+	}
+	bad := map[string]string{
+		"point":    `{"on-lunch": [{"command": ["true"]}]}`,
+		"command":  `{"on-catch": [{"command": []}]}`,
+		"timeout":  `{"on-catch": [{"command": ["true"], "timeout": "soon"}]}`,
+		"template": `{"on-catch": [{"command": ["echo", "{{.Nickname}}"]}]}`,
+	}
+	for name, content := range bad {
+		if _, err := Load(writeHooks(t, content)); err == nil {
+			t.Errorf("expected a hook with a bad %s to be refused", name)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "catches.csv")
+	hooks := Hooks{Catch: {{Command: []string{"sh", "-c", `echo "$1,$2" >> "$3"`, "sh", "{{.Pokemon}}", "{{.Level}}", out}}}}
+	// The name reaches the hook as one argument, not as shell code.
+	if errs := hooks.Run(context.Background(), Catch, Data{Pokemon: "pikachu; rm -rf /", Level: 5}); errs != nil {
+		t.Fatal(errs)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "pikachu; rm -rf /,5\n" {
+		t.Errorf("got %q", got)
+	}
+	if errs := hooks.Run(context.Background(), Exit, Data{}); errs != nil {
+		t.Errorf("expected no hooks to run on exit, got %v", errs)
+	}
+}
+
+func TestRunFails(t *testing.T) {
+	err := Hook{Command: []string{"sh", "-c", "echo broken >&2; exit 3"}}.Run(context.Background(), Data{})
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected the error to include what the hook printed, got %v", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	start := time.Now()
+	err := Hook{Command: []string{"sleep", "10"}, Timeout: "50ms"}.Run(context.Background(), Data{})
+	if err == nil || !strings.Contains(err.Error(), "longer than") {
+		t.Errorf("expected the hook to time out, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the hook to be stopped, took %v", time.Since(start))
+	}
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSV reads one Pokémon per row: species, then optionally the level and
+// its moves separated by semicolons. A header row starting with "species"
+// is skipped.
+//
+//	species,level,moves
+//	pikachu,12,thunder-shock;quick-attack
+//	Mr. Mime,30
+type CSV struct{}
+
+func (CSV) Import(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var records []Record
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if strings.EqualFold(strings.TrimSpace(row[0]), "species") {
+			continue
+		}
+		record := Record{Species: Slug(row[0])}
+		if record.Species == "" {
+			return nil, fmt.Errorf("line %d: no species", line)
+		}
+		if len(row) > 1 && strings.TrimSpace(row[1]) != "" {
+			level, err := strconv.Atoi(strings.TrimSpace(row[1]))
+			if err != nil || level < 1 || level > MaxLevel {
+				return nil, fmt.Errorf("line %d: %q is not a level", line, row[1])
+			}
+			record.Level = level
+		}
+		if len(row) > 2 {
+			for _, move := range strings.Split(row[2], ";") {
+				if move = Slug(move); move != "" {
+					record.Moves = append(record.Moves, move)
+				}
+			}
+		}
+		records = append(records, record)
+	}
+}
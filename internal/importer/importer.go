@@ -0,0 +1,65 @@
+// Package importer reads Pokémon from other tools' formats so they can be
+// added to the Pokedex.
+package importer
+
+import (
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MaxLevel is the highest level a Pokémon can be.
+const MaxLevel = 100
+
+// Record is one Pokémon read from a file, with names in PokeAPI's form.
+type Record struct {
+	Species string
+	// Level is 0 if the file doesn't give one.
+	Level int
+	Moves []string
+}
+
+// Importer reads one format.
+type Importer interface {
+	Import(r io.Reader) ([]Record, error)
+}
+
+// Importers are the formats that can be imported, by name. A new format
+// only needs an Importer added here, and an extension below if it has one.
+var Importers = map[string]Importer{
+	"showdown": Showdown{},
+	"csv":      CSV{},
+}
+
+var extensions = map[string]string{
+	".txt": "showdown",
+	".csv": "csv",
+}
+
+// Formats lists the importers' names.
+func Formats() []string {
+	var names []string
+	for name := range Importers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForFile picks an importer by the file's extension, falling back to
+// Showdown's format, which is what most team builders export.
+func ForFile(path string) string {
+	if format, ok := extensions[strings.ToLower(filepath.Ext(path))]; ok {
+		return format
+	}
+	return "showdown"
+}
+
+// Slug turns a display name like "Mr. Mime" or "King's Shield" into the
+// name PokeAPI uses.
+func Slug(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(".", "", "'", "", "’", "", ":", "").Replace(name)
+	return strings.Join(strings.Fields(name), "-")
+}
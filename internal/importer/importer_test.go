@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShowdown(t *testing.T) {
+	team := `Sparky (Pikachu) (M) @ Light Ball
+Ability: Static
+Level: 50
+EVs: 252 Atk / 4 SpD / 252 Spe
+Jolly Nature
+- Thunderbolt
+- Hidden Power [Ice]
+
+Mr. Mime
+- King's Shield
+
+Zubat (F)
+`
+	records, err := Showdown{}.Import(strings.NewReader(team))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Record{
+		{Species: "pikachu", Level: 50, Moves: []string{"thunderbolt", "hidden-power"}},
+		{Species: "mr-mime", Moves: []string{"kings-shield"}},
+		{Species: "zubat"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %+v, want %+v", records, want)
+	}
+
+	if _, err := (Showdown{}).Import(strings.NewReader("Pikachu\nLevel: 900\n")); err == nil {
+		t.Error("expected an impossible level to be rejected")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	file := `species,level,moves
+pikachu,12,thunder-shock; Quick Attack
+# a comment
+Mr. Mime,
+zubat
+`
+	records, err := CSV{}.Import(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Record{
+		{Species: "pikachu", Level: 12, Moves: []string{"thunder-shock", "quick-attack"}},
+		{Species: "mr-mime"},
+		{Species: "zubat"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %+v, want %+v", records, want)
+	}
+
+	_, err = CSV{}.Import(strings.NewReader("pikachu,12\nzubat,high\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected the bad level on line 2 to be reported, got %v", err)
+	}
+}
+
+func TestForFile(t *testing.T) {
+	for path, want := range map[string]string{"team.csv": "csv", "TEAM.CSV": "csv", "team.txt": "showdown", "team": "showdown"} {
+		if got := ForFile(path); got != want {
+			t.Errorf("ForFile(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Showdown reads team files exported by Pokémon Showdown and most team
+// builders, one Pokémon per block:
+//
+//	Sparky (Pikachu) (M) @ Light Ball
+//	Ability: Static
+//	Level: 50
+//	- Thunderbolt
+//	- Quick Attack
+//
+// Items, abilities, EVs, IVs and natures are ignored.
+type Showdown struct{}
+
+func (Showdown) Import(r io.Reader) ([]Record, error) {
+	var records []Record
+	var current *Record
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			current = nil
+		case current == nil:
+			records = append(records, Record{Species: Slug(showdownSpecies(line))})
+			current = &records[len(records)-1]
+		case strings.HasPrefix(line, "Level:"):
+			level, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Level:")))
+			if err != nil || level < 1 || level > MaxLevel {
+				return nil, fmt.Errorf("line %d: %q is not a level", n, line)
+			}
+			current.Level = level
+		case strings.HasPrefix(line, "-"):
+			move := strings.TrimSpace(strings.TrimPrefix(line, "-"))
+			// Hidden Power [Fire] is just hidden-power.
+			move, _, _ = strings.Cut(move, "[")
+			current.Moves = append(current.Moves, Slug(move))
+		}
+	}
+	return records, scanner.Err()
+}
+
+// showdownSpecies finds the species on the first line of a set, which may
+// also name a nickname, gender and held item.
+func showdownSpecies(line string) string {
+	line, _, _ = strings.Cut(line, " @ ")
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimSuffix(line, " (M)"), " (F)")
+	// "Nickname (Species)"
+	if strings.HasSuffix(line, ")") {
+		if i := strings.LastIndex(line, "("); i > 0 {
+			return line[i+1 : len(line)-1]
+		}
+	}
+	return line
+}
@@ -0,0 +1,113 @@
+// Package insights keeps local statistics about command usage. Nothing
+// recorded here ever leaves the machine unless the user exports it.
+package insights
+
+import (
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+type CommandStats struct {
+	Count    int           `json:"count"`
+	Errors   int           `json:"errors"`
+	Total    time.Duration `json:"total"`
+	Max      time.Duration `json:"max"`
+	LastUsed time.Time     `json:"last_used"`
+}
+
+func (s CommandStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+type Tracker struct {
+	path     string
+	Since    time.Time                `json:"since"`
+	Commands map[string]*CommandStats `json:"commands"`
+}
+
+// Row is a single command's statistics, as shown in reports.
+type Row struct {
+	Name string `json:"name"`
+	CommandStats
+}
+
+// Report is the document written by Export. It only contains aggregate
+// numbers, never command arguments.
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Since       time.Time `json:"since"`
+	Platform    string    `json:"platform"`
+	Commands    []Row     `json:"commands"`
+}
+
+func Load(path string) (*Tracker, error) {
+	t := &Tracker{
+		path:     path,
+		Since:    time.Now(),
+		Commands: make(map[string]*CommandStats),
+	}
+	if err := storage.ReadJSON(path, t); err != nil {
+		return nil, err
+	}
+	if t.Commands == nil {
+		t.Commands = make(map[string]*CommandStats)
+	}
+	return t, nil
+}
+
+func (t *Tracker) Record(name string, elapsed time.Duration, err error) {
+	stats, ok := t.Commands[name]
+	if !ok {
+		stats = &CommandStats{}
+		t.Commands[name] = stats
+	}
+	stats.Count++
+	stats.Total += elapsed
+	if elapsed > stats.Max {
+		stats.Max = elapsed
+	}
+	if err != nil {
+		stats.Errors++
+	}
+	stats.LastUsed = time.Now()
+}
+
+func (t *Tracker) Save() error {
+	return storage.WriteJSON(t.path, t)
+}
+
+func (t *Tracker) Reset() {
+	t.Since = time.Now()
+	t.Commands = make(map[string]*CommandStats)
+}
+
+// Rows returns every command sorted by how often it was used.
+func (t *Tracker) Rows() []Row {
+	rows := make([]Row, 0, len(t.Commands))
+	for name, stats := range t.Commands {
+		rows = append(rows, Row{Name: name, CommandStats: *stats})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// Export writes an aggregate report to path so it can be shared by hand.
+func (t *Tracker) Export(path string) error {
+	return storage.WriteJSON(path, Report{
+		GeneratedAt: time.Now(),
+		Since:       t.Since,
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+		Commands:    t.Rows(),
+	})
+}
@@ -0,0 +1,38 @@
+package insights
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "insights.json")
+	tracker, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tracker.Record("map", 10*time.Millisecond, nil)
+	tracker.Record("map", 30*time.Millisecond, errors.New("timeout"))
+	tracker.Record("help", time.Millisecond, nil)
+	if err := tracker.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows := loaded.Rows()
+	if len(rows) != 2 || rows[0].Name != "map" {
+		t.Fatalf("expected map first, got %+v", rows)
+	}
+	if rows[0].Average() != 20*time.Millisecond {
+		t.Errorf("expected 20ms average, got %v", rows[0].Average())
+	}
+	if rows[0].Max != 30*time.Millisecond || rows[0].Errors != 1 {
+		t.Errorf("unexpected stats %+v", rows[0])
+	}
+}
@@ -0,0 +1,59 @@
+// Package journal keeps a rolling log of what happened on the player's
+// adventure, newest last.
+package journal
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// Limit is how many entries are kept; older ones are dropped.
+const Limit = 500
+
+type Entry struct {
+	At   time.Time `json:"at"`
+	Text string    `json:"text"`
+}
+
+type Journal struct {
+	path    string
+	Entries []Entry `json:"entries"`
+}
+
+func Open(path string) (*Journal, error) {
+	j := &Journal{path: path}
+	if err := storage.ReadJSON(path, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *Journal) Save() error {
+	return storage.WriteJSON(j.path, j)
+}
+
+func (j *Journal) Add(at time.Time, text string) {
+	j.Entries = append(j.Entries, Entry{At: at, Text: text})
+	if len(j.Entries) > Limit {
+		j.Entries = j.Entries[len(j.Entries)-Limit:]
+	}
+}
+
+// Recent returns up to the last n entries that contain search, ignoring
+// case. An empty search matches everything.
+func (j *Journal) Recent(n int, search string) []Entry {
+	search = strings.ToLower(search)
+	var found []Entry
+	for i := len(j.Entries) - 1; i >= 0 && len(found) < n; i-- {
+		if strings.Contains(strings.ToLower(j.Entries[i].Text), search) {
+			found = append(found, j.Entries[i])
+		}
+	}
+	// Back to oldest first.
+	for i, k := 0, len(found)-1; i < k; i, k = i+1, k-1 {
+		found[i], found[k] = found[k], found[i]
+	}
+	return found
+}
@@ -0,0 +1,40 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for i := 0; i < Limit+10; i++ {
+		j.Add(start.Add(time.Duration(i)*time.Second), "Saw a wild zubat")
+	}
+	j.Add(start, "Caught Pikachu")
+	j.Add(start, "pikachu escaped")
+	if len(j.Entries) != Limit {
+		t.Errorf("expected the journal to keep %d entries, got %d", Limit, len(j.Entries))
+	}
+	if err := j.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := j.Recent(3, "")
+	if len(got) != 3 || got[0].Text != "Saw a wild zubat" || got[2].Text != "pikachu escaped" {
+		t.Errorf("expected the last 3 entries oldest first, got %v", got)
+	}
+	got = j.Recent(10, "PIKACHU")
+	if len(got) != 2 || got[0].Text != "Caught Pikachu" {
+		t.Errorf("expected a case-insensitive search, got %v", got)
+	}
+}
@@ -0,0 +1,86 @@
+// Package ledger keeps a record of every trade the player makes: who with,
+// how, when, and the Pokémon that changed hands as they were at the time,
+// so where a Pokémon came from can always be traced.
+package ledger
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// Trainer is one side of a trade.
+type Trainer struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+// Trade is a trade the player made with Partner. Sent and Received are the
+// Pokémon as they were when they changed hands; Sent is empty when what
+// the player gave is no longer known.
+type Trade struct {
+	ID              int             `json:"id"`
+	At              time.Time       `json:"at"`
+	Method          string          `json:"method"`
+	Player          Trainer         `json:"player"`
+	Partner         Trainer         `json:"partner"`
+	SentSpecies     string          `json:"sent_species,omitempty"`
+	Sent            json.RawMessage `json:"sent,omitempty"`
+	ReceivedSpecies string          `json:"received_species"`
+	Received        json.RawMessage `json:"received"`
+}
+
+// Ledger is every trade, oldest first, stored in a single file.
+type Ledger struct {
+	path   string
+	NextID int     `json:"next_id"`
+	Trades []Trade `json:"trades"`
+}
+
+func Open(path string) (*Ledger, error) {
+	l := &Ledger{path: path, NextID: 1}
+	if err := storage.ReadJSON(path, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Ledger) Save() error {
+	return storage.WriteJSON(l.path, l)
+}
+
+// Add records a trade, numbering it, and returns it.
+func (l *Ledger) Add(t Trade) Trade {
+	t.ID = l.NextID
+	l.NextID++
+	l.Trades = append(l.Trades, t)
+	return t
+}
+
+// Get returns the trade numbered id.
+func (l *Ledger) Get(id int) (Trade, bool) {
+	for _, t := range l.Trades {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Trade{}, false
+}
+
+// Search returns the trades whose species, partner or method contain
+// query, ignoring case, oldest first. An empty query matches every trade.
+func (l *Ledger) Search(query string) []Trade {
+	query = strings.ToLower(query)
+	var found []Trade
+	for _, t := range l.Trades {
+		for _, field := range []string{t.SentSpecies, t.ReceivedSpecies, t.Partner.Name, t.Method} {
+			if strings.Contains(strings.ToLower(field), query) {
+				found = append(found, t)
+				break
+			}
+		}
+	}
+	return found
+}
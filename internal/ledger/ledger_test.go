@@ -0,0 +1,41 @@
+package ledger
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAddAndSearch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trades.json")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC)
+	l.Add(Trade{At: at, Method: "wonder trade", Partner: Trainer{Name: "WonderBot"}, SentSpecies: "zubat", ReceivedSpecies: "eevee", Received: []byte(`{"name":"eevee"}`)})
+	l.Add(Trade{At: at, Method: "offer board", Partner: Trainer{Name: "Misty", ID: 7}, SentSpecies: "pidgey", ReceivedSpecies: "staryu", Received: []byte(`{"name":"staryu"}`)})
+	if err := l.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Search(""); len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+		t.Fatalf("expected both trades, numbered, oldest first, got %+v", got)
+	}
+	if got := l.Search("misty"); len(got) != 1 || got[0].ReceivedSpecies != "staryu" {
+		t.Errorf("expected the trade with Misty, got %+v", got)
+	}
+	if got := l.Search("EEVEE"); len(got) != 1 || got[0].Method != "wonder trade" {
+		t.Errorf("expected the wonder trade, got %+v", got)
+	}
+	if trade := l.Add(Trade{ReceivedSpecies: "onix"}); trade.ID != 3 {
+		t.Errorf("expected numbering to carry on after reopening, got %d", trade.ID)
+	}
+	if _, ok := l.Get(2); !ok {
+		t.Error("expected to find trade 2")
+	}
+}
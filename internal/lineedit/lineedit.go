@@ -0,0 +1,451 @@
+// Package lineedit reads lines from a terminal with editing, history,
+// reverse search and tab completion. When the input isn't a terminal it
+// can put in raw mode, such as when it is piped, lines are read as they
+// are.
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrInterrupt is returned when Ctrl-C abandons the line.
+var ErrInterrupt = errors.New("interrupted")
+
+// Keys the editor handles.
+const (
+	ctrlA     = 1
+	ctrlB     = 2
+	ctrlC     = 3
+	ctrlD     = 4
+	ctrlE     = 5
+	ctrlF     = 6
+	ctrlG     = 7
+	backspace = 8
+	tab       = 9
+	ctrlK     = 11
+	ctrlN     = 14
+	ctrlP     = 16
+	ctrlR     = 18
+	ctrlU     = 21
+	ctrlW     = 23
+	esc       = 27
+	del       = 127
+)
+
+// Editor reads lines, keeping the history of those entered.
+type Editor struct {
+	In  *bufio.Reader
+	Out io.Writer
+	// Fd is the terminal's file descriptor.
+	Fd int
+	// Complete returns the candidates for partial, the word being typed,
+	// given the words before it.
+	Complete func(words []string, partial string) []string
+	// History is the lines entered so far, oldest first.
+	History []string
+	// Plain reads lines as the terminal hands them over, without the
+	// editor's keys or redrawing, which screen readers can't follow.
+	Plain bool
+
+	mu     sync.Mutex
+	prompt string
+	buf    []rune
+	pos    int
+	// editing is set while a line is being edited, and search while it is
+	// being searched for.
+	editing bool
+	search  *search
+}
+
+// search is a reverse search through the history.
+type search struct {
+	// before is the line as it was when the search started.
+	before []rune
+	query  []rune
+	// match is the index in History of the line found, or -1.
+	match int
+}
+
+// unraw puts the terminal back as it was, while ReadLine or ReadKey has it
+// in raw mode.
+var (
+	rawMu sync.Mutex
+	unraw func()
+)
+
+// raw puts the terminal at fd in raw mode until the returned function is
+// called, or Restore is.
+func raw(fd int) (func(), error) {
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	rawMu.Lock()
+	unraw = restore
+	rawMu.Unlock()
+	return Restore, nil
+}
+
+// Restore takes the terminal out of raw mode if ReadLine or ReadKey has put
+// it there, for a program ending while one of them waits for a key.
+func Restore() {
+	rawMu.Lock()
+	defer rawMu.Unlock()
+	if unraw != nil {
+		unraw()
+		unraw = nil
+	}
+}
+
+// ReadLine shows prompt and returns the line entered, without its newline.
+// At the end of the input it returns io.EOF.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if e.Plain {
+		return e.readPlain(prompt)
+	}
+	restore, err := raw(e.Fd)
+	if err != nil {
+		return e.readPlain(prompt)
+	}
+	defer restore()
+	return e.edit(prompt)
+}
+
+func (e *Editor) readPlain(prompt string) (string, error) {
+	fmt.Fprint(e.Out, prompt)
+	line, err := e.In.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// ReadKey waits for a single key on the terminal at fd and returns it,
+// without it being echoed. Where the terminal can't be put in raw mode, it
+// reads a line and returns its first character, or '\n' for an empty one.
+func ReadKey(in *bufio.Reader, fd int) (rune, error) {
+	restore, err := raw(fd)
+	if err != nil {
+		line, err := in.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			return []rune(line)[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		return '\n', nil
+	}
+	defer restore()
+	r, _, err := in.ReadRune()
+	return r, err
+}
+
+// Redraw draws the line being edited again, for after something else was
+// printed over it. It does nothing when no line is being edited.
+func (e *Editor) Redraw() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.editing {
+		e.render()
+	}
+}
+
+func (e *Editor) edit(prompt string) (string, error) {
+	e.mu.Lock()
+	e.prompt, e.buf, e.pos, e.editing, e.search = prompt, nil, 0, true, nil
+	e.render()
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		e.editing = false
+		e.mu.Unlock()
+	}()
+
+	// History is browsed from the end; the line being typed is kept as
+	// the entry past the last.
+	browsing := len(e.History)
+	draft := ""
+	for {
+		r, _, err := e.In.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		e.mu.Lock()
+		if e.search != nil {
+			done := e.searchKey(r)
+			if done == searching {
+				e.render()
+				e.mu.Unlock()
+				continue
+			}
+			if done == submit {
+				line := string(e.buf)
+				e.finish()
+				e.mu.Unlock()
+				e.remember(line)
+				return line, nil
+			}
+		}
+
+		switch r {
+		case '\r', '\n':
+			line := string(e.buf)
+			e.finish()
+			e.mu.Unlock()
+			e.remember(line)
+			return line, nil
+		case ctrlC:
+			fmt.Fprint(e.Out, "^C\n")
+			e.mu.Unlock()
+			return "", ErrInterrupt
+		case ctrlD:
+			if len(e.buf) == 0 {
+				e.mu.Unlock()
+				return "", io.EOF
+			}
+			e.deleteAt(e.pos)
+		case backspace, del:
+			if e.pos > 0 {
+				e.pos--
+				e.deleteAt(e.pos)
+			}
+		case ctrlA:
+			e.pos = 0
+		case ctrlE:
+			e.pos = len(e.buf)
+		case ctrlB:
+			e.pos = max(e.pos-1, 0)
+		case ctrlF:
+			e.pos = min(e.pos+1, len(e.buf))
+		case ctrlK:
+			e.buf = e.buf[:e.pos]
+		case ctrlU:
+			e.buf = append([]rune(nil), e.buf[e.pos:]...)
+			e.pos = 0
+		case ctrlW:
+			start := e.pos
+			for start > 0 && e.buf[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && e.buf[start-1] != ' ' {
+				start--
+			}
+			e.buf = append(e.buf[:start], e.buf[e.pos:]...)
+			e.pos = start
+		case tab:
+			e.complete()
+		case ctrlR:
+			e.search = &search{before: e.buf, match: -1}
+		case ctrlP, ctrlN:
+			browsing, draft = e.browse(r == ctrlP, browsing, draft)
+		case esc:
+			switch e.escape() {
+			case 'A':
+				browsing, draft = e.browse(true, browsing, draft)
+			case 'B':
+				browsing, draft = e.browse(false, browsing, draft)
+			case 'C':
+				e.pos = min(e.pos+1, len(e.buf))
+			case 'D':
+				e.pos = max(e.pos-1, 0)
+			case 'H':
+				e.pos = 0
+			case 'F':
+				e.pos = len(e.buf)
+			case '3':
+				if e.pos < len(e.buf) {
+					e.deleteAt(e.pos)
+				}
+			}
+		default:
+			if unicode.IsPrint(r) {
+				e.insert(r)
+			}
+		}
+		e.render()
+		e.mu.Unlock()
+	}
+}
+
+// escape reads the rest of an escape sequence and returns what it means:
+// the arrow keys' A, B, C and D, H and F for home and end, and 3 for
+// delete. Sequences it doesn't know are 0.
+func (e *Editor) escape() rune {
+	r, _, err := e.In.ReadRune()
+	if err != nil || r != '[' && r != 'O' {
+		return 0
+	}
+	r, _, err = e.In.ReadRune()
+	if err != nil {
+		return 0
+	}
+	switch r {
+	case 'A', 'B', 'C', 'D', 'H', 'F':
+		return r
+	case '1', '3', '4', '7', '8':
+		// Delete, and home and end on some terminals, end with a ~.
+		if next, _, err := e.In.ReadRune(); err != nil || next != '~' {
+			return 0
+		}
+		switch r {
+		case '1', '7':
+			return 'H'
+		case '4', '8':
+			return 'F'
+		}
+		return r
+	}
+	return 0
+}
+
+// What a key does to a reverse search.
+const (
+	searching = iota
+	// leave ends the search, keeping the line found, and lets the key be
+	// handled as usual.
+	leave
+	submit
+)
+
+func (e *Editor) searchKey(r rune) int {
+	s := e.search
+	switch {
+	case r == ctrlR:
+		e.find(s.match - 1)
+		return searching
+	case r == backspace || r == del:
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			e.find(len(e.History) - 1)
+		}
+		return searching
+	case r == ctrlG:
+		e.buf, e.pos = s.before, len(s.before)
+		e.search = nil
+		return searching
+	case r == '\r' || r == '\n':
+		e.search = nil
+		return submit
+	case unicode.IsPrint(r):
+		s.query = append(s.query, r)
+		e.find(max(s.match, len(e.History)-1))
+		return searching
+	}
+	e.search = nil
+	return leave
+}
+
+// find looks for the search query in the history, from index from back to
+// the oldest line, and puts the line found in the buffer.
+func (e *Editor) find(from int) {
+	s := e.search
+	for i := min(from, len(e.History)-1); i >= 0; i-- {
+		if strings.Contains(e.History[i], string(s.query)) {
+			s.match = i
+			e.buf = []rune(e.History[i])
+			e.pos = len(e.buf)
+			return
+		}
+	}
+}
+
+// browse moves through the history, older or newer, and returns where it
+// got to and the draft of the line being typed.
+func (e *Editor) browse(older bool, at int, draft string) (int, string) {
+	if at == len(e.History) {
+		draft = string(e.buf)
+	}
+	switch {
+	case older && at > 0:
+		at--
+	case !older && at < len(e.History):
+		at++
+	default:
+		return at, draft
+	}
+	line := draft
+	if at < len(e.History) {
+		line = e.History[at]
+	}
+	e.buf = []rune(line)
+	e.pos = len(e.buf)
+	return at, draft
+}
+
+func (e *Editor) insert(r rune) {
+	e.buf = append(e.buf, 0)
+	copy(e.buf[e.pos+1:], e.buf[e.pos:])
+	e.buf[e.pos] = r
+	e.pos++
+}
+
+func (e *Editor) deleteAt(i int) {
+	e.buf = append(e.buf[:i], e.buf[i+1:]...)
+}
+
+// complete completes the word before the cursor: fully if there is one
+// candidate, as far as they agree if there are several, and lists them if
+// they don't agree any further.
+func (e *Editor) complete() {
+	if e.Complete == nil {
+		return
+	}
+	before := string(e.buf[:e.pos])
+	start := strings.LastIndex(before, " ") + 1
+	partial := before[start:]
+	candidates := e.Complete(strings.Fields(before[:start]), partial)
+	if len(candidates) == 0 {
+		return
+	}
+	sort.Strings(candidates)
+	common := []rune(candidates[0])
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(c, string(common)) {
+			common = common[:len(common)-1]
+		}
+	}
+	if len(candidates) == 1 {
+		common = append(common, ' ')
+	}
+	if extra := common[min(len([]rune(partial)), len(common)):]; len(extra) > 0 {
+		for _, r := range extra {
+			e.insert(r)
+		}
+		return
+	}
+	fmt.Fprintf(e.Out, "\r\033[K%s\n", strings.Join(candidates, "  "))
+}
+
+// finish ends the line. The caller holds the lock.
+func (e *Editor) finish() {
+	e.render()
+	fmt.Fprint(e.Out, "\n")
+}
+
+func (e *Editor) remember(line string) {
+	line = strings.TrimRight(line, " ")
+	if strings.TrimSpace(line) == "" || len(e.History) > 0 && e.History[len(e.History)-1] == line {
+		return
+	}
+	e.History = append(e.History, line)
+}
+
+// render draws the prompt and the line, with the cursor in place. The
+// caller holds the lock.
+func (e *Editor) render() {
+	prompt := e.prompt
+	if e.search != nil {
+		prompt = fmt.Sprintf("(reverse-i-search)`%s': ", string(e.search.query))
+	}
+	fmt.Fprintf(e.Out, "\r\033[K%s%s", prompt, string(e.buf))
+	if back := len(e.buf) - e.pos; back > 0 {
+		fmt.Fprintf(e.Out, "\033[%dD", back)
+	}
+}
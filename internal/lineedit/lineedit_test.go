@@ -0,0 +1,101 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func editor(input string, history ...string) *Editor {
+	return &Editor{
+		In:      bufio.NewReader(strings.NewReader(input)),
+		Out:     io.Discard,
+		History: history,
+		Complete: func(words []string, partial string) []string {
+			var out []string
+			options := []string{"explore", "exit", "inspect"}
+			if len(words) == 1 && words[0] == "inspect" {
+				options = []string{"pikachu", "pidgey", "zubat"}
+			}
+			for _, o := range options {
+				if strings.HasPrefix(o, partial) {
+					out = append(out, o)
+				}
+			}
+			return out
+		},
+	}
+}
+
+func TestEdit(t *testing.T) {
+	cases := []struct {
+		name, input string
+		history     []string
+		want        string
+	}{
+		{"typing", "map\r", nil, "map"},
+		{"backspace", "mapp\x7f\r", nil, "map"},
+		{"cursor", "ap\x1b[D\x1b[Dm\x05 x\r", nil, "map x"},
+		{"kill word", "catch pikachu\x17zubat\r", nil, "catch zubat"},
+		{"history", "\x1b[A\x1b[A\x1b[B\r", []string{"map", "mapb"}, "mapb"},
+		{"draft kept", "ins\x1b[A\x1b[B\r", []string{"map"}, "ins"},
+		{"complete command", "ins\t\r", nil, "inspect "},
+		{"complete common prefix", "e\t\r", nil, "ex"},
+		{"complete name", "inspect pik\t\r", nil, "inspect pikachu "},
+		{"search", "\x12ex\r", []string{"explore test-area", "map", "exit"}, "exit"},
+		{"search older", "\x12ex\x12\r", []string{"explore test-area", "map", "exit"}, "explore test-area"},
+		{"search then edit", "\x12map\x05b\r", []string{"map", "catch pidgey"}, "mapb"},
+		{"search cancelled", "cat\x12map\x07s\r", []string{"map"}, "cats"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := editor(c.input, c.history...).edit("> ")
+			if err != nil || got != c.want {
+				t.Errorf("got %q, %v, want %q", got, err, c.want)
+			}
+		})
+	}
+}
+
+func TestEditEnds(t *testing.T) {
+	if _, err := editor("\x04").edit("> "); err != io.EOF {
+		t.Errorf("expected Ctrl-D on an empty line to be EOF, got %v", err)
+	}
+	if _, err := editor("map\x03").edit("> "); !errors.Is(err, ErrInterrupt) {
+		t.Errorf("expected Ctrl-C to interrupt, got %v", err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	e := editor("map\rmap\r \rmapb\r")
+	for i := 0; i < 4; i++ {
+		e.edit("> ")
+	}
+	if got := strings.Join(e.History, ","); got != "map,mapb" {
+		t.Errorf("got history %s", got)
+	}
+}
+
+func TestReadLineWithoutTerminal(t *testing.T) {
+	e := editor("map\nexit")
+	e.Fd = -1
+	for _, want := range []string{"map", "exit"} {
+		if got, err := e.ReadLine("> "); got != want || err != nil {
+			t.Errorf("got %q, %v, want %q", got, err, want)
+		}
+	}
+	if _, err := e.ReadLine("> "); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestReadLinePlain(t *testing.T) {
+	// Editing keys are left to the terminal, so they come through as typed.
+	e := editor("mapp\x7f\r\n")
+	e.Plain = true
+	if got, err := e.ReadLine("> "); got != "mapp\x7f" || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
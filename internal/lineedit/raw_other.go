@@ -0,0 +1,20 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package lineedit
+
+import "errors"
+
+// makeRaw isn't supported here, so lines are read without editing.
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("line editing is not supported on this system")
+}
+
+// Width can't tell how wide the terminal is here.
+func Width(fd int) int {
+	return 0
+}
+
+// Height can't tell how tall the terminal is here.
+func Height(fd int) int {
+	return 0
+}
@@ -0,0 +1,56 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package lineedit
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+func termios(fd int, request uintptr, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// makeRaw turns off the terminal's own line editing and echo, and signals
+// so Ctrl-C reaches the editor, and returns a function that turns them back
+// on. It fails if fd isn't a terminal.
+func makeRaw(fd int) (func(), error) {
+	var old syscall.Termios
+	if err := termios(fd, getTermios, &old); err != nil {
+		return nil, err
+	}
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG | syscall.IEXTEN
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termios(fd, setTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() { termios(fd, setTermios, &old) }, nil
+}
+
+// Width is how many columns wide the terminal at fd is, or 0 if fd isn't a
+// terminal.
+func Width(fd int) int {
+	_, cols := size(fd)
+	return cols
+}
+
+// Height is how many rows tall the terminal at fd is, or 0 if fd isn't a
+// terminal.
+func Height(fd int) int {
+	rows, _ := size(fd)
+	return rows
+}
+
+func size(fd int) (rows, cols int) {
+	var size struct{ rows, cols, x, y uint16 }
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); errno != 0 {
+		return 0, 0
+	}
+	return int(size.rows), int(size.cols)
+}
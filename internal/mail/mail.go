@@ -0,0 +1,123 @@
+// Package mail keeps the player's inbox: notices of what happened while
+// they were busy or away, like a friend's gift arriving or berries
+// ripening, kept until they are read.
+package mail
+
+import (
+	"sync"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// Limit is how many letters are kept; older ones are dropped, read or not.
+const Limit = 100
+
+type Letter struct {
+	ID   int       `json:"id"`
+	At   time.Time `json:"at"`
+	Text string    `json:"text"`
+	Read bool      `json:"read,omitempty"`
+}
+
+// Inbox is safe to use from more than one goroutine, as news from the
+// server arrives in the background.
+type Inbox struct {
+	path    string
+	mu      sync.Mutex
+	Letters []Letter `json:"letters"`
+	// Checked is when the game last looked for news it works out for
+	// itself, like berries that have ripened.
+	Checked time.Time `json:"checked"`
+}
+
+func Open(path string) (*Inbox, error) {
+	in := &Inbox{path: path}
+	if err := storage.ReadJSON(path, in); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+func (in *Inbox) Save() error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return storage.WriteJSON(in.path, in)
+}
+
+// Add puts a new, unread letter in the inbox.
+func (in *Inbox) Add(at time.Time, text string) Letter {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	id := 1
+	if n := len(in.Letters); n > 0 {
+		id = in.Letters[n-1].ID + 1
+	}
+	l := Letter{ID: id, At: at, Text: text}
+	in.Letters = append(in.Letters, l)
+	if len(in.Letters) > Limit {
+		in.Letters = in.Letters[len(in.Letters)-Limit:]
+	}
+	return l
+}
+
+// Unread counts the letters not read yet.
+func (in *Inbox) Unread() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	n := 0
+	for _, l := range in.Letters {
+		if !l.Read {
+			n++
+		}
+	}
+	return n
+}
+
+// Open returns the unread letters, oldest first, and marks them read.
+func (in *Inbox) Open() []Letter {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	var unread []Letter
+	for i, l := range in.Letters {
+		if !l.Read {
+			unread = append(unread, l)
+			in.Letters[i].Read = true
+		}
+	}
+	return unread
+}
+
+// Recent returns up to the last n letters, read or not, oldest first.
+func (in *Inbox) Recent(n int) []Letter {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	start := max(len(in.Letters)-n, 0)
+	return append([]Letter(nil), in.Letters[start:]...)
+}
+
+// Clear throws away the letters that have been read and returns how many
+// there were.
+func (in *Inbox) Clear() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	kept := in.Letters[:0]
+	for _, l := range in.Letters {
+		if !l.Read {
+			kept = append(kept, l)
+		}
+	}
+	n := len(in.Letters) - len(kept)
+	in.Letters = kept
+	return n
+}
+
+// Check records that the game looked for news at now, and returns when it
+// last did, which is the zero time the first time.
+func (in *Inbox) Check(now time.Time) time.Time {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	since := in.Checked
+	in.Checked = now
+	return since
+}
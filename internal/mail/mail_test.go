@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInbox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.json")
+	in, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	in.Add(now, "Misty sent you 1 potion")
+	in.Add(now, "Your oran-berry is ready to harvest")
+	if in.Unread() != 2 {
+		t.Errorf("expected 2 unread letters, got %d", in.Unread())
+	}
+	if err := in.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	in, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := in.Open()
+	if len(got) != 2 || got[0].Text != "Misty sent you 1 potion" || got[1].ID != 2 {
+		t.Errorf("expected both letters oldest first, got %+v", got)
+	}
+	if in.Unread() != 0 || len(in.Open()) != 0 {
+		t.Errorf("expected opened letters to be read")
+	}
+
+	in.Add(now, "Bug Catching Contest has started")
+	if n := in.Clear(); n != 2 {
+		t.Errorf("expected 2 read letters to be cleared, got %d", n)
+	}
+	if got := in.Recent(10); len(got) != 1 || got[0].ID != 3 {
+		t.Errorf("expected the unread letter to be kept, got %+v", got)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	in, err := Open(filepath.Join(t.TempDir(), "mail.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < Limit+5; i++ {
+		in.Add(time.Now(), "news")
+	}
+	got := in.Recent(Limit + 5)
+	if len(got) != Limit || got[0].ID != 6 {
+		t.Errorf("expected the last %d letters, got %d starting at %d", Limit, len(got), got[0].ID)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	in, err := Open(filepath.Join(t.TempDir(), "mail.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if since := in.Check(now); !since.IsZero() {
+		t.Errorf("expected the first check to have no previous one, got %v", since)
+	}
+	if since := in.Check(now.Add(time.Hour)); !since.Equal(now) {
+		t.Errorf("expected the previous check, got %v", since)
+	}
+}
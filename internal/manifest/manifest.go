@@ -0,0 +1,155 @@
+// Package manifest keeps track of what a mirror of PokeAPI holds: the ID of
+// each resource and when it was fetched. Comparing it with PokeAPI's own
+// lists shows what is new, changed or gone, so updating a mirror only
+// fetches those.
+package manifest
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// Version is the manifest format this package writes.
+const Version = 1
+
+// Entry is one mirrored resource.
+type Entry struct {
+	// ID is PokeAPI's number for the resource, or 0 if it isn't known.
+	ID      int       `json:"id"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// Resource is every mirrored resource of one kind, by name.
+type Resource struct {
+	Updated time.Time        `json:"updated"`
+	Items   map[string]Entry `json:"items"`
+}
+
+// Manifest is what a mirror holds, stored in a single file.
+type Manifest struct {
+	path      string
+	Version   int                  `json:"version"`
+	Resources map[string]*Resource `json:"resources"`
+}
+
+// Load reads the manifest at path; a missing one is empty.
+func Load(path string) (*Manifest, error) {
+	m := &Manifest{path: path, Version: Version, Resources: make(map[string]*Resource)}
+	if err := storage.ReadJSON(path, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Manifest) Save() error {
+	return storage.WriteJSON(m.path, m)
+}
+
+func (m *Manifest) resource(kind string) *Resource {
+	r, ok := m.Resources[kind]
+	if !ok {
+		r = &Resource{Items: make(map[string]Entry)}
+		m.Resources[kind] = r
+	}
+	if r.Items == nil {
+		r.Items = make(map[string]Entry)
+	}
+	return r
+}
+
+// Kinds lists the kinds of resource the mirror holds, in order.
+func (m *Manifest) Kinds() []string {
+	var kinds []string
+	for kind := range m.Resources {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Has reports whether the mirror holds a resource.
+func (m *Manifest) Has(kind, name string) bool {
+	_, ok := m.Resources[kind].items()[name]
+	return ok
+}
+
+func (r *Resource) items() map[string]Entry {
+	if r == nil {
+		return nil
+	}
+	return r.Items
+}
+
+// Record notes that a resource was fetched at at, with its data.
+func (m *Manifest) Record(kind, name string, data []byte, at time.Time) {
+	var resource struct {
+		ID int `json:"id"`
+	}
+	json.Unmarshal(data, &resource)
+	r := m.resource(kind)
+	r.Items[name] = Entry{ID: resource.ID, Fetched: at}
+	r.Updated = at
+}
+
+// Forget notes that a resource is no longer mirrored.
+func (m *Manifest) Forget(kind, name string) {
+	delete(m.Resources[kind].items(), name)
+}
+
+// Changes are the differences between a mirror and PokeAPI.
+type Changes struct {
+	// Added are resources the mirror doesn't have.
+	Added []string
+	// Changed are resources whose ID is no longer the one mirrored,
+	// because PokeAPI renumbered or replaced them.
+	Changed []string
+	// Removed are resources PokeAPI no longer lists.
+	Removed []string
+}
+
+// Empty reports whether the mirror is up to date.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// Diff compares the mirror's resources of a kind with PokeAPI's list of
+// them.
+func (m *Manifest) Diff(kind string, listed []moves.NamedResource) Changes {
+	var c Changes
+	have := m.Resources[kind].items()
+	seen := make(map[string]bool, len(listed))
+	for _, r := range listed {
+		seen[r.Name] = true
+		entry, ok := have[r.Name]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, r.Name)
+		case entry.ID != 0 && ID(r.URL) != 0 && entry.ID != ID(r.URL):
+			c.Changed = append(c.Changed, r.Name)
+		}
+	}
+	for name := range have {
+		if !seen[name] {
+			c.Removed = append(c.Removed, name)
+		}
+	}
+	sort.Strings(c.Removed)
+	return c
+}
+
+// ID is the ID at the end of a resource's URL, like 25 for
+// https://pokeapi.co/api/v2/pokemon/25/, or 0.
+func ID(url string) int {
+	id, err := strconv.Atoi(path.Base(strings.TrimSuffix(url, "/")))
+	if err != nil {
+		return 0
+	}
+	return id
+}
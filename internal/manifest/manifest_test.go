@@ -0,0 +1,62 @@
+package manifest
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+)
+
+func listed(names ...string) []moves.NamedResource {
+	var list []moves.NamedResource
+	for i, name := range names {
+		list = append(list, moves.NamedResource{Name: name, URL: "https://pokeapi.co/api/v2/pokemon/" + string(rune('1'+i)) + "/"})
+	}
+	return list
+}
+
+func TestDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	m, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	m.Record("pokemon", "bulbasaur", []byte(`{"id": 1}`), now)
+	m.Record("pokemon", "ivysaur", []byte(`{"id": 2}`), now)
+	m.Record("pokemon", "missingno", []byte(`{"id": 9}`), now)
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if m, err = Load(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// ivysaur is now number 3, venusaur is new and missingno is gone.
+	list := listed("bulbasaur", "venusaur", "ivysaur")
+	c := m.Diff("pokemon", list)
+	if !slices.Equal(c.Added, []string{"venusaur"}) || !slices.Equal(c.Changed, []string{"ivysaur"}) || !slices.Equal(c.Removed, []string{"missingno"}) {
+		t.Errorf("got %+v", c)
+	}
+
+	m.Record("pokemon", "venusaur", []byte(`{"id": 2}`), now)
+	m.Record("pokemon", "ivysaur", []byte(`{"id": 3}`), now)
+	m.Forget("pokemon", "missingno")
+	if c := m.Diff("pokemon", list); !c.Empty() {
+		t.Errorf("expected the mirror to be up to date, got %+v", c)
+	}
+	if kinds := m.Kinds(); !slices.Equal(kinds, []string{"pokemon"}) {
+		t.Errorf("got kinds %v", kinds)
+	}
+}
+
+func TestID(t *testing.T) {
+	if id := ID("https://pokeapi.co/api/v2/pokemon/25/"); id != 25 {
+		t.Errorf("expected 25, got %d", id)
+	}
+	if id := ID("https://pokeapi.co/api/v2/pokemon/"); id != 0 {
+		t.Errorf("expected 0, got %d", id)
+	}
+}
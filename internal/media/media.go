@@ -0,0 +1,186 @@
+// Package media keeps downloaded sprites and cries on disk. Files are named
+// by the SHA-256 of their contents, so one downloaded from two URLs is only
+// stored once, and the least recently used are removed to keep the cache
+// within a quota.
+package media
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// file is one stored file.
+type file struct {
+	Ext  string    `json:"ext"`
+	Size int64     `json:"size"`
+	Used time.Time `json:"used"`
+}
+
+// index is what the cache holds: the files by hash, and the hash each URL
+// downloaded to.
+type index struct {
+	Files map[string]file   `json:"files"`
+	URLs  map[string]string `json:"urls"`
+}
+
+type Cache struct {
+	dir   string
+	quota int64
+	HTTP  *http.Client
+	mu    sync.Mutex
+}
+
+// New returns a cache in dir that keeps at most quota bytes.
+func New(dir string, quota int64, client *http.Client) *Cache {
+	return &Cache{dir: dir, quota: quota, HTTP: client}
+}
+
+// Stats describes what the cache holds.
+type Stats struct {
+	Files int
+	Bytes int64
+	Quota int64
+}
+
+func (c *Cache) indexPath() string {
+	return filepath.Join(c.dir, "index.json")
+}
+
+func (c *Cache) load() (*index, error) {
+	idx := &index{}
+	if err := storage.ReadJSON(c.indexPath(), idx); err != nil {
+		return nil, err
+	}
+	if idx.Files == nil {
+		idx.Files = make(map[string]file)
+	}
+	if idx.URLs == nil {
+		idx.URLs = make(map[string]string)
+	}
+	return idx, nil
+}
+
+func (c *Cache) filePath(hash string, f file) string {
+	return filepath.Join(c.dir, hash+f.Ext)
+}
+
+// Fetch returns the path of the file at url, downloading it unless it is
+// already cached.
+func (c *Cache) Fetch(url string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx, err := c.load()
+	if err != nil {
+		return "", err
+	}
+	if hash, ok := idx.URLs[url]; ok {
+		f, ok := idx.Files[hash]
+		if _, err := os.Stat(c.filePath(hash, f)); ok && err == nil {
+			f.Used = time.Now()
+			idx.Files[hash] = f
+			return c.filePath(hash, f), storage.WriteJSON(c.indexPath(), idx)
+		}
+	}
+
+	data, err := c.download(url)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	f := file{Ext: path.Ext(url), Size: int64(len(data)), Used: time.Now()}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(c.filePath(hash, f), data, 0o644); err != nil {
+		return "", err
+	}
+	idx.Files[hash] = f
+	idx.URLs[url] = hash
+	c.evict(idx, hash)
+	return c.filePath(hash, f), storage.WriteJSON(c.indexPath(), idx)
+}
+
+func (c *Cache) download(url string) ([]byte, error) {
+	response, err := c.HTTP.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, response.Status)
+	}
+	return io.ReadAll(response.Body)
+}
+
+// evict removes the least recently used files until the cache is within
+// its quota, keeping the file just added.
+func (c *Cache) evict(idx *index, keep string) {
+	var total int64
+	var hashes []string
+	for hash, f := range idx.Files {
+		total += f.Size
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return idx.Files[hashes[i]].Used.Before(idx.Files[hashes[j]].Used) })
+	for _, hash := range hashes {
+		if total <= c.quota {
+			break
+		}
+		if hash == keep {
+			continue
+		}
+		total -= idx.Files[hash].Size
+		c.remove(idx, hash)
+	}
+}
+
+func (c *Cache) remove(idx *index, hash string) {
+	os.Remove(c.filePath(hash, idx.Files[hash]))
+	delete(idx.Files, hash)
+	for url, h := range idx.URLs {
+		if h == hash {
+			delete(idx.URLs, url)
+		}
+	}
+}
+
+func (c *Cache) Stats() (Stats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx, err := c.load()
+	if err != nil {
+		return Stats{}, err
+	}
+	s := Stats{Files: len(idx.Files), Quota: c.quota}
+	for _, f := range idx.Files {
+		s.Bytes += f.Size
+	}
+	return s, nil
+}
+
+// Purge removes every cached file and returns how many there were.
+func (c *Cache) Purge() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx, err := c.load()
+	if err != nil {
+		return 0, err
+	}
+	n := len(idx.Files)
+	for hash := range idx.Files {
+		c.remove(idx, hash)
+	}
+	return n, storage.WriteJSON(c.indexPath(), idx)
+}
@@ -0,0 +1,66 @@
+package media
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetch(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/a.png", "/copy-of-a.png":
+			w.Write([]byte(strings.Repeat("a", 60)))
+		case "/b.ogg":
+			w.Write([]byte(strings.Repeat("b", 60)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	c := New(t.TempDir(), 100, ts.Client())
+
+	a, err := c.Fetch(ts.URL + "/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(a, ".png") {
+		t.Errorf("expected the extension to be kept, got %s", a)
+	}
+	if again, _ := c.Fetch(ts.URL + "/a.png"); again != a || hits != 1 {
+		t.Errorf("expected a cached copy, got %s after %d downloads", again, hits)
+	}
+	if copied, _ := c.Fetch(ts.URL + "/copy-of-a.png"); copied != a {
+		t.Errorf("expected identical files to be stored once, got %s and %s", a, copied)
+	}
+	if s, _ := c.Stats(); s.Files != 1 || s.Bytes != 60 {
+		t.Errorf("got %+v", s)
+	}
+
+	// b doesn't fit alongside a, which was used longest ago.
+	time.Sleep(time.Millisecond)
+	if _, err := c.Fetch(ts.URL + "/b.ogg"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(a); !os.IsNotExist(err) {
+		t.Errorf("expected a to be evicted, got %v", err)
+	}
+	if s, _ := c.Stats(); s.Files != 1 || s.Bytes != 60 {
+		t.Errorf("got %+v", s)
+	}
+
+	if _, err := c.Fetch(ts.URL + "/missing.png"); err == nil {
+		t.Errorf("expected a missing file to fail")
+	}
+	if n, err := c.Purge(); n != 1 || err != nil {
+		t.Errorf("purged %d, %v", n, err)
+	}
+	if s, _ := c.Stats(); s.Files != 0 {
+		t.Errorf("expected an empty cache, got %+v", s)
+	}
+}
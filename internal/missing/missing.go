@@ -0,0 +1,100 @@
+// Package missing deals with PokeAPI entries that leave things out. Newer
+// species and forms can lack base experience, sizes, stats or a Pokédex
+// entry, and PokeAPI sends null or nothing for them, which decode
+// as zeros that would pass for real, if odd, values. None of these can
+// really be zero, so zero means the data is missing.
+package missing
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// NoData is shown in place of what PokeAPI doesn't have.
+const NoData = "no data"
+
+// StatNames are the base stats every Pokémon should have.
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// Defaults stand in for what battles and catching need and PokeAPI doesn't
+// have: a middling base stat, and the base experience of a typical
+// Pokémon that hasn't evolved.
+const (
+	DefaultBaseStat       = 50
+	DefaultBaseExperience = 64
+)
+
+// Fields lists what p is missing, by JSON key.
+func Fields(p pokeapi.Pokemon) []string {
+	var fields []string
+	if p.BaseExperience <= 0 {
+		fields = append(fields, "base_experience")
+	}
+	if p.Height <= 0 {
+		fields = append(fields, "height")
+	}
+	if p.Weight <= 0 {
+		fields = append(fields, "weight")
+	}
+	if len(Stats(p)) > 0 {
+		fields = append(fields, "stats")
+	}
+	if len(p.Types) == 0 {
+		fields = append(fields, "types")
+	}
+	return fields
+}
+
+// Stats lists the base stats p doesn't have, in the order of StatNames.
+func Stats(p pokeapi.Pokemon) []string {
+	var names []string
+	for _, name := range StatNames {
+		if !slices.ContainsFunc(p.Stats, func(s pokeapi.Stat) bool { return s.Stat.Name == name && s.BaseStat > 0 }) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Fill returns p with defaults for the stats and base experience it is
+// missing, for battles and catching to use. Pokémon without types are
+// left without them, and deal and take neutral damage.
+func Fill(p pokeapi.Pokemon) pokeapi.Pokemon {
+	if p.BaseExperience <= 0 {
+		p.BaseExperience = DefaultBaseExperience
+	}
+	absent := Stats(p)
+	if len(absent) == 0 {
+		return p
+	}
+	var stats []pokeapi.Stat
+	for _, s := range p.Stats {
+		if s.BaseStat > 0 {
+			stats = append(stats, s)
+		}
+	}
+	for _, name := range absent {
+		stats = append(stats, pokeapi.Stat{BaseStat: DefaultBaseStat, Stat: pokeapi.NamedResource{Name: name}})
+	}
+	p.Stats = stats
+	return p
+}
+
+// Number writes n, or NoData if it is missing.
+func Number(n int) string {
+	if n <= 0 {
+		return NoData
+	}
+	return fmt.Sprint(n)
+}
+
+// Tenths writes a height in decimetres or a weight in hectograms in the
+// unit ten times as big, like "0.4 m", or NoData if it is missing.
+func Tenths(n int, unit string) string {
+	if n <= 0 {
+		return NoData
+	}
+	return fmt.Sprintf("%.1f %s", float64(n)/10, unit)
+}
@@ -0,0 +1,61 @@
+package missing
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+func stat(name string, base int) pokeapi.Stat {
+	return pokeapi.Stat{BaseStat: base, Stat: pokeapi.NamedResource{Name: name}}
+}
+
+func TestFields(t *testing.T) {
+	p := pokeapi.Pokemon{Name: "pikachu", BaseExperience: 112, Height: 4, Weight: 60}
+	for _, name := range StatNames {
+		p.Stats = append(p.Stats, stat(name, 40))
+	}
+	p.Types = []pokeapi.Type{{Type: pokeapi.NamedResource{Name: "electric"}}}
+	if got := Fields(p); len(got) != 0 {
+		t.Errorf("expected nothing missing, got %v", got)
+	}
+
+	p = pokeapi.Pokemon{Name: "new-form", Height: 7, Stats: []pokeapi.Stat{stat("hp", 60), stat("speed", 0)}}
+	want := []string{"base_experience", "weight", "stats", "types"}
+	if got := Fields(p); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFill(t *testing.T) {
+	p := pokeapi.Pokemon{Stats: []pokeapi.Stat{stat("hp", 60), stat("speed", 0)}}
+	filled := Fill(p)
+	if filled.BaseExperience != DefaultBaseExperience {
+		t.Errorf("expected base experience %d, got %d", DefaultBaseExperience, filled.BaseExperience)
+	}
+	if got := Stats(filled); len(got) != 0 {
+		t.Errorf("expected every stat filled in, %v are still missing", got)
+	}
+	for _, s := range filled.Stats {
+		want := DefaultBaseStat
+		if s.Stat.Name == "hp" {
+			want = 60
+		}
+		if s.BaseStat != want {
+			t.Errorf("expected %s to be %d, got %d", s.Stat.Name, want, s.BaseStat)
+		}
+	}
+	if p.Stats[1].BaseStat != 0 {
+		t.Error("Fill changed the Pokémon it was given")
+	}
+}
+
+func TestNumber(t *testing.T) {
+	if got := Number(0); got != NoData {
+		t.Errorf("expected %q, got %q", NoData, got)
+	}
+	if got := Tenths(4, "m"); got != "0.4 m" {
+		t.Errorf("expected 0.4 m, got %q", got)
+	}
+}
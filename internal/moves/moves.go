@@ -0,0 +1,245 @@
+// Package moves parses PokeAPI move data and turns its meta data into the
+// secondary effects the battle engine applies, so moves work without being
+// coded one by one.
+package moves
+
+import "sort"
+
+// Limit is how many moves a Pokémon knows at once.
+const Limit = 4
+
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Move is the payload of /move/{name}. Power and Accuracy are 0 when the
+// API returns null: the move deals no direct damage or never misses.
+type Move struct {
+	Name        string        `json:"name"`
+	Power       int           `json:"power"`
+	Accuracy    int           `json:"accuracy"`
+	Priority    int           `json:"priority"`
+	Type        NamedResource `json:"type"`
+	DamageClass NamedResource `json:"damage_class"`
+	Target      NamedResource `json:"target"`
+	Meta        *Meta         `json:"meta"`
+	StatChanges []StatChange  `json:"stat_changes"`
+	// Machines are the TMs and HMs that teach the move, per version group.
+	Machines []MachineVersion `json:"machines"`
+}
+
+type Meta struct {
+	Category     NamedResource `json:"category"`
+	CritRate     int           `json:"crit_rate"`
+	Drain        int           `json:"drain"`
+	Healing      int           `json:"healing"`
+	FlinchChance int           `json:"flinch_chance"`
+	StatChance   int           `json:"stat_chance"`
+	MinHits      *int          `json:"min_hits"`
+	MaxHits      *int          `json:"max_hits"`
+}
+
+type MachineVersion struct {
+	Machine      NamedResource `json:"machine"`
+	VersionGroup NamedResource `json:"version_group"`
+}
+
+// Machine is the payload of /machine/{id}.
+type Machine struct {
+	Item NamedResource `json:"item"`
+}
+
+type StatChange struct {
+	Change int           `json:"change"`
+	Stat   NamedResource `json:"stat"`
+}
+
+const (
+	Physical = "physical"
+	Special  = "special"
+	Status   = "status"
+)
+
+// Class is Physical, Special or Status.
+func (m Move) Class() string {
+	return m.DamageClass.Name
+}
+
+// CritStage is how much more likely the move is to land a critical hit.
+func (m Move) CritStage() int {
+	if m.Meta == nil {
+		return 0
+	}
+	return m.Meta.CritRate
+}
+
+// Strength is the damage the move can be expected to deal for its power:
+// its power times its chance of hitting. Moves that never miss always hit.
+func (m Move) Strength() int {
+	if m.Accuracy == 0 {
+		return m.Power
+	}
+	return m.Power * m.Accuracy / 100
+}
+
+// Best returns the n strongest moves that deal damage, strongest first.
+// Moves as strong as each other keep their order.
+func Best(ms []Move, n int) []Move {
+	var best []Move
+	for _, m := range ms {
+		if m.Class() != Status && m.Power > 0 {
+			best = append(best, m)
+		}
+	}
+	sort.SliceStable(best, func(i, j int) bool { return best[i].Strength() > best[j].Strength() })
+	if len(best) > n {
+		best = best[:n]
+	}
+	return best
+}
+
+type Kind string
+
+const (
+	Flinch    Kind = "flinch"
+	StatStage Kind = "stat-stage"
+	Recoil    Kind = "recoil"
+	Drain     Kind = "drain"
+	Heal      Kind = "heal"
+	MultiHit  Kind = "multi-hit"
+)
+
+// Effect is one thing a move does besides its direct damage.
+type Effect struct {
+	Kind Kind
+	// Chance is the percentage chance of the effect happening.
+	Chance int
+	// Amount is a percentage of the damage dealt for Drain and Recoil, a
+	// percentage of max HP for Heal and a number of stages for StatStage.
+	Amount int
+	Stat   string
+	// Self is set when the effect applies to the user instead of the
+	// target.
+	Self    bool
+	MinHits int
+	MaxHits int
+}
+
+// Effects lists the move's secondary effects in the order they apply.
+func (m Move) Effects() []Effect {
+	var effects []Effect
+	meta := m.Meta
+	if meta == nil {
+		meta = &Meta{}
+	}
+	if meta.MinHits != nil && meta.MaxHits != nil && *meta.MaxHits > 1 {
+		effects = append(effects, Effect{Kind: MultiHit, Chance: 100, MinHits: *meta.MinHits, MaxHits: *meta.MaxHits})
+	}
+	switch {
+	case meta.Drain > 0:
+		effects = append(effects, Effect{Kind: Drain, Chance: 100, Amount: meta.Drain, Self: true})
+	case meta.Drain < 0:
+		effects = append(effects, Effect{Kind: Recoil, Chance: 100, Amount: -meta.Drain, Self: true})
+	}
+	if meta.Healing > 0 {
+		effects = append(effects, Effect{Kind: Heal, Chance: 100, Amount: meta.Healing, Self: true})
+	}
+	if meta.FlinchChance > 0 {
+		effects = append(effects, Effect{Kind: Flinch, Chance: meta.FlinchChance})
+	}
+
+	chance := meta.StatChance
+	if chance == 0 {
+		chance = 100
+	}
+	self := m.Target.Name == "user" || meta.Category.Name == "damage+raise"
+	for _, change := range m.StatChanges {
+		effects = append(effects, Effect{
+			Kind:   StatStage,
+			Chance: chance,
+			Amount: change.Change,
+			Stat:   change.Stat.Name,
+			Self:   self,
+		})
+	}
+	return effects
+}
+
+// Learnable is an entry of a Pokémon's moves in /pokemon/{name}.
+type Learnable struct {
+	Move                NamedResource  `json:"move"`
+	VersionGroupDetails []LearnDetails `json:"version_group_details"`
+}
+
+type LearnDetails struct {
+	LevelLearnedAt  int           `json:"level_learned_at"`
+	MoveLearnMethod NamedResource `json:"move_learn_method"`
+	VersionGroup    NamedResource `json:"version_group"`
+}
+
+// Ways a Pokémon learns a move.
+const (
+	ByLevelUp = "level-up"
+	ByMachine = "machine"
+	ByTutor   = "tutor"
+)
+
+// Learns reports whether a Pokémon with the given learnset can learn move
+// by method in any game.
+func Learns(learnable []Learnable, move, method string) bool {
+	for _, l := range learnable {
+		if l.Move.Name != move {
+			continue
+		}
+		for _, detail := range l.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name == method {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LevelUp returns the moves a wild Pokémon of the given level knows: the
+// last Limit moves it learned by levelling up, going by the most recent
+// games that teach each move.
+func LevelUp(learnable []Learnable, level int) []string {
+	type learned struct {
+		name  string
+		level int
+	}
+	var known []learned
+	for _, l := range learnable {
+		at := -1
+		for _, detail := range l.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name == ByLevelUp {
+				at = detail.LevelLearnedAt
+			}
+		}
+		if at >= 0 && at <= level {
+			known = append(known, learned{l.Move.Name, at})
+		}
+	}
+	sort.SliceStable(known, func(i, j int) bool { return known[i].level < known[j].level })
+	if len(known) > Limit {
+		known = known[len(known)-Limit:]
+	}
+	names := make([]string, len(known))
+	for i, k := range known {
+		names[i] = k.name
+	}
+	return names
+}
+
+// Learnset returns every move a Pokémon with the given learnset can learn
+// by method in any game.
+func Learnset(learnable []Learnable, method string) []string {
+	var names []string
+	for _, l := range learnable {
+		if Learns([]Learnable{l}, l.Move.Name, method) {
+			names = append(names, l.Move.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,91 @@
+package moves
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const swordsDance = `{"name":"swords-dance","power":null,"accuracy":null,
+"damage_class":{"name":"status"},"target":{"name":"user"},
+"meta":{"category":{"name":"net-good-stats"},"drain":0,"healing":0,"flinch_chance":0,"stat_chance":0,"min_hits":null,"max_hits":null},
+"stat_changes":[{"change":2,"stat":{"name":"attack"}}]}`
+
+const doubleEdge = `{"name":"double-edge","power":120,"accuracy":100,
+"damage_class":{"name":"physical"},"target":{"name":"selected-pokemon"},
+"meta":{"category":{"name":"damage"},"drain":-33,"flinch_chance":0,"stat_chance":0,"min_hits":null,"max_hits":null},
+"stat_changes":[]}`
+
+func parse(t *testing.T, data string) Move {
+	t.Helper()
+	var m Move
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestEffects(t *testing.T) {
+	got := parse(t, swordsDance).Effects()
+	want := []Effect{{Kind: StatStage, Chance: 100, Amount: 2, Stat: "attack", Self: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("swords-dance: expected %+v, got %+v", want, got)
+	}
+
+	got = parse(t, doubleEdge).Effects()
+	want = []Effect{{Kind: Recoil, Chance: 100, Amount: 33, Self: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("double-edge: expected %+v, got %+v", want, got)
+	}
+}
+
+func TestLevelUp(t *testing.T) {
+	learn := func(name, method string, level int) Learnable {
+		return Learnable{
+			Move: NamedResource{Name: name},
+			VersionGroupDetails: []LearnDetails{{
+				LevelLearnedAt:  level,
+				MoveLearnMethod: NamedResource{Name: method},
+			}},
+		}
+	}
+	learnable := []Learnable{
+		learn("thunder-shock", "level-up", 1),
+		learn("growl", "level-up", 1),
+		learn("mega-punch", "machine", 0),
+		learn("thunder-wave", "level-up", 9),
+		learn("quick-attack", "level-up", 5),
+		learn("double-team", "level-up", 8),
+		learn("thunder", "level-up", 40),
+	}
+	got := LevelUp(learnable, 10)
+	want := []string{"growl", "quick-attack", "double-team", "thunder-wave"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if !Learns(learnable, "mega-punch", ByMachine) || Learns(learnable, "mega-punch", ByTutor) {
+		t.Errorf("expected mega-punch to be learnable by machine only")
+	}
+	if Learns(learnable, "surf", ByMachine) {
+		t.Errorf("expected surf not to be learnable")
+	}
+}
+
+func TestBest(t *testing.T) {
+	ms := []Move{
+		parse(t, swordsDance),
+		{Name: "thunder", Power: 110, Accuracy: 70, DamageClass: NamedResource{Name: Special}},
+		parse(t, doubleEdge),
+		{Name: "swift", Power: 60, DamageClass: NamedResource{Name: Special}},
+		{Name: "thunderbolt", Power: 90, Accuracy: 100, DamageClass: NamedResource{Name: Special}},
+	}
+	var got []string
+	for _, m := range Best(ms, 3) {
+		got = append(got, m.Name)
+	}
+	want := []string{"double-edge", "thunderbolt", "thunder"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
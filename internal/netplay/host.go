@@ -0,0 +1,135 @@
+package netplay
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+type arrival struct {
+	conn  *Conn
+	hello Hello
+}
+
+// Host accepts a guest to battle and any number of spectators on a
+// listener. Spectators are handed to the Relay.
+type Host struct {
+	Relay *Relay
+
+	listener net.Listener
+	guests   chan arrival
+}
+
+// NewHost starts accepting connections on l until l is closed.
+func NewHost(l net.Listener) *Host {
+	h := &Host{Relay: &Relay{}, listener: l, guests: make(chan arrival)}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go h.greet(newConn(c))
+		}
+	}()
+	return h
+}
+
+func (h *Host) greet(c *Conn) {
+	hello, err := c.receiveHello()
+	if err != nil {
+		c.Close()
+		return
+	}
+	if hello.Spectator {
+		h.Relay.Add(c)
+		return
+	}
+	// Players who join once the host has a guest are turned away.
+	select {
+	case h.guests <- arrival{c, hello}:
+	default:
+		c.Close()
+	}
+}
+
+// WaitForGuest waits up to timeout for a player to join, then introduces
+// the host and starts the battle.
+func (h *Host) WaitForGuest(me Hello, start Start, timeout time.Duration) (*Conn, Hello, error) {
+	select {
+	case guest := <-h.guests:
+		if err := guest.conn.sendHello(me); err != nil {
+			guest.conn.Close()
+			return nil, guest.hello, err
+		}
+		if err := guest.conn.Send(Message{Type: TypeStart, Start: &start}); err != nil {
+			guest.conn.Close()
+			return nil, guest.hello, err
+		}
+		return guest.conn, guest.hello, nil
+	case <-time.After(timeout):
+		return nil, Hello{}, ErrTimeout
+	}
+}
+
+// Relay broadcasts a battle to spectators. It keeps everything it sent so
+// spectators who join late can catch up.
+type Relay struct {
+	mu       sync.Mutex
+	watchers []*Conn
+	history  []Message
+	closed   bool
+}
+
+// Add sends a new spectator everything so far and adds it to the
+// broadcast.
+func (r *Relay) Add(c *Conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		c.Close()
+		return
+	}
+	for _, m := range r.history {
+		if err := c.Send(m); err != nil {
+			c.Close()
+			return
+		}
+	}
+	r.watchers = append(r.watchers, c)
+}
+
+// Broadcast sends m to every spectator, dropping those that can't be
+// reached.
+func (r *Relay) Broadcast(m Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.history = append(r.history, m)
+	watching := r.watchers[:0]
+	for _, c := range r.watchers {
+		if err := c.Send(m); err != nil {
+			c.Close()
+			continue
+		}
+		watching = append(watching, c)
+	}
+	r.watchers = watching
+}
+
+// Watchers is how many spectators are watching.
+func (r *Relay) Watchers() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.watchers)
+}
+
+// Close disconnects every spectator.
+func (r *Relay) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.watchers {
+		c.Close()
+	}
+	r.watchers = nil
+	r.closed = true
+}
@@ -0,0 +1,333 @@
+// Package netplay lets two Pokedex instances battle each other over TCP.
+//
+// Both instances run the same battle simulation in lockstep. They swap their
+// Pokémon in a handshake, the host picks a random seed, and from then on
+// only the actions each player chooses are sent across. Since the
+// simulation is deterministic for a given seed and actions, both sides see
+// the same battle without trusting each other's results.
+//
+// Messages are single lines of JSON.
+package netplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+	"github.com/eymardfreire/pokedexcli/internal/growth"
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+)
+
+// Version is the protocol version. Both sides must speak the same one.
+const Version = 1
+
+// DefaultPort is the port hosts listen on unless told otherwise.
+const DefaultPort = 7777
+
+// TurnTimeout is how long a player has to choose an action before they
+// forfeit.
+const TurnTimeout = 2 * time.Minute
+
+// HandshakeTimeout is how long each side waits for the other's hello.
+const HandshakeTimeout = 30 * time.Second
+
+var (
+	ErrTimeout           = errors.New("the opponent took too long and forfeited")
+	ErrForfeit           = errors.New("you forfeited")
+	ErrOpponentForfeited = errors.New("the opponent forfeited")
+	ErrDisconnected      = errors.New("the opponent disconnected")
+)
+
+const (
+	TypeHello   = "hello"
+	TypeStart   = "start"
+	TypeAction  = "action"
+	TypeForfeit = "forfeit"
+
+	// Spectators are sent the match, then every turn, then the end.
+	TypeMatch = "match"
+	TypeTurn  = "turn"
+	TypeEnd   = "end"
+)
+
+type Message struct {
+	Type    string        `json:"type"`
+	Hello   *Hello        `json:"hello,omitempty"`
+	Start   *Start        `json:"start,omitempty"`
+	Action  battle.Action `json:"action,omitempty"`
+	Match   *Match        `json:"match,omitempty"`
+	Turn    *battle.Turn  `json:"turn,omitempty"`
+	Outcome string        `json:"outcome,omitempty"`
+}
+
+// Hello introduces a player and the Pokémon they battle with, or a
+// spectator.
+type Hello struct {
+	Version   int     `json:"version"`
+	Trainer   string  `json:"trainer"`
+	TrainerID int     `json:"trainer_id"`
+	Rating    int     `json:"rating"`
+	Fighter   Fighter `json:"fighter"`
+	Spectator bool    `json:"spectator,omitempty"`
+}
+
+// Match tells spectators who is battling.
+type Match struct {
+	Host  Hello `json:"host"`
+	Guest Hello `json:"guest"`
+}
+
+// Start is sent by the host once both sides have said hello.
+type Start struct {
+	Seed       int64 `json:"seed"`
+	Generation int   `json:"generation"`
+}
+
+// Fighter is everything needed to set up a combatant identically on both
+// sides.
+type Fighter struct {
+	Name    string       `json:"name"`
+	Level   int          `json:"level"`
+	Types   []string     `json:"types"`
+	Stats   battle.Stats `json:"stats"`
+	Ability string       `json:"ability,omitempty"`
+	Moves   []moves.Move `json:"moves"`
+}
+
+func FighterOf(c *battle.Combatant) Fighter {
+	return Fighter{Name: c.Name, Level: c.Level, Types: c.Types, Stats: c.Stats, Ability: c.Ability, Moves: c.Moves}
+}
+
+// maxBaseStat is the highest a species' base stat can be.
+const maxBaseStat = 255
+
+// Check reports what is wrong with a Fighter the other side sent, if
+// anything: its level and stats must be ones a Pokémon could have.
+func (f Fighter) Check() error {
+	if f.Level < 1 || f.Level > growth.MaxLevel {
+		return fmt.Errorf("%s is level %d; levels are from 1 to %d", f.Name, f.Level, growth.MaxLevel)
+	}
+	lowest, highest := battle.StatsAt(baseStats(1), f.Level), battle.StatsAt(baseStats(maxBaseStat), f.Level)
+	for _, s := range []struct {
+		name                   string
+		value, lowest, highest int
+	}{
+		{"hp", f.Stats.HP, lowest.HP, highest.HP},
+		{"attack", f.Stats.Attack, lowest.Attack, highest.Attack},
+		{"defense", f.Stats.Defense, lowest.Defense, highest.Defense},
+		{"special-attack", f.Stats.SpAttack, lowest.SpAttack, highest.SpAttack},
+		{"special-defense", f.Stats.SpDefense, lowest.SpDefense, highest.SpDefense},
+		{"speed", f.Stats.Speed, lowest.Speed, highest.Speed},
+	} {
+		if s.value < s.lowest || s.value > s.highest {
+			return fmt.Errorf("%s's %s is %d; at level %d it is from %d to %d", f.Name, s.name, s.value, f.Level, s.lowest, s.highest)
+		}
+	}
+	return nil
+}
+
+// baseStats are base stats all of one value.
+func baseStats(value int) map[string]int {
+	base := make(map[string]int)
+	for _, name := range []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"} {
+		base[name] = value
+	}
+	return base
+}
+
+// Combatant sets up a fresh combatant at full HP.
+func (f Fighter) Combatant() *battle.Combatant {
+	return &battle.Combatant{
+		Name:    f.Name,
+		Level:   f.Level,
+		Types:   f.Types,
+		Stats:   f.Stats,
+		HP:      f.Stats.HP,
+		Ability: f.Ability,
+		Moves:   f.Moves,
+		Stages:  make(map[string]int),
+	}
+}
+
+// Conn is a connection to another instance.
+type Conn struct {
+	conn     net.Conn
+	messages chan Message
+	err      error
+	// done is closed by Close, which then waits for the goroutine reading
+	// messages to stop and close read.
+	done, read chan struct{}
+	closing    sync.Once
+	closeErr   error
+}
+
+func newConn(c net.Conn) *Conn {
+	conn := &Conn{conn: c, messages: make(chan Message), done: make(chan struct{}), read: make(chan struct{})}
+	go func() {
+		defer close(conn.read)
+		defer close(conn.messages)
+		scanner := bufio.NewScanner(c)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var m Message
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				conn.err = fmt.Errorf("bad message %q: %w", scanner.Text(), err)
+				return
+			}
+			select {
+			case conn.messages <- m:
+			case <-conn.done:
+				return
+			}
+		}
+	}()
+	return conn
+}
+
+func Dial(addr string) (*Conn, error) {
+	c, err := net.DialTimeout("tcp", addr, HandshakeTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(c), nil
+}
+
+func (c *Conn) Send(m Message) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.conn, "%s\n", data)
+	return err
+}
+
+// Receive waits up to timeout for the next message.
+func (c *Conn) Receive(timeout time.Duration) (Message, error) {
+	select {
+	case m, ok := <-c.messages:
+		if !ok {
+			if c.err != nil {
+				return m, c.err
+			}
+			return m, ErrDisconnected
+		}
+		return m, nil
+	case <-time.After(timeout):
+		return Message{}, ErrTimeout
+	}
+}
+
+// Close closes the connection and waits for it to stop reading messages.
+// Closing it again does nothing.
+func (c *Conn) Close() error {
+	c.closing.Do(func() {
+		close(c.done)
+		c.closeErr = c.conn.Close()
+		<-c.read
+	})
+	return c.closeErr
+}
+
+// RemoteAddr is the address of the other instance.
+func (c *Conn) RemoteAddr() string {
+	return c.conn.RemoteAddr().String()
+}
+
+// receiveHello waits for the other side to introduce itself.
+func (c *Conn) receiveHello() (Hello, error) {
+	m, err := c.Receive(HandshakeTimeout)
+	if err != nil {
+		return Hello{}, err
+	}
+	if m.Type != TypeHello || m.Hello == nil {
+		return Hello{}, fmt.Errorf("expected a hello, got %q", m.Type)
+	}
+	if m.Hello.Version != Version {
+		return Hello{}, fmt.Errorf("the other side speaks protocol version %d, we speak %d", m.Hello.Version, Version)
+	}
+	if !m.Hello.Spectator {
+		if err := m.Hello.Fighter.Check(); err != nil {
+			return Hello{}, fmt.Errorf("the other side's Pokémon can't be right: %w", err)
+		}
+	}
+	return *m.Hello, nil
+}
+
+func (c *Conn) sendHello(me Hello) error {
+	me.Version = Version
+	return c.Send(Message{Type: TypeHello, Hello: &me})
+}
+
+// Join introduces the guest to the host and waits for the battle to start.
+func (c *Conn) Join(me Hello) (Hello, Start, error) {
+	if err := c.sendHello(me); err != nil {
+		return Hello{}, Start{}, err
+	}
+	host, err := c.receiveHello()
+	if err != nil {
+		return host, Start{}, err
+	}
+	m, err := c.Receive(HandshakeTimeout)
+	if err != nil {
+		return host, Start{}, err
+	}
+	if m.Type != TypeStart || m.Start == nil {
+		return host, Start{}, fmt.Errorf("expected the battle to start, got %q", m.Type)
+	}
+	return host, *m.Start, nil
+}
+
+// Watch connects to a host as a spectator. The host then sends the match
+// and the turns as they happen; read them with Receive.
+func Watch(addr string, me Hello) (*Conn, error) {
+	c, err := Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	me.Spectator = true
+	if err := c.sendHello(me); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Remote controls the opponent's combatant by waiting for the action they
+// chose on the other end of the connection.
+type Remote struct {
+	Conn    *Conn
+	Timeout time.Duration
+}
+
+func (r Remote) Choose(s battle.Situation) (battle.Action, error) {
+	m, err := r.Conn.Receive(r.Timeout)
+	if err != nil {
+		return "", err
+	}
+	switch m.Type {
+	case TypeForfeit:
+		return "", ErrOpponentForfeited
+	case TypeAction:
+		if !s.Allows(m.Action) {
+			return "", fmt.Errorf("the opponent chose %q, which %s can't do", m.Action, s.Self.Name)
+		}
+		return m.Action, nil
+	default:
+		return "", fmt.Errorf("expected an action, got %q", m.Type)
+	}
+}
+
+// SendAction tells the opponent what the local player chose.
+func (c *Conn) SendAction(action battle.Action) error {
+	return c.Send(Message{Type: TypeAction, Action: action})
+}
+
+// Forfeit tells the opponent the local player gave up.
+func (c *Conn) Forfeit() error {
+	return c.Send(Message{Type: TypeForfeit})
+}
@@ -0,0 +1,260 @@
+package netplay
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+)
+
+// local plays the local side with the built-in AI and sends its actions.
+type local struct {
+	conn *Conn
+}
+
+func (l local) Choose(s battle.Situation) (battle.Action, error) {
+	action, _ := battle.Auto{}.Choose(s)
+	return action, l.conn.SendAction(action)
+}
+
+func fighter(name string, speed int) Fighter {
+	tackle := moves.Move{
+		Name:        "tackle",
+		Power:       40,
+		Accuracy:    100,
+		Type:        moves.NamedResource{Name: "normal"},
+		DamageClass: moves.NamedResource{Name: moves.Physical},
+	}
+	return Fighter{
+		Name:  name,
+		Level: 20,
+		Types: []string{"normal"},
+		Stats: battle.Stats{HP: 50, Attack: 30, Defense: 25, SpAttack: 20, SpDefense: 20, Speed: speed},
+		Moves: []moves.Move{tackle},
+	}
+}
+
+// connect returns both ends of a handshaken connection and the host.
+func connect(t *testing.T) (host, guest *Conn, start Start, h *Host) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	h = NewHost(l)
+
+	start = Start{Seed: 42, Generation: 9}
+	errs := make(chan error, 1)
+	go func() {
+		var err error
+		host, _, err = h.WaitForGuest(Hello{Trainer: "red", Fighter: fighter("eevee", 55)}, start, time.Second)
+		errs <- err
+	}()
+	guest, err = Dial(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	them, got, err := guest.Join(Hello{Trainer: "blue", Fighter: fighter("rattata", 72)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if them.Trainer != "red" || got != start {
+		t.Fatalf("unexpected handshake: %+v %+v", them, got)
+	}
+	return host, guest, start, h
+}
+
+func TestLockstep(t *testing.T) {
+	host, guest, start, _ := connect(t)
+	defer host.Close()
+	defer guest.Close()
+
+	conditions := battle.Conditions{Generation: start.Generation}
+	play := func(a, b battle.Controller) (battle.Result, error) {
+		return battle.FightWith(fighter("eevee", 55).Combatant(), fighter("rattata", 72).Combatant(),
+			a, b, conditions, rand.New(rand.NewSource(start.Seed)))
+	}
+	type outcome struct {
+		result battle.Result
+		err    error
+	}
+	hosted := make(chan outcome)
+	go func() {
+		result, err := play(local{host}, Remote{Conn: host, Timeout: time.Second})
+		hosted <- outcome{result, err}
+	}()
+	joined, err := play(Remote{Conn: guest, Timeout: time.Second}, local{guest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := <-hosted
+	if h.err != nil {
+		t.Fatal(h.err)
+	}
+	if h.result.Winner == nil || h.result.Winner.Name != joined.Winner.Name {
+		t.Fatalf("expected both sides to agree on the winner")
+	}
+	if !reflect.DeepEqual(fmt.Sprint(h.result.Turns), fmt.Sprint(joined.Turns)) {
+		t.Errorf("expected both sides to see the same turns")
+	}
+}
+
+func TestForfeitAndTimeout(t *testing.T) {
+	host, guest, _, _ := connect(t)
+	defer host.Close()
+	defer guest.Close()
+
+	remote := Remote{Conn: guest, Timeout: 50 * time.Millisecond}
+	if _, err := remote.Choose(battle.Situation{}); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+	host.Forfeit()
+	if _, err := remote.Choose(battle.Situation{}); !errors.Is(err, ErrOpponentForfeited) {
+		t.Errorf("expected a forfeit, got %v", err)
+	}
+	host.SendAction("hyper-beam")
+	if _, err := remote.Choose(battle.Situation{}); err == nil {
+		t.Errorf("expected an unknown move to be rejected")
+	}
+	host.Close()
+	if _, err := remote.Choose(battle.Situation{}); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("expected a disconnect, got %v", err)
+	}
+}
+
+func TestCloseStopsReading(t *testing.T) {
+	ours, theirs := net.Pipe()
+	defer theirs.Close()
+	c := newConn(ours)
+	// The other side sends actions that are never received.
+	go func() {
+		for {
+			if _, err := fmt.Fprintln(theirs, `{"type":"action","action":"physical"}`); err != nil {
+				return
+			}
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	c.Close()
+	select {
+	case <-c.read:
+	default:
+		t.Error("expected Close to wait for the connection to stop reading")
+	}
+}
+
+func TestIllegalFighter(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	NewHost(l)
+	guest, err := Dial(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer guest.Close()
+	cheat := fighter("rattata", 72)
+	cheat.Stats.Attack = 999
+	if _, _, err := guest.Join(Hello{Trainer: "blue", Fighter: cheat}); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("expected the host to turn away a Pokémon with impossible stats, got %v", err)
+	}
+}
+
+func TestFighterCheck(t *testing.T) {
+	for _, tt := range []struct {
+		change func(*Fighter)
+		ok     bool
+	}{
+		{func(*Fighter) {}, true},
+		{func(f *Fighter) { f.Level = 0 }, false},
+		{func(f *Fighter) { f.Level = 101 }, false},
+		{func(f *Fighter) { f.Stats.HP = 29 }, false},
+		{func(f *Fighter) { f.Stats.HP = 132 }, true},
+		{func(f *Fighter) { f.Stats.HP = 133 }, false},
+		{func(f *Fighter) { f.Stats.Speed = 4 }, false},
+		{func(f *Fighter) { f.Stats.SpDefense = 108 }, false},
+	} {
+		f := fighter("eevee", 55)
+		tt.change(&f)
+		if err := f.Check(); (err == nil) != tt.ok {
+			t.Errorf("level %d, %+v: got %v, want ok %v", f.Level, f.Stats, err, tt.ok)
+		}
+	}
+}
+
+func TestSpectators(t *testing.T) {
+	host, guest, _, h := connect(t)
+	defer host.Close()
+	defer guest.Close()
+
+	h.Relay.Broadcast(Message{Type: TypeMatch, Match: &Match{}})
+	watcher, err := Watch(h.listener.Addr().String(), Hello{Trainer: "green"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	for h.Relay.Watchers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	h.Relay.Broadcast(Message{Type: TypeTurn, Turn: &battle.Turn{Attacker: "eevee", Move: "tackle"}})
+	h.Relay.Close()
+
+	var got []string
+	for {
+		m, err := watcher.Receive(time.Second)
+		if err != nil {
+			break
+		}
+		got = append(got, m.Type)
+	}
+	if want := []string{TypeMatch, TypeTurn}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected a late spectator to catch up with %v, got %v", want, got)
+	}
+}
+
+func TestReplayVerify(t *testing.T) {
+	host, guest := &Recorder{Controller: battle.Auto{}}, &Recorder{Controller: battle.Auto{}}
+	replay := Replay{
+		Host:  Hello{Trainer: "red", Fighter: fighter("eevee", 55)},
+		Guest: Hello{Trainer: "blue", Fighter: fighter("rattata", 72)},
+		Start: Start{Seed: 7, Generation: 9},
+	}
+	a, b := replay.Host.Fighter.Combatant(), replay.Guest.Fighter.Combatant()
+	result, err := battle.FightWith(a, b, host, guest, battle.Conditions{Generation: 9}, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay.HostActions, replay.GuestActions = host.Actions, guest.Actions
+	replay.Summary = Summarize(result, a, b)
+	if err := replay.Verify(); err != nil {
+		t.Fatalf("expected the replay to verify, got %v", err)
+	}
+
+	seeded := replay
+	seeded.Start.Seed = 8
+	if err := seeded.Verify(); !errors.Is(err, ErrDesync) {
+		t.Errorf("expected a different seed to desync, got %v", err)
+	}
+	cheated := replay
+	cheated.GuestActions = append([]battle.Action{"hyper-beam"}, replay.GuestActions[1:]...)
+	if err := cheated.Verify(); !errors.Is(err, ErrDesync) {
+		t.Errorf("expected an impossible action to desync, got %v", err)
+	}
+	forfeited := replay
+	forfeited.HostActions = replay.HostActions[:1]
+	if err := forfeited.Verify(); !errors.Is(err, ErrDesync) {
+		t.Errorf("expected a cut-short replay to desync, got %v", err)
+	}
+}
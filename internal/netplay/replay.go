@@ -0,0 +1,122 @@
+package netplay
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/battle"
+)
+
+// ErrDesync means a replayed duel did not end the way it was recorded.
+var ErrDesync = errors.New("the replay does not match the recorded duel")
+
+// Replay is everything needed to run a duel again: both players, the shared
+// start and the actions each side chose, in order.
+type Replay struct {
+	ID           int             `json:"id"`
+	Played       time.Time       `json:"played"`
+	Host         Hello           `json:"host"`
+	Guest        Hello           `json:"guest"`
+	Start        Start           `json:"start"`
+	HostActions  []battle.Action `json:"host_actions"`
+	GuestActions []battle.Action `json:"guest_actions"`
+	// Outcome is how the duel ended, as shown to the players.
+	Outcome string `json:"outcome"`
+	// Summary is the state the battle was in when it ended.
+	Summary Summary `json:"summary"`
+}
+
+// Summary is enough of how a battle ended to tell two runs apart.
+type Summary struct {
+	Winner  string `json:"winner,omitempty"`
+	Turns   int    `json:"turns"`
+	HostHP  int    `json:"host_hp"`
+	GuestHP int    `json:"guest_hp"`
+}
+
+func (s Summary) String() string {
+	winner := s.Winner
+	if winner == "" {
+		winner = "nobody"
+	}
+	return fmt.Sprintf("%s won after %d turns, %d and %d HP left", winner, s.Turns, s.HostHP, s.GuestHP)
+}
+
+func Summarize(result battle.Result, host, guest *battle.Combatant) Summary {
+	s := Summary{Turns: len(result.Turns), HostHP: host.HP, GuestHP: guest.HP}
+	if result.Winner != nil {
+		s.Winner = result.Winner.Name
+	}
+	return s
+}
+
+// Recorder remembers every action its Controller chooses. Turns are passed
+// on to the Controller if it is an Observer.
+type Recorder struct {
+	Controller battle.Controller
+	Actions    []battle.Action
+}
+
+func (r *Recorder) Choose(s battle.Situation) (battle.Action, error) {
+	action, err := r.Controller.Choose(s)
+	if err == nil {
+		r.Actions = append(r.Actions, action)
+	}
+	return action, err
+}
+
+func (r *Recorder) Observe(turn battle.Turn) {
+	if o, ok := r.Controller.(battle.Observer); ok {
+		o.Observe(turn)
+	}
+}
+
+// errReplayOver ends a replayed battle where the recording stops, as it
+// does when a player forfeits.
+var errReplayOver = errors.New("no more recorded actions")
+
+// scripted plays back recorded actions.
+type scripted struct {
+	actions []battle.Action
+}
+
+func (s *scripted) Choose(situation battle.Situation) (battle.Action, error) {
+	if len(s.actions) == 0 {
+		return "", errReplayOver
+	}
+	action := s.actions[0]
+	s.actions = s.actions[1:]
+	if !situation.Allows(action) {
+		return "", fmt.Errorf("%s can't do %q", situation.Self.Name, action)
+	}
+	return action, nil
+}
+
+// Simulate runs the duel again from its seed and actions.
+func (r Replay) Simulate() (Summary, error) {
+	host, guest := r.Host.Fighter.Combatant(), r.Guest.Fighter.Combatant()
+	result, err := battle.FightWith(host, guest,
+		&scripted{r.HostActions}, &scripted{r.GuestActions},
+		battle.Conditions{Generation: r.Start.Generation},
+		rand.New(rand.NewSource(r.Start.Seed)))
+	if err != nil && !errors.Is(err, errReplayOver) {
+		return Summary{}, err
+	}
+	return Summarize(result, host, guest), nil
+}
+
+// Verify runs the duel again and checks it ends the way it was recorded. A
+// mismatch means the two instances fell out of step, or one of them was
+// tampered with.
+func (r Replay) Verify() error {
+	got, err := r.Simulate()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDesync, err)
+	}
+	if got != r.Summary {
+		return fmt.Errorf("%w: recorded %s, replayed %s", ErrDesync, r.Summary, got)
+	}
+	return nil
+}
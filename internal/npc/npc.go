@@ -0,0 +1,124 @@
+// Package npc generates computer-controlled trainers and remembers the ones
+// the player has battled so they can be challenged again.
+package npc
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RematchCooldown is how long a trainer needs before accepting a rematch.
+const RematchCooldown = 30 * time.Minute
+
+// RematchLevels is how many levels a trainer's team gains per rematch.
+const RematchLevels = 4
+
+type Class struct {
+	Name    string
+	Names   []string
+	Species []string
+}
+
+var Classes = []Class{
+	{Name: "Youngster", Names: []string{"Joey", "Ben", "Calvin", "Dan"}, Species: []string{"rattata", "spearow", "ekans", "sandshrew"}},
+	{Name: "Lass", Names: []string{"Dana", "Haley", "Robin", "Iris"}, Species: []string{"pidgey", "nidoran-f", "clefairy", "jigglypuff"}},
+	{Name: "Bug Catcher", Names: []string{"Rick", "Doug", "Wade", "Arnie"}, Species: []string{"caterpie", "weedle", "metapod", "kakuna", "paras"}},
+	{Name: "Hiker", Names: []string{"Marcos", "Franklin", "Nob", "Wayne"}, Species: []string{"geodude", "onix", "machop", "graveler"}},
+	{Name: "Swimmer", Names: []string{"Luis", "Parker", "Denise", "Tiffany"}, Species: []string{"tentacool", "horsea", "goldeen", "staryu", "poliwag"}},
+	{Name: "Ace Trainer", Names: []string{"Blake", "Kay", "Jake", "Sofia"}, Species: []string{"growlithe", "eevee", "magnemite", "gastly", "dratini"}},
+}
+
+type Member struct {
+	Species string
+	Level   int
+}
+
+// Trainer is a registered NPC. Its team is regenerated from Seed, so only
+// the seed and the battle record need to be stored.
+type Trainer struct {
+	ID         int       `json:"id"`
+	Seed       int64     `json:"seed"`
+	Class      string    `json:"class"`
+	Name       string    `json:"name"`
+	BaseLevel  int       `json:"base_level"`
+	Wins       int       `json:"wins"`
+	Losses     int       `json:"losses"`
+	Rematches  int       `json:"rematches"`
+	LastBattle time.Time `json:"last_battle"`
+}
+
+func (t Trainer) String() string {
+	return fmt.Sprintf("%s %s", t.Class, t.Name)
+}
+
+// Generate creates a new trainer whose team is around level. It gets an ID
+// once it is registered.
+func Generate(level int, r *rand.Rand) Trainer {
+	seed := r.Int63()
+	g := rand.New(rand.NewSource(seed))
+	class := Classes[g.Intn(len(Classes))]
+	return Trainer{
+		Seed:      seed,
+		Class:     class.Name,
+		Name:      class.Names[g.Intn(len(class.Names))],
+		BaseLevel: level,
+	}
+}
+
+func (t Trainer) class() Class {
+	for _, c := range Classes {
+		if c.Name == t.Class {
+			return c
+		}
+	}
+	return Classes[0]
+}
+
+// Team regenerates the trainer's team for its current rematch count. The
+// species are the same every time; only the levels grow.
+func (t Trainer) Team() []Member {
+	g := rand.New(rand.NewSource(t.Seed))
+	species := t.class().Species
+	size := 1 + g.Intn(3)
+	team := make([]Member, size)
+	for i := range team {
+		level := t.BaseLevel + g.Intn(3) - 1 + t.Rematches*RematchLevels
+		if level < 2 {
+			level = 2
+		}
+		team[i] = Member{Species: species[g.Intn(len(species))], Level: level}
+	}
+	return team
+}
+
+// ReadyAt returns when the trainer will accept another battle.
+func (t Trainer) ReadyAt() time.Time {
+	return t.LastBattle.Add(RematchCooldown)
+}
+
+type Registry struct {
+	NextID   int       `json:"next_id"`
+	Trainers []Trainer `json:"trainers"`
+}
+
+func (r *Registry) Find(id int) (*Trainer, bool) {
+	for i := range r.Trainers {
+		if r.Trainers[i].ID == id {
+			return &r.Trainers[i], true
+		}
+	}
+	return nil, false
+}
+
+// Register assigns an ID to a trainer the player has battled for the first
+// time and stores it.
+func (r *Registry) Register(t Trainer) *Trainer {
+	if r.NextID == 0 {
+		r.NextID = 1
+	}
+	t.ID = r.NextID
+	r.NextID++
+	r.Trainers = append(r.Trainers, t)
+	return &r.Trainers[len(r.Trainers)-1]
+}
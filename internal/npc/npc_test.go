@@ -0,0 +1,51 @@
+package npc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTeamIsStable(t *testing.T) {
+	trainer := Generate(10, rand.New(rand.NewSource(1)))
+	first := trainer.Team()
+	again := trainer.Team()
+	if len(first) != len(again) {
+		t.Fatalf("expected the same team size every time")
+	}
+	for i := range first {
+		if first[i] != again[i] {
+			t.Errorf("expected the same team every time, got %v and %v", first, again)
+		}
+	}
+}
+
+func TestRematchScaling(t *testing.T) {
+	trainer := Generate(10, rand.New(rand.NewSource(1)))
+	before := trainer.Team()
+	trainer.Rematches = 2
+	after := trainer.Team()
+	for i := range before {
+		if after[i].Species != before[i].Species {
+			t.Errorf("expected rematches to keep the same species")
+		}
+		if after[i].Level != before[i].Level+2*RematchLevels {
+			t.Errorf("expected levels to grow by %d, got %d -> %d", 2*RematchLevels, before[i].Level, after[i].Level)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	var reg Registry
+	reg.Register(Generate(5, rand.New(rand.NewSource(1))))
+	if second := reg.Register(Generate(5, rand.New(rand.NewSource(2)))); second.ID != 2 {
+		t.Errorf("expected the second trainer to get id 2, got %d", second.ID)
+	}
+	trainer, ok := reg.Find(2)
+	if !ok {
+		t.Fatalf("expected to find trainer 2")
+	}
+	trainer.Wins++
+	if again, _ := reg.Find(2); again.Wins != 1 {
+		t.Errorf("expected Find to return the stored trainer")
+	}
+}
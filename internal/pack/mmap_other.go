@@ -0,0 +1,11 @@
+//go:build !unix
+
+package pack
+
+import "os"
+
+// mapFile reads the file at path into memory, where it can't be mapped.
+func mapFile(path string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(path)
+	return data, func() error { return nil }, err
+}
@@ -0,0 +1,211 @@
+// Package pack stores many small files, like a mirror of PokeAPI, in one
+// read-only file that is memory-mapped when opened. Opening it only reads
+// its index, and looking a key up binary-searches the index in place, so
+// even every Pokémon's data costs next to no memory until it is used.
+//
+// A pack is the values and keys one after another, then the index, sorted
+// by key, of where each key and value are, then a footer:
+//
+//	magic "PDXPACK1" | data... | index: count × (key offset, key length,
+//	value offset, value length), uint32 each | index offset, uint64 |
+//	count, uint32 | magic
+//
+// Numbers are little-endian. Offsets are from the start of the file.
+package pack
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+const magic = "PDXPACK1"
+
+const (
+	entrySize  = 16
+	footerSize = 8 + 4 + len(magic)
+)
+
+// ErrCorrupt means a file isn't a pack, or is damaged.
+var ErrCorrupt = errors.New("not a pack file, or a damaged one")
+
+type entry struct {
+	key              string
+	keyOff, valueOff uint32
+	valueLen         uint32
+}
+
+// Writer writes a pack. Values go straight to disk as they are added;
+// only the index is kept in memory.
+type Writer struct {
+	path   string
+	file   *os.File
+	w      *bufio.Writer
+	offset int64
+	index  []entry
+	err    error
+}
+
+// Create starts a pack at path. Until Close, it is written beside path,
+// so a pack already there can still be read.
+func Create(path string) (*Writer, error) {
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return nil, err
+	}
+	w := &Writer{path: path, file: file, w: bufio.NewWriter(file)}
+	w.write([]byte(magic))
+	return w, w.err
+}
+
+func (w *Writer) write(b []byte) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.w.Write(b)
+	w.offset += int64(len(b))
+	if w.err == nil && w.offset > math.MaxUint32 {
+		w.err = fmt.Errorf("packs can't be bigger than 4 GB")
+	}
+}
+
+// Add adds a value under key. Each key can only be added once.
+func (w *Writer) Add(key string, value []byte) error {
+	e := entry{key: key, valueOff: uint32(w.offset), valueLen: uint32(len(value))}
+	w.write(value)
+	e.keyOff = uint32(w.offset)
+	w.write([]byte(key))
+	w.index = append(w.index, e)
+	return w.err
+}
+
+// Close writes the index and puts the pack in place.
+func (w *Writer) Close() error {
+	sort.Slice(w.index, func(i, j int) bool { return w.index[i].key < w.index[j].key })
+	for i := 1; i < len(w.index); i++ {
+		if w.index[i].key == w.index[i-1].key && w.err == nil {
+			w.err = fmt.Errorf("%s was added twice", w.index[i].key)
+		}
+	}
+	indexOff := w.offset
+	var buf [entrySize]byte
+	for _, e := range w.index {
+		binary.LittleEndian.PutUint32(buf[0:], e.keyOff)
+		binary.LittleEndian.PutUint32(buf[4:], uint32(len(e.key)))
+		binary.LittleEndian.PutUint32(buf[8:], e.valueOff)
+		binary.LittleEndian.PutUint32(buf[12:], e.valueLen)
+		w.write(buf[:])
+	}
+	footer := binary.LittleEndian.AppendUint64(nil, uint64(indexOff))
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(w.index)))
+	w.write(append(footer, magic...))
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	if err := w.file.Close(); w.err == nil {
+		w.err = err
+	}
+	if w.err != nil {
+		os.Remove(w.file.Name())
+		return w.err
+	}
+	return os.Rename(w.file.Name(), w.path)
+}
+
+// Abort gives up on the pack, leaving any pack already at its path alone.
+func (w *Writer) Abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// Pack is an open pack. It is safe for concurrent use.
+type Pack struct {
+	data  []byte
+	index []byte
+	count int
+	unmap func() error
+}
+
+// Open maps the pack at path into memory.
+func Open(path string) (*Pack, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &Pack{data: data, unmap: unmap}
+	if err := p.check(); err != nil {
+		unmap()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+func (p *Pack) check() error {
+	size := len(p.data)
+	if size < len(magic)+footerSize || string(p.data[:len(magic)]) != magic || string(p.data[size-len(magic):]) != magic {
+		return ErrCorrupt
+	}
+	footer := p.data[size-footerSize:]
+	indexOff := binary.LittleEndian.Uint64(footer)
+	count := int(binary.LittleEndian.Uint32(footer[8:]))
+	if indexOff > uint64(size-footerSize) || uint64(count)*entrySize != uint64(size-footerSize)-indexOff {
+		return ErrCorrupt
+	}
+	p.index = p.data[indexOff : size-footerSize]
+	p.count = count
+	for i := 0; i < count; i++ {
+		keyOff, keyLen, valueOff, valueLen := p.entry(i)
+		if uint64(keyOff)+uint64(keyLen) > indexOff || uint64(valueOff)+uint64(valueLen) > indexOff {
+			return ErrCorrupt
+		}
+	}
+	return nil
+}
+
+func (p *Pack) entry(i int) (keyOff, keyLen, valueOff, valueLen uint32) {
+	e := p.index[i*entrySize : (i+1)*entrySize]
+	return binary.LittleEndian.Uint32(e), binary.LittleEndian.Uint32(e[4:]),
+		binary.LittleEndian.Uint32(e[8:]), binary.LittleEndian.Uint32(e[12:])
+}
+
+func (p *Pack) key(i int) []byte {
+	off, n, _, _ := p.entry(i)
+	return p.data[off : off+n]
+}
+
+// Get returns the value stored under key. It is the pack's own memory, so
+// must not be changed, and is only valid until the pack is closed.
+func (p *Pack) Get(key string) ([]byte, bool) {
+	i := sort.Search(p.count, func(i int) bool { return bytes.Compare(p.key(i), []byte(key)) >= 0 })
+	if i == p.count || string(p.key(i)) != key {
+		return nil, false
+	}
+	_, _, off, n := p.entry(i)
+	return p.data[off : off+n : off+n], true
+}
+
+// Len is how many values the pack holds.
+func (p *Pack) Len() int {
+	return p.count
+}
+
+// Keys returns the keys that start with prefix, in order.
+func (p *Pack) Keys(prefix string) []string {
+	i := sort.Search(p.count, func(i int) bool { return bytes.Compare(p.key(i), []byte(prefix)) >= 0 })
+	var keys []string
+	for ; i < p.count && strings.HasPrefix(string(p.key(i)), prefix); i++ {
+		keys = append(keys, string(p.key(i)))
+	}
+	return keys
+}
+
+// Close unmaps the pack. Values it returned can't be used afterwards.
+func (p *Pack) Close() error {
+	return p.unmap()
+}
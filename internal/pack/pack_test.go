@@ -0,0 +1,90 @@
+package pack
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.pack")
+	w, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if err := w.Add(fmt.Sprintf("pokemon/%03d", 99-i), []byte(fmt.Sprintf(`{"id":%d}`, 99-i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Add("location-area/canalave-city-area", []byte(`{}`))
+	w.Add("empty", nil)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if p.Len() != 102 {
+		t.Errorf("expected 102 values, got %d", p.Len())
+	}
+	if v, ok := p.Get("pokemon/042"); !ok || string(v) != `{"id":42}` {
+		t.Errorf("got %q, %v", v, ok)
+	}
+	if v, ok := p.Get("empty"); !ok || len(v) != 0 {
+		t.Errorf("expected an empty value, got %q, %v", v, ok)
+	}
+	for _, missing := range []string{"pokemon/100", "pokemon", "a", "zzz"} {
+		if _, ok := p.Get(missing); ok {
+			t.Errorf("found %s, which was never added", missing)
+		}
+	}
+	if keys := p.Keys("location-area/"); !slices.Equal(keys, []string{"location-area/canalave-city-area"}) {
+		t.Errorf("got keys %v", keys)
+	}
+}
+
+func TestDuplicateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.pack")
+	w, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Add("a", []byte("1"))
+	w.Add("a", []byte("2"))
+	if err := w.Close(); err == nil {
+		t.Error("expected an error for a key added twice")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected no pack to be left behind")
+	}
+}
+
+func TestCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.pack")
+	for _, data := range []string{"", "PDXPACK1", "not a pack at all, really not"} {
+		os.WriteFile(path, []byte(data), 0o644)
+		if _, err := Open(path); !errors.Is(err, ErrCorrupt) {
+			t.Errorf("%q: expected ErrCorrupt, got %v", data, err)
+		}
+	}
+}
+
+func TestAbort(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.pack")
+	w, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Add("a", []byte("1"))
+	w.Abort()
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+		t.Errorf("expected nothing left behind, got %v", entries)
+	}
+}
@@ -0,0 +1,94 @@
+// Package pager shows long output a screen at a time, as more does: space
+// shows the next screen, enter the next line, and q skips the rest.
+package pager
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// Prompt is shown at the bottom of the screen while the pager waits.
+const Prompt = "-- More -- (space: next page, enter: next line, q: quit)"
+
+// Pager is an io.Writer that passes what is written on to Out, stopping
+// to wait for a key each time a screen has filled up.
+type Pager struct {
+	Out io.Writer
+	// Height and Width are the size of the screen. With a Height below 2
+	// the pager never stops; with a Width of 0 long lines count as one.
+	Height, Width int
+	// Key waits for the player to press a key and returns it.
+	Key func() (rune, error)
+
+	// lines have been shown since the pager last stopped, and col is the
+	// column the line being written has reached.
+	lines, col int
+	escape     bool
+	quit       bool
+}
+
+// Write passes p on, a screen at a time. Once the player has quit, the
+// rest is thrown away, though Write still reports it written so the
+// command writing it carries on.
+func (pg *Pager) Write(p []byte) (int, error) {
+	if pg.quit {
+		return len(p), nil
+	}
+	start := 0
+	for i := 0; i < len(p); {
+		r, size := utf8.DecodeRune(p[i:])
+		i += size
+		switch {
+		case pg.escape:
+			// Escape sequences, like colors, end with a letter and take no
+			// room on the screen.
+			pg.escape = !(r >= '@' && r <= '~' && r != '[')
+			continue
+		case r == '\033':
+			pg.escape = true
+			continue
+		case r == '\r':
+			pg.col = 0
+			continue
+		case r != '\n':
+			pg.col++
+			if pg.Width > 0 && pg.col > pg.Width {
+				pg.lines, pg.col = pg.lines+1, 1
+			}
+			continue
+		}
+		pg.lines, pg.col = pg.lines+1, 0
+		if pg.Height < 2 || pg.lines < pg.Height-1 {
+			continue
+		}
+		if _, err := pg.Out.Write(p[start:i]); err != nil {
+			return start, err
+		}
+		start = i
+		if !pg.more() {
+			pg.quit = true
+			return len(p), nil
+		}
+	}
+	if _, err := pg.Out.Write(p[start:]); err != nil {
+		return start, err
+	}
+	return len(p), nil
+}
+
+// more waits for a key, and reports whether to carry on.
+func (pg *Pager) more() bool {
+	fmt.Fprint(pg.Out, Prompt)
+	key, err := pg.Key()
+	fmt.Fprint(pg.Out, "\r\033[K")
+	switch {
+	case err != nil, key == 'q', key == 'Q', key == 3:
+		return false
+	case key == '\r', key == '\n':
+		pg.lines = pg.Height - 2
+	default:
+		pg.lines = 0
+	}
+	return true
+}
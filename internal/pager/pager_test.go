@@ -0,0 +1,60 @@
+package pager
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// keys presses the keys given, one each time the pager waits.
+func keys(pressed ...rune) func() (rune, error) {
+	return func() (rune, error) {
+		if len(pressed) == 0 {
+			return 0, fmt.Errorf("no more keys")
+		}
+		key := pressed[0]
+		pressed = pressed[1:]
+		return key, nil
+	}
+}
+
+func lines(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestPages(t *testing.T) {
+	var out strings.Builder
+	pg := &Pager{Out: &out, Height: 4, Key: keys(' ', '\n', 'q')}
+	fmt.Fprint(pg, lines(20))
+
+	got := strings.Split(strings.ReplaceAll(out.String(), Prompt+"\r\033[K", "|"), "\n")
+	want := []string{"line 1", "line 2", "line 3", "|line 4", "line 5", "line 6", "|line 7", "|"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if n, err := fmt.Fprint(pg, "more\n"); n != 5 || err != nil || strings.Contains(out.String(), "more") {
+		t.Errorf("expected output after q to be thrown away, got %d, %v", n, err)
+	}
+}
+
+func TestShortOutput(t *testing.T) {
+	var out strings.Builder
+	pg := &Pager{Out: &out, Height: 24, Key: keys()}
+	fmt.Fprint(pg, lines(5))
+	if out.String() != lines(5) {
+		t.Errorf("expected output shorter than the screen to pass straight through, got %q", out.String())
+	}
+}
+
+func TestWrappedLines(t *testing.T) {
+	var out strings.Builder
+	pg := &Pager{Out: &out, Height: 4, Width: 10, Key: keys('q')}
+	fmt.Fprint(pg, "\033[32m"+strings.Repeat("x", 25)+"\033[0m\nnext\n")
+	if !strings.HasSuffix(out.String(), Prompt+"\r\033[K") || strings.Contains(out.String(), "\nnext") {
+		t.Errorf("expected a line three screens wide to fill the screen, got %q", out.String())
+	}
+}
@@ -0,0 +1,138 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const appName = "pokedexcli"
+
+// Paths holds the directories the Pokedex reads from and writes to.
+type Paths struct {
+	Config string
+	Data   string
+	Cache  string
+	Logs   string
+}
+
+// Resolve returns the directories for the current platform, honouring the
+// XDG base directory variables on Linux and other Unix systems.
+func Resolve() (Paths, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Paths{}, err
+	}
+	return resolve(runtime.GOOS, os.Getenv, home), nil
+}
+
+func resolve(goos string, getenv func(string) string, home string) Paths {
+	switch goos {
+	case "windows":
+		roaming := envOr(getenv, "APPDATA", filepath.Join(home, "AppData", "Roaming"))
+		local := envOr(getenv, "LOCALAPPDATA", filepath.Join(home, "AppData", "Local"))
+		return Paths{
+			Config: filepath.Join(roaming, appName),
+			Data:   filepath.Join(roaming, appName, "data"),
+			Cache:  filepath.Join(local, appName, "cache"),
+			Logs:   filepath.Join(local, appName, "logs"),
+		}
+	case "darwin":
+		support := filepath.Join(home, "Library", "Application Support", appName)
+		return Paths{
+			Config: support,
+			Data:   filepath.Join(support, "data"),
+			Cache:  filepath.Join(home, "Library", "Caches", appName),
+			Logs:   filepath.Join(home, "Library", "Logs", appName),
+		}
+	default:
+		return Paths{
+			Config: filepath.Join(xdg(getenv, "XDG_CONFIG_HOME", home, ".config"), appName),
+			Data:   filepath.Join(xdg(getenv, "XDG_DATA_HOME", home, ".local", "share"), appName),
+			Cache:  filepath.Join(xdg(getenv, "XDG_CACHE_HOME", home, ".cache"), appName),
+			Logs:   filepath.Join(xdg(getenv, "XDG_STATE_HOME", home, ".local", "state"), appName, "logs"),
+		}
+	}
+}
+
+// xdg returns the value of an XDG variable, falling back to the spec default
+// when it is unset or not absolute.
+func xdg(getenv func(string) string, key, home string, def ...string) string {
+	if dir := getenv(key); filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(append([]string{home}, def...)...)
+}
+
+func envOr(getenv func(string) string, key, def string) string {
+	if dir := getenv(key); dir != "" {
+		return dir
+	}
+	return def
+}
+
+// Ensure creates every directory that does not exist yet.
+func (p Paths) Ensure() error {
+	for _, dir := range []string{p.Config, p.Data, p.Cache, p.Logs} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Legacy returns the single dot-directory used by older versions.
+func Legacy(home string) string {
+	return filepath.Join(home, "."+appName)
+}
+
+// Migrate moves files out of the legacy directory into their new homes and
+// returns the destinations that were written. It must run before Ensure so a
+// legacy cache directory can be moved as a whole. Existing files are never
+// overwritten; anything left behind keeps the legacy directory in place.
+func Migrate(legacy string, p Paths) ([]string, error) {
+	entries, err := os.ReadDir(legacy)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var moved []string
+	for _, entry := range entries {
+		dst := p.destination(entry)
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return moved, err
+		}
+		if err := os.Rename(filepath.Join(legacy, entry.Name()), dst); err != nil {
+			return moved, fmt.Errorf("migrating %s: %w", entry.Name(), err)
+		}
+		moved = append(moved, dst)
+	}
+
+	// Only succeeds once everything has been moved out.
+	os.Remove(legacy)
+	return moved, nil
+}
+
+func (p Paths) destination(entry fs.DirEntry) string {
+	name := entry.Name()
+	switch {
+	case entry.IsDir() && name == "cache":
+		return p.Cache
+	case strings.HasPrefix(name, "config."):
+		return filepath.Join(p.Config, name)
+	case strings.HasSuffix(name, ".log"):
+		return filepath.Join(p.Logs, name)
+	default:
+		return filepath.Join(p.Data, name)
+	}
+}
@@ -0,0 +1,91 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	env := map[string]string{
+		"XDG_CONFIG_HOME": "/xdg/config",
+		"XDG_CACHE_HOME":  "relative/cache",
+		"APPDATA":         `C:\Users\ash\AppData\Roaming`,
+	}
+	getenv := func(key string) string { return env[key] }
+
+	cases := []struct {
+		goos string
+		want Paths
+	}{
+		{
+			goos: "linux",
+			want: Paths{
+				Config: "/xdg/config/pokedexcli",
+				Data:   "/home/ash/.local/share/pokedexcli",
+				Cache:  "/home/ash/.cache/pokedexcli",
+				Logs:   "/home/ash/.local/state/pokedexcli/logs",
+			},
+		},
+		{
+			goos: "darwin",
+			want: Paths{
+				Config: "/home/ash/Library/Application Support/pokedexcli",
+				Data:   "/home/ash/Library/Application Support/pokedexcli/data",
+				Cache:  "/home/ash/Library/Caches/pokedexcli",
+				Logs:   "/home/ash/Library/Logs/pokedexcli",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.goos, func(t *testing.T) {
+			got := resolve(c.goos, getenv, "/home/ash")
+			if got != c.want {
+				t.Errorf("expected %+v, got %+v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	root := t.TempDir()
+	legacy := filepath.Join(root, ".pokedexcli")
+	p := Paths{
+		Config: filepath.Join(root, "config"),
+		Data:   filepath.Join(root, "data"),
+		Cache:  filepath.Join(root, "cache"),
+		Logs:   filepath.Join(root, "logs"),
+	}
+
+	files := []string{"config.json", "pokedex.json", "session.log", "cache/entry"}
+	for _, name := range files {
+		path := filepath.Join(legacy, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moved, err := Migrate(legacy, p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(moved) != 4 {
+		t.Errorf("expected 4 moved entries, got %v", moved)
+	}
+
+	for _, path := range []string{
+		filepath.Join(p.Config, "config.json"),
+		filepath.Join(p.Data, "pokedex.json"),
+		filepath.Join(p.Logs, "session.log"),
+		filepath.Join(p.Cache, "entry"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to exist", path)
+		}
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("expected legacy directory to be removed")
+	}
+}
@@ -0,0 +1,119 @@
+// Package photo composes text cards of caught Pokémon and stores them in an
+// album directory.
+package photo
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/validate"
+)
+
+type Stat struct {
+	Name  string
+	Value int
+}
+
+type Subject struct {
+	Name     string
+	Types    []string
+	Stats    []Stat
+	CaughtAt time.Time
+	// Ribbons are listed under the stats, by name.
+	Ribbons []string
+	// Art is drawn at the top of the card when present, one line per row.
+	Art []string
+}
+
+// Card renders the subject inside a box.
+func Card(s Subject, takenAt time.Time) string {
+	lines := append([]string(nil), s.Art...)
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, strings.ToUpper(s.Name))
+	if len(s.Types) > 0 {
+		lines = append(lines, "Type: "+strings.Join(s.Types, "/"))
+	} else {
+		lines = append(lines, "Type: "+missing.NoData)
+	}
+	for _, stat := range s.Stats {
+		lines = append(lines, fmt.Sprintf("%-16s %4s", stat.Name, missing.Number(stat.Value)))
+	}
+	if len(s.Ribbons) > 0 {
+		lines = append(lines, "")
+		for _, r := range s.Ribbons {
+			lines = append(lines, "★ "+r)
+		}
+	}
+	lines = append(lines, "")
+	if !s.CaughtAt.IsZero() {
+		lines = append(lines, "Caught "+s.CaughtAt.Format("2006-01-02"))
+	}
+	lines = append(lines, "Photo taken "+takenAt.Format("2006-01-02 15:04"))
+
+	width := 0
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line); n > width {
+			width = n
+		}
+	}
+
+	var b strings.Builder
+	border := "+" + strings.Repeat("-", width+2) + "+\n"
+	b.WriteString(border)
+	for _, line := range lines {
+		pad := width - utf8.RuneCountInString(line)
+		fmt.Fprintf(&b, "| %s%s |\n", line, strings.Repeat(" ", pad))
+	}
+	b.WriteString(border)
+	return b.String()
+}
+
+// Save writes a card into dir and returns its file name.
+func Save(dir, name, card string, takenAt time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	file := fmt.Sprintf("%s-%s.txt", name, takenAt.Format("20060102-150405"))
+	path, err := validate.Within(dir, file)
+	if err != nil {
+		return "", err
+	}
+	return file, os.WriteFile(path, []byte(card), 0o644)
+}
+
+// List returns the cards in dir, oldest first.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".txt") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return stamp(files[i]) < stamp(files[j])
+	})
+	return files, nil
+}
+
+// stamp returns the timestamp part of a card's file name.
+func stamp(file string) string {
+	name := strings.TrimSuffix(file, ".txt")
+	if len(name) < len("20060102-150405") {
+		return name
+	}
+	return name[len(name)-len("20060102-150405"):]
+}
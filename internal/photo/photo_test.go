@@ -0,0 +1,51 @@
+package photo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCard(t *testing.T) {
+	takenAt := time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC)
+	card := Card(Subject{
+		Name:     "pikachu",
+		Types:    []string{"electric"},
+		Stats:    []Stat{{Name: "hp", Value: 35}, {Name: "speed", Value: 90}},
+		CaughtAt: takenAt.Add(-24 * time.Hour),
+		Ribbons:  []string{"Tower Ribbon"},
+	}, takenAt)
+
+	for _, want := range []string{"PIKACHU", "Type: electric", "speed", "★ Tower Ribbon", "Caught 2024-05-01", "Photo taken 2024-05-02 18:30"} {
+		if !strings.Contains(card, want) {
+			t.Errorf("expected card to contain %q:\n%s", want, card)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(card, "\n"), "\n")
+	for _, line := range lines {
+		if len([]rune(line)) != len([]rune(lines[0])) {
+			t.Errorf("expected every line to have the same width:\n%s", card)
+			break
+		}
+	}
+}
+
+func TestSaveAndList(t *testing.T) {
+	dir := t.TempDir()
+	first := time.Date(2024, 5, 2, 18, 30, 0, 0, time.UTC)
+	if _, err := Save(dir, "zubat", "card", first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := Save(dir, "abra", "card", first.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, err := List(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 || !strings.HasPrefix(files[0], "zubat") {
+		t.Errorf("expected photos in the order they were taken, got %v", files)
+	}
+}
@@ -0,0 +1,319 @@
+package pokeapi
+
+import (
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/evolution"
+	"github.com/eymardfreire/pokedexcli/internal/moves"
+)
+
+type NamedResource = moves.NamedResource
+
+// LocationAreaList is one page of location areas.
+type LocationAreaList struct {
+	Results  []NamedResource `json:"results"`
+	Next     string          `json:"next"`
+	Previous string          `json:"previous"`
+}
+
+type LocationArea struct {
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	GameIndex int           `json:"game_index"`
+	Location  NamedResource `json:"location"`
+	// EncounterMethodRates are how often each way of meeting Pokémon, like
+	// walking through tall grass, turns one up, in each version.
+	EncounterMethodRates []EncounterMethodRate `json:"encounter_method_rates"`
+	PokemonEncounters    []PokemonEncounter    `json:"pokemon_encounters"`
+}
+
+// EncounterMethodRate is how often one method of meeting Pokémon in an
+// area turns one up.
+type EncounterMethodRate struct {
+	EncounterMethod NamedResource       `json:"encounter_method"`
+	VersionDetails  []EncounterRateInfo `json:"version_details"`
+}
+
+// EncounterRateInfo is the percent chance of an encounter happening each
+// time a method is tried, like each step in tall grass, in one version.
+type EncounterRateInfo struct {
+	Rate    int           `json:"rate"`
+	Version NamedResource `json:"version"`
+}
+
+type PokemonEncounter struct {
+	Pokemon        NamedResource      `json:"pokemon"`
+	VersionDetails []EncounterVersion `json:"version_details"`
+}
+
+// EncounterVersion is how a Pokémon is met in one version of the games.
+type EncounterVersion struct {
+	Version NamedResource `json:"version"`
+	// MaxChance is the percent chance of meeting the Pokémon in this
+	// version, by any method.
+	MaxChance        int               `json:"max_chance"`
+	EncounterDetails []EncounterDetail `json:"encounter_details"`
+}
+
+type EncounterDetail struct {
+	MinLevel int           `json:"min_level"`
+	MaxLevel int           `json:"max_level"`
+	Chance   int           `json:"chance"`
+	Method   NamedResource `json:"method"`
+	// ConditionValues must hold for the encounter to happen, like
+	// time-night or swarm-yes; there are none for most.
+	ConditionValues []NamedResource `json:"condition_values"`
+}
+
+type Location struct {
+	Name   string          `json:"name"`
+	Region NamedResource   `json:"region"`
+	Areas  []NamedResource `json:"areas"`
+}
+
+type Region struct {
+	Name      string          `json:"name"`
+	Locations []NamedResource `json:"locations"`
+}
+
+type Pokemon struct {
+	Name           string    `json:"name"`
+	BaseExperience int       `json:"base_experience"`
+	Height         int       `json:"height"`
+	Weight         int       `json:"weight"`
+	Stats          []Stat    `json:"stats"`
+	Types          []Type    `json:"types"`
+	Abilities      []Ability `json:"abilities"`
+	// Moves is every move the species can learn.
+	Moves   []moves.Learnable `json:"moves"`
+	Cries   Cries             `json:"cries"`
+	Sprites Sprites           `json:"sprites"`
+}
+
+// Sprites link to pictures of the Pokémon as PNG files, in its usual
+// colors and as a shiny.
+type Sprites struct {
+	FrontDefault string `json:"front_default"`
+	FrontShiny   string `json:"front_shiny,omitempty"`
+}
+
+// Cries link to the Pokémon's cry as an Ogg file: as it sounds in the
+// latest games, and in the oldest ones it appeared in.
+type Cries struct {
+	Latest string `json:"latest"`
+	Legacy string `json:"legacy"`
+}
+
+type Stat struct {
+	BaseStat int           `json:"base_stat"`
+	Stat     NamedResource `json:"stat"`
+}
+
+type Ability struct {
+	Ability  NamedResource `json:"ability"`
+	IsHidden bool          `json:"is_hidden"`
+}
+
+// AbilityDetails is the payload of /ability/{name}.
+type AbilityDetails struct {
+	Name          string        `json:"name"`
+	EffectEntries []EffectEntry `json:"effect_entries"`
+}
+
+// EffectEntry describes what an ability does in one language, at length
+// and in short.
+type EffectEntry struct {
+	Effect      string        `json:"effect"`
+	ShortEffect string        `json:"short_effect"`
+	Language    NamedResource `json:"language"`
+}
+
+// ShortEffect is what the ability does in a language, like "en", in a
+// sentence, or "".
+func (a AbilityDetails) ShortEffect(lang string) string {
+	return shortEffect(a.EffectEntries, lang)
+}
+
+// ItemDetails is the payload of /item/{name}.
+type ItemDetails struct {
+	Name          string        `json:"name"`
+	Cost          int           `json:"cost"`
+	Category      NamedResource `json:"category"`
+	EffectEntries []EffectEntry `json:"effect_entries"`
+}
+
+// ShortEffect is what the item does in a language, like "en", in a
+// sentence, or "".
+func (i ItemDetails) ShortEffect(lang string) string {
+	return shortEffect(i.EffectEntries, lang)
+}
+
+func shortEffect(entries []EffectEntry, lang string) string {
+	for _, e := range entries {
+		if e.Language.Name == lang {
+			return strings.Join(strings.Fields(e.ShortEffect), " ")
+		}
+	}
+	return ""
+}
+
+type Type struct {
+	Type NamedResource `json:"type"`
+}
+
+// TypeDetails is the payload of /type/{name}.
+type TypeDetails struct {
+	Name            string          `json:"name"`
+	DamageRelations DamageRelations `json:"damage_relations"`
+}
+
+// DamageRelations are the types a type's attacks are strong and weak
+// against (To), and the types whose attacks are strong and weak against
+// Pokémon of the type (From).
+type DamageRelations struct {
+	DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+	HalfDamageTo     []NamedResource `json:"half_damage_to"`
+	NoDamageTo       []NamedResource `json:"no_damage_to"`
+	DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+	HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+	NoDamageFrom     []NamedResource `json:"no_damage_from"`
+}
+
+type Species struct {
+	Name           string        `json:"name"`
+	CaptureRate    int           `json:"capture_rate"`
+	EvolutionChain NamedResource `json:"evolution_chain"`
+	// Habitat is nil for species introduced after habitats stopped being
+	// recorded.
+	Habitat           *NamedResource `json:"habitat"`
+	IsLegendary       bool           `json:"is_legendary"`
+	IsMythical        bool           `json:"is_mythical"`
+	Genera            []Genus        `json:"genera"`
+	FlavorTextEntries []FlavorText   `json:"flavor_text_entries"`
+}
+
+// Genus is what kind of Pokémon a species is, like "Mouse Pokémon", in
+// one language.
+type Genus struct {
+	Genus    string        `json:"genus"`
+	Language NamedResource `json:"language"`
+}
+
+// FlavorText is a species' Pokédex entry in one game and language.
+type FlavorText struct {
+	FlavorText string        `json:"flavor_text"`
+	Language   NamedResource `json:"language"`
+	Version    NamedResource `json:"version"`
+}
+
+// Genus is the species' genus in a language, like "en", or "".
+func (s Species) Genus(lang string) string {
+	for _, g := range s.Genera {
+		if g.Language.Name == lang {
+			return g.Genus
+		}
+	}
+	return ""
+}
+
+// Description is the species' latest Pokédex entry in a language, as one
+// line, or "". The games' entries are broken into lines and pages for
+// their screens.
+func (s Species) Description(lang string) string {
+	for i := len(s.FlavorTextEntries) - 1; i >= 0; i-- {
+		if entry := s.FlavorTextEntries[i]; entry.Language.Name == lang {
+			return strings.Join(strings.Fields(strings.ReplaceAll(entry.FlavorText, "\u00ad\n", "")), " ")
+		}
+	}
+	return ""
+}
+
+// ListLocationAreas returns the page of location areas at url, or the
+// first page if url is empty. Pages link to each other with Next and
+// Previous.
+func (c *Client) ListLocationAreas(url string) (LocationAreaList, error) {
+	if url == "" {
+		url = c.baseURL() + "/location-area/"
+	}
+	var list LocationAreaList
+	err := c.getJSON(url, &list)
+	return list, err
+}
+
+// List returns every resource of a kind, like List("pokemon"), in one
+// request.
+func (c *Client) List(resource string) ([]NamedResource, error) {
+	var list struct {
+		Results []NamedResource `json:"results"`
+	}
+	err := c.getJSON(c.baseURL()+"/"+resource+"/?limit=100000", &list)
+	return list.Results, err
+}
+
+func (c *Client) GetLocationArea(name string) (LocationArea, error) {
+	var area LocationArea
+	err := c.getJSON(c.URL("location-area", name), &area)
+	return area, err
+}
+
+func (c *Client) GetLocation(name string) (Location, error) {
+	var location Location
+	err := c.getJSON(c.URL("location", name), &location)
+	return location, err
+}
+
+func (c *Client) GetRegion(name string) (Region, error) {
+	var region Region
+	err := c.getJSON(c.URL("region", name), &region)
+	return region, err
+}
+
+func (c *Client) GetPokemon(name string) (Pokemon, error) {
+	var pokemon Pokemon
+	err := c.getJSON(c.URL("pokemon", name), &pokemon)
+	return pokemon, err
+}
+
+func (c *Client) GetSpecies(name string) (Species, error) {
+	var species Species
+	err := c.getJSON(c.URL("pokemon-species", name), &species)
+	return species, err
+}
+
+func (c *Client) GetMove(name string) (moves.Move, error) {
+	var move moves.Move
+	err := c.getJSON(c.URL("move", name), &move)
+	return move, err
+}
+
+func (c *Client) GetType(name string) (TypeDetails, error) {
+	var t TypeDetails
+	err := c.getJSON(c.URL("type", name), &t)
+	return t, err
+}
+
+func (c *Client) GetAbility(name string) (AbilityDetails, error) {
+	var ability AbilityDetails
+	err := c.getJSON(c.URL("ability", name), &ability)
+	return ability, err
+}
+
+func (c *Client) GetItem(name string) (ItemDetails, error) {
+	var item ItemDetails
+	err := c.getJSON(c.URL("item", name), &item)
+	return item, err
+}
+
+// GetMachine follows a link to a machine, which PokeAPI only gives by URL.
+func (c *Client) GetMachine(url string) (moves.Machine, error) {
+	var machine moves.Machine
+	err := c.getJSON(url, &machine)
+	return machine, err
+}
+
+// GetEvolutionChain follows a species' link to its evolution chain.
+func (c *Client) GetEvolutionChain(url string) (evolution.Chain, error) {
+	var chain evolution.Chain
+	err := c.getJSON(url, &chain)
+	return chain, err
+}
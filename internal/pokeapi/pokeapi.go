@@ -0,0 +1,281 @@
+// Package pokeapi is a client for the parts of PokeAPI the game uses.
+// Responses are cached, so asking for the same resource twice only fetches
+// it once, even when both ask at the same time.
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const BaseURL = "https://pokeapi.co/api/v2"
+
+// ErrNotFound means PokeAPI has no such resource, usually because of a
+// misspelt name.
+var ErrNotFound = errors.New("not found")
+
+// ErrOffline means the client is offline and has no copy of a resource.
+var ErrOffline = errors.New("not available offline")
+
+// Cache stores response bodies by URL.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, val []byte)
+}
+
+// Fetcher is a Cache that fills missing entries itself, fetching each once
+// for everyone who asks for it at the same time.
+type Fetcher interface {
+	Cache
+	GetOrFetch(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error)
+}
+
+// Store holds resources by "<resource>/<name>", like "pokemon/pikachu".
+type Store interface {
+	Get(key string) ([]byte, bool)
+}
+
+// Client is safe for concurrent use as long as its Cache is, and it is
+// changed with SetCache.
+type Client struct {
+	// BaseURL is where the API lives, without a trailing slash. Change it
+	// with SetBaseURL.
+	BaseURL string
+	// HTTP makes the requests. Change its timeout with SetTimeout.
+	HTTP *http.Client
+	// Cache may be nil, to fetch everything afresh. If it is a Fetcher,
+	// concurrent requests for the same URL share one fetch.
+	Cache Cache
+	// Snapshot is a directory of resources saved as <resource>/<name>.json,
+	// like mirror writes, for when the client is offline.
+	Snapshot string
+	// Pack holds the snapshot in a single file instead, as `mirror pack`
+	// writes it. It is looked in before Snapshot. Change it with SetPack.
+	Pack Store
+	// Local holds resources PokeAPI doesn't have, like homebrew species.
+	// It is looked in before anything else, online or off, and never
+	// cached. Change it with SetLocal.
+	Local Store
+
+	mu      sync.Mutex
+	ctx     context.Context
+	offline atomic.Bool
+}
+
+// NewClient returns a client for PokeAPI that gives up on requests after
+// timeout, retries included. Its requests go through a Transport.
+func NewClient(cache Cache, timeout time.Duration) *Client {
+	return &Client{BaseURL: BaseURL, HTTP: &http.Client{Timeout: timeout, Transport: NewTransport(nil)}, Cache: cache}
+}
+
+// SetBaseURL points requests from now on at the API at url, even while
+// other requests are under way.
+func (c *Client) SetBaseURL(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.BaseURL = url
+}
+
+// baseURL is BaseURL, read under the lock.
+func (c *Client) baseURL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.BaseURL
+}
+
+// SetCache replaces the cache, even while other requests are under way.
+func (c *Client) SetCache(cache Cache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Cache = cache
+}
+
+// SetPack replaces the pack, even while other requests are under way.
+func (c *Client) SetPack(pack Store) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Pack = pack
+}
+
+// SetLocal replaces the local resources, even while other requests are
+// under way.
+func (c *Client) SetLocal(local Store) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Local = local
+}
+
+// SetTimeout makes requests from now on give up after timeout, even while
+// other requests are under way. Those keep the timeout they started with.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	client := *c.HTTP
+	client.Timeout = timeout
+	c.HTTP = &client
+}
+
+// SetContext makes requests from now on give up when ctx is done; nil
+// means they only give up after the HTTP client's timeout. Prefetches are
+// left to finish regardless.
+func (c *Client) SetContext(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ctx = ctx
+}
+
+// Get returns the body at url, from the cache when possible.
+func (c *Client) Get(url string) ([]byte, error) {
+	c.mu.Lock()
+	ctx := c.ctx
+	c.mu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.GetContext(ctx, url)
+}
+
+// GetContext is Get, giving up when ctx is done rather than the client's
+// context.
+func (c *Client) GetContext(ctx context.Context, url string) ([]byte, error) {
+	c.mu.Lock()
+	cache, local, base := c.Cache, c.Local, c.BaseURL
+	c.mu.Unlock()
+	if local != nil {
+		if data, ok := local.Get(strings.TrimSuffix(strings.TrimPrefix(url, base+"/"), "/")); ok {
+			return data, nil
+		}
+	}
+	switch cache := cache.(type) {
+	case nil:
+		return c.fetch(ctx, url)
+	case Fetcher:
+		return cache.GetOrFetch(ctx, url, func(ctx context.Context) ([]byte, error) { return c.fetch(ctx, url) })
+	}
+	if data, ok := cache.Get(url); ok {
+		return data, nil
+	}
+	body, err := c.fetch(ctx, url)
+	if err == nil {
+		cache.Add(url, body)
+	}
+	return body, err
+}
+
+// Prefetch fetches urls into the cache in the background, workers at a
+// time, skipping empty ones. Errors are dropped; whoever asks for the
+// resource later meets them again.
+func (c *Client) Prefetch(workers int, urls ...string) {
+	urls = slices.DeleteFunc(slices.Clone(urls), func(url string) bool { return url == "" })
+	c.mu.Lock()
+	cached := c.Cache != nil
+	c.mu.Unlock()
+	if !cached || len(urls) == 0 {
+		return
+	}
+	queue := make(chan string)
+	for i := 0; i < min(workers, len(urls)); i++ {
+		go func() {
+			for url := range queue {
+				c.GetContext(context.Background(), url)
+			}
+		}()
+	}
+	go func() {
+		for _, url := range urls {
+			queue <- url
+		}
+		close(queue)
+	}()
+}
+
+// SetOffline takes the client off the network, or back on. Offline, it
+// only answers from its cache and Snapshot.
+func (c *Client) SetOffline(offline bool) {
+	c.offline.Store(offline)
+}
+
+func (c *Client) Offline() bool {
+	return c.offline.Load()
+}
+
+// fetch gets url from the API itself, or from the snapshot when offline.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	if c.Offline() {
+		return c.fromSnapshot(url)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	client := c.HTTP
+	c.mu.Unlock()
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", strings.TrimPrefix(url, c.baseURL()+"/"), ErrNotFound)
+	case response.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%s: %s", url, response.Status)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Only what the API itself answered may be cached, not a captive
+	// portal's page or a cut-off download.
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("%s: the response isn't JSON", url)
+	}
+	return body, nil
+}
+
+// fromSnapshot reads a named resource from the pack or the snapshot.
+// Lists and anything else are never in them.
+func (c *Client) fromSnapshot(url string) ([]byte, error) {
+	c.mu.Lock()
+	pack, base := c.Pack, c.BaseURL
+	c.mu.Unlock()
+	path := strings.TrimSuffix(strings.TrimPrefix(url, base+"/"), "/")
+	resource, name, ok := strings.Cut(path, "/")
+	if ok && pack != nil && !strings.ContainsAny(name, "/?") {
+		if data, found := pack.Get(resource + "/" + name); found {
+			return data, nil
+		}
+	}
+	if ok && c.Snapshot != "" && !strings.ContainsAny(name, "/?") {
+		data, err := os.ReadFile(filepath.Join(c.Snapshot, resource, name+".json"))
+		if err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", path, ErrOffline)
+}
+
+func (c *Client) getJSON(url string, v any) error {
+	data, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// URL is the address of a named resource, like URL("pokemon", "pikachu").
+func (c *Client) URL(resource, name string) string {
+	return fmt.Sprintf("%s/%s/%s/", c.baseURL(), resource, name)
+}
@@ -0,0 +1,366 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/pokecache"
+)
+
+type mapCache map[string][]byte
+
+func (m mapCache) Get(key string) ([]byte, bool) {
+	val, ok := m[key]
+	return val, ok
+}
+
+func (m mapCache) Add(key string, val []byte) {
+	m[key] = val
+}
+
+func testClient(t *testing.T, hits *int) *Client {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*hits++
+		if r.URL.Path != "/pokemon/pikachu/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"name": "pikachu", "base_experience": 112, "types": [{"slot": 1, "type": {"name": "electric"}}]}`)
+	}))
+	t.Cleanup(ts.Close)
+	c := NewClient(mapCache{}, time.Second)
+	c.BaseURL = ts.URL
+	return c
+}
+
+func TestGetPokemon(t *testing.T) {
+	hits := 0
+	c := testClient(t, &hits)
+	for i := 0; i < 2; i++ {
+		pokemon, err := c.GetPokemon("pikachu")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pokemon.Name != "pikachu" || pokemon.BaseExperience != 112 || pokemon.Types[0].Type.Name != "electric" {
+			t.Errorf("got %+v", pokemon)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("fetched %d times, want 1", hits)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	hits := 0
+	c := testClient(t, &hits)
+	_, err := c.GetPokemon("pikachoo")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+	if err.Error() != "pokemon/pikachoo/: not found" {
+		t.Errorf("got %q", err)
+	}
+}
+
+func TestConcurrentGets(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		fmt.Fprint(w, `{"name": "pikachu"}`)
+	}))
+	defer ts.Close()
+	c := NewClient(pokecache.NewCache(time.Minute), time.Second)
+	c.BaseURL = ts.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if pokemon, err := c.GetPokemon("pikachu"); err != nil || pokemon.Name != "pikachu" {
+				t.Errorf("got %+v, %v", pokemon, err)
+			}
+		}()
+	}
+	// Let every request start before the first one is answered.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if hits.Load() != 1 {
+		t.Errorf("fetched %d times, want 1", hits.Load())
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprintf(w, `{"name": %q}`, r.URL.Path)
+	}))
+	defer ts.Close()
+	cache := pokecache.NewCache(time.Minute)
+	c := NewClient(cache, time.Second)
+	c.BaseURL = ts.URL
+
+	urls := []string{c.URL("pokemon", "a"), c.URL("pokemon", "b"), c.URL("pokemon", "c")}
+	c.Prefetch(2, urls...)
+	deadline := time.Now().Add(time.Second)
+	for _, url := range urls {
+		for time.Now().Before(deadline) {
+			if _, ok := cache.Get(url); ok {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if _, err := c.Get(url); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits.Load() != 3 {
+		t.Errorf("fetched %d times, want 3", hits.Load())
+	}
+}
+
+func TestOffline(t *testing.T) {
+	hits := 0
+	c := testClient(t, &hits)
+	c.Snapshot = t.TempDir()
+	if err := os.MkdirAll(filepath.Join(c.Snapshot, "pokemon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(c.Snapshot, "pokemon", "zubat.json"), []byte(`{"name": "zubat"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetPokemon("pikachu"); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetOffline(true)
+	if _, err := c.GetPokemon("pikachu"); err != nil {
+		t.Errorf("expected the cached pikachu offline, got %v", err)
+	}
+	if p, err := c.GetPokemon("zubat"); err != nil || p.Name != "zubat" {
+		t.Errorf("expected zubat from the snapshot, got %q, %v", p.Name, err)
+	}
+	if _, err := c.GetPokemon("golbat"); !errors.Is(err, ErrOffline) {
+		t.Errorf("expected ErrOffline, got %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("expected no requests offline, got %d", hits-1)
+	}
+}
+
+// store is a Store in memory.
+type store map[string][]byte
+
+func (s store) Get(key string) ([]byte, bool) {
+	data, ok := s[key]
+	return data, ok
+}
+
+func TestOfflinePack(t *testing.T) {
+	hits := 0
+	c := testClient(t, &hits)
+	c.Pack = store{"pokemon/zubat": []byte(`{"name": "zubat"}`)}
+	c.SetOffline(true)
+	if p, err := c.GetPokemon("zubat"); err != nil || p.Name != "zubat" {
+		t.Errorf("expected zubat from the pack, got %q, %v", p.Name, err)
+	}
+	if _, err := c.GetPokemon("golbat"); !errors.Is(err, ErrOffline) {
+		t.Errorf("expected ErrOffline, got %v", err)
+	}
+	if hits != 0 {
+		t.Errorf("expected no requests offline, got %d", hits)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	hits := 0
+	c := testClient(t, &hits)
+	c.SetLocal(store{"pokemon/custom/voltorbeast": []byte(`{"name": "custom/voltorbeast"}`)})
+	for _, offline := range []bool{false, true} {
+		c.SetOffline(offline)
+		if p, err := c.GetPokemon("custom/voltorbeast"); err != nil || p.Name != "custom/voltorbeast" {
+			t.Errorf("expected voltorbeast from the local resources, got %q, %v", p.Name, err)
+		}
+	}
+	if hits != 0 {
+		t.Errorf("expected no requests for local resources, got %d", hits)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch hits.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		case 2:
+			http.Error(w, "<html>oops</html>", http.StatusBadGateway)
+		default:
+			fmt.Fprint(w, `{"name": "pikachu"}`)
+		}
+	}))
+	defer ts.Close()
+	cache := mapCache{}
+	c := NewClient(cache, time.Second)
+	c.BaseURL = ts.URL
+	c.HTTP.Transport = &Transport{Retries: 2, Backoff: time.Millisecond, MaxWait: time.Millisecond}
+
+	pokemon, err := c.GetPokemon("pikachu")
+	if err != nil || pokemon.Name != "pikachu" || hits.Load() != 3 {
+		t.Fatalf("expected pikachu on the third try, got %+v, %v after %d", pokemon, err, hits.Load())
+	}
+
+	hits.Store(0)
+	c.HTTP.Transport = &Transport{Retries: 1, Backoff: time.Millisecond, MaxWait: time.Millisecond}
+	if _, err := c.GetPokemon("zubat"); err == nil || hits.Load() != 2 {
+		t.Fatalf("expected giving up after one retry, got %v after %d", err, hits.Load())
+	}
+	if len(cache) != 1 {
+		t.Errorf("expected only pikachu cached, got %d entries", len(cache))
+	}
+}
+
+func TestNotJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html>Sign in to the Wi-Fi</html>")
+	}))
+	defer ts.Close()
+	cache := mapCache{}
+	c := NewClient(cache, time.Second)
+	c.BaseURL = ts.URL
+	if _, err := c.GetPokemon("pikachu"); err == nil || len(cache) != 0 {
+		t.Errorf("expected an error and nothing cached, got %v and %d entries", err, len(cache))
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, `{"name": "pikachu"}`)
+	}))
+	defer ts.Close()
+	c := NewClient(nil, time.Second)
+	c.BaseURL = ts.URL
+
+	// A request under way keeps the timeout it started with.
+	slow := make(chan error)
+	go func() {
+		_, err := c.GetPokemon("pikachu")
+		slow <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	c.SetTimeout(50 * time.Millisecond)
+	if err := <-slow; err != nil {
+		t.Errorf("expected the request started before the change to finish, got %v", err)
+	}
+	if _, err := c.GetPokemon("pikachu"); err == nil {
+		t.Error("expected a request after the change to time out")
+	}
+}
+
+func TestSetBaseURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "pikachu"}`)
+	}))
+	defer ts.Close()
+	c := NewClient(nil, time.Second)
+
+	// The address changes while it is read, as it is by prefetches.
+	changed := make(chan struct{})
+	go func() {
+		c.SetBaseURL(ts.URL)
+		close(changed)
+	}()
+	c.URL("pokemon", "pikachu")
+	<-changed
+	if pokemon, err := c.GetPokemon("pikachu"); err != nil || pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu from the new address, got %+v, %v", pokemon, err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+	cache := mapCache{}
+	c := NewClient(cache, time.Minute)
+	c.BaseURL = ts.URL
+	ctx, cancel := context.WithCancel(context.Background())
+	c.SetContext(ctx)
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := c.GetPokemon("pikachu"); !errors.Is(err, context.Canceled) || len(cache) != 0 {
+		t.Errorf("expected the request to be cancelled and nothing cached, got %v and %d entries", err, len(cache))
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the request to give up once cancelled, took %v", time.Since(start))
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	tr := &Transport{Every: 10 * time.Millisecond}
+	var waits []time.Duration
+	for i := 0; i < 3; i++ {
+		waits = append(waits, tr.slot())
+	}
+	if waits[0] != 0 || waits[2] < 15*time.Millisecond {
+		t.Errorf("expected requests spaced 10ms apart, got waits %v", waits)
+	}
+}
+
+func TestSpeciesText(t *testing.T) {
+	var s Species
+	data := `{
+		"genera": [{"genus": "Mäusepokémon", "language": {"name": "de"}}, {"genus": "Mouse Pokémon", "language": {"name": "en"}}],
+		"flavor_text_entries": [
+			{"flavor_text": "Old entry.", "language": {"name": "en"}, "version": {"name": "red"}},
+			{"flavor_text": "It stores elec­\ntricity in its\fcheeks.", "language": {"name": "en"}, "version": {"name": "sword"}},
+			{"flavor_text": "Speichert Strom.", "language": {"name": "de"}, "version": {"name": "sword"}}
+		]
+	}`
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Genus("en"); got != "Mouse Pokémon" {
+		t.Errorf("got genus %q", got)
+	}
+	if got := s.Description("en"); got != "It stores electricity in its cheeks." {
+		t.Errorf("got description %q", got)
+	}
+	if got := s.Description("fr"); got != "" {
+		t.Errorf("expected no French entry, got %q", got)
+	}
+}
+
+func TestAbilityEffect(t *testing.T) {
+	data := `{"name": "static", "effect_entries": [
+		{"short_effect": "Has a 30% chance of paralyzing attacking Pokémon on contact.", "language": {"name": "en"}},
+		{"short_effect": "Kann bei Berührung paralysieren.", "language": {"name": "de"}}
+	]}`
+	var a AbilityDetails
+	if err := json.Unmarshal([]byte(data), &a); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.ShortEffect("en"); got != "Has a 30% chance of paralyzing attacking Pokémon on contact." {
+		t.Errorf("got effect %q", got)
+	}
+	if got := a.ShortEffect("fr"); got != "" {
+		t.Errorf("expected no French effect, got %q", got)
+	}
+}
@@ -0,0 +1,133 @@
+package pokeapi
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limits NewClient's clients keep to.
+const (
+	retries = 3
+	backoff = 500 * time.Millisecond
+	maxWait = 10 * time.Second
+	// perSecond is the most requests a client makes in a second, well
+	// within PokeAPI's fair use.
+	perSecond = 20
+)
+
+// Transport spaces requests out to one every Every, and retries those
+// that fail for now: ones PokeAPI turns away with 429 or a 5xx error, and
+// ones that never got an answer. Retries wait Backoff, doubling each time,
+// or as long as the Retry-After header asks, but never more than MaxWait.
+type Transport struct {
+	// Base makes the requests; nil means http.DefaultTransport.
+	Base    http.RoundTripper
+	Retries int
+	Backoff time.Duration
+	MaxWait time.Duration
+	Every   time.Duration
+
+	mu sync.Mutex
+	// next is when the next request may go out.
+	next time.Time
+}
+
+// NewTransport returns a transport with the limits NewClient uses.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base, Retries: retries, Backoff: backoff, MaxWait: maxWait, Every: time.Second / perSecond}
+}
+
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wait := t.Backoff
+	for try := 0; ; try++ {
+		if err := t.sleep(r, t.slot()); err != nil {
+			return nil, err
+		}
+		response, err := base.RoundTrip(r)
+		// Only requests without a body can be sent again as they were.
+		if try >= t.Retries || r.Body != nil && r.Body != http.NoBody || !retryable(response, err) || r.Context().Err() != nil {
+			return response, err
+		}
+		delay := wait
+		if response != nil {
+			if after, ok := retryAfter(response); ok {
+				delay = after
+			}
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		if err := t.sleep(r, min(delay, t.MaxWait)); err != nil {
+			return nil, err
+		}
+		wait *= 2
+	}
+}
+
+// slot books the next turn to make a request, and returns how long until
+// it comes.
+func (t *Transport) slot() time.Duration {
+	if t.Every <= 0 {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.Every)
+	return wait
+}
+
+// sleep waits for d, or until the request is cancelled.
+func (t *Transport) sleep(r *http.Request, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-r.Context().Done():
+		return r.Context().Err()
+	}
+}
+
+// retryable reports whether a request that got response, or err, may
+// succeed if tried again.
+func retryable(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads how long a response asks to wait before trying again,
+// given in seconds or as a date.
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	header := response.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
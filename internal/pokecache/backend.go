@@ -0,0 +1,52 @@
+package pokecache
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Backend opens a cache kept in dir, whose entries last ttl.
+type Backend func(dir string, ttl time.Duration) (Cache, error)
+
+var (
+	backendsMu sync.Mutex
+	backends   = map[string]Backend{
+		"disk": func(dir string, ttl time.Duration) (Cache, error) {
+			return NewDiskCache(dir, ttl), nil
+		},
+	}
+)
+
+// Register makes a backend available by name, usually from an init
+// function in a file of its own, so a cache kept in something like bolt
+// or SQLite can be used without changing how responses are fetched.
+func Register(name string, b Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = b
+}
+
+// Backends lists the names of the registered backends, in order.
+func Backends() []string {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	var names []string
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open opens a cache with the named backend.
+func Open(name, dir string, ttl time.Duration) (Cache, error) {
+	backendsMu.Lock()
+	b, ok := backends[name]
+	backendsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("there is no %s cache; try %v", name, Backends())
+	}
+	return b(dir, ttl)
+}
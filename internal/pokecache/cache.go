@@ -1,50 +1,209 @@
 package pokecache
 
 import (
+	"container/list"
+	"context"
 	"sync"
 	"time"
 )
 
 type cacheEntry struct {
+	key       string
 	createdAt time.Time
 	val       []byte
 }
 
-type Cache struct {
-	mu       sync.Mutex
-	entries  map[string]cacheEntry
-	interval time.Duration
+// call is a fetch under way. done is closed once val and err are set.
+// The fetch runs under a context of its own, cancelled once every caller
+// waiting for it has given up, so no one caller's context decides it;
+// waiters counts them, under the cache's lock.
+type call struct {
+	done    chan struct{}
+	val     []byte
+	err     error
+	cancel  context.CancelFunc
+	waiters int
 }
 
-func NewCache(interval time.Duration) *Cache {
-	c := &Cache{
-		entries:  make(map[string]cacheEntry),
+// Cache stores response bodies by key. MemoryCache and DiskCache are
+// Caches, and others, like one kept in a database, can be used in their
+// place; see Register.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, val []byte)
+	Delete(key string)
+	// Len is how many entries the cache holds.
+	Len() int
+}
+
+// MemoryCache keeps entries in memory for interval. With limits set, the
+// least recently used entries are evicted to stay within them.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru holds the entries, most recently used first.
+	lru        *list.List
+	calls      map[string]*call
+	interval   time.Duration
+	maxEntries int
+	maxBytes   int64
+	stats      Stats
+}
+
+// Stats are how a cache has done since it was made.
+type Stats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+	Entries   int
+	Bytes     int64
+}
+
+func NewCache(interval time.Duration) *MemoryCache {
+	c := &MemoryCache{
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		calls:    make(map[string]*call),
 		interval: interval,
 	}
 	go c.reapLoop()
 	return c
 }
 
-func (c *Cache) Add(key string, val []byte) {
+// SetLimits bounds how many entries the cache holds and how many bytes
+// their values take. Zero means no limit.
+func (c *MemoryCache) SetLimits(maxEntries int, maxBytes int64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.entries[key] = cacheEntry{
-		createdAt: time.Now(),
-		val:       val,
+	c.maxEntries, c.maxBytes = maxEntries, maxBytes
+	c.evict()
+}
+
+func (c *MemoryCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+func (c *MemoryCache) Add(key string, val []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(key, val)
+}
+
+func (c *MemoryCache) add(key string, val []byte) {
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
 	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, createdAt: time.Now(), val: val})
+	c.stats.Bytes += int64(len(val))
+	c.evict()
+}
+
+// evict removes the least recently used entries until the cache is within
+// its limits.
+func (c *MemoryCache) evict() {
+	for c.lru.Len() > 0 && (c.maxEntries > 0 && c.lru.Len() > c.maxEntries || c.maxBytes > 0 && c.stats.Bytes > c.maxBytes) {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *MemoryCache) remove(el *list.Element) {
+	entry := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.stats.Bytes -= int64(len(entry.val))
 }
 
-func (c *Cache) Get(key string) ([]byte, bool) {
+func (c *MemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	entry, ok := c.entries[key]
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key)
+}
+
+// get looks key up, counting a hit or a miss.
+func (c *MemoryCache) get(key string) ([]byte, bool) {
+	el, ok := c.entries[key]
 	if !ok {
+		c.stats.Misses++
 		return nil, false
 	}
-	return entry.val, true
+	c.stats.Hits++
+	c.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry).val, true
+}
+
+// GetOrFetch returns the entry for key, calling fetch to fill it if there
+// is none. Callers asking for key while fetch runs wait for it and share its
+// result, so it runs once. Errors aren't cached.
+//
+// Each caller stops waiting when its own ctx is done, returning ctx's
+// error. fetch is given a context that is only cancelled once every caller
+// has stopped waiting, so one caller giving up doesn't fail the others.
+func (c *MemoryCache) GetOrFetch(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	if val, ok := c.get(key); ok {
+		c.mu.Unlock()
+		return val, nil
+	}
+	cl, ok := c.calls[key]
+	if !ok {
+		fetchCtx, cancel := context.WithCancel(context.Background())
+		cl = &call{done: make(chan struct{}), cancel: cancel}
+		c.calls[key] = cl
+		go func() {
+			val, err := fetch(fetchCtx)
+			c.mu.Lock()
+			cl.val, cl.err = val, err
+			if c.calls[key] == cl {
+				delete(c.calls, key)
+			}
+			if err == nil {
+				c.add(key, val)
+			}
+			c.mu.Unlock()
+			cancel()
+			close(cl.done)
+		}()
+	}
+	cl.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-cl.done:
+		return cl.val, cl.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		// With no one left waiting, the fetch is called off, and whoever
+		// asks next starts another.
+		if cl.waiters--; cl.waiters == 0 {
+			cl.cancel()
+			if c.calls[key] == cl {
+				delete(c.calls, key)
+			}
+		}
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
 }
 
-func (c *Cache) reapLoop() {
+func (c *MemoryCache) reapLoop() {
 	ticker := time.NewTicker(c.interval)
 	defer ticker.Stop()
 	for range ticker.C {
@@ -52,13 +211,13 @@ func (c *Cache) reapLoop() {
 	}
 }
 
-func (c *Cache) reap() {
+func (c *MemoryCache) reap() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	now := time.Now()
-	for key, entry := range c.entries {
-		if now.Sub(entry.createdAt) > c.interval {
-			delete(c.entries, key)
+	for _, el := range c.entries {
+		if now.Sub(el.Value.(*cacheEntry).createdAt) > c.interval {
+			c.remove(el)
 		}
 	}
 }
@@ -1,7 +1,12 @@
 package pokecache
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -59,3 +64,228 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	NewDiskCache(dir, time.Hour).Add("https://example.com", []byte("testdata"))
+
+	// A new cache on the same directory, as after a restart.
+	disk := NewDiskCache(dir, time.Hour)
+	memory := NewCache(time.Hour)
+	layers := Layers{memory, disk}
+	if val, ok := layers.Get("https://example.com"); !ok || string(val) != "testdata" {
+		t.Fatalf("got %q, %t", val, ok)
+	}
+	if _, ok := memory.Get("https://example.com"); !ok {
+		t.Errorf("expected the disk entry to be copied into memory")
+	}
+
+	expired := NewDiskCache(dir, 0)
+	if _, ok := expired.Get("https://example.com"); ok {
+		t.Errorf("expected an expired entry to be missing")
+	}
+	if _, ok := disk.Get("https://example.com"); ok {
+		t.Errorf("expected the expired entry to be removed")
+	}
+
+	disk.Add("https://example.com/corrupt", []byte("x"))
+	if err := os.WriteFile(disk.path("https://example.com/corrupt"), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := disk.Get("https://example.com/corrupt"); ok {
+		t.Errorf("expected a corrupt entry to be missing")
+	}
+
+	disk.Add("https://example.com/fresh", []byte("x"))
+	os.WriteFile(disk.path("https://example.com/corrupt"), []byte("{not json"), 0o644)
+	if n, err := NewDiskCache(dir, time.Hour).Prune(); err != nil || n != 1 {
+		t.Errorf("expected to prune the corrupt entry, pruned %d: %v", n, err)
+	}
+	if n, _ := NewDiskCache(dir, 0).Prune(); n != 1 {
+		t.Errorf("expected to prune the expired entry, pruned %d", n)
+	}
+}
+
+func TestGetOrFetch(t *testing.T) {
+	cache := NewCache(time.Minute)
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	fetch := func(context.Context) ([]byte, error) {
+		fetches.Add(1)
+		<-release
+		return []byte("testdata"), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if val, err := cache.GetOrFetch(context.Background(), "https://example.com", fetch); err != nil || string(val) != "testdata" {
+				t.Errorf("got %q, %v", val, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if fetches.Load() != 1 {
+		t.Errorf("fetched %d times, want 1", fetches.Load())
+	}
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Error("expected the result to be cached")
+	}
+
+	failed := errors.New("offline")
+	if _, err := cache.GetOrFetch(context.Background(), "https://example.com/path", func(context.Context) ([]byte, error) { return nil, failed }); err != failed {
+		t.Errorf("expected the fetch's error, got %v", err)
+	}
+	if _, ok := cache.Get("https://example.com/path"); ok {
+		t.Error("expected errors not to be cached")
+	}
+}
+
+func TestGetOrFetchCancelled(t *testing.T) {
+	cache := NewCache(time.Minute)
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]byte, error) {
+		select {
+		case <-release:
+			return []byte("testdata"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// The first caller giving up leaves the fetch to those still waiting.
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := cache.GetOrFetch(first, "https://example.com", fetch)
+		firstErr <- err
+	}()
+	second := make(chan []byte)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		val, _ := cache.GetOrFetch(context.Background(), "https://example.com", fetch)
+		second <- val
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled caller to stop waiting, got %v", err)
+	}
+	close(release)
+	if val := <-second; string(val) != "testdata" {
+		t.Errorf("expected the caller still waiting to get the result, got %q", val)
+	}
+
+	// With every caller gone, the fetch is called off.
+	fetched := make(chan error, 1)
+	stuck := func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		fetched <- ctx.Err()
+		return nil, ctx.Err()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := cache.GetOrFetch(ctx, "https://example.com/path", stuck)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the caller to stop waiting, got %v", err)
+	}
+	select {
+	case err := <-fetched:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the fetch to be cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the fetch to be called off once no one was waiting")
+	}
+}
+
+func TestLayersGetOrFetch(t *testing.T) {
+	memory := NewCache(time.Minute)
+	disk := NewDiskCache(t.TempDir(), time.Minute)
+	layers := Layers{memory, disk}
+	disk.Add("https://example.com", []byte("ondisk"))
+
+	fetch := func(context.Context) ([]byte, error) { return []byte("fetched"), nil }
+	if val, _ := layers.GetOrFetch(context.Background(), "https://example.com", fetch); string(val) != "ondisk" {
+		t.Errorf("expected the disk's entry, got %q", val)
+	}
+	if val, _ := layers.GetOrFetch(context.Background(), "https://example.com/path", fetch); string(val) != "fetched" {
+		t.Errorf("expected a fetch, got %q", val)
+	}
+	for _, layer := range layers {
+		if val, ok := layer.Get("https://example.com/path"); !ok || string(val) != "fetched" {
+			t.Errorf("expected every layer to keep the fetched entry, got %q", val)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	cache := NewCache(time.Minute)
+	cache.SetLimits(2, 10)
+	cache.Add("a", []byte("1234"))
+	cache.Add("b", []byte("1234"))
+	cache.Get("a")
+	// c is over the entry limit, so b, the least recently used, goes.
+	cache.Add("c", []byte("1234"))
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	// d takes the cache over both limits, and a is now the least recently
+	// used.
+	cache.Add("d", []byte("123456"))
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected a to be evicted")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Errorf("expected c to be kept")
+	}
+
+	want := Stats{Hits: 2, Misses: 2, Evictions: 2, Entries: 2, Bytes: 10}
+	if got := cache.Stats(); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestDeleteLen(t *testing.T) {
+	memory := NewCache(time.Minute)
+	disk := NewDiskCache(t.TempDir(), time.Minute)
+	for name, c := range map[string]Cache{"memory": memory, "disk": disk, "layers": Layers{NewCache(time.Minute), NewDiskCache(t.TempDir(), time.Minute)}} {
+		c.Add("a", []byte("1"))
+		c.Add("b", []byte("2"))
+		if n := c.Len(); n != 2 {
+			t.Errorf("%s: expected 2 entries, got %d", name, n)
+		}
+		c.Delete("a")
+		c.Delete("missing")
+		if _, ok := c.Get("a"); ok {
+			t.Errorf("%s: expected a to be deleted", name)
+		}
+		if n := c.Len(); n != 1 {
+			t.Errorf("%s: expected 1 entry, got %d", name, n)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if _, err := Open("bolt", t.TempDir(), time.Minute); err == nil {
+		t.Errorf("expected an unregistered backend to fail")
+	}
+	memory := NewCache(time.Minute)
+	Register("test", func(dir string, ttl time.Duration) (Cache, error) { return memory, nil })
+	c, err := Open("test", t.TempDir(), time.Minute)
+	if err != nil || c != Cache(memory) {
+		t.Fatalf("got %v, %v", c, err)
+	}
+	if names := Backends(); len(names) != 2 || names[0] != "disk" || names[1] != "test" {
+		t.Errorf("got %v", names)
+	}
+}
@@ -0,0 +1,178 @@
+package pokecache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// diskEntry is how an entry is stored in its file.
+type diskEntry struct {
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+	Val       []byte    `json:"val"`
+}
+
+// DiskCache keeps entries in files under a directory, so they outlive the
+// process. Entries older than ttl, and files that can't be read, are
+// treated as missing and removed.
+type DiskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+func NewDiskCache(dir string, ttl time.Duration) *DiskCache {
+	return &DiskCache{dir: dir, ttl: ttl}
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Add stores an entry. Entries that can't be written are only lost, since
+// the cache can always be filled again.
+func (c *DiskCache) Add(key string, val []byte) {
+	storage.WriteJSON(c.path(key), diskEntry{Key: key, CreatedAt: time.Now(), Val: val})
+}
+
+func (c *DiskCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+// Len counts the entries' files, including any that have expired but
+// haven't been pruned yet.
+func (c *DiskCache) Len() int {
+	files, _ := os.ReadDir(c.dir)
+	n := 0
+	for _, file := range files {
+		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key || time.Since(entry.CreatedAt) > c.ttl {
+		os.Remove(path)
+		return nil, false
+	}
+	return entry.Val, true
+}
+
+// Prune removes the entries that have expired, and files that can't be
+// read, and returns how many it removed.
+func (c *DiskCache) Prune() (int, error) {
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(c.dir, file.Name())
+		data, err := os.ReadFile(path)
+		var entry diskEntry
+		if err == nil && json.Unmarshal(data, &entry) == nil && time.Since(entry.CreatedAt) <= c.ttl {
+			continue
+		}
+		if os.Remove(path) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// Layers looks entries up in each cache in turn, fastest first, copying
+// what it finds into the faster ones. Adding or deleting changes all of
+// them.
+type Layers []Cache
+
+func (l Layers) Get(key string) ([]byte, bool) {
+	for i, layer := range l {
+		if val, ok := layer.Get(key); ok {
+			for _, faster := range l[:i] {
+				faster.Add(key, val)
+			}
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+func (l Layers) Add(key string, val []byte) {
+	for _, layer := range l {
+		layer.Add(key, val)
+	}
+}
+
+func (l Layers) Delete(key string) {
+	for _, layer := range l {
+		layer.Delete(key)
+	}
+}
+
+// Len is how many entries the slowest layer holds, which is the one that
+// keeps the most.
+func (l Layers) Len() int {
+	if len(l) == 0 {
+		return 0
+	}
+	return l[len(l)-1].Len()
+}
+
+// fetcher is a cache that shares a fetch among callers asking for the same
+// key at once.
+type fetcher interface {
+	GetOrFetch(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error)
+}
+
+// GetOrFetch is Get, calling fetch and adding what it returns if no layer
+// has key. If the fastest layer has a GetOrFetch of its own, like a
+// MemoryCache, callers asking for the same key at once share one fetch.
+func (l Layers) GetOrFetch(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if len(l) == 0 {
+		return fetch(ctx)
+	}
+	fill := func(ctx context.Context) ([]byte, error) {
+		if val, ok := l[1:].Get(key); ok {
+			return val, nil
+		}
+		val, err := fetch(ctx)
+		if err == nil {
+			l[1:].Add(key, val)
+		}
+		return val, err
+	}
+	if f, ok := l[0].(fetcher); ok {
+		return f.GetOrFetch(ctx, key, fill)
+	}
+	if val, ok := l[0].Get(key); ok {
+		return val, nil
+	}
+	val, err := fill(ctx)
+	if err == nil {
+		l[0].Add(key, val)
+	}
+	return val, err
+}
@@ -0,0 +1,123 @@
+// Package quest hands out daily objectives, like catching three Pokémon in
+// an area, and keeps track of the player's progress on them.
+package quest
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// PerDay is how many quests are handed out each day.
+const PerDay = 3
+
+type Kind string
+
+const (
+	// CatchIn is catching Goal Pokémon in the area Target.
+	CatchIn Kind = "catch_in"
+	// CatchType is catching Goal Pokémon of the type Target.
+	CatchType Kind = "catch_type"
+	// Explore is exploring Goal areas.
+	Explore Kind = "explore"
+)
+
+// Types are the types catch-type quests ask for, common enough to find.
+var Types = []string{"normal", "water", "grass", "bug", "flying", "poison", "fire", "electric"}
+
+// Reward is what finishing a quest earns.
+type Reward struct {
+	Item  string `json:"item"`
+	Count int    `json:"count"`
+}
+
+var rewards = []Reward{{"poke-ball", 5}, {"great-ball", 3}, {"ultra-ball", 1}, {"potion", 2}, {"rare-candy", 1}}
+
+type Quest struct {
+	Kind     Kind   `json:"kind"`
+	Target   string `json:"target,omitempty"`
+	Goal     int    `json:"goal"`
+	Progress int    `json:"progress"`
+	Reward   Reward `json:"reward"`
+}
+
+func (q Quest) Done() bool {
+	return q.Progress >= q.Goal
+}
+
+// String reads like "Catch 3 Pokémon in viridian-forest-area".
+func (q Quest) String() string {
+	switch q.Kind {
+	case CatchIn:
+		return fmt.Sprintf("Catch %d Pokémon in %s", q.Goal, q.Target)
+	case CatchType:
+		return fmt.Sprintf("Catch %d %s-type Pokémon", q.Goal, q.Target)
+	}
+	return fmt.Sprintf("Explore %d areas", q.Goal)
+}
+
+// Board is the day's quests.
+type Board struct {
+	Day    string  `json:"day"`
+	Quests []Quest `json:"quests"`
+}
+
+// Refresh hands out a new day's quests if the board's are from another
+// day, picking catch-in quests' areas from areas. It reports whether it
+// did.
+func (b *Board) Refresh(now time.Time, areas []string, r *rand.Rand) bool {
+	day := now.Format("2006-01-02")
+	if b.Day == day {
+		return false
+	}
+	b.Day = day
+	b.Quests = nil
+	kinds := []Kind{CatchIn, CatchType, Explore}
+	if len(areas) == 0 {
+		kinds = kinds[1:]
+	}
+	for i := 0; i < PerDay; i++ {
+		q := Quest{Kind: kinds[i%len(kinds)], Reward: rewards[r.Intn(len(rewards))]}
+		switch q.Kind {
+		case CatchIn:
+			q.Target = areas[r.Intn(len(areas))]
+			q.Goal = 2 + r.Intn(3)
+		case CatchType:
+			q.Target = Types[r.Intn(len(Types))]
+			q.Goal = 1 + r.Intn(3)
+		case Explore:
+			q.Goal = 2 + r.Intn(4)
+		}
+		b.Quests = append(b.Quests, q)
+	}
+	return true
+}
+
+// Advance counts progress on every quest of kind whose target is one of
+// targets, and returns the quests it finished.
+func (b *Board) Advance(kind Kind, targets ...string) []Quest {
+	var finished []Quest
+	for i := range b.Quests {
+		q := &b.Quests[i]
+		if q.Kind != kind || q.Done() || !matches(q.Target, targets) {
+			continue
+		}
+		q.Progress++
+		if q.Done() {
+			finished = append(finished, *q)
+		}
+	}
+	return finished
+}
+
+func matches(target string, targets []string) bool {
+	if target == "" {
+		return true
+	}
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
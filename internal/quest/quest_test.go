@@ -0,0 +1,51 @@
+package quest
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRefresh(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	day := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	var b Board
+	if !b.Refresh(day, []string{"viridian-forest-area"}, r) {
+		t.Fatal("expected quests for a new board")
+	}
+	if len(b.Quests) != PerDay || b.Quests[0].Kind != CatchIn || b.Quests[0].Target != "viridian-forest-area" {
+		t.Fatalf("unexpected quests %+v", b.Quests)
+	}
+	if b.Refresh(day.Add(time.Hour), nil, r) {
+		t.Error("expected the same quests later the same day")
+	}
+	if !b.Refresh(day.AddDate(0, 0, 1), nil, r) {
+		t.Fatal("expected new quests the next day")
+	}
+	for _, q := range b.Quests {
+		if q.Kind == CatchIn {
+			t.Errorf("expected no area quests without areas, got %v", q)
+		}
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	b := Board{Quests: []Quest{
+		{Kind: CatchType, Target: "water", Goal: 2},
+		{Kind: CatchIn, Target: "viridian-forest-area", Goal: 1},
+		{Kind: Explore, Goal: 1},
+	}}
+	if done := b.Advance(CatchType, "grass", "poison"); len(done) != 0 || b.Quests[0].Progress != 0 {
+		t.Errorf("expected no progress for grass types, got %+v", b.Quests[0])
+	}
+	b.Advance(CatchType, "water")
+	if done := b.Advance(CatchType, "water", "flying"); len(done) != 1 || done[0].String() != "Catch 2 water-type Pokémon" {
+		t.Errorf("expected the water quest done, got %v", done)
+	}
+	if done := b.Advance(CatchType, "water"); len(done) != 0 || b.Quests[0].Progress != 2 {
+		t.Errorf("expected a done quest to stay done, got %+v", b.Quests[0])
+	}
+	if done := b.Advance(Explore, "pallet-town-area"); len(done) != 1 {
+		t.Errorf("expected exploring anywhere to count, got %v", done)
+	}
+}
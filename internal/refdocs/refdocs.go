@@ -0,0 +1,121 @@
+// Package refdocs renders the command reference as a man page and as
+// Markdown, from the same command list the game runs, so the two can't drift
+// apart from the code.
+package refdocs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Command describes one command.
+type Command struct {
+	Name        string
+	Description string
+	// Flags are the command's flags, without the leading dashes.
+	Flags []string
+	// Names is the kind of name the command's first argument is, if any.
+	Names string
+}
+
+// Usage is how the command is typed, as far as Command says, like
+// "catch <pokemon> [--ball]".
+func (c Command) Usage() string {
+	parts := []string{c.Name}
+	if c.Names != "" {
+		parts = append(parts, "<"+c.Names+">")
+	}
+	for _, flag := range c.Flags {
+		parts = append(parts, "[--"+flag+"]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Files are the generated documents, by file name.
+func Files(program string, commands []Command, topics []string) map[string]string {
+	return map[string]string{
+		program + ".1": Man(program, commands, topics),
+		"commands.md":  Markdown(program, commands, topics),
+	}
+}
+
+func sorted(commands []Command) []Command {
+	commands = append([]Command(nil), commands...)
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	return commands
+}
+
+// Man renders a man page for program. It has no date, so regenerating it
+// only changes it when the commands do.
+func Man(program string, commands []Command, topics []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".\\\" Generated by `%s gen docs`; do not edit.\n", program)
+	fmt.Fprintf(&b, ".TH %s 1 \"\" %q \"User Commands\"\n", strings.ToUpper(program), program)
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- a Pok\\['e]dex in your terminal\n", program)
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n[\\fIcommand\\fR [\\fIargs\\fR ...]]\n.br\n", program)
+	fmt.Fprintf(&b, ".B %s completion\n.I shell\n", program)
+	b.WriteString(".SH DESCRIPTION\n")
+	b.WriteString("Without arguments, the Pok\\['e]dex starts an interactive prompt where each\n")
+	b.WriteString("line is a command. Given a command, it runs just that command and exits,\n")
+	b.WriteString("with a non-zero status if the command failed.\n")
+	b.WriteString(".SH COMMANDS\n")
+	for _, c := range sorted(commands) {
+		fmt.Fprintf(&b, ".TP\n\\fB%s\\fR", roff(c.Name))
+		if c.Names != "" {
+			fmt.Fprintf(&b, " \\fI<%s>\\fR", roff(c.Names))
+		}
+		for _, flag := range c.Flags {
+			fmt.Fprintf(&b, " [\\fB\\-\\-%s\\fR]", roff(flag))
+		}
+		fmt.Fprintf(&b, "\n%s\n", line(c.Description))
+	}
+	if len(topics) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		b.WriteString("At the prompt,\n.B docs\n.I topic\nshows a longer guide. The topics are\n")
+		fmt.Fprintf(&b, "%s.\n", roff(strings.Join(topics, ", ")))
+	}
+	return b.String()
+}
+
+// roff escapes s for use inside a line of a man page.
+func roff(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`, "é", `\['e]`).Replace(s)
+}
+
+// line escapes s to be a line of its own, which mustn't look like a request.
+func line(s string) string {
+	s = roff(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// Markdown renders the command reference as a Markdown page.
+func Markdown(program string, commands []Command, topics []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!-- Generated by `%s gen docs`; do not edit. -->\n\n", program)
+	fmt.Fprintf(&b, "# %s commands\n\n", program)
+	fmt.Fprintf(&b, "Type these at the `Pokedex >` prompt, or run one from your shell with\n`%s <command> [args]`.\n\n", program)
+	b.WriteString("| Command | Description |\n")
+	b.WriteString("| --- | --- |\n")
+	for _, c := range sorted(commands) {
+		fmt.Fprintf(&b, "| `%s` | %s |\n", c.Usage(), cell(c.Description))
+	}
+	if len(topics) > 0 {
+		b.WriteString("\n## Guides\n\n")
+		b.WriteString("`docs <topic>` shows a longer guide to each of these:\n\n")
+		for _, topic := range topics {
+			fmt.Fprintf(&b, "- %s\n", topic)
+		}
+	}
+	return b.String()
+}
+
+// cell escapes s for a Markdown table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
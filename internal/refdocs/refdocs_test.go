@@ -0,0 +1,52 @@
+package refdocs
+
+import (
+	"strings"
+	"testing"
+)
+
+var commands = []Command{
+	{Name: "map", Description: "Displays the next 20 location areas"},
+	{Name: "catch", Description: "Catch a Pokémon | maybe", Flags: []string{"ball"}, Names: "species"},
+	{Name: "note", Description: ".hidden things"},
+}
+
+func TestUsage(t *testing.T) {
+	if got := commands[1].Usage(); got != "catch <species> [--ball]" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestMan(t *testing.T) {
+	page := Man("pokedexcli", commands, []string{"battles", "catching"})
+	for _, want := range []string{
+		".TH POKEDEXCLI 1",
+		".TP\n\\fBcatch\\fR \\fI<species>\\fR [\\fB\\-\\-ball\\fR]\nCatch a Pok\\['e]mon | maybe\n",
+		"\n\\&.hidden things\n",
+		"battles, catching.",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("man page is missing %q:\n%s", want, page)
+		}
+	}
+	if strings.Index(page, "catch") > strings.Index(page, "map") {
+		t.Errorf("commands aren't sorted:\n%s", page)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	page := Markdown("pokedexcli", commands, nil)
+	if !strings.Contains(page, "| `catch <species> [--ball]` | Catch a Pokémon \\| maybe |\n") {
+		t.Errorf("got:\n%s", page)
+	}
+	if strings.Contains(page, "## Guides") {
+		t.Errorf("listed guides without any topics:\n%s", page)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	files := Files("pokedexcli", commands, nil)
+	if len(files) != 2 || files["pokedexcli.1"] == "" || files["commands.md"] == "" {
+		t.Errorf("got %v", files)
+	}
+}
@@ -0,0 +1,606 @@
+// Package render writes command output as plain text, JSON, YAML, CSV, a
+// table or through a Go template the player provides.
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"unicode/utf8"
+)
+
+// Renderer writes a command's result.
+type Renderer interface {
+	Render(w io.Writer, v any) error
+}
+
+// Formats are the names New accepts.
+var Formats = []string{"text", "json", "yaml", "csv", "table", "template"}
+
+// New returns the renderer for format. The template format needs tmpl.
+func New(format, tmpl string) (Renderer, error) {
+	switch format {
+	case "", "text":
+		return Text{}, nil
+	case "json":
+		return JSON{}, nil
+	case "yaml":
+		return YAML{}, nil
+	case "csv":
+		return CSV{}, nil
+	case "table":
+		return Table{}, nil
+	case "template":
+		if tmpl == "" {
+			return nil, fmt.Errorf("the template format needs a template")
+		}
+		return NewTemplate(tmpl)
+	}
+	return nil, fmt.Errorf("unknown format %q; use one of %s", format, strings.Join(Formats, ", "))
+}
+
+// Text writes values as fmt prints them, and lists one item a line.
+type Text struct{}
+
+func (Text) Render(w io.Writer, v any) error {
+	return each(v, func(item any) error {
+		_, err := fmt.Fprintln(w, item)
+		return err
+	})
+}
+
+// JSON writes values as indented JSON.
+type JSON struct{}
+
+func (JSON) Render(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// YAML writes values as YAML, with the keys their JSON encoding has, in the
+// same order.
+type YAML struct{}
+
+func (YAML) Render(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	tree, err := decode(dec)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	block(&b, tree, 0, false)
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// CSV writes a list as CSV, with a header row of its JSON keys and a row for
+// each item; a value that isn't a list is one row. See cell for how nested
+// values fit in a column.
+type CSV struct{}
+
+func (CSV) Render(w io.Writer, v any) error {
+	keys, rows, err := rows(v)
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Write(keys)
+	cw.WriteAll(rows)
+	return cw.Error()
+}
+
+// Table writes a list as aligned columns under a header of its JSON keys,
+// or a value that isn't a list as a column of keys beside their values.
+type Table struct {
+	// Box draws lines around the cells with box-drawing characters.
+	Box bool
+	// ASCII cuts long cells short with "..." rather than "…".
+	ASCII bool
+}
+
+// tableWidth is how wide a table's cells get before they are cut short.
+const tableWidth = 40
+
+func (t Table) Render(w io.Writer, v any) error {
+	keys, rows, err := rows(v)
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	var lines [][]string
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array && len(rows) == 1 {
+		for i, key := range keys {
+			lines = append(lines, []string{strings.ToUpper(key), rows[0][i]})
+		}
+	} else {
+		header := make([]string, len(keys))
+		for i, key := range keys {
+			header[i] = strings.ToUpper(key)
+		}
+		lines = append([][]string{header}, rows...)
+	}
+	ellipsis := "…"
+	if t.ASCII {
+		ellipsis = "..."
+	}
+	for _, cells := range lines {
+		for i, c := range cells {
+			if r := []rune(c); len(r) > tableWidth {
+				c = string(r[:tableWidth-len([]rune(ellipsis))]) + ellipsis
+			}
+			cells[i] = strings.ReplaceAll(c, "\n", " ")
+		}
+	}
+	if t.Box {
+		return box(w, lines)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cells := range lines {
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// box writes lines as a table drawn with box-drawing characters, with a
+// rule under the first line.
+func box(w io.Writer, lines [][]string) error {
+	widths := make([]int, len(lines[0]))
+	for _, cells := range lines {
+		for i, c := range cells {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+	rule := func(left, middle, right string) string {
+		parts := make([]string, len(widths))
+		for i, width := range widths {
+			parts[i] = strings.Repeat("─", width+2)
+		}
+		return left + strings.Join(parts, middle) + right + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(rule("┌", "┬", "┐"))
+	for n, cells := range lines {
+		for i, c := range cells {
+			fmt.Fprintf(&b, "│ %s%s ", c, strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c)))
+		}
+		b.WriteString("│\n")
+		if n == 0 && len(lines) > 1 {
+			b.WriteString(rule("├", "┼", "┤"))
+		}
+	}
+	b.WriteString(rule("└", "┴", "┘"))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// rows lays v out as a table: its items' JSON keys, in the order the first
+// item to have each gives them, and a row of cells for each item.
+func rows(v any) ([]string, [][]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	tree, err := decode(dec)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, ok := tree.([]any)
+	if !ok && tree != nil {
+		items = []any{tree}
+	}
+	var keys []string
+	for _, item := range items {
+		obj, ok := item.(object)
+		if !ok {
+			return []string{"value"}, scalars(items), nil
+		}
+		for _, m := range obj {
+			if !slices.Contains(keys, m.key) {
+				keys = append(keys, m.key)
+			}
+		}
+	}
+	table := make([][]string, len(items))
+	for i, item := range items {
+		table[i] = make([]string, len(keys))
+		for _, m := range item.(object) {
+			table[i][slices.Index(keys, m.key)] = cell(m.value, m.key)
+		}
+	}
+	return keys, table, nil
+}
+
+// scalars lays out a list of values that aren't objects, one a row.
+func scalars(items []any) [][]string {
+	table := make([][]string, len(items))
+	for i, item := range items {
+		table[i] = []string{cell(item, "")}
+	}
+	return table
+}
+
+// cell writes a value found under key in one column: objects as their name,
+// and their value if they have one, lists as their items joined by commas,
+// and anything else as YAML writes it on one line.
+func cell(v any, key string) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		cells := make([]string, len(v))
+		for i, item := range v {
+			cells[i] = cell(item, key)
+		}
+		return strings.Join(cells, ", ")
+	case object:
+		for _, want := range []string{"name", singular(key)} {
+			for _, m := range v {
+				if m.key == want {
+					name := cell(m.value, m.key)
+					if value, ok := valueOf(v, key); ok {
+						return name + "=" + value
+					}
+					return name
+				}
+			}
+		}
+		var b strings.Builder
+		for i, m := range v {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(m.key + "=" + cell(m.value, m.key))
+		}
+		return b.String()
+	}
+	return scalar(v)
+}
+
+// valueOf finds the value of an object found under key that is named by
+// another of its members: its "value", or a member like base_stat in the
+// stats of a Pokémon.
+func valueOf(v object, key string) (string, bool) {
+	for _, m := range v {
+		if m.key == "value" || strings.HasSuffix(m.key, "_"+singular(key)) {
+			return cell(m.value, m.key), true
+		}
+	}
+	return "", false
+}
+
+// Template executes a template for a value, or for each item of a list,
+// ending each on a new line.
+type Template struct {
+	t *template.Template
+}
+
+// NewTemplate parses text, in text/template's syntax.
+func NewTemplate(text string) (Template, error) {
+	t, err := template.New("format").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return Template{}, err
+	}
+	return Template{t}, nil
+}
+
+func (t Template) Render(w io.Writer, v any) error {
+	return each(v, func(item any) error {
+		var b bytes.Buffer
+		if err := t.t.Execute(&b, item); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+			b.WriteByte('\n')
+		}
+		_, err := w.Write(b.Bytes())
+		return err
+	})
+}
+
+// Count writes how many items a list has instead of the items, or with By,
+// how many have each value of that field, through Then. By names a field by
+// its JSON key, or a path of them like "types.type"; objects count as their
+// name, and the s of a plural key may be left out.
+type Count struct {
+	By   string
+	Then Renderer
+}
+
+// Group is how many items have a value. Items with several values, like a
+// Pokémon with two types, count in each of their groups.
+type Group struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+func (c Count) Render(w io.Writer, v any) error {
+	items, err := list(v)
+	if err != nil {
+		return err
+	}
+	_, text := c.Then.(Text)
+	if c.By == "" {
+		if text {
+			_, err := fmt.Fprintln(w, len(items))
+			return err
+		}
+		return c.Then.Render(w, map[string]int{"count": len(items)})
+	}
+	groups, err := Groups(items, c.By)
+	if err != nil {
+		return err
+	}
+	if !text {
+		return c.Then.Render(w, groups)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t%d\n", g.Value, g.Count)
+	}
+	return tw.Flush()
+}
+
+// Groups counts items, as list decodes them, by the values of a field, the
+// largest group first. Items without the field are counted as "none".
+func Groups(items []any, by string) ([]Group, error) {
+	counts := make(map[string]int)
+	found := false
+	for _, item := range items {
+		vals, ok := values(item, "", strings.Split(by, "."))
+		found = found || ok
+		if len(vals) == 0 {
+			vals = []string{"none"}
+		}
+		slices.Sort(vals)
+		for _, v := range slices.Compact(vals) {
+			counts[v]++
+		}
+	}
+	if len(items) > 0 && !found {
+		return nil, fmt.Errorf("there is no %s to count by", by)
+	}
+	groups := []Group{}
+	for v, n := range counts {
+		groups = append(groups, Group{v, n})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups, nil
+}
+
+// list decodes v, which must be a list, into its items as JSON has them.
+func list(v any) ([]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var items []any
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("only lists can be counted")
+	}
+	return items, nil
+}
+
+// values finds the values at path in v, which was found under key. It
+// reports whether the path was there at all.
+func values(v any, key string, path []string) ([]string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return nil, len(path) == 0
+	case []any:
+		var all []string
+		found := len(v) == 0
+		for _, item := range v {
+			vals, ok := values(item, key, path)
+			all, found = append(all, vals...), found || ok
+		}
+		return all, found
+	case map[string]any:
+		if len(path) == 0 {
+			if name, ok := v["name"]; ok {
+				return values(name, "name", nil)
+			}
+			// An item of a list like types, as {"slot": 1, "type": {...}}.
+			if inner, ok := v[singular(key)]; ok {
+				return values(inner, "", nil)
+			}
+			return nil, false
+		}
+		for _, k := range []string{path[0], path[0] + "s"} {
+			if next, ok := v[k]; ok {
+				return values(next, k, path[1:])
+			}
+		}
+		return nil, false
+	}
+	if len(path) > 0 {
+		return nil, false
+	}
+	return []string{fmt.Sprint(v)}, true
+}
+
+// singular is the singular of a plural key, like type for types.
+func singular(key string) string {
+	if base, ok := strings.CutSuffix(key, "ies"); ok {
+		return base + "y"
+	}
+	return strings.TrimSuffix(key, "s")
+}
+
+// each calls fn for every item of a slice, or for v itself if it isn't one.
+func each(v any, fn func(any) error) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fn(v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := fn(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// object is a JSON object with its keys in order.
+type object []member
+
+type member struct {
+	key   string
+	value any
+}
+
+// decode reads a JSON value, keeping the order of object keys.
+func decode(dec *json.Decoder) (any, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := t.(json.Delim)
+	if !ok {
+		return t, nil
+	}
+	switch delim {
+	case '{':
+		obj := object{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, member{key.(string), value})
+		}
+		_, err = dec.Token()
+		return obj, err
+	default:
+		list := []any{}
+		for dec.More() {
+			item, err := decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		_, err = dec.Token()
+		return list, err
+	}
+}
+
+// block writes v indented by indent levels. If inline is set, its first
+// line follows what is already on the current line, like after "- ".
+func block(b *strings.Builder, v any, indent int, inline bool) {
+	pad := strings.Repeat("  ", indent)
+	switch v := v.(type) {
+	case object:
+		if len(v) == 0 {
+			b.WriteString(scalar(v) + "\n")
+			return
+		}
+		for i, m := range v {
+			if i > 0 || !inline {
+				b.WriteString(pad)
+			}
+			b.WriteString(scalar(m.key) + ":")
+			if nested(m.value) {
+				b.WriteString("\n")
+				block(b, m.value, indent+1, false)
+			} else {
+				b.WriteString(" " + scalar(m.value) + "\n")
+			}
+		}
+	case []any:
+		if len(v) == 0 {
+			b.WriteString(scalar(v) + "\n")
+			return
+		}
+		for i, item := range v {
+			if i > 0 || !inline {
+				b.WriteString(pad)
+			}
+			b.WriteString("- ")
+			if nested(item) {
+				block(b, item, indent+1, true)
+			} else {
+				b.WriteString(scalar(item) + "\n")
+			}
+		}
+	default:
+		b.WriteString(scalar(v) + "\n")
+	}
+}
+
+// nested reports whether v takes lines of its own.
+func nested(v any) bool {
+	switch v := v.(type) {
+	case object:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	}
+	return false
+}
+
+// scalar writes a value that fits on one line.
+func scalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case object:
+		return "{}"
+	case []any:
+		return "[]"
+	case string:
+		if plain(v) {
+			return v
+		}
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
+
+// plain reports whether a string can be written without quotes and still
+// be read back as the same string.
+func plain(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`0123456789.+~") {
+		return false
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "y", "n":
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+package render
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+type stat struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+type pokemon struct {
+	Name           string   `json:"name"`
+	BaseExperience int      `json:"base_experience"`
+	Types          []string `json:"types"`
+	Stats          []stat   `json:"stats"`
+	Nickname       string   `json:"nickname"`
+	Moves          []string `json:"moves"`
+}
+
+var pikachu = pokemon{
+	Name:           "pikachu",
+	BaseExperience: 112,
+	Types:          []string{"electric"},
+	Stats:          []stat{{"hp", 35}, {"speed", 90}},
+	Nickname:       "yes",
+	Moves:          []string{},
+}
+
+func TestYAML(t *testing.T) {
+	var b strings.Builder
+	if err := (YAML{}).Render(&b, pikachu); err != nil {
+		t.Fatal(err)
+	}
+	want := `name: pikachu
+base_experience: 112
+types:
+  - electric
+stats:
+  - name: hp
+    value: 35
+  - name: speed
+    value: 90
+nickname: "yes"
+moves: []
+`
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	r, err := New("template", "{{.Name}}: {{.BaseExperience}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := r.Render(&b, []pokemon{pikachu, {Name: "zubat", BaseExperience: 49}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "pikachu: 112\nzubat: 49\n"; b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+	if err := r.Render(&b, map[string]int{}); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, format := range Formats {
+		tmpl := ""
+		if format == "template" {
+			tmpl = "{{.}}"
+		}
+		if _, err := New(format, tmpl); err != nil {
+			t.Errorf("%s: %v", format, err)
+		}
+	}
+	if _, err := New("xml", ""); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, err := New("template", ""); err == nil {
+		t.Error("expected an error for a missing template")
+	}
+}
+
+func TestCount(t *testing.T) {
+	team := []pokemon{
+		pikachu,
+		{Name: "zubat", Types: []string{"poison", "flying"}, Stats: []stat{{"hp", 40}}},
+		{Name: "ekans", Types: []string{"poison"}},
+	}
+	cases := []struct {
+		r    Count
+		want string
+	}{
+		{Count{Then: Text{}}, "3\n"},
+		{Count{By: "type", Then: Text{}}, "poison    2\nelectric  1\nflying    1\n"},
+		{Count{By: "stats", Then: Text{}}, "hp     2\nnone   1\nspeed  1\n"},
+		{Count{Then: JSON{}}, "{\n  \"count\": 3\n}\n"},
+	}
+	for _, c := range cases {
+		var b strings.Builder
+		if err := c.r.Render(&b, team); err != nil {
+			t.Fatalf("by %q: %v", c.r.By, err)
+		}
+		if b.String() != c.want {
+			t.Errorf("by %q: got %q, want %q", c.r.By, b.String(), c.want)
+		}
+	}
+	if err := (Count{By: "egg_group", Then: Text{}}).Render(io.Discard, team); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+	if err := (Count{Then: Text{}}).Render(io.Discard, pikachu); err == nil {
+		t.Error("expected an error for counting something that isn't a list")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	var b strings.Builder
+	if err := (CSV{}).Render(&b, []pokemon{pikachu, {Name: "zubat", Types: []string{"poison", "flying"}}}); err != nil {
+		t.Fatal(err)
+	}
+	want := `name,base_experience,types,stats,nickname,moves
+pikachu,112,electric,"hp=35, speed=90",yes,
+zubat,0,"poison, flying",,,
+`
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestTable(t *testing.T) {
+	var b strings.Builder
+	if err := (Table{}).Render(&b, []stat{{"hp", 35}, {"speed", 90}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "NAME   VALUE\nhp     35\nspeed  90\n"; b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+	b.Reset()
+	if err := (Table{}).Render(&b, stat{"hp", 35}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "NAME   hp\nVALUE  35\n"; b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestTableBox(t *testing.T) {
+	var b strings.Builder
+	if err := (Table{Box: true}).Render(&b, []stat{{"hp", 35}, {"speed", 90}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "┌───────┬───────┐\n" +
+		"│ NAME  │ VALUE │\n" +
+		"├───────┼───────┤\n" +
+		"│ hp    │ 35    │\n" +
+		"│ speed │ 90    │\n" +
+		"└───────┴───────┘\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,61 @@
+// Package ribbon names the ribbons Pokémon earn for feats and the marks
+// that commemorate when they were caught, and keeps each Pokémon from
+// earning the same one twice.
+package ribbon
+
+import (
+	"slices"
+	"time"
+)
+
+// Ribbon is a ribbon or mark a Pokémon has, and when it got it.
+type Ribbon struct {
+	Name string    `json:"name"`
+	At   time.Time `json:"at"`
+}
+
+// The ribbons earned for feats.
+const (
+	// Tower is earned by a Battle Tower streak of TowerStreak.
+	Tower = "Tower Ribbon"
+	// Champion is earned by winning a ranked duel.
+	Champion = "Champion Ribbon"
+	// Chain is earned by the Pokémon that makes a chain of ChainLength
+	// catches in a row without one getting away.
+	Chain = "Chain Ribbon"
+)
+
+// TowerStreak is the Battle Tower streak that earns the Tower Ribbon.
+const TowerStreak = 10
+
+// ChainLength is how many catches in a row earn the Chain Ribbon.
+const ChainLength = 100
+
+// Mark is the name of the mark for a Pokémon caught during a seasonal
+// event, like "Spooky Season Mark".
+func Mark(event string) string {
+	return event + " Mark"
+}
+
+// Has reports whether ribbons include the one called name.
+func Has(ribbons []Ribbon, name string) bool {
+	return slices.ContainsFunc(ribbons, func(r Ribbon) bool { return r.Name == name })
+}
+
+// Award adds the ribbon called name, earned at, unless it is already
+// there. It reports whether it was added.
+func Award(ribbons []Ribbon, name string, at time.Time) ([]Ribbon, bool) {
+	if Has(ribbons, name) {
+		return ribbons, false
+	}
+	return append(ribbons, Ribbon{Name: name, At: at}), true
+}
+
+// Names lists the names of ribbons, in the order they were earned.
+func Names(ribbons []Ribbon) []string {
+	names := make([]string, len(ribbons))
+	for i, r := range ribbons {
+		names[i] = r.Name
+	}
+	return names
+}
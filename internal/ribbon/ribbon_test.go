@@ -0,0 +1,23 @@
+package ribbon
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAward(t *testing.T) {
+	at := time.Date(2024, 10, 31, 12, 0, 0, 0, time.UTC)
+	ribbons, added := Award(nil, Tower, at)
+	if !added || !Has(ribbons, Tower) {
+		t.Fatalf("expected the Tower Ribbon to be added, got %v", ribbons)
+	}
+	ribbons, added = Award(ribbons, Tower, at.Add(time.Hour))
+	if added || len(ribbons) != 1 || !ribbons[0].At.Equal(at) {
+		t.Errorf("expected a second Tower Ribbon to be refused, got %v", ribbons)
+	}
+	ribbons, _ = Award(ribbons, Mark("Spooky Season"), at)
+	if got, want := Names(ribbons), []string{"Tower Ribbon", "Spooky Season Mark"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
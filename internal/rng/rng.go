@@ -0,0 +1,113 @@
+// Package rng splits the game's randomness into named streams, each seeded
+// on its own, so that pinning one, like catches, leaves the others random,
+// and a session played again with the same seeds rolls the same way.
+package rng
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The streams the game draws from.
+const (
+	Catch     = "catch"
+	Encounter = "encounter"
+	Shiny     = "shiny"
+	Battle    = "battle"
+	// Find is what is found lying around while exploring.
+	Find = "find"
+	// World is everything else: the roamer, trades and duel seeds.
+	World = "world"
+)
+
+// Names are the streams, in the order Seeds lists them.
+var Names = []string{Catch, Encounter, Shiny, Battle, Find, World}
+
+// Streams hands out the random streams.
+type Streams struct {
+	seeds   map[string]int64
+	streams map[string]*rand.Rand
+}
+
+// New returns streams all seeded from seed; each stream's own seed mixes in
+// its name, so they don't roll alike.
+func New(seed int64) *Streams {
+	s := &Streams{seeds: make(map[string]int64), streams: make(map[string]*rand.Rand)}
+	for _, name := range Names {
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		s.Seed(name, seed^int64(h.Sum64()))
+	}
+	return s
+}
+
+// Seed starts the named stream over from seed.
+func (s *Streams) Seed(name string, seed int64) {
+	s.seeds[name] = seed
+	s.streams[name] = rand.New(rand.NewSource(seed))
+}
+
+// Get returns the named stream.
+func (s *Streams) Get(name string) *rand.Rand {
+	r, ok := s.streams[name]
+	if !ok {
+		panic("rng: no stream named " + name)
+	}
+	return r
+}
+
+// Seeds lists every stream's seed as "name=seed,…", in the form Parse reads.
+func (s *Streams) Seeds() string {
+	names := make([]string, 0, len(s.seeds))
+	for name := range s.seeds {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return index(names[i]) < index(names[j]) })
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, s.seeds[name])
+	}
+	return strings.Join(parts, ",")
+}
+
+func index(name string) int {
+	for i, n := range Names {
+		if n == name {
+			return i
+		}
+	}
+	return len(Names)
+}
+
+// Parse seeds streams from text: a number seeds every stream as New does,
+// and "name=seed" pairs, separated by commas, seed single streams. Streams
+// not named take their seeds from fallback.
+func Parse(text string, fallback int64) (*Streams, error) {
+	text = strings.TrimSpace(text)
+	if seed, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return New(seed), nil
+	}
+	s := New(fallback)
+	if text == "" {
+		return s, nil
+	}
+	for _, pair := range strings.Split(text, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("%q isn't a seed or name=seed", pair)
+		}
+		if _, known := s.streams[name]; !known {
+			return nil, fmt.Errorf("there is no %s stream; use %s", name, strings.Join(Names, ", "))
+		}
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the %s seed %q isn't a number", name, value)
+		}
+		s.Seed(name, seed)
+	}
+	return s, nil
+}
@@ -0,0 +1,35 @@
+package rng
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	a, err := Parse("catch=42", time.Now().UnixNano())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Parse("catch=42, battle=7", time.Now().UnixNano()+1)
+	if x, y := a.Get(Catch).Int63(), b.Get(Catch).Int63(); x != y {
+		t.Errorf("expected a pinned stream to roll alike, got %d and %d", x, y)
+	}
+	if x, y := a.Get(Encounter).Int63(), b.Get(Encounter).Int63(); x == y {
+		t.Errorf("expected unpinned streams to roll apart, both got %d", x)
+	}
+
+	all, _ := Parse("1", 0)
+	if all.Get(Catch).Int63() == all.Get(Battle).Int63() {
+		t.Errorf("expected streams seeded from one number to differ")
+	}
+	again, _ := Parse(all.Seeds(), 0)
+	if again.Seeds() != all.Seeds() {
+		t.Errorf("expected %q to read back the same, got %q", all.Seeds(), again.Seeds())
+	}
+
+	for _, bad := range []string{"luck=1", "catch=x", "catch"} {
+		if _, err := Parse(bad, 0); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
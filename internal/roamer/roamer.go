@@ -0,0 +1,86 @@
+// Package roamer moves a legendary Pokémon between areas as the player plays.
+package roamer
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// MoveEvery is how many commands the roamer stays in one area.
+const MoveEvery = 5
+
+var Species = []string{"raikou", "entei", "suicune"}
+
+// Areas are the location areas a roamer can wander between.
+var Areas = []string{
+	"kanto-route-1-area",
+	"kanto-route-2-south-towards-viridian-city",
+	"kanto-route-3-area",
+	"kanto-route-4-area",
+	"kanto-route-5-area",
+	"kanto-route-6-area",
+	"kanto-route-7-area",
+	"kanto-route-8-area",
+	"kanto-route-24-area",
+	"kanto-route-25-area",
+	"viridian-forest-area",
+	"mt-moon-1f",
+	"rock-tunnel-b1f",
+}
+
+type Roamer struct {
+	Species  string `json:"species"`
+	Area     string `json:"area"`
+	Commands int    `json:"commands"`
+	Caught   bool   `json:"caught"`
+}
+
+// New releases a random roamer into a random area.
+func New(r *rand.Rand) Roamer {
+	return Roamer{
+		Species: Species[r.Intn(len(Species))],
+		Area:    Areas[r.Intn(len(Areas))],
+	}
+}
+
+func (ro *Roamer) Active() bool {
+	return ro.Species != "" && !ro.Caught
+}
+
+// Tick counts one command and moves the roamer to a different area every
+// MoveEvery commands. It reports whether the roamer moved.
+func (ro *Roamer) Tick(r *rand.Rand) bool {
+	if !ro.Active() {
+		return false
+	}
+	ro.Commands++
+	if ro.Commands < MoveEvery {
+		return false
+	}
+	ro.Move(r)
+	return true
+}
+
+// Move sends the roamer to a different area at once.
+func (ro *Roamer) Move(r *rand.Rand) {
+	ro.Commands = 0
+	next := Areas[r.Intn(len(Areas)-1)]
+	if next == ro.Area {
+		next = Areas[len(Areas)-1]
+	}
+	ro.Area = next
+}
+
+// Hint describes the roamer's area without naming it.
+func (ro *Roamer) Hint() string {
+	area := ro.Area
+	switch {
+	case strings.Contains(area, "route"):
+		return fmt.Sprintf("%s was seen running along one of kanto's routes.", ro.Species)
+	case strings.Contains(area, "forest"):
+		return fmt.Sprintf("%s was seen among the trees of a forest.", ro.Species)
+	default:
+		return fmt.Sprintf("%s was heard roaring deep inside a cave.", ro.Species)
+	}
+}
@@ -0,0 +1,41 @@
+package roamer
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestTick(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ro := New(r)
+	start := ro.Area
+	for i := 1; i < MoveEvery; i++ {
+		if ro.Tick(r) {
+			t.Fatalf("expected roamer to stay put for %d commands", MoveEvery)
+		}
+	}
+	if !ro.Tick(r) {
+		t.Fatalf("expected roamer to move after %d commands", MoveEvery)
+	}
+	if ro.Area == start {
+		t.Errorf("expected roamer to move to a different area")
+	}
+
+	ro.Caught = true
+	for i := 0; i < MoveEvery; i++ {
+		if ro.Tick(r) {
+			t.Errorf("expected a caught roamer to stay put")
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	for _, area := range Areas {
+		ro := Roamer{Species: "raikou", Area: area}
+		hint := ro.Hint()
+		if strings.Contains(hint, area) {
+			t.Errorf("expected hint for %s not to give the area away: %s", area, hint)
+		}
+	}
+}
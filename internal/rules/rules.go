@@ -0,0 +1,128 @@
+// Package rules checks teams against rulesets, like the level cap and
+// bans a community agrees on for its battles. Rulesets are JSON files, so
+// communities can share their own alongside the built-in ones.
+package rules
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+//go:embed rulesets/*.json
+var builtin embed.FS
+
+// Default is the ruleset used when none is named.
+const Default = "standard"
+
+// Ruleset is a set of rules a team must follow. Zero fields don't limit
+// anything.
+type Ruleset struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// LevelCap is the highest level a Pokémon can be.
+	LevelCap int `json:"level_cap,omitempty"`
+	// PartySize is the most Pokémon a team can have.
+	PartySize int `json:"party_size,omitempty"`
+	// SpeciesClause allows only one Pokémon of each species.
+	SpeciesClause bool     `json:"species_clause,omitempty"`
+	BannedSpecies []string `json:"banned_species,omitempty"`
+	BannedMoves   []string `json:"banned_moves,omitempty"`
+	// BannedItems can't be used during battles.
+	BannedItems []string `json:"banned_items,omitempty"`
+}
+
+// Member is a Pokémon on a team, as the rules see it.
+type Member struct {
+	Species string
+	Level   int
+	Moves   []string
+}
+
+// Validate returns everything about team that breaks the rules, or nothing
+// if it is battle-ready.
+func (r Ruleset) Validate(team []Member) []string {
+	var problems []string
+	if r.PartySize > 0 && len(team) > r.PartySize {
+		problems = append(problems, fmt.Sprintf("The team has %d Pokémon; at most %d are allowed.", len(team), r.PartySize))
+	}
+	seen := make(map[string]bool)
+	for _, m := range team {
+		if r.SpeciesClause && seen[m.Species] {
+			problems = append(problems, fmt.Sprintf("%s is on the team more than once.", m.Species))
+		}
+		seen[m.Species] = true
+		if slices.Contains(r.BannedSpecies, m.Species) {
+			problems = append(problems, fmt.Sprintf("%s is banned.", m.Species))
+		}
+		if r.LevelCap > 0 && m.Level > r.LevelCap {
+			problems = append(problems, fmt.Sprintf("%s is level %d; the cap is %d.", m.Species, m.Level, r.LevelCap))
+		}
+		for _, move := range m.Moves {
+			if slices.Contains(r.BannedMoves, move) {
+				problems = append(problems, fmt.Sprintf("%s knows %s, which is banned.", m.Species, move))
+			}
+		}
+	}
+	return problems
+}
+
+// AllowsItem reports whether an item can be used in battle.
+func (r Ruleset) AllowsItem(item string) bool {
+	return !slices.Contains(r.BannedItems, item)
+}
+
+// Load returns the built-in rulesets and those in the JSON files in dir,
+// by name. A ruleset in dir replaces a built-in one of the same name. A
+// ruleset without a name is named after its file.
+func Load(dir string) (map[string]Ruleset, error) {
+	rulesets := make(map[string]Ruleset)
+	if err := load(builtin, "rulesets", rulesets); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return rulesets, nil
+	}
+	if err := load(os.DirFS(dir), ".", rulesets); err != nil {
+		return nil, err
+	}
+	return rulesets, nil
+}
+
+func load(fsys fs.FS, dir string, into map[string]Ruleset) error {
+	files, err := fs.Glob(fsys, dir+"/*.json")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		var r Ruleset
+		if err := json.Unmarshal(data, &r); err != nil {
+			return fmt.Errorf("ruleset %s: %w", filepath.Base(file), err)
+		}
+		if r.Name == "" {
+			r.Name = strings.TrimSuffix(filepath.Base(file), ".json")
+		}
+		into[r.Name] = r
+	}
+	return nil
+}
+
+// Names lists rulesets' names in alphabetical order.
+func Names(rulesets map[string]Ruleset) []string {
+	names := make([]string, 0, len(rulesets))
+	for name := range rulesets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
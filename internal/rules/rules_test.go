@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	r := Ruleset{
+		LevelCap:      50,
+		PartySize:     2,
+		SpeciesClause: true,
+		BannedSpecies: []string{"mewtwo"},
+		BannedMoves:   []string{"fissure"},
+	}
+	if problems := r.Validate([]Member{{Species: "pikachu", Level: 20, Moves: []string{"thunderbolt"}}}); len(problems) != 0 {
+		t.Errorf("expected pikachu to be allowed, got %v", problems)
+	}
+	got := r.Validate([]Member{
+		{Species: "mewtwo", Level: 70},
+		{Species: "dugtrio", Level: 30, Moves: []string{"dig", "fissure"}},
+		{Species: "dugtrio", Level: 30},
+	})
+	want := []string{
+		"The team has 3 Pokémon; at most 2 are allowed.",
+		"mewtwo is banned.",
+		"mewtwo is level 70; the cap is 50.",
+		"dugtrio knows fissure, which is banned.",
+		"dugtrio is on the team more than once.",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "kanto-only.json"), []byte(`{"level_cap": 30, "banned_items": ["potion"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mine.json"), []byte(`{"name": "standard", "level_cap": 10}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rulesets, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Names(rulesets), []string{"kanto-only", "little-cup", "standard"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected rulesets %v, got %v", want, got)
+	}
+	if rulesets["standard"].LevelCap != 10 {
+		t.Errorf("expected the local standard ruleset to replace the built-in one")
+	}
+	if rulesets["kanto-only"].AllowsItem("potion") || !rulesets["little-cup"].AllowsItem("rare-candy") {
+		t.Errorf("unexpected item bans")
+	}
+
+	rulesets, err = Load(filepath.Join(dir, "missing"))
+	if err != nil || len(rulesets) != 2 {
+		t.Errorf("expected only the built-in rulesets, got %v, %v", rulesets, err)
+	}
+}
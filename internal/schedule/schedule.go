@@ -0,0 +1,223 @@
+// Package schedule runs tasks on cron expressions.
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression: minute, hour, day of month, month
+// and day of week.
+type Schedule struct {
+	Expr    string
+	minute  set
+	hour    set
+	dom     set
+	month   set
+	dow     set
+	anyDay  bool
+	anyWeek bool
+}
+
+// set holds the values a field matches, as bits.
+type set uint64
+
+func (s set) has(n int) bool {
+	return s&(1<<n) != 0
+}
+
+// shorthands are the @ names cron accepts for common schedules.
+var shorthands = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// fields are the ranges of a cron expression's fields, in order.
+var fields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// Parse parses a cron expression of five fields, each *, a number, a range
+// like 1-5 or a list of them, with an optional /step; or one of @hourly,
+// @daily, @weekly and @monthly.
+func Parse(expr string) (Schedule, error) {
+	full := expr
+	if s, ok := shorthands[expr]; ok {
+		full = s
+	}
+	parts := strings.Fields(full)
+	if len(parts) != len(fields) {
+		return Schedule{}, fmt.Errorf("%q: expected %d fields, got %d", expr, len(fields), len(parts))
+	}
+	sets := make([]set, len(fields))
+	for i, part := range parts {
+		s, err := parseField(part, fields[i].min, fields[i].max)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("%q: %s: %w", expr, fields[i].name, err)
+		}
+		sets[i] = s
+	}
+	return Schedule{
+		Expr:    expr,
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		anyDay:  strings.HasPrefix(parts[2], "*"),
+		anyWeek: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+func parseField(field string, min, max int) (set, error) {
+	var s set
+	for _, item := range strings.Split(field, ",") {
+		rng, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepText)
+			}
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(first); err != nil {
+				return 0, fmt.Errorf("invalid value %q", first)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(last); err != nil {
+					return 0, fmt.Errorf("invalid value %q", last)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%s is outside %d-%d", rng, min, max)
+		}
+		for n := lo; n <= hi; n += step {
+			s |= 1 << n
+		}
+	}
+	return s, nil
+}
+
+// day reports whether the schedule runs on t's day. As in cron, when both
+// the day of month and the day of week are restricted, either will do.
+func (s Schedule) day(t time.Time) bool {
+	dom, dow := s.dom.has(t.Day()), s.dow.has(int(t.Weekday()))
+	switch {
+	case s.anyDay && s.anyWeek:
+		return true
+	case s.anyDay:
+		return dow
+	case s.anyWeek:
+		return dom
+	}
+	return dom || dow
+}
+
+// Next is the first minute after t the schedule runs at, or the zero time
+// if it never does, like on the 31st of February.
+func (s Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.day(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !s.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !s.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+type Task struct {
+	Name     string
+	Schedule Schedule
+	Run      func() error
+}
+
+// Status is how a task last went.
+type Status struct {
+	LastRun time.Time `json:"last_run"`
+	Err     string    `json:"error,omitempty"`
+}
+
+// Scheduler runs its tasks when they are due.
+type Scheduler struct {
+	Tasks  []Task
+	Status map[string]Status
+	// Since is when tasks that never ran start counting from.
+	Since time.Time
+}
+
+// Next is when the task runs next.
+func (s *Scheduler) Next(task Task) time.Time {
+	last := s.Status[task.Name].LastRun
+	if last.IsZero() || last.Before(s.Since) {
+		last = s.Since
+	}
+	return task.Schedule.Next(last)
+}
+
+// RunDue runs the tasks due at now, in order, and returns their names. A
+// task that missed several runs runs once.
+func (s *Scheduler) RunDue(now time.Time) []string {
+	if s.Status == nil {
+		s.Status = make(map[string]Status)
+	}
+	var ran []string
+	for _, task := range s.Tasks {
+		next := s.Next(task)
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		status := Status{LastRun: now}
+		if err := task.Run(); err != nil {
+			status.Err = err.Error()
+		}
+		s.Status[task.Name] = status
+		ran = append(ran, task.Name)
+	}
+	return ran
+}
+
+// Run runs due tasks at the start of every minute until ctx is done,
+// calling after each time any ran.
+func (s *Scheduler) Run(ctx context.Context, after func(ran []string)) {
+	for {
+		now := time.Now()
+		if ran := s.RunDue(now); len(ran) > 0 {
+			after(ran)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(now.Truncate(time.Minute).Add(time.Minute).Sub(now)):
+		}
+	}
+}
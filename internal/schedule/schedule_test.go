@@ -0,0 +1,82 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNext(t *testing.T) {
+	cases := []struct {
+		expr, after, want string
+	}{
+		{"* * * * *", "2024-03-01 10:00", "2024-03-01 10:01"},
+		{"*/15 * * * *", "2024-03-01 10:05", "2024-03-01 10:15"},
+		{"@hourly", "2024-03-01 10:00", "2024-03-01 11:00"},
+		{"@daily", "2024-03-01 10:00", "2024-03-02 00:00"},
+		{"30 9 * * 1-5", "2024-03-01 10:00", "2024-03-04 09:30"}, // Friday to Monday
+		{"0 0 29 2 *", "2024-03-01 00:00", "2028-02-29 00:00"},
+		{"0 12 1,15 * *", "2024-03-02 00:00", "2024-03-15 12:00"},
+		// Restricting both days matches either.
+		{"0 0 13 * 5", "2024-03-02 00:00", "2024-03-08 00:00"},
+	}
+	for _, c := range cases {
+		s, err := Parse(c.expr)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.expr, err)
+		}
+		if got := s.Next(at(c.after)); !got.Equal(at(c.want)) {
+			t.Errorf("%s after %s: expected %s, got %s", c.expr, c.after, c.want, got)
+		}
+	}
+
+	never, _ := Parse("0 0 31 2 *")
+	if got := never.Next(at("2024-01-01 00:00")); !got.IsZero() {
+		t.Errorf("expected February 31st never to come, got %s", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@yearly"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	hourly, _ := Parse("@hourly")
+	ran := 0
+	s := &Scheduler{
+		Tasks: []Task{
+			{Name: "count", Schedule: hourly, Run: func() error { ran++; return nil }},
+			{Name: "fail", Schedule: hourly, Run: func() error { return errors.New("boom") }},
+		},
+		Since: at("2024-03-01 10:30"),
+	}
+
+	if got := s.RunDue(at("2024-03-01 10:59")); len(got) != 0 {
+		t.Errorf("expected nothing due yet, ran %v", got)
+	}
+	// Missed runs only run once.
+	if got := s.RunDue(at("2024-03-01 13:05")); len(got) != 2 || ran != 1 {
+		t.Errorf("expected both tasks to run once, ran %v, counted %d", got, ran)
+	}
+	if s.Status["fail"].Err != "boom" || s.Status["count"].Err != "" {
+		t.Errorf("unexpected statuses: %+v", s.Status)
+	}
+	if next := s.Next(s.Tasks[0]); !next.Equal(at("2024-03-01 14:00")) {
+		t.Errorf("expected the next run at 14:00, got %s", next)
+	}
+	if got := s.RunDue(at("2024-03-01 13:30")); len(got) != 0 {
+		t.Errorf("expected nothing due again before 14:00, ran %v", got)
+	}
+}
@@ -0,0 +1,166 @@
+// Package schema checks JSON from an API against the Go structs it is
+// decoded into, to notice when the API changes shape. encoding/json quietly
+// leaves fields it can't find at their zero value, so without a check a
+// renamed field just looks like missing data.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Problem is a field that doesn't look the way the struct expects.
+type Problem struct {
+	// Path is the field's JSON path, like "stats[].stat.name".
+	Path    string
+	Message string
+}
+
+func (p Problem) String() string {
+	return p.Path + " " + p.Message
+}
+
+// Check compares data with the fields of v, a struct or a pointer to one.
+// Fields missing from data, of the wrong type, or null or empty are
+// reported; numbers and booleans may be zero. Paths listed in skip aren't
+// checked, nor is anything under them. Only the first element of each array
+// is looked at.
+func Check(data []byte, v any, skip ...string) ([]Problem, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	c := checker{skip: skip}
+	c.walk(reflect.TypeOf(v), value, "")
+	return c.problems, nil
+}
+
+type checker struct {
+	skip     []string
+	problems []Problem
+}
+
+func (c *checker) report(path, format string, args ...any) {
+	if path == "" {
+		path = "(top level)"
+	}
+	c.problems = append(c.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+var unmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func (c *checker) walk(t reflect.Type, value any, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if value == nil {
+		c.report(path, "is null")
+		return
+	}
+	// Types that decode themselves can look like anything.
+	if reflect.PointerTo(t).Implements(unmarshaler) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			c.report(path, "is %s, expected an object", describe(value))
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := jsonName(field)
+			if !ok {
+				continue
+			}
+			fieldPath := join(path, name)
+			if slices.Contains(c.skip, fieldPath) {
+				continue
+			}
+			fieldValue, present := object[name]
+			if !present {
+				c.report(fieldPath, "is missing")
+				continue
+			}
+			c.walk(field.Type, fieldValue, fieldPath)
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]any)
+		if !ok {
+			c.report(path, "is %s, expected an array", describe(value))
+			return
+		}
+		if len(list) == 0 {
+			c.report(path, "is empty")
+			return
+		}
+		c.walk(t.Elem(), list[0], path+"[]")
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			c.report(path, "is %s, expected an object", describe(value))
+		} else if len(object) == 0 {
+			c.report(path, "is empty")
+		}
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			c.report(path, "is %s, expected a string", describe(value))
+		} else if s == "" {
+			c.report(path, "is empty")
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			c.report(path, "is %s, expected a boolean", describe(value))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if _, ok := value.(float64); !ok {
+			c.report(path, "is %s, expected a number", describe(value))
+		}
+	}
+}
+
+// jsonName is the name encoding/json uses for an exported field.
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return field.Name, true
+	}
+	return name, true
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func describe(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return "null"
+}
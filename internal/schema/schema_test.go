@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+type pokemon struct {
+	Name  string `json:"name"`
+	Stats []struct {
+		BaseStat int `json:"base_stat"`
+		Stat     struct {
+			Name string `json:"name"`
+		} `json:"stat"`
+	} `json:"stats"`
+	Shiny    bool      `json:"shiny"`
+	CaughtAt time.Time `json:"caught_at"`
+	Nickname string    `json:"nickname"`
+	secret   string
+}
+
+func problems(t *testing.T, data string, skip ...string) []string {
+	t.Helper()
+	found, err := Check([]byte(data), &pokemon{}, skip...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range found {
+		got = append(got, p.String())
+	}
+	return got
+}
+
+func TestCheck(t *testing.T) {
+	ok := `{"name":"pikachu","stats":[{"base_stat":35,"stat":{"name":"hp"}}],"shiny":false,"caught_at":"2024-01-01T00:00:00Z","nickname":"sparky"}`
+	if got := problems(t, ok); len(got) != 0 {
+		t.Errorf("expected no problems, got %v", got)
+	}
+
+	drifted := `{"name":"","stats":[{"baseStat":35,"stat":{"name":7}}],"shiny":"no","caught_at":null}`
+	want := []string{
+		"name is empty",
+		"stats[].base_stat is missing",
+		"stats[].stat.name is a number, expected a string",
+		"shiny is a string, expected a boolean",
+		"caught_at is null",
+		"nickname is missing",
+	}
+	if got := problems(t, drifted); !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := problems(t, `{"name":"pikachu","stats":[],"shiny":true,"caught_at":""}`, "stats", "nickname"); len(got) != 0 {
+		t.Errorf("expected skipped fields to be ignored, got %v", got)
+	}
+	if got := problems(t, `[]`); !slices.Equal(got, []string{"(top level) is an array, expected an object"}) {
+		t.Errorf("unexpected problems %v", got)
+	}
+}
@@ -0,0 +1,114 @@
+// Package seasons decides which themed events are running on a given date.
+package seasons
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// Event runs every year between Start and End, both inclusive and written as
+// MM-DD. Ranges may wrap around the new year.
+type Event struct {
+	Name       string   `json:"name"`
+	Start      string   `json:"start"`
+	End        string   `json:"end"`
+	Message    string   `json:"message"`
+	BoostTypes []string `json:"boost_types"`
+	// Boost multiplies the spawn weight of Pokémon with one of BoostTypes.
+	Boost float64 `json:"boost"`
+}
+
+var Builtin = []Event{
+	{
+		Name:       "Spooky Season",
+		Start:      "10-20",
+		End:        "11-02",
+		Message:    "Ghost-type Pokémon are appearing more often!",
+		BoostTypes: []string{"ghost", "dark"},
+		Boost:      2,
+	},
+	{
+		Name:       "Winter Festival",
+		Start:      "12-20",
+		End:        "01-05",
+		Message:    "Ice-type Pokémon are out in force for the holidays!",
+		BoostTypes: []string{"ice"},
+		Boost:      2,
+	},
+	{
+		Name:       "Spring Bloom",
+		Start:      "04-01",
+		End:        "04-14",
+		Message:    "Grass and fairy-type Pokémon are blooming everywhere!",
+		BoostTypes: []string{"grass", "fairy"},
+		Boost:      1.5,
+	},
+}
+
+// Load returns the built-in events plus any defined in the manifest at path.
+func Load(path string) ([]Event, error) {
+	var local []Event
+	if err := storage.ReadJSON(path, &local); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	all := append(append([]Event(nil), Builtin...), local...)
+	for _, e := range all {
+		if err := e.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return all, nil
+}
+
+func (e Event) validate() error {
+	for _, day := range []string{e.Start, e.End} {
+		if _, err := time.Parse("01-02", day); err != nil {
+			return fmt.Errorf("event %q: invalid date %q, expected MM-DD", e.Name, day)
+		}
+	}
+	return nil
+}
+
+// ActiveOn reports whether the event is running on the day of t.
+func (e Event) ActiveOn(t time.Time) bool {
+	day := t.Format("01-02")
+	if e.Start <= e.End {
+		return e.Start <= day && day <= e.End
+	}
+	return day >= e.Start || day <= e.End
+}
+
+func Active(events []Event, t time.Time) []Event {
+	var active []Event
+	for _, e := range events {
+		if e.ActiveOn(t) {
+			active = append(active, e)
+		}
+	}
+	return active
+}
+
+// SpawnWeight returns the multiplier active events apply to a Pokémon with
+// the given types.
+func SpawnWeight(active []Event, types []string) float64 {
+	weight := 1.0
+	for _, e := range active {
+		if e.Boost > 0 && overlaps(e.BoostTypes, types) {
+			weight *= e.Boost
+		}
+	}
+	return weight
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package seasons
+
+import (
+	"testing"
+	"time"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2024, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestActiveOn(t *testing.T) {
+	halloween := Event{Start: "10-20", End: "11-02"}
+	winter := Event{Start: "12-20", End: "01-05"}
+	cases := []struct {
+		event Event
+		t     time.Time
+		want  bool
+	}{
+		{halloween, date(time.October, 31), true},
+		{halloween, date(time.November, 2), true},
+		{halloween, date(time.November, 3), false},
+		{winter, date(time.December, 25), true},
+		{winter, date(time.January, 1), true},
+		{winter, date(time.February, 1), false},
+	}
+	for _, c := range cases {
+		if got := c.event.ActiveOn(c.t); got != c.want {
+			t.Errorf("%s-%s on %s: expected %v, got %v", c.event.Start, c.event.End, c.t.Format("01-02"), c.want, got)
+		}
+	}
+}
+
+func TestSpawnWeight(t *testing.T) {
+	active := Active(Builtin, date(time.October, 31))
+	if got := SpawnWeight(active, []string{"ghost", "poison"}); got != 2 {
+		t.Errorf("expected gengar to be boosted, got %v", got)
+	}
+	if got := SpawnWeight(active, []string{"normal"}); got != 1 {
+		t.Errorf("expected rattata not to be boosted, got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (Event{Name: "bad", Start: "13-01", End: "01-01"}).validate(); err == nil {
+		t.Errorf("expected invalid month to fail")
+	}
+}
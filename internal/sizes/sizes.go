@@ -0,0 +1,67 @@
+// Package sizes rolls how big an individual Pokémon is compared to its
+// species and keeps the biggest and smallest ever caught.
+package sizes
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	Min = 0.5
+	Max = 1.5
+)
+
+// Roll returns a size multiplier. Most Pokémon are close to 1; very large or
+// very small ones are rare.
+func Roll(r *rand.Rand) float64 {
+	scale := 1 + r.NormFloat64()*0.1
+	if scale < Min {
+		return Min
+	}
+	if scale > Max {
+		return Max
+	}
+	return scale
+}
+
+// Measurement is an individual's size in PokeAPI units: decimetres and
+// hectograms.
+type Measurement struct {
+	Height float64   `json:"height"`
+	Weight float64   `json:"weight"`
+	At     time.Time `json:"at"`
+}
+
+// Measure scales a species' base height and weight. Weight grows with the
+// square of the scale so big Pokémon are noticeably heavier.
+func Measure(baseHeight, baseWeight int, scale float64, at time.Time) Measurement {
+	return Measurement{
+		Height: float64(baseHeight) * scale,
+		Weight: float64(baseWeight) * scale * scale,
+		At:     at,
+	}
+}
+
+func (m Measurement) String() string {
+	return fmt.Sprintf("%.2f m, %.1f kg", m.Height/10, m.Weight/10)
+}
+
+type Record struct {
+	Biggest  Measurement `json:"biggest"`
+	Smallest Measurement `json:"smallest"`
+}
+
+// Update stores m if it beats either record and reports which ones it beat.
+func (r *Record) Update(m Measurement) (biggest, smallest bool) {
+	if r.Biggest.At.IsZero() || m.Height > r.Biggest.Height {
+		r.Biggest = m
+		biggest = true
+	}
+	if r.Smallest.At.IsZero() || m.Height < r.Smallest.Height {
+		r.Smallest = m
+		smallest = true
+	}
+	return biggest, smallest
+}
@@ -0,0 +1,33 @@
+package sizes
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRoll(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if scale := Roll(r); scale < Min || scale > Max {
+			t.Fatalf("expected scale within [%v, %v], got %v", Min, Max, scale)
+		}
+	}
+}
+
+func TestRecordUpdate(t *testing.T) {
+	var r Record
+	now := time.Now()
+	if big, small := r.Update(Measure(4, 60, 1, now)); !big || !small {
+		t.Errorf("expected the first measurement to set both records")
+	}
+	if big, small := r.Update(Measure(4, 60, 1.2, now)); !big || small {
+		t.Errorf("expected a larger Pokémon to only beat the biggest record")
+	}
+	if big, small := r.Update(Measure(4, 60, 0.9, now)); big || !small {
+		t.Errorf("expected a smaller Pokémon to only beat the smallest record")
+	}
+	if r.Biggest.Height != 4*1.2 || r.Smallest.Height != 4*0.9 {
+		t.Errorf("unexpected records %+v", r)
+	}
+}
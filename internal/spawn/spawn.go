@@ -0,0 +1,46 @@
+// Package spawn picks wild Pokémon the way the games do: from an area's
+// encounter slots, each as likely as PokeAPI's encounter rates say.
+package spawn
+
+import "math/rand"
+
+// Slot is one way of meeting a Pokémon in an area: the percent chance of
+// it being the one met, by Method, and the levels it comes at.
+type Slot struct {
+	Pokemon  string
+	Method   string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+}
+
+// Wild is a Pokémon that appeared.
+type Wild struct {
+	Pokemon string
+	Level   int
+	Method  string
+}
+
+// Pick returns a Pokémon from slots, weighted by their chances, at a level
+// in its slot's range. It reports false if no slot has any chance.
+func Pick(slots []Slot, r *rand.Rand) (Wild, bool) {
+	total := 0
+	for _, s := range slots {
+		total += max(s.Chance, 0)
+	}
+	if total == 0 {
+		return Wild{}, false
+	}
+	n := r.Intn(total)
+	for _, s := range slots {
+		if n < max(s.Chance, 0) {
+			level := s.MinLevel
+			if s.MaxLevel > s.MinLevel {
+				level += r.Intn(s.MaxLevel - s.MinLevel + 1)
+			}
+			return Wild{Pokemon: s.Pokemon, Level: level, Method: s.Method}, true
+		}
+		n -= max(s.Chance, 0)
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,34 @@
+package spawn
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPick(t *testing.T) {
+	slots := []Slot{
+		{Pokemon: "pidgey", Method: "walk", Chance: 70, MinLevel: 2, MaxLevel: 5},
+		{Pokemon: "rattata", Method: "walk", Chance: 30, MinLevel: 3, MaxLevel: 3},
+		{Pokemon: "mew", Method: "walk", Chance: 0, MinLevel: 50, MaxLevel: 50},
+	}
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		wild, ok := Pick(slots, r)
+		if !ok {
+			t.Fatal("expected a Pokémon")
+		}
+		counts[wild.Pokemon]++
+		switch {
+		case wild.Pokemon == "pidgey" && (wild.Level < 2 || wild.Level > 5),
+			wild.Pokemon == "rattata" && wild.Level != 3:
+			t.Fatalf("%s came at level %d, outside its slot", wild.Pokemon, wild.Level)
+		}
+	}
+	if counts["mew"] != 0 || counts["pidgey"] < 2*counts["rattata"] {
+		t.Errorf("expected picks to follow the chances, got %v", counts)
+	}
+	if _, ok := Pick(nil, r); ok {
+		t.Error("expected nothing from no slots")
+	}
+}
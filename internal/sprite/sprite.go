@@ -0,0 +1,76 @@
+// Package sprite draws Pokémon sprites in the terminal as ANSI art: two
+// pixels to a character, with the top one as the upper half block's colour
+// and the bottom one as its background.
+package sprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png"
+	"io"
+	"strings"
+)
+
+// reset ends a coloured run.
+const reset = "\x1b[0m"
+
+// Decode reads a sprite, as PokeAPI serves them, as PNG.
+func Decode(r io.Reader) (image.Image, error) {
+	img, _, err := image.Decode(r)
+	return img, err
+}
+
+// Render draws img at most width columns wide, cropped to its opaque
+// pixels and scaled down if it doesn't fit. Each line ends with a reset.
+func Render(img image.Image, width int) string {
+	box := opaque(img)
+	if box.Empty() {
+		return ""
+	}
+	scale := 1
+	if width > 0 {
+		scale = (box.Dx() + width - 1) / width
+	}
+	w, h := (box.Dx()+scale-1)/scale, (box.Dy()+scale-1)/scale
+	at := func(x, y int) color.NRGBA {
+		if y >= h {
+			return color.NRGBA{}
+		}
+		return color.NRGBAModel.Convert(img.At(box.Min.X+x*scale, box.Min.Y+y*scale)).(color.NRGBA)
+	}
+
+	var b strings.Builder
+	for y := 0; y < h; y += 2 {
+		for x := 0; x < w; x++ {
+			top, bottom := at(x, y), at(x, y+1)
+			switch {
+			case top.A < 128 && bottom.A < 128:
+				b.WriteString(reset + " ")
+			case top.A < 128:
+				fmt.Fprintf(&b, "%s\x1b[38;2;%d;%d;%dm▄", reset, bottom.R, bottom.G, bottom.B)
+			case bottom.A < 128:
+				fmt.Fprintf(&b, "%s\x1b[38;2;%d;%d;%dm▀", reset, top.R, top.G, top.B)
+			default:
+				fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%d;48;2;%d;%d;%dm▀", top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+			}
+		}
+		b.WriteString(reset + "\n")
+	}
+	return b.String()
+}
+
+// opaque is the smallest rectangle holding every pixel of img that isn't
+// mostly transparent.
+func opaque(img image.Image) image.Rectangle {
+	var box image.Rectangle
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a >= 0x8000 {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return box
+}
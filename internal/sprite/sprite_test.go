@@ -0,0 +1,46 @@
+package sprite
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	red, blue := color.NRGBA{255, 0, 0, 255}, color.NRGBA{0, 0, 255, 255}
+	// A 2x3 sprite in the middle of transparent padding.
+	for x := 4; x < 6; x++ {
+		img.Set(x, 3, red)
+		img.Set(x, 4, blue)
+		img.Set(x, 5, red)
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	decoded, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	art := Render(decoded, 80)
+	lines := strings.Split(strings.TrimSuffix(art, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected the sprite cropped to 2 lines, got %q", art)
+	}
+	if want := "\x1b[38;2;255;0;0;48;2;0;0;255m▀"; !strings.HasPrefix(lines[0], want) || strings.Count(lines[0], "▀") != 2 {
+		t.Errorf("expected red over blue, two wide, got %q", lines[0])
+	}
+	if want := reset + "\x1b[38;2;255;0;0m▀"; !strings.HasPrefix(lines[1], want) {
+		t.Errorf("expected red over nothing, got %q", lines[1])
+	}
+
+	if art := Render(decoded, 1); strings.Count(art, "▀") != 1 || strings.Count(art, "\n") != 1 {
+		t.Errorf("expected the sprite scaled to one column, got %q", art)
+	}
+	if art := Render(image.NewNRGBA(image.Rect(0, 0, 4, 4)), 80); art != "" {
+		t.Errorf("expected nothing for a blank sprite, got %q", art)
+	}
+}
@@ -0,0 +1,94 @@
+// Package stamina paces travel between areas. Travelling costs stamina
+// depending on how far apart the areas are, and stamina comes back over
+// time or with items.
+package stamina
+
+import "time"
+
+// Max is the most stamina a trainer can have.
+const Max = 100
+
+// RegenEvery is how long it takes to get one point of stamina back.
+const RegenEvery = time.Minute
+
+// Place is where an area is: its location and region.
+type Place struct {
+	Area     string `json:"area"`
+	Location string `json:"location"`
+	Region   string `json:"region"`
+}
+
+// Costs of travelling to another area of the same location, another
+// location in the same region, and another region.
+const (
+	SameLocation = 5
+	SameRegion   = 15
+	OtherRegion  = 40
+)
+
+// Cost is how much stamina it takes to travel from one place to another.
+// Nothing is known about the distance between places beyond which location
+// and region they are in.
+func Cost(from, to Place) int {
+	switch {
+	case from.Area == "" || from.Area == to.Area:
+		return 0
+	case from.Location != "" && from.Location == to.Location:
+		return SameLocation
+	case from.Region != "" && from.Region == to.Region:
+		return SameRegion
+	}
+	return OtherRegion
+}
+
+// Items restore stamina by the given amounts.
+var Items = map[string]int{
+	"fresh-water": 30,
+	"soda-pop":    50,
+	"lemonade":    70,
+}
+
+// Stamina is how much stamina was left at a point in time. The zero value
+// is full.
+type Stamina struct {
+	Spent int       `json:"spent"`
+	At    time.Time `json:"at"`
+}
+
+func (s Stamina) Current(now time.Time) int {
+	spent := s.Spent - int(now.Sub(s.At)/RegenEvery)
+	if spent < 0 {
+		spent = 0
+	}
+	return Max - spent
+}
+
+// Full is how long until stamina is back to Max.
+func (s Stamina) Full(now time.Time) time.Duration {
+	return time.Duration(Max-s.Current(now)) * RegenEvery
+}
+
+func (s *Stamina) set(current int, now time.Time) {
+	if current > Max {
+		current = Max
+	}
+	s.Spent, s.At = Max-current, now
+}
+
+// Spend takes cost from the current stamina and reports whether there was
+// enough.
+func (s *Stamina) Spend(cost int, now time.Time) bool {
+	current := s.Current(now)
+	if cost > current {
+		return false
+	}
+	s.set(current-cost, now)
+	return true
+}
+
+// Restore gives back up to n points and returns how many were restored.
+func (s *Stamina) Restore(n int, now time.Time) int {
+	current := s.Current(now)
+	s.set(current+n, now)
+	return s.Current(now) - current
+}
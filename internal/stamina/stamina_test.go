@@ -0,0 +1,53 @@
+package stamina
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCost(t *testing.T) {
+	forest := Place{Area: "eterna-forest-area", Location: "eterna-forest", Region: "sinnoh"}
+	tests := []struct {
+		to   Place
+		want int
+	}{
+		{forest, 0},
+		{Place{Area: "eterna-forest-inside", Location: "eterna-forest", Region: "sinnoh"}, SameLocation},
+		{Place{Area: "route-205-area", Location: "route-205", Region: "sinnoh"}, SameRegion},
+		{Place{Area: "viridian-forest-area", Location: "viridian-forest", Region: "kanto"}, OtherRegion},
+	}
+	for _, test := range tests {
+		if got := Cost(forest, test.to); got != test.want {
+			t.Errorf("Cost to %s = %d, want %d", test.to.Area, got, test.want)
+		}
+	}
+	if got := Cost(Place{}, forest); got != 0 {
+		t.Errorf("expected the first trip to be free, got %d", got)
+	}
+}
+
+func TestSpendAndRegenerate(t *testing.T) {
+	var s Stamina
+	now := time.Now()
+	if s.Current(now) != Max {
+		t.Fatalf("expected to start full, got %d", s.Current(now))
+	}
+	if !s.Spend(OtherRegion, now) || !s.Spend(OtherRegion, now) {
+		t.Fatal("expected to afford two long trips")
+	}
+	if s.Spend(OtherRegion, now) {
+		t.Error("expected a third long trip to be too much")
+	}
+	if got := s.Current(now.Add(10 * RegenEvery)); got != 30 {
+		t.Errorf("expected 30 stamina after resting, got %d", got)
+	}
+	if got := s.Full(now); got != 80*RegenEvery {
+		t.Errorf("expected to be full in %v, got %v", 80*RegenEvery, got)
+	}
+	if got := s.Restore(Items["lemonade"], now); got != 70 {
+		t.Errorf("expected lemonade to restore 70, got %d", got)
+	}
+	if got := s.Restore(Items["lemonade"], now); got != 10 {
+		t.Errorf("expected stamina to stop at the max, restored %d", got)
+	}
+}
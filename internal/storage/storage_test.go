@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+	in := map[string]int{"pokeball": 3}
+	if err := WriteJSON(path, in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out map[string]int
+	if err := ReadJSON(path, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out["pokeball"] != 3 {
+		t.Errorf("expected 3, got %d", out["pokeball"])
+	}
+}
+
+func TestReadMissing(t *testing.T) {
+	out := map[string]int{"kept": 1}
+	if err := ReadJSON(filepath.Join(t.TempDir(), "missing.json"), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out["kept"] != 1 {
+		t.Errorf("expected value to be left untouched")
+	}
+}
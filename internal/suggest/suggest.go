@@ -0,0 +1,77 @@
+// Package suggest predicts the line a player will type next from what they
+// typed before, with a small n-gram model over their commands.
+package suggest
+
+import "strings"
+
+// Order is how many earlier commands a prediction looks at, at most.
+const Order = 2
+
+// A prediction needs its context to have come up at least minSeen times,
+// and to have been followed by the same line at least half of them.
+const minSeen = 3
+
+// Model counts which lines follow which commands.
+type Model struct {
+	// next maps a context, the names of the last one or more commands run,
+	// to how often each line came next.
+	next   map[string]map[string]int
+	recent []string
+}
+
+// New returns a model that has seen nothing yet.
+func New() *Model {
+	return &Model{next: make(map[string]map[string]int)}
+}
+
+// Observe records that line was entered after the lines observed before.
+func (m *Model) Observe(line string) {
+	line = strings.Join(strings.Fields(line), " ")
+	if line == "" {
+		return
+	}
+	for n := 1; n <= min(Order, len(m.recent)); n++ {
+		key := context(m.recent[len(m.recent)-n:])
+		if m.next[key] == nil {
+			m.next[key] = make(map[string]int)
+		}
+		m.next[key][line]++
+	}
+	m.recent = append(m.recent, line)
+	if len(m.recent) > Order {
+		m.recent = m.recent[1:]
+	}
+}
+
+// Predict returns the line that usually comes next, preferring what
+// followed the longest matching context. It doesn't predict the last line
+// again.
+func (m *Model) Predict() (string, bool) {
+	if len(m.recent) == 0 {
+		return "", false
+	}
+	last := m.recent[len(m.recent)-1]
+	for n := min(Order, len(m.recent)); n >= 1; n-- {
+		counts := m.next[context(m.recent[len(m.recent)-n:])]
+		best, bestCount, total := "", 0, 0
+		for line, count := range counts {
+			total += count
+			if count > bestCount || count == bestCount && line < best {
+				best, bestCount = line, count
+			}
+		}
+		if total >= minSeen && bestCount*2 >= total && best != last {
+			return best, true
+		}
+	}
+	return "", false
+}
+
+// context is the key for lines: their command names.
+func context(lines []string) string {
+	names := make([]string, len(lines))
+	for i, line := range lines {
+		names[i], _, _ = strings.Cut(line, " ")
+	}
+	return strings.Join(names, " ")
+}
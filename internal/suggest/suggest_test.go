@@ -0,0 +1,43 @@
+package suggest
+
+import "testing"
+
+func TestPredict(t *testing.T) {
+	m := New()
+	for i := 0; i < 3; i++ {
+		m.Observe("map")
+		m.Observe("explore great-marsh-area-1")
+		m.Observe("catch wooper")
+	}
+	m.Observe("map")
+	if got, ok := m.Predict(); !ok || got != "explore great-marsh-area-1" {
+		t.Errorf("got %q, %v; want explore great-marsh-area-1", got, ok)
+	}
+
+	// After exploring, catch follows every time.
+	m.Observe("explore  great-marsh-area-1")
+	if got, ok := m.Predict(); !ok || got != "catch wooper" {
+		t.Errorf("got %q, %v; want catch wooper", got, ok)
+	}
+}
+
+func TestPredictNeedsHistory(t *testing.T) {
+	m := New()
+	if _, ok := m.Predict(); ok {
+		t.Error("predicted with no history")
+	}
+	m.Observe("map")
+	m.Observe("explore a")
+	m.Observe("map")
+	if got, ok := m.Predict(); ok {
+		t.Errorf("predicted %q from one example", got)
+	}
+	// Two different areas each time: neither is usual.
+	for _, area := range []string{"b", "c", "d"} {
+		m.Observe("explore " + area)
+		m.Observe("map")
+	}
+	if got, ok := m.Predict(); ok {
+		t.Errorf("predicted %q without a usual next line", got)
+	}
+}
@@ -0,0 +1,82 @@
+// Package tower generates the opponents of the Battle Tower and keeps its
+// streak records.
+package tower
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Tiers holds opponent species from weakest to strongest. Later rounds draw
+// from stronger tiers.
+var Tiers = [][]string{
+	{"rattata", "pidgey", "caterpie", "weedle", "zubat", "magikarp"},
+	{"pikachu", "growlithe", "machop", "geodude", "ponyta", "psyduck"},
+	{"arcanine", "machamp", "golem", "gengar", "alakazam", "gyarados"},
+	{"dragonite", "snorlax", "lapras", "tyranitar", "salamence", "metagross"},
+}
+
+// RoundsPerTier is how many rounds are fought before moving up a tier.
+const RoundsPerTier = 3
+
+// Milestones are the items awarded for reaching a streak.
+var Milestones = map[int]string{
+	3:  "rare-candy",
+	5:  "ultra-ball",
+	10: "master-ball",
+}
+
+// PotionHeal is how much HP a potion restores between rounds.
+const PotionHeal = 20
+
+type Opponent struct {
+	Species string
+	Level   int
+}
+
+// Next returns the opponent for a round, starting at round 1, whose level
+// climbs from startLevel.
+func Next(round, startLevel int, r *rand.Rand) Opponent {
+	tier := (round - 1) / RoundsPerTier
+	if tier >= len(Tiers) {
+		tier = len(Tiers) - 1
+	}
+	species := Tiers[tier][r.Intn(len(Tiers[tier]))]
+	return Opponent{Species: species, Level: startLevel + 2*(round-1)}
+}
+
+type Entry struct {
+	Trainer string    `json:"trainer"`
+	Pokemon string    `json:"pokemon"`
+	Streak  int       `json:"streak"`
+	At      time.Time `json:"at"`
+}
+
+// MaxEntries is how many streaks the leaderboard keeps.
+const MaxEntries = 10
+
+// Leaderboard holds the best streaks, longest first.
+type Leaderboard []Entry
+
+// Add records a finished streak and reports its rank, starting at 1, or 0 if
+// it did not make the board.
+func (l *Leaderboard) Add(e Entry) int {
+	if e.Streak == 0 {
+		return 0
+	}
+	board := append(*l, e)
+	sort.SliceStable(board, func(i, j int) bool {
+		return board[i].Streak > board[j].Streak
+	})
+	if len(board) > MaxEntries {
+		board = board[:MaxEntries]
+	}
+	*l = board
+	for i := range board {
+		if board[i] == e {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package tower
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	first := Next(1, 10, r)
+	if first.Level != 10 {
+		t.Errorf("expected the first opponent at the start level, got %d", first.Level)
+	}
+	late := Next(100, 10, r)
+	found := false
+	for _, species := range Tiers[len(Tiers)-1] {
+		found = found || species == late.Species
+	}
+	if !found {
+		t.Errorf("expected late rounds to use the strongest tier, got %s", late.Species)
+	}
+	if late.Level <= first.Level {
+		t.Errorf("expected opponents to get stronger")
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	var board Leaderboard
+	now := time.Now()
+	if rank := board.Add(Entry{Pokemon: "pikachu", Streak: 0, At: now}); rank != 0 {
+		t.Errorf("expected an empty streak not to be ranked")
+	}
+	board.Add(Entry{Pokemon: "pikachu", Streak: 3, At: now})
+	if rank := board.Add(Entry{Pokemon: "gengar", Streak: 7, At: now}); rank != 1 {
+		t.Errorf("expected the longer streak to rank first, got %d", rank)
+	}
+	for i := 0; i < MaxEntries; i++ {
+		board.Add(Entry{Pokemon: "zubat", Streak: 5, At: now.Add(time.Duration(i))})
+	}
+	if len(board) != MaxEntries {
+		t.Errorf("expected %d entries, got %d", MaxEntries, len(board))
+	}
+	if board[len(board)-1].Streak != 5 {
+		t.Errorf("expected the shortest streak to drop off")
+	}
+}
@@ -0,0 +1,171 @@
+// Package trainer keeps the player's trainer profile: the experience and
+// level they earn by catching Pokémon, their daily catch streak, and the
+// achievements they unlock along the way.
+package trainer
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxLevel is the highest level a trainer can reach.
+const MaxLevel = 50
+
+// MinCatchXP is the experience for catching a Pokémon whose base
+// experience is unknown or tiny.
+const MinCatchXP = 10
+
+// Profile is the trainer's progress.
+type Profile struct {
+	XP int `json:"xp"`
+	// Streak is how many days in a row, up to LastCatch, the trainer has
+	// caught a Pokémon; Best is the longest streak so far.
+	Streak    int    `json:"streak"`
+	Best      int    `json:"best_streak"`
+	LastCatch string `json:"last_catch,omitempty"`
+	// Types counts the Pokémon caught of each type.
+	Types map[string]int `json:"types"`
+	// Regions are the locations explored in each region, and how many can
+	// be, once known.
+	Regions      map[string]*Region `json:"regions"`
+	Achievements []Unlocked         `json:"achievements"`
+}
+
+// Region is what the trainer has explored of a region. Explorable is the
+// number of its locations with areas to explore, or 0 until looked up.
+type Region struct {
+	Explored   []string `json:"explored"`
+	Explorable int      `json:"explorable"`
+}
+
+// Unlocked is an achievement the trainer has, and when it was unlocked.
+type Unlocked struct {
+	Name string    `json:"name"`
+	At   time.Time `json:"at"`
+}
+
+// XPFor is the experience needed to reach a level from level 1: 100 for
+// level 2, 300 for level 3, 600 for level 4 and so on.
+func XPFor(level int) int {
+	return 50 * level * (level - 1)
+}
+
+// Level is the trainer's level for their experience.
+func (p *Profile) Level() int {
+	level := 1
+	for level < MaxLevel && p.XP >= XPFor(level+1) {
+		level++
+	}
+	return level
+}
+
+// CatchXP is the experience for catching a Pokémon with the given base
+// experience: the more experience the species gives in battle, the more
+// catching it is worth.
+func CatchXP(baseExperience int) int {
+	return max(baseExperience, MinCatchXP)
+}
+
+// Catch records a Pokémon of types caught at now, worth xp. It reports the
+// levels gained, if any.
+func (p *Profile) Catch(types []string, xp int, now time.Time) int {
+	before := p.Level()
+	p.XP += xp
+	if p.Types == nil {
+		p.Types = make(map[string]int)
+	}
+	for _, t := range types {
+		p.Types[t]++
+	}
+
+	today := now.Format("2006-01-02")
+	switch p.LastCatch {
+	case today:
+	case now.AddDate(0, 0, -1).Format("2006-01-02"):
+		p.Streak++
+	default:
+		p.Streak = 1
+	}
+	p.LastCatch = today
+	p.Best = max(p.Best, p.Streak)
+	return p.Level() - before
+}
+
+// CurrentStreak is the streak still alive at now: it lapses once a whole
+// day passes without a catch.
+func (p *Profile) CurrentStreak(now time.Time) int {
+	if p.LastCatch == now.Format("2006-01-02") || p.LastCatch == now.AddDate(0, 0, -1).Format("2006-01-02") {
+		return p.Streak
+	}
+	return 0
+}
+
+// Explore records exploring a location in a region.
+func (p *Profile) Explore(region, location string) {
+	if p.Regions == nil {
+		p.Regions = make(map[string]*Region)
+	}
+	r := p.Regions[region]
+	if r == nil {
+		r = &Region{}
+		p.Regions[region] = r
+	}
+	if !slices.Contains(r.Explored, location) {
+		r.Explored = append(r.Explored, location)
+	}
+}
+
+// Achievement is unlocked by catching Count Pokémon of Type, exploring
+// every location of Region, keeping up a Streak, or reaching Level.
+type Achievement struct {
+	Name        string
+	Description string
+	Type        string
+	Count       int
+	Region      string
+	Streak      int
+	Level       int
+}
+
+// Achievements are every achievement there is.
+var Achievements = []Achievement{
+	{Name: "Tide Caller", Description: "Caught 10 water types", Type: "water", Count: 10},
+	{Name: "Firestarter", Description: "Caught 10 fire types", Type: "fire", Count: 10},
+	{Name: "Green Thumb", Description: "Caught 10 grass types", Type: "grass", Count: 10},
+	{Name: "Live Wire", Description: "Caught 10 electric types", Type: "electric", Count: 10},
+	{Name: "Kanto Cartographer", Description: "Explored all of Kanto", Region: "kanto"},
+	{Name: "Johto Cartographer", Description: "Explored all of Johto", Region: "johto"},
+	{Name: "Dedicated", Description: "Caught Pokémon 7 days in a row", Streak: 7},
+	{Name: "Veteran", Description: "Reached trainer level 10", Level: 10},
+}
+
+func (p *Profile) met(a Achievement) bool {
+	switch {
+	case a.Type != "":
+		return p.Types[a.Type] >= a.Count
+	case a.Region != "":
+		r := p.Regions[a.Region]
+		return r != nil && r.Explorable > 0 && len(r.Explored) >= r.Explorable
+	case a.Streak > 0:
+		return p.Best >= a.Streak
+	}
+	return p.Level() >= a.Level
+}
+
+// Has reports whether the trainer has unlocked the achievement called name.
+func (p *Profile) Has(name string) bool {
+	return slices.ContainsFunc(p.Achievements, func(u Unlocked) bool { return u.Name == name })
+}
+
+// Unlock unlocks, at now, the achievements the trainer has met since last
+// time, and returns them.
+func (p *Profile) Unlock(now time.Time) []Achievement {
+	var unlocked []Achievement
+	for _, a := range Achievements {
+		if !p.Has(a.Name) && p.met(a) {
+			p.Achievements = append(p.Achievements, Unlocked{Name: a.Name, At: now})
+			unlocked = append(unlocked, a)
+		}
+	}
+	return unlocked
+}
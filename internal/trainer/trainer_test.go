@@ -0,0 +1,61 @@
+package trainer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLevel(t *testing.T) {
+	tests := []struct{ xp, level int }{{0, 1}, {99, 1}, {100, 2}, {299, 2}, {300, 3}, {1 << 30, MaxLevel}}
+	for _, tt := range tests {
+		p := Profile{XP: tt.xp}
+		if got := p.Level(); got != tt.level {
+			t.Errorf("Level() with %d XP = %d, want %d", tt.xp, got, tt.level)
+		}
+	}
+}
+
+func TestCatch(t *testing.T) {
+	day := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	var p Profile
+	if gained := p.Catch([]string{"water"}, CatchXP(64), day); gained != 0 {
+		t.Errorf("expected no level from 64 XP, got %d", gained)
+	}
+	if gained := p.Catch([]string{"water", "flying"}, CatchXP(189), day.Add(time.Hour)); gained != 1 {
+		t.Errorf("expected a level from 253 XP, got %d", gained)
+	}
+	p.Catch(nil, CatchXP(0), day.AddDate(0, 0, 1))
+	if p.XP != 263 || p.Types["water"] != 2 || p.Types["flying"] != 1 {
+		t.Errorf("unexpected profile %+v", p)
+	}
+	if p.Streak != 2 || p.CurrentStreak(day.AddDate(0, 0, 2)) != 2 {
+		t.Errorf("expected a 2 day streak, got %d", p.Streak)
+	}
+	if got := p.CurrentStreak(day.AddDate(0, 0, 3)); got != 0 {
+		t.Errorf("expected the streak to lapse after a day without catches, got %d", got)
+	}
+	p.Catch(nil, 10, day.AddDate(0, 0, 5))
+	if p.Streak != 1 || p.Best != 2 {
+		t.Errorf("expected a new streak with the best kept, got %d and %d", p.Streak, p.Best)
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	now := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	p := Profile{Types: map[string]int{"water": 10}}
+	p.Explore("kanto", "pallet-town")
+	p.Explore("kanto", "viridian-forest")
+	p.Explore("kanto", "viridian-forest")
+
+	got := p.Unlock(now)
+	if len(got) != 1 || got[0].Name != "Tide Caller" {
+		t.Fatalf("expected only Tide Caller, got %v", got)
+	}
+	if got := p.Unlock(now); len(got) != 0 {
+		t.Errorf("expected nothing new, got %v", got)
+	}
+	p.Regions["kanto"].Explorable = 2
+	if got := p.Unlock(now); len(got) != 1 || got[0].Name != "Kanto Cartographer" {
+		t.Errorf("expected Kanto Cartographer, got %v", got)
+	}
+}
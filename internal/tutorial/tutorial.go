@@ -0,0 +1,47 @@
+package tutorial
+
+import "github.com/eymardfreire/pokedexcli/internal/events"
+
+// Reward is the number of Poké Balls handed out when the tutorial is done.
+const Reward = 5
+
+type Step struct {
+	Instruction string
+	Event       events.Kind
+}
+
+var Steps = []Step{
+	{Instruction: "Run `map` to list some location areas.", Event: events.MapViewed},
+	{Instruction: "Run `explore <area_name>` on one of the areas you found.", Event: events.AreaExplored},
+	{Instruction: "Run `catch <pokemon_name>` until you catch one of the Pokémon you saw.", Event: events.PokemonCaught},
+	{Instruction: "Run `inspect <pokemon_name>` on the Pokémon you caught.", Event: events.PokemonInspected},
+}
+
+type Progress struct {
+	Started   bool `json:"started"`
+	Step      int  `json:"step"`
+	Completed bool `json:"completed"`
+}
+
+// Current returns the step the player is on, or false when the tutorial is
+// not running.
+func (p *Progress) Current() (Step, bool) {
+	if !p.Started || p.Completed {
+		return Step{}, false
+	}
+	return Steps[p.Step], true
+}
+
+// Advance moves to the next step if e is what the current step asks for.
+// It reports whether the step changed.
+func (p *Progress) Advance(e events.Event) bool {
+	step, ok := p.Current()
+	if !ok || step.Event != e.Kind {
+		return false
+	}
+	p.Step++
+	if p.Step == len(Steps) {
+		p.Completed = true
+	}
+	return true
+}
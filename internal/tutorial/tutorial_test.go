@@ -0,0 +1,30 @@
+package tutorial
+
+import (
+	"testing"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+)
+
+func TestAdvance(t *testing.T) {
+	var p Progress
+	if p.Advance(events.Event{Kind: events.MapViewed}) {
+		t.Errorf("expected no progress before the tutorial starts")
+	}
+
+	p.Started = true
+	if p.Advance(events.Event{Kind: events.PokemonCaught}) {
+		t.Errorf("expected steps to be done in order")
+	}
+	for _, step := range Steps {
+		if !p.Advance(events.Event{Kind: step.Event}) {
+			t.Fatalf("expected %s to advance the tutorial", step.Event)
+		}
+	}
+	if !p.Completed {
+		t.Errorf("expected tutorial to be completed")
+	}
+	if _, ok := p.Current(); ok {
+		t.Errorf("expected no current step after completion")
+	}
+}
@@ -0,0 +1,189 @@
+// Package types holds the type effectiveness charts used by battles, one
+// per generation of the games.
+package types
+
+import (
+	"slices"
+	"sort"
+)
+
+// Generations that changed the type chart. Every generation uses the chart
+// of the latest entry at or before it.
+const (
+	Gen1   = 1
+	Gen2   = 2
+	Gen6   = 6
+	Latest = 9
+)
+
+// chart maps an attacking type to the defending types it is not neutral
+// against.
+type chart map[string]map[string]float64
+
+var modern = chart{
+	"normal":   {"rock": 0.5, "ghost": 0, "steel": 0.5},
+	"fire":     {"fire": 0.5, "water": 0.5, "grass": 2, "ice": 2, "bug": 2, "rock": 0.5, "dragon": 0.5, "steel": 2},
+	"water":    {"fire": 2, "water": 0.5, "grass": 0.5, "ground": 2, "rock": 2, "dragon": 0.5},
+	"electric": {"water": 2, "electric": 0.5, "grass": 0.5, "ground": 0, "flying": 2, "dragon": 0.5},
+	"grass":    {"fire": 0.5, "water": 2, "grass": 0.5, "poison": 0.5, "ground": 2, "flying": 0.5, "bug": 0.5, "rock": 2, "dragon": 0.5, "steel": 0.5},
+	"ice":      {"fire": 0.5, "water": 0.5, "grass": 2, "ice": 0.5, "ground": 2, "flying": 2, "dragon": 2, "steel": 0.5},
+	"fighting": {"normal": 2, "ice": 2, "poison": 0.5, "flying": 0.5, "psychic": 0.5, "bug": 0.5, "rock": 2, "ghost": 0, "dark": 2, "steel": 2, "fairy": 0.5},
+	"poison":   {"grass": 2, "poison": 0.5, "ground": 0.5, "rock": 0.5, "ghost": 0.5, "steel": 0, "fairy": 2},
+	"ground":   {"fire": 2, "electric": 2, "grass": 0.5, "poison": 2, "flying": 0, "bug": 0.5, "rock": 2, "steel": 2},
+	"flying":   {"electric": 0.5, "grass": 2, "fighting": 2, "bug": 2, "rock": 0.5, "steel": 0.5},
+	"psychic":  {"fighting": 2, "poison": 2, "psychic": 0.5, "dark": 0, "steel": 0.5},
+	"bug":      {"fire": 0.5, "grass": 2, "fighting": 0.5, "poison": 0.5, "flying": 0.5, "psychic": 2, "ghost": 0.5, "dark": 2, "steel": 0.5, "fairy": 0.5},
+	"rock":     {"fire": 2, "ice": 2, "fighting": 0.5, "ground": 0.5, "flying": 2, "bug": 2, "steel": 0.5},
+	"ghost":    {"normal": 0, "psychic": 2, "ghost": 2, "dark": 0.5},
+	"dragon":   {"dragon": 2, "steel": 0.5, "fairy": 0},
+	"dark":     {"fighting": 0.5, "psychic": 2, "ghost": 2, "dark": 0.5, "fairy": 0.5},
+	"steel":    {"fire": 0.5, "water": 0.5, "electric": 0.5, "ice": 2, "rock": 2, "steel": 0.5, "fairy": 2},
+	"fairy":    {"fire": 0.5, "fighting": 2, "poison": 0.5, "dragon": 2, "dark": 2, "steel": 0.5},
+}
+
+// order is every type, in the order the games list them.
+var order = []string{
+	"normal", "fire", "water", "electric", "grass", "ice", "fighting", "poison", "ground",
+	"flying", "psychic", "bug", "rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// charts are derived from the modern chart by undoing each generation's
+// changes.
+var charts = map[int]chart{
+	Gen6: modern,
+	Gen2: modern.
+		without("fairy").
+		with("ghost", "steel", 0.5).
+		with("dark", "steel", 0.5),
+	Gen1: modern.
+		without("fairy", "dark", "steel").
+		with("bug", "poison", 2).
+		with("poison", "bug", 2).
+		with("ghost", "psychic", 0).
+		with("ice", "fire", 1),
+}
+
+func (c chart) clone() chart {
+	out := make(chart, len(c))
+	for attack, row := range c {
+		out[attack] = make(map[string]float64, len(row))
+		for defend, v := range row {
+			out[attack][defend] = v
+		}
+	}
+	return out
+}
+
+func (c chart) without(removed ...string) chart {
+	out := c.clone()
+	for _, t := range removed {
+		delete(out, t)
+		for _, row := range out {
+			delete(row, t)
+		}
+	}
+	return out
+}
+
+func (c chart) with(attack, defend string, v float64) chart {
+	out := c.clone()
+	if v == 1 {
+		delete(out[attack], defend)
+	} else {
+		out[attack][defend] = v
+	}
+	return out
+}
+
+func chartFor(gen int) chart {
+	for g := gen; g > 0; g-- {
+		if c, ok := charts[g]; ok {
+			return c
+		}
+	}
+	return modern
+}
+
+// Exists reports whether a type is part of a generation's chart.
+func Exists(gen int, t string) bool {
+	_, ok := chartFor(gen)[t]
+	return ok
+}
+
+// Effectiveness returns the damage multiplier of an attack of type attack
+// against a Pokémon with the defending types. Types that do not exist in
+// the generation are treated as neutral.
+func Effectiveness(gen int, attack string, defending []string) float64 {
+	row := chartFor(gen)[attack]
+	multiplier := 1.0
+	for _, d := range defending {
+		if v, ok := row[d]; ok {
+			multiplier *= v
+		}
+	}
+	return multiplier
+}
+
+// Describe returns the message the games show for a multiplier, or "" for
+// neutral hits.
+func Describe(multiplier float64) string {
+	switch {
+	case multiplier == 0:
+		return "It doesn't affect the target..."
+	case multiplier > 1:
+		return "It's super effective!"
+	case multiplier < 1:
+		return "It's not very effective..."
+	default:
+		return ""
+	}
+}
+
+// Names returns every type of a generation, in the order the games list
+// them.
+func Names(gen int) []string {
+	var names []string
+	for _, t := range order {
+		if Exists(gen, t) {
+			names = append(names, t)
+		}
+	}
+	return names
+}
+
+// Defending returns the multiplier of an attack of each type of the
+// generation against a Pokémon with the defending types, which for two
+// types is the product of each one's.
+func Defending(gen int, defending []string) map[string]float64 {
+	multipliers := make(map[string]float64)
+	for _, attack := range Names(gen) {
+		multipliers[attack] = Effectiveness(gen, attack, defending)
+	}
+	return multipliers
+}
+
+// Group is the types that share a multiplier.
+type Group struct {
+	Multiplier float64
+	Types      []string
+}
+
+// Grouped groups types by their multipliers, highest first, leaving out
+// neutral ones. Each group's types are in the games' order.
+func Grouped(multipliers map[string]float64) []Group {
+	var groups []Group
+	for _, t := range order {
+		m, ok := multipliers[t]
+		if !ok || m == 1 {
+			continue
+		}
+		i := slices.IndexFunc(groups, func(g Group) bool { return g.Multiplier == m })
+		if i < 0 {
+			groups = append(groups, Group{Multiplier: m})
+			i = len(groups) - 1
+		}
+		groups[i].Types = append(groups[i].Types, t)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Multiplier > groups[j].Multiplier })
+	return groups
+}
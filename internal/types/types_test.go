@@ -0,0 +1,67 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEffectiveness(t *testing.T) {
+	cases := []struct {
+		gen       int
+		attack    string
+		defending []string
+		want      float64
+	}{
+		{Latest, "water", []string{"fire"}, 2},
+		{Latest, "electric", []string{"water", "flying"}, 4},
+		{Latest, "fire", []string{"water", "rock"}, 0.25},
+		{Latest, "normal", []string{"ghost"}, 0},
+		{Latest, "dragon", []string{"fairy"}, 0},
+		{Latest, "ghost", []string{"steel"}, 1},
+		{5, "ghost", []string{"steel"}, 0.5},
+		{5, "dragon", []string{"fairy"}, 1},
+		{Gen1, "ghost", []string{"psychic"}, 0},
+		{Gen1, "bug", []string{"poison"}, 2},
+		{Gen1, "ice", []string{"fire"}, 1},
+		{Gen2, "ice", []string{"fire"}, 0.5},
+	}
+	for _, c := range cases {
+		if got := Effectiveness(c.gen, c.attack, c.defending); got != c.want {
+			t.Errorf("gen %d %s vs %v: expected %v, got %v", c.gen, c.attack, c.defending, c.want, got)
+		}
+	}
+}
+
+func TestExists(t *testing.T) {
+	if Exists(Gen1, "dark") || Exists(Gen1, "steel") || Exists(5, "fairy") {
+		t.Errorf("expected types to only exist from the generation they were added")
+	}
+	if !Exists(Latest, "fairy") || !Exists(Gen2, "steel") {
+		t.Errorf("expected modern types to exist")
+	}
+}
+
+func TestNames(t *testing.T) {
+	if n := len(Names(Latest)); n != 18 {
+		t.Errorf("expected 18 types today, got %d", n)
+	}
+	if n := len(Names(Gen1)); n != 15 {
+		t.Errorf("expected 15 types in generation 1, got %d", n)
+	}
+}
+
+func TestDefending(t *testing.T) {
+	// Charizard is fire and flying: rock hits it four times as hard, and
+	// ground not at all.
+	groups := Grouped(Defending(Latest, []string{"fire", "flying"}))
+	want := []Group{
+		{4, []string{"rock"}},
+		{2, []string{"water", "electric"}},
+		{0.5, []string{"fire", "fighting", "steel", "fairy"}},
+		{0.25, []string{"grass", "bug"}},
+		{0, []string{"ground"}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("expected %v, got %v", want, groups)
+	}
+}
@@ -0,0 +1,226 @@
+// Package update finds newer releases of the game on GitHub and installs
+// them over the running binary.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Checksums is the release asset listing each binary's SHA-256, in the
+// format sha256sum prints.
+const Checksums = "checksums.txt"
+
+var ErrChecksum = errors.New("checksum mismatch")
+
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	Assets []Asset `json:"assets"`
+}
+
+func (r Release) Asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Source is where releases are published.
+type Source struct {
+	API  string
+	Repo string
+	HTTP *http.Client
+}
+
+func GitHub(repo string) Source {
+	return Source{API: "https://api.github.com", Repo: repo, HTTP: &http.Client{Timeout: 5 * time.Minute}}
+}
+
+// get fetches url, giving up when ctx is done.
+func (s Source) get(ctx context.Context, url string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.HTTP.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, response.Status)
+	}
+	return io.ReadAll(response.Body)
+}
+
+// Latest looks up the newest release, giving up when ctx is done.
+func (s Source) Latest(ctx context.Context) (Release, error) {
+	var release Release
+	data, err := s.get(ctx, fmt.Sprintf("%s/repos/%s/releases/latest", s.API, s.Repo))
+	if err != nil {
+		return release, err
+	}
+	err = json.Unmarshal(data, &release)
+	return release, err
+}
+
+// Download fetches a release's binary for this platform and checks it
+// against the release's checksums, giving up when ctx is done.
+func (s Source) Download(ctx context.Context, r Release) ([]byte, error) {
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	binary, ok := r.Asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no build for %s/%s", r.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	sums, ok := r.Asset(Checksums)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s", r.Tag, Checksums)
+	}
+	list, err := s.get(ctx, sums.URL)
+	if err != nil {
+		return nil, err
+	}
+	want, err := Checksum(list, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.get(ctx, binary.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(data, want); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// AssetName is what the binary for a platform is called in a release.
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("pokedexcli_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Checksum finds a file's SHA-256 in a checksums list.
+func Checksum(list []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(list))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// sha256sum marks binary files with a leading asterisk.
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no checksum for %s", Checksums, name)
+}
+
+func Verify(data []byte, want string) error {
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksum, want, got)
+	}
+	return nil
+}
+
+// Newer reports whether version a is later than b. Versions look like
+// v1.2.3; anything else isn't newer than anything.
+func Newer(a, b string) bool {
+	va, okA := parse(a)
+	vb, okB := parse(b)
+	if !okA || !okB {
+		return false
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] > vb[i]
+		}
+	}
+	return false
+}
+
+func parse(version string) ([3]int, bool) {
+	var v [3]int
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// Replace swaps the binary at path for data. The new binary is written
+// next to it first so the swap is a rename, which can't leave a half
+// written binary behind. Windows won't rename over a running program, so
+// there the old one is moved aside first.
+func Replace(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".new-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+	return swap(tmp.Name(), path, runtime.GOOS == "windows")
+}
+
+// swap renames the binary at next over the one at path. With aside, the
+// old binary is moved out of the way first, and moved back if the new one
+// can't take its place, so a failed update still leaves one behind.
+func swap(next, path string, aside bool) error {
+	if !aside {
+		return os.Rename(next, path)
+	}
+	old := path + ".old"
+	os.Remove(old)
+	if err := os.Rename(path, old); err != nil {
+		return err
+	}
+	if err := os.Rename(next, path); err != nil {
+		if restoreErr := os.Rename(old, path); restoreErr != nil {
+			return fmt.Errorf("%w; the old binary is left at %s", err, old)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestNewer(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.2.0", "v1.1.9", true},
+		{"v1.10.0", "v1.9.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.2.3", "v2.0.0", false},
+		{"v1.2.3", "dev", false},
+		{"nightly", "v1.0.0", false},
+	}
+	for _, c := range cases {
+		if got := Newer(c.a, c.b); got != c.want {
+			t.Errorf("Newer(%q, %q) = %t, want %t", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestDownload(t *testing.T) {
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	checksums := hex.EncodeToString(sum[:]) + " *" + name + "\n"
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/game/releases/latest":
+			json.NewEncoder(w).Encode(Release{Tag: "v1.1.0", Assets: []Asset{
+				{Name: name, URL: ts.URL + "/bin"},
+				{Name: Checksums, URL: ts.URL + "/sums"},
+			}})
+		case "/bin":
+			w.Write(binary)
+		case "/sums":
+			w.Write([]byte(checksums))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	source := Source{API: ts.URL, Repo: "owner/game", HTTP: ts.Client()}
+	release, err := source.Latest(context.Background())
+	if err != nil || release.Tag != "v1.1.0" {
+		t.Fatalf("expected release v1.1.0, got %+v, %v", release, err)
+	}
+	data, err := source.Download(context.Background(), release)
+	if err != nil || string(data) != string(binary) {
+		t.Fatalf("expected the binary, got %q, %v", data, err)
+	}
+
+	if err := Verify([]byte("something else"), hex.EncodeToString(sum[:])); !errors.Is(err, ErrChecksum) {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+	release.Assets[0].URL = ts.URL + "/missing"
+	if _, err := source.Download(context.Background(), release); err == nil {
+		t.Error("expected a missing binary to fail")
+	}
+}
+
+func TestCancelDownload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+	source := Source{API: ts.URL, Repo: "owner/game", HTTP: ts.Client()}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	release := Release{Tag: "v1.1.0", Assets: []Asset{
+		{Name: AssetName(runtime.GOOS, runtime.GOARCH), URL: ts.URL + "/bin"},
+		{Name: Checksums, URL: ts.URL + "/sums"},
+	}}
+	if _, err := source.Download(ctx, release); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a stalled download to be cancelled, got %v", err)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedexcli")
+	if err := os.WriteFile(path, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replace(path, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "new" {
+		t.Errorf("expected the new binary, got %q", data)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("expected the new binary to be executable, got %v", info.Mode())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected no leftover files, got %d entries", len(entries))
+	}
+}
+
+func TestSwapRestoresOldBinary(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pokedexcli")
+	if err := os.WriteFile(path, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// A new binary that isn't there can't be moved into place.
+	if err := swap(filepath.Join(dir, "missing"), path, true); err == nil {
+		t.Fatal("expected the swap to fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("expected the old binary to be put back, got %q", data)
+	}
+}
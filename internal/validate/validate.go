@@ -0,0 +1,78 @@
+// Package validate checks the names and paths players type before commands
+// use them, so every command turns away the same mistakes with the same
+// messages, and keeps files the game names itself inside their directory.
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// maxName is longer than any name PokeAPI has.
+const maxName = 64
+
+// kindNames are how errors name each kind of name.
+var kindNames = map[string]string{
+	"pokemon": "Pokémon name",
+	"species": "Pokémon name",
+	"area":    "area name",
+	"type":    "type name",
+}
+
+// Namespace starts the names of homebrew species, like custom/voltorbeast,
+// so they can't clash with PokeAPI's.
+const Namespace = "custom/"
+
+// Name tidies a name of a kind, as cli.Arg's Kind names them, into the form
+// PokeAPI uses, lowercase, and checks it is one: letters, digits and
+// hyphens only, after the Namespace for Pokémon. Kinds it doesn't know pass
+// through as they are.
+func Name(kind, name string) (string, error) {
+	what, ok := kindNames[kind]
+	if !ok {
+		return name, nil
+	}
+	tidy := strings.ToLower(strings.TrimSpace(name))
+	bare := tidy
+	if kind == "pokemon" || kind == "species" {
+		bare = strings.TrimPrefix(tidy, Namespace)
+	}
+	if bare == "" || len(tidy) > maxName || strings.IndexFunc(bare, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-'
+	}) >= 0 {
+		return "", fmt.Errorf("%q isn't a %s; they are letters, digits and hyphens, like mr-mime", name, what)
+	}
+	return tidy, nil
+}
+
+// Path tidies a file path the player typed, expanding a leading ~ to their
+// home directory, and checks it is one.
+func Path(path string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("the file name is empty")
+	}
+	if strings.IndexFunc(path, unicode.IsControl) >= 0 {
+		return "", fmt.Errorf("%q has control characters in it, which a file name can't", path)
+	}
+	if rest, ok := strings.CutPrefix(path, "~"+string(filepath.Separator)); ok || path == "~" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	return filepath.Clean(path), nil
+}
+
+// Within joins name under dir, for files the game names from data, like
+// photos named after Pokémon, refusing names that would land outside dir,
+// like "../x" or absolute paths.
+func Within(dir, name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%q would be outside %s", name, dir)
+	}
+	return filepath.Join(dir, name), nil
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestName(t *testing.T) {
+	for in, want := range map[string]string{"Pikachu": "pikachu", " mr-mime ": "mr-mime", "porygon2": "porygon2", "Custom/Voltorbeast": "custom/voltorbeast"} {
+		if got, err := Name("species", in); err != nil || got != want {
+			t.Errorf("%q: got %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "../pikachu", "pika chu", "pikachu;rm", "flabébé", "custom/", "custom/a/b", "other/pikachu"} {
+		if _, err := Name("pokemon", bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+	if _, err := Name("area", "custom/route-1"); err == nil {
+		t.Error("expected only Pokémon to have a namespace")
+	}
+	if got, err := Name("", "Anything at all"); err != nil || got != "Anything at all" {
+		t.Errorf("expected other kinds to pass through, got %q, %v", got, err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	if got, err := Path("team/../team.txt"); err != nil || got != "team.txt" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	t.Setenv("HOME", "/home/ash")
+	if got, _ := Path("~/team.txt"); got != filepath.Join("/home/ash", "team.txt") {
+		t.Errorf("expected ~ to be the home directory, got %q", got)
+	}
+	for _, bad := range []string{"", " ", "team\x00.txt"} {
+		if _, err := Path(bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	if got, err := Within("photos", "pikachu.txt"); err != nil || got != filepath.Join("photos", "pikachu.txt") {
+		t.Errorf("got %q, %v", got, err)
+	}
+	for _, bad := range []string{"../pikachu.txt", "/etc/passwd", "a/../../b", ""} {
+		if _, err := Within("photos", bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
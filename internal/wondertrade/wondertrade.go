@@ -0,0 +1,63 @@
+// Package wondertrade is a local bot that trades random Pokémon.
+package wondertrade
+
+import (
+	"math/rand"
+	"time"
+)
+
+// DailyLimit is how many wonder trades can be made per day.
+const DailyLimit = 3
+
+// BotName is the original trainer of every Pokémon the bot hands out.
+const BotName = "WonderBot"
+
+type Rarity struct {
+	Name    string
+	Weight  int
+	Species []string
+}
+
+var Pool = []Rarity{
+	{Name: "common", Weight: 60, Species: []string{"pidgey", "rattata", "caterpie", "weedle", "zubat", "geodude", "magikarp", "oddish"}},
+	{Name: "uncommon", Weight: 30, Species: []string{"pikachu", "eevee", "growlithe", "abra", "machop", "gastly", "kadabra", "haunter"}},
+	{Name: "rare", Weight: 10, Species: []string{"dratini", "lapras", "snorlax", "scyther", "porygon"}},
+}
+
+// Pick returns a random species and its rarity, weighted by rarity.
+func Pick(r *rand.Rand) (string, string) {
+	total := 0
+	for _, rarity := range Pool {
+		total += rarity.Weight
+	}
+	n := r.Intn(total)
+	for _, rarity := range Pool {
+		if n < rarity.Weight {
+			return rarity.Species[r.Intn(len(rarity.Species))], rarity.Name
+		}
+		n -= rarity.Weight
+	}
+	panic("unreachable")
+}
+
+// Allowance counts the trades made on a single day.
+type Allowance struct {
+	Day   string `json:"day"`
+	Count int    `json:"count"`
+}
+
+func (a Allowance) Remaining(now time.Time) int {
+	if a.Day != now.Format("2006-01-02") {
+		return DailyLimit
+	}
+	return DailyLimit - a.Count
+}
+
+func (a *Allowance) Use(now time.Time) {
+	day := now.Format("2006-01-02")
+	if a.Day != day {
+		a.Day = day
+		a.Count = 0
+	}
+	a.Count++
+}
@@ -0,0 +1,33 @@
+package wondertrade
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestPick(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		_, rarity := Pick(r)
+		counts[rarity]++
+	}
+	if !(counts["common"] > counts["uncommon"] && counts["uncommon"] > counts["rare"]) {
+		t.Errorf("expected picks to follow rarity weights, got %v", counts)
+	}
+}
+
+func TestAllowance(t *testing.T) {
+	today := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	var a Allowance
+	for i := 0; i < DailyLimit; i++ {
+		a.Use(today)
+	}
+	if got := a.Remaining(today); got != 0 {
+		t.Errorf("expected no trades left today, got %d", got)
+	}
+	if got := a.Remaining(today.Add(24 * time.Hour)); got != DailyLimit {
+		t.Errorf("expected the limit to reset tomorrow, got %d", got)
+	}
+}
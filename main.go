@@ -2,79 +2,257 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"math/rand"
-	"net/http"
+	"io"
+	"log"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/eymardfreire/pokedexcli/internal/balance"
+	"github.com/eymardfreire/pokedexcli/internal/biome"
+	"github.com/eymardfreire/pokedexcli/internal/capture"
+	"github.com/eymardfreire/pokedexcli/internal/cli"
+	"github.com/eymardfreire/pokedexcli/internal/color"
+	"github.com/eymardfreire/pokedexcli/internal/encounter"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/fakemon"
+	"github.com/eymardfreire/pokedexcli/internal/filter"
+	"github.com/eymardfreire/pokedexcli/internal/friendship"
+	"github.com/eymardfreire/pokedexcli/internal/glyph"
+	"github.com/eymardfreire/pokedexcli/internal/hooks"
+	"github.com/eymardfreire/pokedexcli/internal/insights"
+	"github.com/eymardfreire/pokedexcli/internal/journal"
+	"github.com/eymardfreire/pokedexcli/internal/lineedit"
+	"github.com/eymardfreire/pokedexcli/internal/mail"
+	"github.com/eymardfreire/pokedexcli/internal/media"
+	"github.com/eymardfreire/pokedexcli/internal/missing"
+	"github.com/eymardfreire/pokedexcli/internal/paths"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
 	"github.com/eymardfreire/pokedexcli/internal/pokecache"
+	"github.com/eymardfreire/pokedexcli/internal/render"
+	"github.com/eymardfreire/pokedexcli/internal/ribbon"
+	"github.com/eymardfreire/pokedexcli/internal/rng"
+	"github.com/eymardfreire/pokedexcli/internal/seasons"
+	"github.com/eymardfreire/pokedexcli/internal/sizes"
 )
 
 type cliCommand struct {
-	name        string
-	description string
-	callback    func(cfg *config, args []string) error
+	// Command is how the command is typed: its arguments, flags and
+	// subcommands, which are checked before callback runs.
+	cli.Command
+	callback func(cfg *config, args []string) error
+	// writes is set on commands that change the game, which read-only mode
+	// refuses. confirm is a question to ask before running the command.
+	writes  bool
+	confirm string
+	// dryRun is set on commands that change the game but, in a dry run,
+	// show what they would do instead; dry runs refuse the others.
+	dryRun bool
+	// online commands are only offered with a community server set.
+	online bool
+	// pages is set on commands whose output can run long, and which don't
+	// ask for input, to show it through a pager; see paged. Commands
+	// taking --format are paged anyway.
+	pages bool
 }
 
 type config struct {
 	Next     string
 	Previous string
 	Current  []string
-	Cache    *pokecache.Cache
+	API      *pokeapi.Client
 	Caught   map[string]Pokemon
+	Paths    paths.Paths
+	Insights *insights.Tracker
+	Journal  *journal.Journal
+	// Mail is the player's inbox of news that came while they were busy.
+	Mail    *mail.Inbox
+	Events  *events.Bus
+	State   *gameState
+	In      *bufio.Reader
+	Seasons []seasons.Event
+	// Hooks are the player's commands to run at points in the game.
+	Hooks hooks.Hooks
+	// RNG is the game's randomness, in named streams.
+	RNG  *rng.Streams
+	Area string
+	// Encounters holds the level ranges of the last explored area.
+	Encounters map[string]levelRange
+	Chat       *chatFeed
+	// Memory is the in-memory layer of the PokeAPI cache.
+	Memory *pokecache.MemoryCache
+	// Media holds downloaded sprites and cries.
+	Media *media.Cache
+	// ReadOnly stops anything being saved; see readOnly.
+	ReadOnly bool
+	Log      *log.Logger
+	// Format and Template are the format given before the command, if any,
+	// which commands use in place of the format setting.
+	Format, Template string
+	// Render shows the result of the command running, if it takes --format,
+	// on Results.
+	Render  render.Renderer
+	Results io.Writer
+	// Where is the filter given to a list command with --where, if any.
+	Where *filter.Filter
+	// Input is how the running command was typed, as checked parsed it.
+	// Commands read their flags from it; their arguments are the rest.
+	Input cli.Input
+	// DryRun is set while a command runs as a dry run.
+	DryRun bool
+	// Role is the player's role on the community server, once known.
+	Role string
+	// Session counts catch attempts since the game started.
+	Session catchTally
+	// Fakemon are the homebrew species in play, if the player opted in.
+	Fakemon *fakemon.Set
+	// Balance is the numbers the game is tuned with.
+	Balance balance.Balance
+	// Settings are the player's preferences from config.json.
+	Settings settings
+	// NoColor turns color off for the session, whatever the settings say.
+	NoColor bool
+	// Ctx is the running command's context, cancelled by Ctrl-C or once
+	// the command has taken longer than Timeout, if that is set.
+	Ctx        context.Context
+	Timeout    time.Duration
+	Interrupts *interrupts
+	// Quit is set by `exit` to end the session once the command returns.
+	Quit bool
 }
 
+// Pokemon is one of the player's Pokémon: its species' data from PokeAPI,
+// and what is particular to it.
 type Pokemon struct {
-	Name           string `json:"name"`
-	BaseExperience int    `json:"base_experience"`
-	Height         int    `json:"height"`
-	Weight         int    `json:"weight"`
-	Stats          []Stat `json:"stats"`
-	Types          []Type `json:"types"`
+	pokeapi.Pokemon
+	// Known is the moves it has been taught; until then it knows its
+	// level-up moves.
+	Known        []string  `json:"known_moves,omitempty"`
+	Friendship   int       `json:"friendship"`
+	FriendshipAt time.Time `json:"friendship_at"`
+	CaughtAt     time.Time `json:"caught_at"`
+	Size         float64   `json:"size"`
+	MetAt        string    `json:"met_at"`
+	Level        int       `json:"level"`
+	Nickname     string    `json:"nickname,omitempty"`
+	Shiny        bool      `json:"shiny,omitempty"`
+	// Experience is its total experience, or 0 until it first wins a
+	// battle.
+	Experience int `json:"experience,omitempty"`
+	// OriginalTrainer is who first caught this Pokémon. Provenance lists
+	// every time it changed hands since.
+	OriginalTrainer trainerID  `json:"original_trainer"`
+	Provenance      []transfer `json:"provenance"`
+	// Ribbons are the ribbons it earned and the marks it was caught with.
+	Ribbons []ribbon.Ribbon `json:"ribbons,omitempty"`
+	// HeldItem is the item it holds, which goes with it when traded;
+	// empty means none.
+	HeldItem string `json:"held_item,omitempty"`
 }
 
-type Stat struct {
-	BaseStat int `json:"base_stat"`
-	Stat     struct {
-		Name string `json:"name"`
-	} `json:"stat"`
-}
-
-type Type struct {
-	Type struct {
-		Name string `json:"name"`
-	} `json:"type"`
+// transfer records a Pokémon changing hands.
+type transfer struct {
+	From   trainerID `json:"from"`
+	To     trainerID `json:"to"`
+	Method string    `json:"method"`
+	At     time.Time `json:"at"`
 }
 
 func commandHelp(cfg *config, args []string) error {
+	commands := commandRegistry()
+	if len(args) > 0 {
+		cmd, ok := commands[args[0]]
+		if expansion, alias := cfg.Settings.Aliases[args[0]]; !ok && alias {
+			fmt.Printf("%s is an alias for %s.\n", args[0], expansion)
+			return nil
+		}
+		if !ok {
+			fmt.Printf("There is no %s command.\n", args[0])
+			return nil
+		}
+		fmt.Print(cmd.Help())
+		return nil
+	}
+
+	var names []string
+	for name, cmd := range commands {
+		if !cmd.online || cfg.State.Server != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Usage:")
-	fmt.Println("help: Displays a help message")
-	fmt.Println("exit: Exit the Pokedex")
-	fmt.Println("map: Display the next 20 location areas")
-	fmt.Println("mapb: Display the previous 20 location areas")
-	fmt.Println("explore <area_name>: Explore a specific location area")
-	fmt.Println("catch <pokemon_name>: Try to catch a Pokémon")
-	fmt.Println("inspect <pokemon_name>: Inspect a caught Pokémon")
-	fmt.Println("pokedex: List all caught Pokémon")
+	for _, name := range names {
+		fmt.Printf("%s: %s\n", commands[name].Usage(), commands[name].Summary)
+	}
+	if len(cfg.Settings.Aliases) > 0 {
+		fmt.Println()
+		fmt.Println("Your aliases:")
+		printAliases(cfg)
+	}
+	fmt.Println()
+	fmt.Println("`help <command>` or `<command> --help` explains a command and its flags.")
+	fmt.Println("At the prompt, Tab completes commands and names, the arrow keys go back")
+	fmt.Println("through earlier commands, and Ctrl-R searches them. Ctrl-C clears the line, or stops")
+	fmt.Println("a command that is taking too long; your Pokedex is saved however you leave.")
+	fmt.Println("Separate commands with ; to run them one after another, and use `alias` to name")
+	fmt.Println("a command, or several, you run often.")
+	fmt.Println("From your shell, `pokedexcli <command> [args]` runs a single command and exits")
+	fmt.Println("with 0 if it worked, 1 if it failed or 2 if it was typed wrong; only its result")
+	fmt.Println("goes to stdout. `pokedexcli completion bash|zsh|fish` prints a completion script.")
+	fmt.Println("inspect, pokedex, map, mapb, region, explore, party and box take --format json|yaml|csv|table,")
+	fmt.Println("--json and --template '{{.Name}}' to show their results in other formats; given")
+	fmt.Println("before the command, as in `pokedexcli --format csv pokedex`, or with `set format`,")
+	fmt.Println("the format applies to every command that takes it.")
+	fmt.Println("Add --dry-run to a command to see what it would do without changing your game.")
+	fmt.Println("Start with --timeout 1m, or give it before a command, to give up on any command that")
+	fmt.Println("takes longer; Ctrl-C gives up on one straight away.")
+	fmt.Println("Output to a terminal is colored; start with --no-color, set NO_COLOR or use `set color`")
+	fmt.Println("to turn that off.")
+	fmt.Println("With POKEDEXCLI_READ_ONLY=1 set, you can look around but nothing is saved.")
+	fmt.Println("POKEDEXCLI_SEED=42 pins every random stream, and catch=42,battle=7 only those named")
+	fmt.Println("(catch, encounter, shiny, battle, find, world); commands.log records each session's seeds.")
+	fmt.Println("POKEDEXCLI_API=http://localhost:8000/api/v2 uses another copy of PokeAPI, like a local one.")
+	fmt.Println("Every command is logged to commands.log in the logs directory (see `paths`).")
 	return nil
 }
 
 func commandExit(cfg *config, args []string) error {
+	if err := closeSession(cfg); err != nil {
+		return err
+	}
 	fmt.Println("Exiting Pokedex...")
-	os.Exit(0)
+	cfg.Quit = true
 	return nil
 }
 
 func commandMap(cfg *config, args []string) error {
-	if cfg.Next == "" {
-		cfg.Next = "https://pokeapi.co/api/v2/location-area/"
+	if len(args) > 1 && args[0] == "goto" {
+		return mapGoto(cfg, args[1])
+	}
+	url := cfg.Next
+	if url == "" && cfg.Settings.pageSize() != areasPerPage {
+		url = mapPage(cfg, 1)
+	}
+	list, err := cfg.API.ListLocationAreas(url)
+	if err != nil {
+		return err
 	}
-	return fetchLocations(cfg, cfg.Next)
+	if err := displayLocations(cfg, list); err != nil {
+		return err
+	}
+	cfg.API.Prefetch(1, cfg.Next)
+	cfg.Events.Publish(events.Event{Kind: events.MapViewed})
+	return nil
 }
 
 func commandMapB(cfg *config, args []string) error {
@@ -82,150 +260,435 @@ func commandMapB(cfg *config, args []string) error {
 		fmt.Println("No previous locations to display.")
 		return nil
 	}
-	return fetchLocations(cfg, cfg.Previous)
-}
-
-func commandExplore(cfg *config, args []string) error {
-	if len(args) < 1 {
-		fmt.Println("Please specify a location area to explore.")
-		return nil
+	list, err := cfg.API.ListLocationAreas(cfg.Previous)
+	if err != nil {
+		return err
 	}
-	areaName := args[0]
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%s/", areaName)
-	return fetchLocationDetails(cfg, url)
+	if err := displayLocations(cfg, list); err != nil {
+		return err
+	}
+	cfg.API.Prefetch(1, cfg.Previous)
+	cfg.Events.Publish(events.Event{Kind: events.MapViewed})
+	return nil
 }
 
-func commandCatch(cfg *config, args []string) error {
-	if len(args) < 1 {
-		fmt.Println("Please specify a Pokémon to catch.")
-		return nil
-	}
-	pokemonName := args[0]
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%s/", pokemonName)
-	return catchPokemon(cfg, url)
+// areasPerPage is how many location areas a page of the map lists, as
+// PokeAPI pages them, unless the player chose otherwise.
+const areasPerPage = 20
+
+// mapPage is the URL of a page of the map, counting from 1.
+func mapPage(cfg *config, n int) string {
+	size := cfg.Settings.pageSize()
+	return fmt.Sprintf("%s/location-area/?offset=%d&limit=%d", cfg.API.BaseURL, (n-1)*size, size)
 }
 
-func commandInspect(cfg *config, args []string) error {
-	if len(args) < 1 {
-		fmt.Println("Please specify a Pokémon to inspect.")
+// mapGoto jumps to a page of the map, counting from 1. The index of every
+// area is cached, so finding out how many pages there are only takes a
+// request the first time.
+func mapGoto(cfg *config, page string) error {
+	index, err := cfg.API.List("location-area")
+	if err != nil {
+		return err
+	}
+	size := cfg.Settings.pageSize()
+	pages := (len(index) + size - 1) / size
+	n, err := strconv.Atoi(page)
+	if err != nil || n < 1 || n > pages {
+		fmt.Printf("Pick a page from 1 to %d.\n", pages)
 		return nil
 	}
-	pokemonName := args[0]
-	if pokemon, exists := cfg.Caught[pokemonName]; exists {
-		printPokemonDetails(pokemon)
-	} else {
-		fmt.Println("You have not caught that Pokémon.")
+	list, err := cfg.API.ListLocationAreas(mapPage(cfg, n))
+	if err != nil {
+		return err
 	}
-	return nil
-}
-
-func commandPokedex(cfg *config, args []string) error {
-	fmt.Println("Your Pokedex:")
-	for name := range cfg.Caught {
-		fmt.Printf(" - %s\n", name)
+	if err := displayLocations(cfg, list); err != nil {
+		return err
 	}
+	cfg.API.Prefetch(1, cfg.Next)
+	cfg.Events.Publish(events.Event{Kind: events.MapViewed})
 	return nil
 }
 
-func fetchLocations(cfg *config, url string) error {
-	if data, ok := cfg.Cache.Get(url); ok {
-		fmt.Println("Using cached data")
-		return displayLocations(data, cfg)
+func commandExplore(cfg *config, args []string) error {
+	filter, problem := exploreFilter(cfg.Input.Flags)
+	if problem != "" {
+		fmt.Println(problem)
+		return nil
 	}
-
-	fmt.Println("Fetching new data")
-	response, err := http.Get(url)
+	biomeName := cfg.Input.Flags["biome"]
+	if len(args) == 0 && biomeName == "" {
+		fmt.Println("Which area? Give its name, or --biome to go somewhere at random.")
+		return nil
+	}
+	var areaName string
+	if len(args) > 0 {
+		areaName = args[0]
+	}
+	if biomeName != "" {
+		b, ok := biome.Parse(biomeName)
+		if !ok {
+			fmt.Println("Biomes are cave, forest, water or urban.")
+			return nil
+		}
+		if areaName == "" {
+			if areaName = pickArea(cfg, b); areaName == "" {
+				fmt.Printf("You don't know of any %s areas. Look at the `map` for more.\n", b)
+				return nil
+			}
+			fmt.Printf("Heading for %s.\n", areaName)
+		} else if areaBiome(cfg, areaName) != b {
+			fmt.Printf("%s isn't a %s area.\n", areaName, b)
+			return nil
+		}
+	}
+	area, err := cfg.API.GetLocationArea(areaName)
 	if err != nil {
 		return err
 	}
-	defer response.Body.Close()
+	area.PokemonEncounters = append(area.PokemonEncounters, cfg.Fakemon.Encounters(areaName)...)
+	if filter.Version != "" {
+		versions := areaVersions(area)
+		if !slices.Contains(versions, filter.Version) {
+			fmt.Printf("No Pokémon are met in %s in %s. Try %s.\n", areaName, filter.Version, strings.Join(versions, ", "))
+			return nil
+		}
+		area = inVersion(area, filter.Version)
+	}
+	if ok, err := travel(cfg, area); !ok {
+		return err
+	}
+	prefetchEncounters(cfg, area)
+	if err := displayPokemon(cfg, area, filter); err != nil {
+		return err
+	}
+	cfg.Area = areaName
+	cfg.State.Explored = areaName
+	findItem(cfg)
+	if err := rateArea(cfg, areaName); err != nil {
+		return err
+	}
+	printNotes(cfg, areaName)
+	if roamerHere(cfg) {
+		fmt.Printf("A wild %s appears! Catch it before it runs off.\n", cfg.State.Roamer.Species)
+	}
+	announceSpawns(cfg, areaName)
+	cfg.Events.Publish(events.Event{Kind: events.AreaExplored, Subject: areaName})
+	return nil
+}
 
-	body, err := ioutil.ReadAll(response.Body)
+func commandCatch(cfg *config, args []string) error {
+	asked := cfg.Input.Flags["ball"]
+	ball := "poke-ball"
+	if asked != "" {
+		var ok bool
+		if ball, ok = capture.Ball(asked); !ok {
+			fmt.Printf("There is no %s. Try poke, great, ultra or master.\n", ball)
+			return errRefused
+		}
+	}
+	if cfg.State.Items[ball] == 0 {
+		fmt.Printf("You have no %ss.", ballName(ball))
+		if capture.Supply[ball] > 0 {
+			fmt.Print(" More arrive each day you play.")
+		}
+		fmt.Println()
+		return errRefused
+	}
+	pokemonName := args[0]
+	// The Pokedex keeps one Pokémon of each species, so catching another
+	// would throw away the one the player has, nickname, ribbons and all.
+	if _, owned := cfg.Caught[pokemonName]; owned {
+		fmt.Printf("You already have a %s; release or trade it first.\n", pokemonName)
+		return errRefused
+	}
+	if ro := cfg.State.Roamer; ro.Active() && pokemonName == ro.Species && !roamerHere(cfg) {
+		fmt.Printf("There is no %s here. Try `whereis roaming`.\n", pokemonName)
+		return errRefused
+	}
+	pokemon, err := fetchPokemon(cfg, pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no Pokémon called %s.%s\n", pokemonName, didYouMean(cfg, pokemonName))
+		return errRefused
+	}
 	if err != nil {
 		return err
 	}
+	return attemptCatch(cfg, pokemon, ball)
+}
 
-	cfg.Cache.Add(url, body)
-	return displayLocations(body, cfg)
+// inspection is what inspect shows: a caught Pokémon, and what PokeAPI says
+// about its species, if that could be fetched. Missing lists what PokeAPI
+// doesn't have for it, by JSON key.
+type inspection struct {
+	Pokemon
+	Species *speciesInfo `json:"species,omitempty"`
+	Missing []string     `json:"missing,omitempty"`
 }
 
-func fetchLocationDetails(cfg *config, url string) error {
-	if data, ok := cfg.Cache.Get(url); ok {
-		fmt.Println("Using cached data")
-		return displayPokemon(data)
-	}
+// speciesInfo is the part of a species' PokeAPI entry that inspect shows.
+type speciesInfo struct {
+	Genus       string `json:"genus,omitempty"`
+	Description string `json:"description,omitempty"`
+	Habitat     string `json:"habitat,omitempty"`
+	Legendary   bool   `json:"legendary"`
+	Mythical    bool   `json:"mythical"`
+	CaptureRate int    `json:"capture_rate,omitempty"`
+}
 
-	fmt.Println("Fetching new data")
-	response, err := http.Get(url)
+// lookUpSpecies fetches a Pokémon's species entry, in English, or returns
+// nil if it can't; inspect shows the Pokémon either way.
+func lookUpSpecies(cfg *config, name string) *speciesInfo {
+	species, err := cfg.API.GetSpecies(name)
 	if err != nil {
-		return err
+		if cfg.Log != nil {
+			cfg.Log.Printf("species of %s: %v", name, err)
+		}
+		return nil
 	}
-	defer response.Body.Close()
+	info := &speciesInfo{
+		Genus:       species.Genus("en"),
+		Description: species.Description("en"),
+		Legendary:   species.IsLegendary,
+		Mythical:    species.IsMythical,
+		CaptureRate: species.CaptureRate,
+	}
+	if species.Habitat != nil {
+		info.Habitat = species.Habitat.Name
+	}
+	return info
+}
 
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return err
+func printSpecies(info *speciesInfo) {
+	if info == nil {
+		return
+	}
+	kind := info.Genus
+	switch {
+	case info.Mythical:
+		kind += " (mythical)"
+	case info.Legendary:
+		kind += " (legendary)"
 	}
+	if kind != "" {
+		fmt.Printf("Species: %s\n", kind)
+	}
+	if info.Description != "" {
+		fmt.Printf("  %s\n", info.Description)
+	} else {
+		fmt.Printf("Pokédex entry: %s\n", missing.NoData)
+	}
+	if info.Habitat != "" {
+		fmt.Printf("Habitat: %s\n", info.Habitat)
+	}
+	fmt.Printf("Capture rate: %s\n", missing.Number(info.CaptureRate))
+}
 
-	cfg.Cache.Add(url, body)
-	return displayPokemon(body)
+func commandInspect(cfg *config, args []string) error {
+	pokemonName := args[0]
+	if pokemon, exists := cfg.Caught[pokemonName]; exists {
+		pokemon = syncFriendship(cfg, pokemon)
+		result := inspection{pokemon, lookUpSpecies(cfg, pokemon.Name), missing.Fields(pokemon.Pokemon)}
+		result.Level = levelOf(pokemon)
+		if result.Species != nil && result.Species.Description == "" {
+			result.Missing = append(result.Missing, "description")
+		}
+		if result.Species != nil && result.Species.CaptureRate <= 0 {
+			result.Missing = append(result.Missing, "capture_rate")
+		}
+		if err := show(cfg, result, func() {
+			printPokemonDetails(cfg, pokemon)
+			printSpecies(result.Species)
+		}); err != nil {
+			return err
+		}
+		cfg.Events.Publish(events.Event{Kind: events.PokemonInspected, Subject: pokemonName})
+		return nil
+	}
+	fmt.Println("You have not caught that Pokémon.")
+	return errRefused
 }
 
-func catchPokemon(cfg *config, url string) error {
-	if data, ok := cfg.Cache.Get(url); ok {
-		return attemptCatch(cfg, data)
+func commandPokedex(cfg *config, args []string) error {
+	metAt, seen := cfg.Input.Flags["met"], cfg.Input.Flags["seen"] != ""
+
+	seenCount, caughtCount := cfg.State.Dex.Counts()
+	if seen {
+		species, err := where(cfg, seenSpecies(cfg))
+		if err != nil {
+			return err
+		}
+		return show(cfg, species, func() {
+			fmt.Printf("Seen: %d  Caught: %d\n", seenCount, caughtCount)
+			printSeen(species)
+		})
 	}
 
-	fmt.Println("Fetching new data")
-	response, err := http.Get(url)
+	var caught []Pokemon
+	for _, pokemon := range cfg.Caught {
+		if metAt != "" && pokemon.MetAt != metAt {
+			continue
+		}
+		// Every format shows the level the text does, which Pokémon caught
+		// before levels were kept don't have saved.
+		pokemon.Level = levelOf(pokemon)
+		caught = append(caught, pokemon)
+	}
+	sort.Slice(caught, func(i, j int) bool { return caught[i].Name < caught[j].Name })
+	caught, err := where(cfg, caught)
 	if err != nil {
 		return err
 	}
-	defer response.Body.Close()
+	return show(cfg, caught, func() {
+		fmt.Printf("Seen: %d  Caught: %d\n", seenCount, caughtCount)
+		fmt.Println("Your Pokedex:")
+		// Types go last, as colors would throw the columns out.
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, pokemon := range caught {
+			var types []string
+			for _, t := range typeNames(pokemon) {
+				types = append(types, typeName(cfg, t))
+			}
+			name := pokemon.Name
+			if pokemon.Shiny {
+				name += " (shiny)"
+			}
+			fmt.Fprintf(tw, " - %s\tLv. %d\t%s\n", name, levelOf(pokemon), strings.Join(types, "/"))
+		}
+		tw.Flush()
+	})
+}
 
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return err
+// metDescription describes where and when a Pokémon was caught, for
+// example "Met at eterna-forest-area on 2024-05-02, Lv. 12".
+func metDescription(pokemon Pokemon) string {
+	if pokemon.CaughtAt.IsZero() {
+		return ""
+	}
+	met := "Met"
+	if pokemon.MetAt != "" {
+		met += " at " + pokemon.MetAt
+	}
+	met += " on " + pokemon.CaughtAt.Format("2006-01-02")
+	if pokemon.Level > 0 {
+		met += fmt.Sprintf(", Lv. %d", pokemon.Level)
 	}
+	return met
+}
+
+// prefetchWorkers is how many Pokémon are fetched at once ahead of time.
+const prefetchWorkers = 4
+
+// prefetchEncounters fetches the Pokémon met in an area into the cache in
+// the background, so filtering and catching them doesn't wait.
+func prefetchEncounters(cfg *config, area pokeapi.LocationArea) {
+	var urls []string
+	for _, encounter := range area.PokemonEncounters {
+		urls = append(urls, cfg.API.URL("pokemon", encounter.Pokemon.Name))
+	}
+	cfg.API.Prefetch(prefetchWorkers, urls...)
+}
 
-	cfg.Cache.Add(url, body)
-	return attemptCatch(cfg, body)
+func fetchPokemon(cfg *config, name string) (Pokemon, error) {
+	species, err := cfg.API.GetPokemon(name)
+	return Pokemon{Pokemon: species}, err
 }
 
-func attemptCatch(cfg *config, data []byte) error {
-	var pokemon Pokemon
-	err := json.Unmarshal(data, &pokemon)
+// attemptCatch throws a ball at a wild Pokémon at full HP. The odds follow
+// the games: the species' capture rate times the ball's bonus.
+func attemptCatch(cfg *config, pokemon Pokemon, ball string) error {
+	rate, err := captureRate(cfg, pokemon.Name)
 	if err != nil {
 		return err
 	}
-
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemon.Name)
-	rand.Seed(time.Now().UnixNano())
-	chance := rand.Intn(100)
-	if chance < 50 { // This can be adjusted based on base experience or other logic
-		fmt.Printf("%s escaped!\n", pokemon.Name)
+	enc := encounter.New(pokemon.Name, rate)
+	enc.OnChange = func(e *encounter.Encounter) { showEncounter(cfg, e) }
+	if err := enc.Engage(); err != nil {
+		return err
+	}
+	// Only rolled for when it could happen, so seeded games play the same
+	// as before there was a chance of it.
+	if chance := cfg.Balance.FleeChance; chance > 0 && !cfg.DryRun && cfg.RNG.Get(rng.Catch).Float64() < chance {
+		if err := enc.Flee(); err != nil {
+			return err
+		}
+		cfg.Events.Publish(events.Event{Kind: events.PokemonSeen, Subject: pokemon.Name})
+		return saveState(cfg)
+	}
+	throw := capture.Throw{Ball: ball, Berry: cfg.State.Berry}
+	if cfg.DryRun {
+		previewCatch(cfg, pokemon, ball, enc.Odds(throw))
 		return nil
 	}
+	cfg.State.Items[ball]--
+	cfg.State.Berry = ""
+	if err := enc.ThrowBall(throw); err != nil {
+		return err
+	}
+	if err := enc.Resolve(cfg.RNG.Get(rng.Catch).Float64()); err != nil {
+		return err
+	}
+	if enc.Phase == encounter.Caught {
+		addToPokedex(cfg, pokemon)
+		showSprite(cfg, pokemon)
+		if cfg.Caught[pokemon.Name].Shiny {
+			fmt.Println(paint(cfg, "It's shiny!", color.Bold, color.Gold) + emoji(cfg, " "+glyph.Shiny))
+		}
+		cfg.Events.Publish(events.Event{Kind: events.PokemonCaught, Subject: pokemon.Name})
+	} else {
+		cfg.Events.Publish(events.Event{Kind: events.PokemonEscaped, Subject: pokemon.Name})
+	}
+	return saveState(cfg)
+}
 
-	fmt.Printf("%s was caught!\n", pokemon.Name)
-	cfg.Caught[pokemon.Name] = pokemon
-	return nil
+// showEncounter tells the player what just happened in an encounter.
+func showEncounter(cfg *config, e *encounter.Encounter) {
+	switch e.Phase {
+	case encounter.Thrown:
+		if e.Throw.Berry != "" {
+			fmt.Printf("%s eats the %s.\n", e.Species, e.Throw.Berry)
+		}
+		fmt.Printf("Throwing a %s at %s... (%d left)\n", ballName(e.Throw.Ball), e.Species, cfg.State.Items[e.Throw.Ball])
+	case encounter.Caught:
+		fmt.Println(paint(cfg, e.Species+" was caught!", color.Bold, color.Green))
+	case encounter.Fled:
+		if e.Throw.Ball == "" {
+			fmt.Println(paint(cfg, e.Species+" ran away before you could throw a ball!", color.Red))
+		} else {
+			fmt.Println(paint(cfg, e.Species+" escaped!", color.Red))
+		}
+	}
 }
 
-func displayLocations(data []byte, cfg *config) error {
-	var result struct {
-		Results []struct {
-			Name string `json:"name"`
-		} `json:"results"`
-		Next     string `json:"next"`
-		Previous string `json:"previous"`
+// addToPokedex stores a newly obtained Pokémon with its starting state.
+func addToPokedex(cfg *config, pokemon Pokemon) {
+	now := time.Now()
+	pokemon.Friendship = friendship.Base
+	pokemon.FriendshipAt = now
+	pokemon.CaughtAt = now
+	pokemon.Size = sizes.Roll(cfg.RNG.Get(rng.Encounter))
+	pokemon.Shiny = cfg.RNG.Get(rng.Shiny).Intn(cfg.Balance.ShinyOdds) == 0
+	if pokemon.Shiny {
+		countShiny(cfg, pokemon.Name)
+	}
+	pokemon.OriginalTrainer = cfg.State.Trainer
+	if levels, ok := cfg.Encounters[pokemon.Name]; ok && levels.Max > 0 {
+		pokemon.MetAt = cfg.Area
+		pokemon.Level = levels.Min + cfg.RNG.Get(rng.Encounter).Intn(levels.Max-levels.Min+1)
 	}
+	cfg.Caught[pokemon.Name] = pokemon
+	cfg.State.Dex.Catch(pokemon.Name, now)
+	recordSize(cfg, pokemon)
+}
 
-	err := json.Unmarshal(data, &result)
-	if err != nil {
-		return err
+// countShiny counts a shiny the player got, for stats.
+func countShiny(cfg *config, species string) {
+	if cfg.State.Shinies == nil {
+		cfg.State.Shinies = make(map[string]int)
 	}
+	cfg.State.Shinies[species]++
+}
 
+func displayLocations(cfg *config, result pokeapi.LocationAreaList) error {
 	cfg.Next = result.Next
 	cfg.Previous = result.Previous
 	cfg.Current = nil
@@ -233,114 +696,1465 @@ func displayLocations(data []byte, cfg *config) error {
 		cfg.Current = append(cfg.Current, location.Name)
 	}
 
+	var areas []mapArea
 	for _, location := range cfg.Current {
-		fmt.Println(location)
+		area := mapArea{Name: location}
+		if levels, ok := cfg.State.Areas[location]; ok {
+			area.Levels = &levels
+		}
+		areas = append(areas, area)
+	}
+	areas, err := where(cfg, areas)
+	if err != nil {
+		return err
 	}
+	return show(cfg, areas, func() {
+		for _, area := range areas {
+			if area.Levels != nil {
+				fmt.Printf("%s (%s)\n", area.Name, areaDanger(cfg, *area.Levels))
+				continue
+			}
+			fmt.Println(area.Name)
+		}
+	})
+}
 
-	return nil
+// mapArea is an area on the map, with its levels once it has been explored.
+type mapArea struct {
+	Name   string      `json:"name"`
+	Levels *levelRange `json:"levels,omitempty"`
 }
 
-func displayPokemon(data []byte) error {
-	var result struct {
-		PokemonEncounters []struct {
-			Pokemon struct {
-				Name string `json:"name"`
-			} `json:"pokemon"`
-		} `json:"pokemon_encounters"`
+// displayPokemon lists the Pokémon found in an area that pass filter, with
+// their levels, rarity and how they are met.
+func displayPokemon(cfg *config, result pokeapi.LocationArea, filter encounterFilter) error {
+	cfg.Encounters = make(map[string]levelRange)
+	b := classifyArea(cfg, result)
+	var found []foundPokemon
+	for _, encounter := range result.PokemonEncounters {
+		levels := encounterLevels(encounter)
+		cfg.Encounters[encounter.Pokemon.Name] = levels
+
+		f := foundPokemon{encounter, levels, biomeChance(cfg, b, encounter)}
+		keep, err := filter.keep(cfg, f)
+		if err != nil {
+			return err
+		}
+		if keep {
+			found = append(found, f)
+		}
 	}
+	sortEncounters(found, filter.Sort)
 
-	err := json.Unmarshal(data, &result)
+	var explored []exploredPokemon
+	for _, f := range found {
+		explored = append(explored, exploredPokemon{
+			Name:     f.encounter.Pokemon.Name,
+			MinLevel: f.levels.Min,
+			MaxLevel: f.levels.Max,
+			Rarity:   rarity(f.chance),
+			Methods:  methods(f.encounter),
+		})
+		cfg.Events.Publish(events.Event{Kind: events.PokemonSeen, Subject: f.encounter.Pokemon.Name})
+	}
+	explored, err := where(cfg, explored)
 	if err != nil {
 		return err
 	}
+	if filter.Details {
+		return showEncounterWays(cfg, result, b, found, explored, filter)
+	}
+	return show(cfg, explored, func() {
+		if b != "" {
+			fmt.Printf("This is a %s area.\n", b)
+		}
+		fmt.Println("Found Pokemon:")
+		for _, p := range explored {
+			if p.MaxLevel > 0 {
+				fmt.Printf(say(cfg, " - %s (Lv. %d–%d, %s; %s)\n"), p.Name, p.MinLevel, p.MaxLevel, p.Rarity, strings.Join(p.Methods, ", "))
+			} else {
+				fmt.Printf(" - %s\n", p.Name)
+			}
+		}
+		if len(explored) == 0 && (filter.active() || cfg.Where != nil) {
+			fmt.Println("None of the Pokémon here match.")
+		}
+	})
+}
 
-	fmt.Println("Found Pokemon:")
-	for _, encounter := range result.PokemonEncounters {
-		fmt.Printf(" - %s\n", encounter.Pokemon.Name)
+// exploredPokemon is how explore shows a Pokémon found in an area.
+type exploredPokemon struct {
+	Name     string   `json:"name"`
+	MinLevel int      `json:"min_level"`
+	MaxLevel int      `json:"max_level"`
+	Rarity   string   `json:"rarity"`
+	Methods  []string `json:"methods"`
+}
+
+// foundPokemon is a Pokémon met in an area, at levels, with a percent
+// chance once the area's biome is taken into account.
+type foundPokemon struct {
+	encounter pokeapi.PokemonEncounter
+	levels    levelRange
+	chance    int
+}
+
+// encounterLevels is the span of levels a Pokémon is met at, in any
+// version and by any method.
+func encounterLevels(encounter pokeapi.PokemonEncounter) levelRange {
+	var levels levelRange
+	for _, version := range encounter.VersionDetails {
+		for _, detail := range version.EncounterDetails {
+			levels = levels.include(detail.MinLevel, detail.MaxLevel)
+		}
 	}
+	return levels
+}
 
-	return nil
+// levelRange is the span of levels a Pokémon is encountered at in an area.
+type levelRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (r levelRange) include(min, max int) levelRange {
+	if r.Min == 0 || min < r.Min {
+		r.Min = min
+	}
+	if max > r.Max {
+		r.Max = max
+	}
+	return r
 }
 
-func printPokemonDetails(pokemon Pokemon) {
+func printPokemonDetails(cfg *config, pokemon Pokemon) {
+	showSprite(cfg, pokemon)
 	fmt.Printf("Name: %s\n", pokemon.Name)
-	fmt.Printf("Height: %d\n", pokemon.Height)
-	fmt.Printf("Weight: %d\n", pokemon.Weight)
-	fmt.Println("Stats:")
+	if pokemon.Shiny {
+		fmt.Println("Shiny!" + emoji(cfg, " "+glyph.Shiny))
+	}
+	if pokemon.Nickname != "" {
+		fmt.Printf("Nickname: %s\n", pokemon.Nickname)
+	}
+	if met := metDescription(pokemon); met != "" {
+		fmt.Println(met)
+	}
+	if pokemon.OriginalTrainer.ID != 0 {
+		fmt.Printf("OT: %s\n", pokemon.OriginalTrainer.Name)
+	}
+	for _, t := range pokemon.Provenance {
+		fmt.Printf("  %s: %s -> %s (%s)\n", t.At.Format("2006-01-02"), t.From.Name, t.To.Name, t.Method)
+	}
+	fmt.Printf("Height: %s\n", missing.Number(pokemon.Height))
+	fmt.Printf("Weight: %s\n", missing.Number(pokemon.Weight))
+	if pokemon.Size > 0 && pokemon.Height > 0 && pokemon.Weight > 0 {
+		m := sizes.Measure(pokemon.Height, pokemon.Weight, pokemon.Size, pokemon.CaughtAt)
+		fmt.Printf("Size: %.2fx (%s)\n", pokemon.Size, m)
+	}
+	if len(pokemon.Stats) == 0 {
+		fmt.Printf("Stats: %s\n", missing.NoData)
+	} else {
+		fmt.Println("Stats:")
+	}
 	for _, stat := range pokemon.Stats {
-		fmt.Printf("  -%s: %d\n", stat.Stat.Name, stat.BaseStat)
+		fmt.Printf("  -%s: %s\n", stat.Stat.Name, missing.Number(stat.BaseStat))
+	}
+	for _, name := range missing.Stats(pokemon.Pokemon) {
+		if len(pokemon.Stats) > 0 && !slices.ContainsFunc(pokemon.Stats, func(s pokeapi.Stat) bool { return s.Stat.Name == name }) {
+			fmt.Printf("  -%s: %s\n", name, missing.NoData)
+		}
+	}
+	if len(pokemon.Types) == 0 {
+		fmt.Printf("Types: %s\n", missing.NoData)
+	} else {
+		fmt.Println("Types:")
 	}
-	fmt.Println("Types:")
 	for _, typ := range pokemon.Types {
-		fmt.Printf("  - %s\n", typ.Type.Name)
+		fmt.Printf("  - %s\n", typeName(cfg, typ.Type.Name))
+	}
+	fmt.Printf("Friendship: %d (%s)\n", pokemon.Friendship, friendship.Describe(pokemon.Friendship))
+	if pokemon.HeldItem != "" {
+		fmt.Printf("Holding: %s\n", pokemon.HeldItem)
+	}
+	if len(pokemon.Ribbons) > 0 {
+		fmt.Println("Ribbons:")
+		for _, r := range pokemon.Ribbons {
+			fmt.Printf("  - %s (%s)\n", r.Name, r.At.Format("2006-01-02"))
+		}
+	}
+}
+
+func setupPaths() (paths.Paths, error) {
+	p, err := paths.Resolve()
+	if err != nil {
+		return p, err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p, err
+	}
+	moved, err := paths.Migrate(paths.Legacy(home), p)
+	if err != nil {
+		return p, err
+	}
+	for _, dst := range moved {
+		fmt.Println("Migrated", dst)
+	}
+	return p, p.Ensure()
+}
+
+// apiTimeout is how long a request to PokeAPI may take.
+const apiTimeout = 30 * time.Second
+
+// globalFlags takes the flags given before the command out of args, the
+// program's arguments: --offline, --no-color, --timeout for how long each
+// command may take, and --format, --json or --template for every command
+// that takes them.
+func globalFlags(args []string) (rest []string, offline, noColor bool, timeout, format, tmpl string) {
+	var flags []string
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(args[0], "--"), "=")
+		if !strings.HasPrefix(args[0], "--") || !slices.Contains([]string{"offline", "no-color", "timeout", "format", "json", "template"}, name) {
+			break
+		}
+		if name == "no-color" {
+			noColor, args = true, args[1:]
+			continue
+		}
+		n := 1
+		if name != "offline" && name != "json" && !hasValue && len(args) > 1 {
+			n = 2
+			value = args[1]
+		}
+		if name == "timeout" {
+			timeout, args = value, args[n:]
+			continue
+		}
+		flags, args = append(flags, args[:n]...), args[n:]
 	}
+	flags, format, tmpl = formatFlags(flags, "", "")
+	return args, len(flags) > 0, noColor, timeout, format, tmpl
 }
 
 func main() {
-	cache := pokecache.NewCache(5 * time.Minute)
+	if len(os.Args) > 1 && os.Args[1] == "completion" {
+		os.Exit(runCompletion(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "gen" {
+		os.Exit(runGen(os.Args[2:]))
+	}
+	args, offline, noColor, timeout, format, tmpl := globalFlags(os.Args[1:])
+	if _, err := render.New(format, tmpl); err != nil {
+		fmt.Fprintf(os.Stderr, "Can't show that: %v.\n", err)
+		os.Exit(exitUsage)
+	}
+	var commandTimeout time.Duration
+	if timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			fmt.Fprintf(os.Stderr, "--timeout must be a duration like 30s, not %q.\n", timeout)
+			os.Exit(exitUsage)
+		}
+		commandTimeout = d
+	}
+	os.Args = append(os.Args[:1], args...)
+	// Given a command on the command line, only its result goes to stdout,
+	// for scripts to read; what is said on the way goes to stderr.
+	stdout := os.Stdout
+	if len(os.Args) > 1 {
+		os.Stdout = os.Stderr
+	}
+
+	dirs, err := setupPaths()
+	if err != nil {
+		fmt.Println("Error setting up directories:", err)
+		os.Exit(1)
+	}
+
+	tracker, err := insights.Load(filepath.Join(dirs.Data, "insights.json"))
+	if err != nil {
+		fmt.Println("Error loading usage insights:", err)
+		os.Exit(1)
+	}
+
+	entries, err := journal.Open(filepath.Join(dirs.Data, "journal.json"))
+	if err != nil {
+		fmt.Println("Error loading the journal:", err)
+		os.Exit(1)
+	}
+
+	inbox, err := mail.Open(filepath.Join(dirs.Data, "mail.json"))
+	if err != nil {
+		fmt.Println("Error loading your mail:", err)
+		os.Exit(1)
+	}
+
+	// POKEDEXCLI_SEED pins the random streams, all of them or some by name,
+	// and the log records them so a session can be played again.
+	streams, err := rng.Parse(os.Getenv("POKEDEXCLI_SEED"), time.Now().UnixNano())
+	if err != nil {
+		fmt.Println("Error in POKEDEXCLI_SEED:", err)
+		os.Exit(exitUsage)
+	}
+
 	cfg := &config{
-		Cache:  cache,
-		Caught: make(map[string]Pokemon),
+		API:      pokeapi.NewClient(nil, apiTimeout),
+		Ctx:      context.Background(),
+		Caught:   make(map[string]Pokemon),
+		Paths:    dirs,
+		Insights: tracker,
+		Journal:  entries,
+		Mail:     inbox,
+		Events:   events.NewBus(),
+		In:       bufio.NewReader(os.Stdin),
+		RNG:      streams,
+		ReadOnly: os.Getenv("POKEDEXCLI_READ_ONLY") != "",
+		NoColor:  noColor,
+		Timeout:  commandTimeout,
+		Format:   format,
+		Template: tmpl,
+	}
+	if err := loadSettings(cfg); err != nil {
+		fmt.Println("Error loading settings:", err)
+		os.Exit(1)
+	}
+	if cfg.Balance, err = balance.Load(balancePath(cfg)); err != nil {
+		fmt.Println("Error loading the balance file:", err)
+		os.Exit(1)
+	}
+	cfg.Balance.Apply()
+	if cfg.Log, err = openLog(cfg); err != nil {
+		fmt.Println("Error opening the command log:", err)
+	} else {
+		cfg.Log.Printf("seeds %s", cfg.RNG.Seeds())
 	}
+	if err := loadState(cfg); err != nil {
+		fmt.Println("Error loading saved progress:", err)
+		os.Exit(1)
+	}
+	if err := migrateSettings(cfg); err != nil {
+		fmt.Println("Error moving settings into config.json:", err)
+		os.Exit(1)
+	}
+	if _, err := loadPokedex(cfg); err != nil {
+		fmt.Println("Error loading your Pokedex:", err)
+		os.Exit(1)
+	}
+	cfg.API.BaseURL = apiURL(cfg)
+	cfg.API.Cache = apiCache(cfg)
+	cfg.API.HTTP.Timeout = cfg.Settings.requestTimeout()
+	cfg.API.Snapshot = snapshotDir(cfg)
+	if err := openPack(cfg); err != nil {
+		fmt.Println("Error opening the packed snapshot:", err)
+	}
+	if err := loadFakemon(cfg); err != nil {
+		fmt.Println("Error loading homebrew species:", err)
+	}
+	cfg.API.HTTP.Transport = offlineTransport{cfg.API, cfg.API.HTTP.Transport}
+	cfg.API.SetOffline(offline)
+	cfg.Media = media.New(filepath.Join(dirs.Cache, "media"), mediaQuota, cfg.API.HTTP)
+	watchTutorial(cfg)
+	watchFriendship(cfg)
+	watchDex(cfg)
+	watchCatches(cfg)
+	watchBiomes(cfg)
+	watchRibbons(cfg)
+	watchTrainer(cfg)
+	watchQuests(cfg)
+	watchJournal(cfg)
+	watchMail(cfg)
+	watchHooks(cfg)
+	setupRoamer(cfg)
+	cfg.Chat = newChatFeed(server(cfg), func(name string) {
+		cfg.Events.Publish(events.Event{Kind: events.EventStarted, Subject: name})
+	})
+	cfg.Chat.plainly(cfg.Settings.Accessible)
 
-	commands := map[string]cliCommand{
+	cfg.Seasons, err = seasons.Load(filepath.Join(dirs.Config, "events.json"))
+	if err != nil {
+		fmt.Println("Error loading seasonal events:", err)
+		os.Exit(1)
+	}
+	announceSeasons(cfg)
+	if cfg.Hooks, err = hooks.Load(hooksPath(cfg)); err != nil {
+		fmt.Println("Error loading hooks:", err)
+	}
+	if offline {
+		fmt.Println(paint(cfg, "Offline mode: PokeAPI data comes from your cache and snapshot only.", color.Dim))
+	}
+	if cfg.ReadOnly {
+		fmt.Println("Read-only mode: nothing you do will be saved.")
+	} else {
+		collectOffersOnStartup(cfg)
+		claimGiftsOnStartup(cfg)
+		collectSupplyOnStartup(cfg)
+	}
+
+	if len(os.Args) == 2 && os.Args[1] == "daemon" {
+		os.Exit(runDaemon(cfg))
+	}
+
+	handleInterrupts(cfg)
+	runHooks(cfg, hooks.Start, hooks.Data{})
+	commands := commandRegistry()
+	// Given a command on the command line, run just that one.
+	if len(os.Args) > 1 {
+		os.Stdout = stdout
+		lines, err := expandWords(cfg, os.Args[1:], 0)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(exitUsage)
+		}
+		status := exitOK
+		for _, parts := range lines {
+			if status = runCommand(cfg, commands, parts); status != exitOK || cfg.Quit || cfg.Interrupts.stopped() {
+				break
+			}
+		}
+		if cfg.Quit {
+			os.Exit(status)
+		}
+		os.Stdout = os.Stderr
+		if err := closeSession(cfg); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			status = max(status, exitError)
+		}
+		os.Exit(status)
+	}
+
+	editor := newEditor(cfg, commands)
+	tips := newTipper(editor.History)
+	for {
+		prompt := "Pokedex > "
+		if cfg.State.DryRun {
+			prompt = "Pokedex (dry run) > "
+		}
+		checkNews(cfg)
+		prompt = mailPrompt(cfg, prompt)
+		editor.Plain = cfg.Settings.Accessible
+		cfg.Chat.editing(prompt, editor.Redraw)
+		input, err := cfg.Interrupts.readLine(editor, prompt)
+		cfg.Chat.busy()
+		if errors.Is(err, lineedit.ErrInterrupt) {
+			continue
+		}
+		// Ctrl-D, the end of piped input, or being asked to end exits like
+		// `exit` does.
+		if err != nil {
+			fmt.Println()
+			if err := commandExit(cfg, nil); err != nil {
+				fmt.Println("Error:", err)
+				os.Exit(1)
+			}
+			return
+		}
+		lines, err := expandLine(cfg, input)
+		if err != nil {
+			fmt.Printf("Can't run that: %v.\n", err)
+			continue
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		saveHistory(cfg, editor)
+		// Commands after one that fails are skipped, so a macro doesn't
+		// carry on from a step that didn't work.
+		for _, parts := range lines {
+			status := runCommand(cfg, commands, parts)
+			if cfg.Interrupts.stopped() && !cfg.Quit {
+				commandExit(cfg, nil)
+			}
+			if cfg.Quit {
+				return
+			}
+			if status != exitOK {
+				break
+			}
+		}
+		tips.ran(cfg, input)
+	}
+}
+
+// Exit statuses of a command run from the shell.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// runCommand runs one command line and returns its exit status.
+func runCommand(cfg *config, commands map[string]cliCommand, parts []string) int {
+	parts, dryRun := takeDryRun(parts)
+	if len(parts) == 0 {
+		fmt.Fprintln(os.Stderr, "Which command should be a dry run?")
+		return exitUsage
+	}
+	cmd, exists := commands[parts[0]]
+	if !exists {
+		fmt.Fprintln(os.Stderr, "Unknown command:", strings.Join(parts, " "))
+		return exitUsage
+	}
+	cfg.DryRun = dryRun || cfg.State.DryRun
+	defer func() { cfg.DryRun = false }()
+	ctx, done := cfg.Interrupts.command(cfg.Timeout)
+	cfg.Ctx = ctx
+	cfg.API.SetContext(ctx)
+	unpage := paged(cfg, cmd)
+	err := chain(cmd)(cfg, parts[1:])
+	unpage()
+	expired := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	done()
+	cfg.Ctx = context.Background()
+	cfg.API.SetContext(nil)
+	status := exitOK
+	switch {
+	case errors.Is(err, errUsage):
+		status = exitUsage
+	case errors.Is(err, errRefused):
+		status = exitError
+	case errors.Is(err, context.Canceled):
+		fmt.Fprintln(os.Stderr, "Interrupted.")
+		status = exitError
+	case timedOut(err) && expired:
+		fmt.Fprintf(os.Stderr, "Gave up: the command took longer than %s.\n", cfg.Timeout)
+		status = exitError
+	case timedOut(err):
+		fmt.Fprintf(os.Stderr, "PokeAPI took too long to answer (%v). `set timeout` waits longer for it.\n", err)
+		status = exitError
+	case errors.Is(err, pokeapi.ErrOffline):
+		fmt.Fprintf(os.Stderr, "You're offline, and %v. Use `offline off` to go back online.\n", err)
+		status = exitError
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		status = exitError
+	}
+	// Time doesn't pass in a dry run.
+	if !cfg.DryRun {
+		tickRoamer(cfg)
+	}
+	return status
+}
+
+// outputFlags are the flags of commands whose results can be shown in other
+// formats; see formatted.
+var outputFlags = []cli.Flag{
+	{Name: "format", Value: "format", Usage: "Show the result as text, json, yaml, csv, table or template"},
+	{Name: "json", Usage: "Short for --format json"},
+	{Name: "template", Value: "template", Usage: "Show each result through a Go template, like '{{.Name}}'"},
+}
+
+// aiFlag plugs another AI into battles against the computer.
+var aiFlag = cli.Flag{Name: "ai", Value: "spec", Usage: "The opponent's AI: auto, or external:<command>"}
+
+// rulesetFlag picks the rules a battle is fought under.
+var rulesetFlag = cli.Flag{Name: "ruleset", Value: "name", Usage: "The ruleset to follow, like standard or little-cup"}
+
+// portFlag is the port a duel is hosted on.
+var portFlag = cli.Flag{Name: "port", Value: "port", Usage: "The port to listen on"}
+
+// commandRegistry is every command, by name.
+func commandRegistry() map[string]cliCommand {
+	return map[string]cliCommand{
 		"help": {
-			name:        "help",
-			description: "Displays a help message",
-			callback:    commandHelp,
+			Command: cli.Command{
+				Name:    "help",
+				Summary: "Displays a help message, or help with one command",
+				Args:    []cli.Arg{{Name: "command", Optional: true}},
+			},
+			callback: commandHelp,
+			pages:    true,
 		},
 		"exit": {
-			name:        "exit",
-			description: "Exit the Pokedex",
-			callback:    commandExit,
+			Command:  cli.Command{Name: "exit", Summary: "Save your Pokedex and exit"},
+			callback: commandExit,
+		},
+		"save": {
+			Command:  cli.Command{Name: "save", Summary: "Save your Pokedex; it is also saved whenever it changes"},
+			callback: commandSave,
+			writes:   true,
+		},
+		"load": {
+			Command:  cli.Command{Name: "load", Summary: "Go back to your last saved Pokedex"},
+			callback: commandLoad,
+			confirm:  "Go back to your last save? Anything changed since will be lost.",
 		},
 		"map": {
-			name:        "map",
-			description: "Display the next 20 location areas",
-			callback:    commandMap,
+			Command: countable(cli.Command{
+				Name:    "map",
+				Summary: "Display the next 20 location areas",
+				Flags:   outputFlags,
+				Subcommands: []cli.Command{
+					{Summary: "Display the next 20 location areas"},
+					{Name: "goto", Summary: "Jump to a page of location areas", Args: []cli.Arg{{Name: "page"}}},
+				},
+			}),
+			callback: commandMap,
+		},
+		"region": {
+			Command: countable(cli.Command{
+				Name:    "region",
+				Summary: "List the regions, or a region's locations and their areas",
+				Args:    []cli.Arg{{Name: "region", Optional: true}},
+				Flags:   outputFlags,
+			}),
+			callback: commandRegion,
 		},
 		"mapb": {
-			name:        "mapb",
-			description: "Display the previous 20 location areas",
-			callback:    commandMapB,
+			Command:  countable(cli.Command{Name: "mapb", Summary: "Display the previous 20 location areas", Flags: outputFlags}),
+			callback: commandMapB,
 		},
 		"explore": {
-			name:        "explore",
-			description: "Explore a specific location area",
-			callback:    commandExplore,
+			Command: countable(cli.Command{
+				Name:    "explore",
+				Summary: "Explore a location area, listing the Pokémon that match any filters",
+				Args:    []cli.Arg{{Name: "area_name", Kind: "area", Optional: true}},
+				Flags: append([]cli.Flag{
+					{Name: "biome", Value: "biome", Usage: "Explore an area of this biome: cave, forest, water or urban, picked at random if none is named"},
+					{Name: "type", Value: "type", Usage: "Only Pokémon of this type"},
+					{Name: "min-level", Value: "level", Usage: "Only Pokémon met at this level or higher"},
+					{Name: "max-level", Value: "level", Usage: "Only Pokémon met at this level or lower"},
+					{Name: "rarity", Value: "rarity", Usage: "Only common, uncommon or rare Pokémon"},
+					{Name: "method", Value: "method", Usage: "Only Pokémon met this way, like walk or surf"},
+					{Name: "sort", Value: "rarity|level", Usage: "List the most common, or the lowest level, Pokémon first"},
+					{Name: "version", Value: "version", Usage: "Only Pokémon met in this version of the games, like red"},
+					{Name: "details", Usage: "List every way each Pokémon is met, by method, with its levels and chance"},
+				}, outputFlags...),
+			}),
+			callback: commandExplore,
+			writes:   true,
 		},
 		"catch": {
-			name:        "catch",
-			description: "Catch a specific Pokémon",
-			callback:    commandCatch,
+			Command: cli.Command{
+				Name:    "catch",
+				Summary: "Try to catch a Pokémon",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "species"}},
+				Flags:   []cli.Flag{{Name: "ball", Value: "ball", Usage: "Throw a great, ultra or master ball"}},
+			},
+			callback: commandCatch,
+			writes:   true,
+			dryRun:   true,
+		},
+		"search": {
+			Command: cli.Command{
+				Name:    "search",
+				Summary: "Find Pokémon by part of their name, or a misspelling of it",
+				Args:    []cli.Arg{{Name: "query"}},
+			},
+			callback: commandSearch,
 		},
 		"inspect": {
-			name:        "inspect",
-			description: "Inspect a caught Pokémon",
-			callback:    commandInspect,
+			Command: cli.Command{
+				Name:    "inspect",
+				Summary: "Inspect a caught Pokémon",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}},
+				Flags:   outputFlags,
+			},
+			callback: commandInspect,
+		},
+		"goto": {
+			Command: cli.Command{
+				Name:    "goto",
+				Summary: "Travel to a bookmarked area and explore it",
+				Args:    []cli.Arg{{Name: "bookmark|area_name", Kind: "area"}},
+			},
+			callback: commandGoto,
+			writes:   true,
+		},
+		"bookmark": {
+			Command: cli.Command{
+				Name:    "bookmark",
+				Summary: "Name areas to travel back to",
+				Subcommands: []cli.Command{
+					{Name: "add", Summary: "Name an area", Args: []cli.Arg{{Name: "area_name", Kind: "area"}, {Name: "name"}}},
+					{Name: "list", Summary: "List your bookmarks"},
+					{Name: "remove", Summary: "Forget a bookmark", Args: []cli.Arg{{Name: "name"}}},
+				},
+			},
+			callback: commandBookmark,
+			writes:   true,
+		},
+		"party": {
+			Command: countable(cli.Command{
+				Name:    "party",
+				Summary: "Manage your party of up to six Pokémon",
+				Flags:   outputFlags,
+				Subcommands: []cli.Command{
+					{Summary: "List your party"},
+					{Name: "list", Summary: "List your party"},
+					{Name: "add", Summary: "Add a Pokémon to your party", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}}},
+					{Name: "remove", Summary: "Take a Pokémon out of your party", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}}},
+					{Name: "validate", Summary: "Check your party follows a ruleset before battling", Flags: []cli.Flag{rulesetFlag}},
+				},
+			}),
+			callback: commandParty,
+			writes:   true,
+		},
+		"box": {
+			Command: countable(cli.Command{
+				Name:    "box",
+				Summary: "Keep the Pokémon outside your party in storage boxes",
+				Flags:   outputFlags,
+				Subcommands: []cli.Command{
+					{Summary: "List every box"},
+					{Name: "list", Summary: "List every box, or one", Args: []cli.Arg{{Name: "box", Optional: true}}},
+					{Name: "move", Summary: "Move a Pokémon to a box or your party", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "box|party"}}},
+				},
+			}),
+			callback: commandBox,
+			writes:   true,
+		},
+		"release": {
+			Command: cli.Command{
+				Name:    "release",
+				Summary: "Let a Pokémon go; you can recover it for 30 days",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}},
+			},
+			callback: commandRelease,
+			writes:   true,
+			dryRun:   true,
+		},
+		"trade": {
+			Command: cli.Command{
+				Name:    "trade",
+				Summary: "Trade a Pokémon with a passing trainer for one you want",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "wanted", Kind: "species"}},
+				Details: "The trainer gives you the species you ask for, a level above the Pokémon\n" +
+					"you send, and you are asked to confirm first. Like any trade, it can make\n" +
+					"the Pokémon you receive evolve.",
+			},
+			callback: commandTrade,
+			writes:   true,
+			dryRun:   true,
+		},
+		"recover": {
+			Command: cli.Command{
+				Name:    "recover",
+				Summary: "Bring back a Pokémon you released, or list those you can",
+				Args:    []cli.Arg{{Name: "pokemon_name", Optional: true}},
+			},
+			callback: commandRecover,
+			writes:   true,
+		},
+		"nickname": {
+			Command: cli.Command{
+				Name:    "nickname",
+				Summary: "Give one of your Pokémon a nickname, or clear it",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "nickname", Optional: true, Rest: true}},
+			},
+			callback: commandNickname,
+			writes:   true,
+		},
+		"team": {
+			Command: cli.Command{
+				Name:    "team",
+				Summary: "Manage your party and saved teams",
+				Subcommands: []cli.Command{
+					{Summary: "Show your party"},
+					{Name: "add", Summary: "Add a Pokémon to your party", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}}},
+					{Name: "remove", Summary: "Take a Pokémon out of your party", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}}},
+					{Name: "save", Summary: "Save your party as a team", Args: []cli.Arg{{Name: "name"}}},
+					{Name: "load", Summary: "Make a saved team your party", Args: []cli.Arg{{Name: "name"}}},
+					{Name: "list", Summary: "List your saved teams"},
+				},
+			},
+			callback: commandTeam,
+			writes:   true,
+		},
+		"note": {
+			Command: cli.Command{
+				Name:    "note",
+				Summary: "Write down a note shown whenever you explore the area",
+				Args:    []cli.Arg{{Name: "area_name", Kind: "area"}, {Name: "text", Rest: true}},
+			},
+			callback: commandNote,
+			writes:   true,
+		},
+		"notes": {
+			Command: cli.Command{
+				Name:    "notes",
+				Summary: "Read your area notes",
+				Subcommands: []cli.Command{
+					{Summary: "List your notes"},
+					{Name: "list", Summary: "List your notes"},
+					{Name: "search", Summary: "Find notes that mention some text", Args: []cli.Arg{{Name: "text", Rest: true}}},
+				},
+			},
+			callback: commandNotes,
+		},
+		"stamina": {
+			Command: cli.Command{
+				Name:    "stamina",
+				Summary: "Show how much stamina you have for travelling, or drink something",
+				Subcommands: []cli.Command{
+					{Summary: "Show your stamina"},
+					{Name: "use", Summary: "Drink something to get stamina back", Args: []cli.Arg{{Name: "item"}}},
+				},
+			},
+			callback: commandStamina,
+			writes:   true,
 		},
 		"pokedex": {
-			name:        "pokedex",
-			description: "List all caught Pokémon",
-			callback:    commandPokedex,
+			Command: countable(cli.Command{
+				Name:    "pokedex",
+				Summary: "List all caught Pokémon, or every species you have seen",
+				Flags: append([]cli.Flag{
+					{Name: "met", Value: "area_name", Usage: "Only Pokémon caught in this area"},
+					{Name: "seen", Usage: "List every species you have seen instead"},
+				}, outputFlags...),
+			}),
+			callback: commandPokedex,
+		},
+		"paths": {
+			Command:  cli.Command{Name: "paths", Summary: "Show where config, data, cache and logs are stored"},
+			callback: commandPaths,
+		},
+		"insights": {
+			Command: cli.Command{
+				Name:    "insights",
+				Summary: "Show local command usage and latency",
+				Subcommands: []cli.Command{
+					{Summary: "Show command usage and latency"},
+					{Name: "export", Summary: "Write them to a file", Args: []cli.Arg{{Name: "file", File: true}}},
+					{Name: "reset", Summary: "Start counting again"},
+				},
+			},
+			callback: commandInsights,
+			writes:   true,
+		},
+		"tutorial": {
+			Command: cli.Command{
+				Name:    "tutorial",
+				Summary: "Learn the basics step by step",
+				Subcommands: []cli.Command{
+					{Summary: "Show the next step"},
+					{Name: "restart", Summary: "Start the tutorial again"},
+				},
+			},
+			callback: commandTutorial,
+			writes:   true,
+		},
+		"docs": {
+			Command: cli.Command{
+				Name:    "docs",
+				Summary: "Read about game mechanics",
+				Args:    []cli.Arg{{Name: "topic", Optional: true}},
+			},
+			callback: commandDocs,
+			pages:    true,
+		},
+		"farm": {
+			Command: cli.Command{
+				Name:    "farm",
+				Summary: "Grow berries over time",
+				Subcommands: []cli.Command{
+					{Name: "status", Summary: "Show what is growing"},
+					{Name: "plant", Summary: "Plant a berry, or list the seeds you can plant", Args: []cli.Arg{{Name: "berry", Optional: true}}},
+					{Name: "harvest", Summary: "Pick the berries that are ready"},
+				},
+			},
+			callback: commandFarm,
+			writes:   true,
+		},
+		"evolve": {
+			Command: cli.Command{
+				Name:    "evolve",
+				Summary: "Evolve a caught Pokémon once it meets the requirements",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "species", Optional: true}},
+				Details: "Pokémon evolve by reaching a level, which they gain by winning battles, by\nbecoming friendly enough, or with an item from your bag. Name the species\nfor Pokémon that can evolve more than one way, like eevee.",
+			},
+			callback: commandEvolve,
+			writes:   true,
+		},
+		"inventory": {
+			Command: cli.Command{
+				Name:    "inventory",
+				Summary: "List the items in your bag, with what they do",
+				Flags:   outputFlags,
+				Details: "Balls arrive each day you play, and exploring an area now and then turns up\n" +
+					"balls, berries or potions. What each item does comes from PokeAPI.",
+			},
+			callback: commandInventory,
+		},
+		"use": {
+			Command: cli.Command{
+				Name:    "use",
+				Summary: "Use an item from your bag, like a razz-berry before a catch",
+				Args:    []cli.Arg{{Name: "item", Kind: "item"}},
+				Details: "A razz-berry makes the next Pokémon you throw a ball at 1.5 times as easy to\n" +
+					"catch; drinks give you stamina back.",
+			},
+			callback: commandUse,
+			writes:   true,
+			dryRun:   true,
+		},
+		"alias": {
+			Command: cli.Command{
+				Name:    "alias",
+				Summary: "Name a command, or several, to run with one word",
+				Subcommands: []cli.Command{
+					{Summary: "Add an alias, or list them", Args: []cli.Arg{{Name: "name", Optional: true}, {Name: "command", Optional: true, Rest: true}}},
+					{Name: "remove", Summary: "Forget an alias", Args: []cli.Arg{{Name: "name"}}},
+				},
+				Details: "`alias e explore` makes `e pallet-town-area` explore it: words after an alias\n" +
+					"are added to what it runs. Quote several commands separated by ; to make a\n" +
+					"macro, like `alias hunt \"explore pastoria-city-area; encounter\"`, which stops\n" +
+					"at the first that fails. Aliases are kept in config.json and listed in help.",
+			},
+			callback: commandAlias,
+		},
+		"feed": {
+			Command: cli.Command{
+				Name:    "feed",
+				Summary: "Feed a berry to a caught Pokémon",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "berry"}},
+			},
+			callback: commandFeed,
+			writes:   true,
+		},
+		"give": {
+			Command: cli.Command{
+				Name:    "give",
+				Summary: "Give a caught Pokémon an item to hold",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "item"}},
+			},
+			callback: commandGive,
+			writes:   true,
+		},
+		"teach": {
+			Command: cli.Command{
+				Name:    "teach",
+				Summary: "Teach a move with a TM or the move tutor",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "move"}},
+			},
+			callback: commandTeach,
+			writes:   true,
+		},
+		"moves": {
+			Command: cli.Command{
+				Name:    "moves",
+				Summary: "List the moves a Pokémon knows and the strongest it can learn",
+				Args:    []cli.Arg{{Name: "pokemon", Kind: "species"}},
+				Flags:   outputFlags,
+			},
+			callback: commandMoves,
+		},
+		"compare": {
+			Command: cli.Command{
+				Name:    "compare",
+				Summary: "Compare two Pokémon's types, size and base stats side by side",
+				Args:    []cli.Arg{{Name: "pokemon_a", Kind: "species"}, {Name: "pokemon_b", Kind: "species"}},
+				Flags:   outputFlags,
+			},
+			callback: commandCompare,
+		},
+		"ability": {
+			Command: cli.Command{
+				Name:    "ability",
+				Summary: "Show what a Pokémon's abilities do",
+				Args:    []cli.Arg{{Name: "pokemon", Kind: "species"}},
+				Flags:   outputFlags,
+			},
+			callback: commandAbility,
+		},
+		"type": {
+			Command: cli.Command{
+				Name:    "type",
+				Summary: "Show what a type is strong and weak against",
+				Args:    []cli.Arg{{Name: "type", Kind: "type"}},
+				Flags:   outputFlags,
+			},
+			callback: commandType,
+		},
+		"weakness": {
+			Command: cli.Command{
+				Name:    "weakness",
+				Summary: "Show how hard each type of attack hits a Pokémon",
+				Args:    []cli.Arg{{Name: "pokemon", Kind: "species"}},
+				Flags:   outputFlags,
+				Details: "Both of a Pokémon's types count, so an attack both are weak to hits four times\nas hard. The chart is the one of the generation you play (see `set generation`).",
+			},
+			callback: commandWeakness,
+		},
+		"mysterygift": {
+			Command: cli.Command{
+				Name:    "mysterygift",
+				Summary: "Redeem a mystery gift code",
+				Args:    []cli.Arg{{Name: "code", Optional: true}},
+			},
+			callback: commandMysteryGift,
+			writes:   true,
+		},
+		"config": {
+			Command: cli.Command{
+				Name:    "config",
+				Summary: "Show your settings",
+				Subcommands: []cli.Command{
+					{Summary: "Show your settings", Flags: outputFlags},
+					{Name: "show", Summary: "Show your settings", Flags: outputFlags},
+				},
+				Details: "How the program behaves (pagesize, cachettl, cachebackend, color, glyphs,\n" +
+					"api and accessible) is kept in config.json in the config folder, which can\n" +
+					"also be edited by hand; how the game plays is kept with your progress.\n" +
+					"POKEDEXCLI_API overrides api for a session. Output taller than the terminal\n" +
+					"is shown a page at a time (space, enter or q), unless accessible is on.",
+			},
+			callback: commandConfig,
+		},
+		"fakemon": {
+			Command: cli.Command{
+				Name:    "fakemon",
+				Summary: "Manage packs of homebrew species",
+				Subcommands: []cli.Command{
+					{Summary: "List the installed packs", Flags: outputFlags},
+					{Name: "import", Summary: "Check a pack and install it", Args: []cli.Arg{{Name: "file", File: true}}},
+					{Name: "remove", Summary: "Uninstall a pack", Args: []cli.Arg{{Name: "pack"}}},
+				},
+				Details: "Packs are JSON files of species made by players, with their types, stats,\n" +
+					"moves, evolutions and ASCII art. Once installed and turned on with\n" +
+					"`set fakemon on`, their species can be met, caught and battled like any\n" +
+					"other, under names like custom/voltorbeast.",
+			},
+			callback: commandFakemon,
+			writes:   true,
+		},
+		"events": {
+			Command:  cli.Command{Name: "events", Summary: "List seasonal events"},
+			callback: commandEvents,
+		},
+		"whereis": {
+			Command: cli.Command{
+				Name:    "whereis",
+				Summary: "Get a hint about where the roaming Pokémon is",
+				Subcommands: []cli.Command{
+					{Name: "roaming", Summary: "Get a hint about where the roaming Pokémon is"},
+				},
+			},
+			callback: commandWhereis,
+		},
+		"photo": {
+			Command: cli.Command{
+				Name:    "photo",
+				Summary: "Take a photo card of a caught Pokémon",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}},
+			},
+			callback: commandPhoto,
+			writes:   true,
+		},
+		"admin": {
+			Command: cli.Command{
+				Name:    "admin",
+				Summary: "Run community events on your server, as its operator",
+				Subcommands: []cli.Command{
+					{Name: "spawn", Summary: "Put a Pokémon in an area for everyone", Args: []cli.Arg{{Name: "pokemon_name", Kind: "species"}, {Name: "area_name", Kind: "area"}}},
+					{Name: "grant", Summary: "Give a player an item", Args: []cli.Arg{{Name: "code|trainer"}, {Name: "item"}}},
+					{
+						Name: "event",
+						Subcommands: []cli.Command{
+							{Name: "start", Summary: "Start a community event", Args: []cli.Arg{{Name: "name"}}},
+						},
+					},
+					{Name: "stats", Summary: "Show how busy the server is"},
+				},
+				Details: "Admin commands need an admin role on the server, and its admin token in\n" + adminTokenEnv + ".",
+			},
+			callback: commandAdmin,
+			online:   true,
+		},
+		"album": {
+			Command: cli.Command{
+				Name:    "album",
+				Summary: "List your photos or show one",
+				Args:    []cli.Arg{{Name: "n", Optional: true}},
+			},
+			callback: commandAlbum,
+		},
+		"advise": {
+			Command: cli.Command{
+				Name:    "advise",
+				Summary: "Find the cheapest way to catch a Pokémon with your bag",
+				Subcommands: []cli.Command{
+					{
+						Name:    "catch",
+						Summary: "Find the cheapest way to catch a Pokémon with your bag",
+						Args:    []cli.Arg{{Name: "pokemon_name", Kind: "species"}},
+						Flags:   []cli.Flag{{Name: "n", Value: "attempts", Usage: "How many throws to plan for"}},
+					},
+				},
+			},
+			callback: commandAdvise,
+		},
+		"journal": {
+			Command: cli.Command{
+				Name:    "journal",
+				Summary: "Show your latest encounters, catches and battles",
+				Args:    []cli.Arg{{Name: "n", Optional: true}, {Name: "search", Optional: true, Rest: true}},
+			},
+			callback: commandJournal,
+			pages:    true,
+		},
+		"mail": {
+			Command: cli.Command{
+				Name:    "mail",
+				Summary: "Read the news that came while you were busy or away",
+				Subcommands: []cli.Command{
+					{Summary: "Read your new mail"},
+					{Name: "all", Summary: "Show your last 20 letters, read or not"},
+					{Name: "clear", Summary: "Throw away the letters you have read"},
+				},
+				Details: "Gifts from friends, accepted offers, events starting and ripe berries are\nall sent to your inbox, and the prompt shows how many letters are new.",
+			},
+			callback: commandMail,
+		},
+		"records": {
+			Command:  cli.Command{Name: "records", Summary: "Show the biggest and smallest Pokémon you have caught"},
+			callback: commandRecords,
+		},
+		"stats": {
+			Command:  cli.Command{Name: "stats", Summary: "Sum up your collection, Pokédex completion and catches this session", Flags: outputFlags},
+			callback: commandStats,
+		},
+		"biomes": {
+			Command:  cli.Command{Name: "biomes", Summary: "Show what you have explored and caught in each biome, and your achievements"},
+			callback: commandBiomes,
+		},
+		"encounter": {
+			Command: cli.Command{
+				Name:    "encounter",
+				Summary: "Walk around the area you last explored until a wild Pokémon appears",
+			},
+			callback: commandEncounter,
+			writes:   true,
+		},
+		"quest": {
+			Command: cli.Command{
+				Name:    "quest",
+				Summary: "Show today's quests and how far along you are",
+				Flags:   outputFlags,
+			},
+			callback: commandQuest,
+			writes:   true,
+		},
+		"trainer": {
+			Command: cli.Command{
+				Name:    "trainer",
+				Summary: "Show your trainer level, catch streak and achievements",
+				Flags:   outputFlags,
+			},
+			callback: commandTrainer,
+		},
+		"name": {
+			Command: cli.Command{
+				Name:    "name",
+				Summary: "Show or change your trainer name",
+				Args:    []cli.Arg{{Name: "new_name", Optional: true}},
+			},
+			callback: commandName,
+			writes:   true,
+		},
+		"wondertrade": {
+			Command: cli.Command{
+				Name:    "wondertrade",
+				Summary: "Trade a Pokémon for a random one",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon", Optional: true}},
+			},
+			callback: commandWonderTrade,
+			writes:   true,
+			dryRun:   true,
+		},
+		"offer": {
+			Command: cli.Command{
+				Name:    "offer",
+				Summary: "Trade through the offer board",
+				Subcommands: []cli.Command{
+					{
+						Name:    "put",
+						Summary: "Offer a Pokémon for a species you want",
+						Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}},
+						Flags:   []cli.Flag{{Name: "want", Value: "species", Usage: "The species you want in return"}},
+					},
+					{Name: "browse", Summary: "See what others are offering"},
+					{Name: "accept", Summary: "Take an offer", Args: []cli.Arg{{Name: "id"}}},
+					{Name: "cancel", Summary: "Take back your offer", Args: []cli.Arg{{Name: "id"}}},
+					{Name: "collect", Summary: "Collect what your offers were traded for"},
+				},
+			},
+			callback: commandOffer,
+			writes:   true,
+		},
+		"trades": {
+			Command: countable(cli.Command{
+				Name:    "trades",
+				Summary: "Look back over every trade you have made",
+				Flags:   outputFlags,
+				Subcommands: []cli.Command{
+					{Summary: "List every trade"},
+					{Name: "list", Summary: "List every trade"},
+					{Name: "search", Summary: "List the trades of a species, trainer or method", Args: []cli.Arg{{Name: "query", Rest: true}}},
+					{Name: "show", Summary: "Show a trade and both Pokémon as they were", Args: []cli.Arg{{Name: "id"}}},
+				},
+			}),
+			callback: commandTrades,
+		},
+		"tower": {
+			Command: cli.Command{
+				Name:    "tower",
+				Summary: "Take on the Battle Tower",
+				Subcommands: []cli.Command{
+					{Summary: "Take on the Battle Tower", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}}, Flags: []cli.Flag{aiFlag, rulesetFlag}},
+					{Name: "records", Summary: "Show your Battle Tower records"},
+				},
+			},
+			callback: commandTower,
+			writes:   true,
+		},
+		"battle": {
+			Command: cli.Command{
+				Name:    "battle",
+				Summary: "Battle a wild Pokémon for experience",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}, {Name: "wild_pokemon"}},
+				Flags:   []cli.Flag{aiFlag},
+			},
+			callback: commandBattle,
+			writes:   true,
+		},
+		"challenge": {
+			Command: cli.Command{
+				Name:    "challenge",
+				Summary: "Battle a new trainer",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}},
+				Flags:   []cli.Flag{aiFlag},
+			},
+			callback: commandChallenge,
+			writes:   true,
+		},
+		"vsseeker": {
+			Command: cli.Command{
+				Name:    "vsseeker",
+				Summary: "List trainers you've battled or challenge one again",
+				Args:    []cli.Arg{{Name: "id", Optional: true}, {Name: "pokemon_name", Optional: true}},
+				Flags:   []cli.Flag{aiFlag},
+			},
+			callback: commandVSSeeker,
+			writes:   true,
+		},
+		"duel": {
+			Command: cli.Command{
+				Name:    "duel",
+				Summary: "Battle another player over the network, or watch a duel",
+				Subcommands: []cli.Command{
+					{Name: "host", Summary: "Wait for a player to join", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon"}}, Flags: []cli.Flag{portFlag}},
+					{Name: "join", Summary: "Join a player's duel", Args: []cli.Arg{{Name: "host:port"}, {Name: "pokemon_name", Kind: "pokemon"}}},
+					{Name: "watch", Summary: "Watch a duel", Args: []cli.Arg{{Name: "host:port"}}},
+					{Name: "record", Summary: "Show your duel record"},
+				},
+			},
+			callback: commandDuel,
+			writes:   true,
+		},
+		"ranked": {
+			Command: cli.Command{
+				Name:    "ranked",
+				Summary: "Duel online players through the community server and see the ladder",
+				Subcommands: []cli.Command{
+					{Name: "queue", Summary: "Wait for an opponent", Args: []cli.Arg{{Name: "pokemon_name", Kind: "pokemon", Optional: true}}, Flags: []cli.Flag{portFlag, rulesetFlag}},
+					{Name: "ladder", Summary: "Show the ladder"},
+				},
+			},
+			callback: commandRanked,
+			writes:   true,
+		},
+		"friends": {
+			Command: cli.Command{
+				Name:    "friends",
+				Summary: "Show your friend code and friends, or send a friend an item once a day",
+				Subcommands: []cli.Command{
+					{Summary: "Show your friend code and friends"},
+					{Name: "add", Summary: "Add a friend", Args: []cli.Arg{{Name: "code"}}},
+					{Name: "remove", Summary: "Remove a friend", Args: []cli.Arg{{Name: "code"}}},
+					{Name: "gift", Summary: "Send a friend an item", Args: []cli.Arg{{Name: "code|trainer"}, {Name: "item"}}},
+				},
+			},
+			callback: commandFriends,
+			writes:   true,
+		},
+		"say": {
+			Command: cli.Command{
+				Name:    "say",
+				Summary: "Chat in the lobby, or with your opponent during a duel",
+				Args:    []cli.Arg{{Name: "message", Rest: true}},
+			},
+			callback: commandSay,
+			writes:   true,
+		},
+		"import": {
+			Command: cli.Command{
+				Name:    "import",
+				Summary: "Add Pokémon from an export, a Showdown team or a CSV of species, levels and moves",
+				Args:    []cli.Arg{{Name: "file", File: true}},
+				Flags: []cli.Flag{
+					{Name: "format", Value: "format", Usage: "The file's format, pokedex, showdown or csv"},
+					{Name: "conflicts", Value: "rule", Usage: "For species you have: keep yours, replace them, or take the higher level"},
+				},
+				Details: "A .json file written by export is merged into your Pokedex with each\nPokémon as it was, so it can restore a backup or bring in another trainer's\nPokémon. Species you already have are kept unless --conflicts says otherwise.",
+			},
+			callback: commandImport,
+			writes:   true,
+		},
+		"export": {
+			Command: cli.Command{
+				Name:    "export",
+				Summary: "Write your Pokedex to a JSON or CSV file, to back it up or share it",
+				Args:    []cli.Arg{{Name: "file", File: true}},
+				Flags:   []cli.Flag{{Name: "format", Value: "format", Usage: "json or csv; by default, from the file's extension"}},
+				Details: "JSON keeps every Pokémon whole, for import to bring back. CSV has a row per\nPokémon for spreadsheets; import reads its species, levels and moves.",
+			},
+			callback: commandExport,
+		},
+		"doctor": {
+			Command:  cli.Command{Name: "doctor", Summary: "Check PokeAPI still returns the fields the game relies on"},
+			callback: commandDoctor,
+		},
+		"cry": {
+			Command: cli.Command{
+				Name:    "cry",
+				Summary: "Play a Pokémon's cry, as in the latest or the original games",
+				Args:    []cli.Arg{{Name: "pokemon_name", Kind: "species"}},
+				Flags:   []cli.Flag{{Name: "legacy", Usage: "Play the cry from the original games"}},
+			},
+			callback: commandCry,
+		},
+		"cache": {
+			Command: cli.Command{
+				Name:    "cache",
+				Summary: "See how much space downloaded sprites and cries take, or remove them",
+				Subcommands: []cli.Command{
+					{
+						Name: "media",
+						Subcommands: []cli.Command{
+							{Name: "stats", Summary: "Show how much space they take"},
+							{Name: "purge", Summary: "Remove them"},
+						},
+					},
+				},
+			},
+			callback: commandCache,
+		},
+		"cachestats": {
+			Command:  cli.Command{Name: "cachestats", Summary: "Show how well the in-memory PokeAPI cache is doing"},
+			callback: commandCacheStats,
+		},
+		"tasks": {
+			Command: cli.Command{
+				Name:    "tasks",
+				Summary: "See the tasks `pokedexcli daemon` runs on a schedule",
+				Subcommands: []cli.Command{
+					{Name: "list", Summary: "List the tasks, when they last ran and when they run next"},
+				},
+				Details: "Change when tasks run in tasks.json in your config directory, a map of task\nnames to cron expressions like \"*/30 * * * *\" or \"@daily\".",
+			},
+			callback: commandTasks,
+		},
+		"hooks": {
+			Command: cli.Command{
+				Name:    "hooks",
+				Summary: "List the commands of your own that run at points in the game",
+				Details: "Set hooks in hooks.json in your config directory, a map of on-start, on-exit,\non-catch and on-levelup to lists of hooks like\n{\"command\": [\"notify-send\", \"Caught {{.Pokemon}} at Lv. {{.Level}}\"], \"timeout\": \"5s\"}.\nEach argument is a template with .Event, .Pokemon, .Level, .Area, .Caught and\n.Time, passed to the program as is rather than through a shell. Hooks are\nstopped after 10s unless they give a timeout.",
+			},
+			callback: commandHooks,
+		},
+		"offline": {
+			Command: cli.Command{
+				Name:    "offline",
+				Summary: "Play without a network, from cached and mirrored data; start with --offline for the same",
+				Args:    []cli.Arg{{Name: "on|off", Optional: true}},
+			},
+			callback: commandOffline,
+		},
+		"mirror": {
+			Command: cli.Command{
+				Name:    "mirror",
+				Summary: "Download every resource of a kind, like pokemon, for offline use",
+				Details: "Without a dir, resources go to the snapshot offline mode reads from.\n" +
+					"`mirror update` checks PokeAPI's lists against manifest.json in the snapshot,\n" +
+					"which notes the ID of each resource and when it was fetched, and downloads only\n" +
+					"the resources that are new or were renumbered. Without a resource it updates\n" +
+					"every kind mirrored.\n" +
+					"`mirror pack` packs the snapshot into a single file that is mapped into memory\n" +
+					"when the game starts, so offline lookups are instant and cost next to no memory.",
+				Flags: []cli.Flag{
+					{Name: "rate", Value: "n", Usage: "Requests a second"},
+					{Name: "resume", Usage: "Carry on where an earlier mirror stopped"},
+				},
+				Subcommands: []cli.Command{
+					{Summary: "Download every resource of a kind", Args: []cli.Arg{{Name: "resource"}, {Name: "dir", Optional: true, File: true}}},
+					{Name: "update", Summary: "Download only what is new or changed since mirroring", Args: []cli.Arg{{Name: "resource", Optional: true}}},
+					{Name: "pack", Summary: "Pack the snapshot into one file for fast offline lookups"},
+				},
+			},
+			callback: commandMirror,
+		},
+		"update": {
+			Command: cli.Command{
+				Name:    "update",
+				Summary: "Install the latest release from GitHub, or just check for one",
+				Flags:   []cli.Flag{{Name: "check-only", Usage: "Only check for a newer release"}},
+			},
+			callback: commandUpdate,
+			writes:   true,
+		},
+		"simulate": {
+			Command: cli.Command{
+				Name:    "simulate",
+				Summary: "Battle two teams many times and report win rates",
+				Args:    []cli.Arg{{Name: "team"}, {Name: "vs", Literal: true}, {Name: "team"}},
+				Flags:   []cli.Flag{{Name: "n", Value: "battles", Usage: "How many battles to run"}},
+				Details: "A team is a saved team name or a comma-separated list of Pokémon.",
+			},
+			callback: commandSimulate,
+		},
+		"battles": {
+			Command: cli.Command{
+				Name:    "battles",
+				Summary: "List your duels or check one replays the same way",
+				Subcommands: []cli.Command{
+					{Summary: "List your duels"},
+					{Name: "verify", Summary: "Check a duel replays the same way", Args: []cli.Arg{{Name: "id"}}},
+				},
+			},
+			callback: commandBattles,
+		},
+		"set": {
+			Command: cli.Command{
+				Name:    "set",
+				Summary: "Change a game setting",
+				Subcommands: []cli.Command{
+					{Name: "generation", Summary: "Choose which games' battle rules to use", Args: []cli.Arg{{Name: "1-9|latest"}}},
+					{Name: "stamina", Summary: "Make travelling cost stamina (on by default)", Args: []cli.Arg{{Name: "on|off"}}},
+					{Name: "cache", Summary: "Keep PokeAPI responses on disk between sessions (on by default)", Args: []cli.Arg{{Name: "on|off"}}},
+					{Name: "player", Summary: "Choose the audio player cries are played with", Args: []cli.Arg{{Name: "command|off", Rest: true}}},
+					{Name: "server", Summary: "Opt in to online play through a community server", Args: []cli.Arg{{Name: "url|off"}}},
+					{
+						Name:    "format",
+						Summary: "Choose how commands that take --format show their results",
+						Args:    []cli.Arg{{Name: "text|json|yaml|csv|table|template"}, {Name: "template", Optional: true, Rest: true}},
+					},
+					{Name: "sprites", Summary: "Draw Pokémon in inspect and when caught (on by default)", Args: []cli.Arg{{Name: "on|off"}}},
+					{Name: "timeout", Summary: "Choose how long a request to PokeAPI may take (30s by default)", Args: []cli.Arg{{Name: "duration|default"}}},
+					{Name: "tips", Summary: "Suggest what you usually run next, from your command history (off by default)", Args: []cli.Arg{{Name: "on|off"}}},
+					{Name: "dryrun", Summary: "Show what commands would do without changing your game, like --dry-run", Args: []cli.Arg{{Name: "on|off"}}},
+					{Name: "fakemon", Summary: "Meet the homebrew species from installed packs in the wild (off by default)", Args: []cli.Arg{{Name: "on|off"}}},
+					{Name: "pagesize", Summary: "Choose how many areas a page of the map lists (20 by default)", Args: []cli.Arg{{Name: "1-100|default"}}},
+					{Name: "cachettl", Summary: "Choose how long PokeAPI responses are kept on disk (168h by default)", Args: []cli.Arg{{Name: "duration|default"}}},
+					{Name: "cachebackend", Summary: "Choose what PokeAPI responses are stored in between sessions (disk by default)", Args: []cli.Arg{{Name: "backend"}}},
+					{Name: "color", Summary: "Color output, or only when it goes to a terminal (auto by default)", Args: []cli.Arg{{Name: "auto|on|off"}}},
+					{Name: "glyphs", Summary: "Choose whether output uses emoji, other Unicode symbols or only ASCII (auto by default)", Args: []cli.Arg{{Name: "auto|ascii|unicode|emoji"}}},
+					{Name: "api", Summary: "Use another copy of PokeAPI, like a local one", Args: []cli.Arg{{Name: "url|default"}}},
+					{Name: "accessible", Summary: "Write output for screen readers, in words and without color (off by default)", Args: []cli.Arg{{Name: "on|off"}}},
+				},
+			},
+			callback: commandSet,
+			writes:   true,
+			dryRun:   true,
 		},
-	}
-
-	reader := bufio.NewReader(os.Stdin)
-	for {
-		fmt.Print("Pokedex > ")
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
-		parts := strings.Fields(input)
-		if len(parts) == 0 {
-			continue
-		}
-		cmdName := parts[0]
-		args := parts[1:]
-		if cmd, exists := commands[cmdName]; exists {
-			cmd.callback(cfg, args)
-		} else {
-			fmt.Println("Unknown command:", input)
-		}
 	}
 }
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/cli"
+	"github.com/eymardfreire/pokedexcli/internal/community"
+	"github.com/eymardfreire/pokedexcli/internal/filter"
+	"github.com/eymardfreire/pokedexcli/internal/glyph"
+	"github.com/eymardfreire/pokedexcli/internal/render"
+	"github.com/eymardfreire/pokedexcli/internal/validate"
+)
+
+// commandFunc runs a command with its arguments.
+type commandFunc func(cfg *config, args []string) error
+
+// middleware wraps a command's callback with behaviour every command shares.
+// It gets the command too, so it can go by the command's metadata.
+type middleware func(cmd cliCommand, next commandFunc) commandFunc
+
+// middlewares wrap every command, outermost first.
+var middlewares = []middleware{timed, logged, checked, validated, permitted, dryRun, readOnly, formatted, confirmed}
+
+// chain wraps a command's callback in the middlewares.
+func chain(cmd cliCommand) commandFunc {
+	next := commandFunc(cmd.callback)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		next = middlewares[i](cmd, next)
+	}
+	return next
+}
+
+// timed records how long each command takes for `insights`.
+func timed(cmd cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, args []string) error {
+		start := time.Now()
+		err := next(cfg, args)
+		cfg.Insights.Record(cmd.Name, time.Since(start), err)
+		if !cfg.ReadOnly {
+			cfg.Insights.Save()
+		}
+		return err
+	}
+}
+
+// logged writes every command and how it went to the command log.
+func logged(cmd cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, args []string) error {
+		if cfg.Log == nil {
+			return next(cfg, args)
+		}
+		start := time.Now()
+		err := next(cfg, args)
+		line := strings.Join(append([]string{cmd.Name}, args...), " ")
+		if err != nil {
+			cfg.Log.Printf("%s (%s): %v", line, time.Since(start).Round(time.Millisecond), err)
+		} else {
+			cfg.Log.Printf("%s (%s)", line, time.Since(start).Round(time.Millisecond))
+		}
+		return err
+	}
+}
+
+// errUsage is returned when a command was typed wrong, after saying how.
+var errUsage = errors.New("usage")
+
+// errRefused is returned when a command wasn't run, after saying why.
+var errRefused = errors.New("refused")
+
+// checked checks a command's arguments against how it is typed, and shows
+// its help or what was wrong instead of running it. Otherwise it puts what
+// it parsed in cfg.Input, and hands the command its subcommand and
+// arguments without the flags.
+func checked(cmd cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, args []string) error {
+		in, err := cmd.Parse(args)
+		var usage *cli.UsageError
+		switch {
+		case errors.Is(err, cli.ErrHelp):
+			fmt.Print(cmd.Help())
+			return nil
+		case errors.As(err, &usage):
+			fmt.Fprintf(os.Stderr, "%s: %s\nUsage: %s\n", cmd.Name, usage.Problem, usage.Usage)
+			return errUsage
+		}
+		cfg.Input = in
+		defer func() { cfg.Input = cli.Input{} }()
+		return next(cfg, append(slices.Clone(in.Path), in.Args...))
+	}
+}
+
+// validated checks the names and file paths typed as a command's arguments,
+// and hands the command them tidied, so Pikachu reaches it as pikachu.
+func validated(cmd cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, args []string) error {
+		in := cfg.Input
+		in.Args = slices.Clone(in.Args)
+		args = slices.Clone(args)
+		for i, arg := range in.Of {
+			value, err := validate.Name(arg.Kind, in.Args[i])
+			if err == nil && arg.File {
+				value, err = validate.Path(value)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.Name, err)
+				return errUsage
+			}
+			in.Args[i], args[len(in.Path)+i] = value, value
+		}
+		cfg.Input = in
+		return next(cfg, args)
+	}
+}
+
+// permitted refuses commands that the player's role on their community
+// server doesn't allow; see permissions.
+func permitted(cmd cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, args []string) error {
+		needs := permission(cmd, cfg.Input)
+		if needs == "" || server(cfg) == "" {
+			return next(cfg, args)
+		}
+		role, err := serverRole(cfg)
+		if err != nil {
+			return fmt.Errorf("checking what you may do on the server: %w", err)
+		}
+		if !community.Allows(role, needs) {
+			fmt.Printf("`%s` needs the %s permission, which a %s on this server doesn't have.\n", cmd.Name, needs, role)
+			return errRefused
+		}
+		return next(cfg, args)
+	}
+}
+
+// dryRun refuses commands that would change the game in a dry run, unless
+// they can show what they would do instead.
+func dryRun(cmd cliCommand, next commandFunc) commandFunc {
+	if !cmd.writes || cmd.dryRun {
+		return next
+	}
+	return func(cfg *config, args []string) error {
+		if cfg.DryRun {
+			fmt.Printf("`%s` can't show what it would do, so it wasn't run. Nothing was changed.\n", cmd.Name)
+			return errRefused
+		}
+		return next(cfg, args)
+	}
+}
+
+// readOnly refuses commands that change the game while read-only mode is
+// on.
+func readOnly(cmd cliCommand, next commandFunc) commandFunc {
+	if !cmd.writes {
+		return next
+	}
+	return func(cfg *config, args []string) error {
+		if cfg.ReadOnly {
+			fmt.Printf("`%s` changes your game, so it can't be used in read-only mode.\n", cmd.Name)
+			return errRefused
+		}
+		return next(cfg, args)
+	}
+}
+
+// formatted picks how a command that takes --template shows its result,
+// from its flags, those given before the command, or the format setting.
+// For list commands' count subcommand, it counts the result instead; see
+// countable. Outside the text format, only the result goes to stdout and
+// anything else the command says goes to stderr, so the result can be
+// piped.
+func formatted(cmd cliCommand, next commandFunc) commandFunc {
+	if !slices.Contains(cmd.FlagNames(), "template") {
+		return next
+	}
+	return func(cfg *config, args []string) error {
+		format, tmpl := cfg.Settings.Format, cfg.Settings.Template
+		if cfg.Format != "" {
+			format, tmpl = cfg.Format, cfg.Template
+		}
+		format, tmpl = flagFormat(cfg.Input.Flags, format, tmpl)
+		r, err := render.New(format, tmpl)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: can't show that: %v\n", cmd.Name, err)
+			return errUsage
+		}
+		if _, ok := r.(render.Table); ok {
+			glyphs := cfg.Settings.glyphs()
+			r = render.Table{Box: glyphs >= glyph.Unicode, ASCII: glyphs == glyph.ASCII}
+		}
+		if slices.Equal(cfg.Input.Path, []string{"count"}) {
+			r = render.Count{By: cfg.Input.Flags["by"], Then: r}
+			args = args[1:]
+		}
+		if expr := cfg.Input.Flags["where"]; expr != "" {
+			if cfg.Where, err = filter.Parse(expr); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: can't filter on that: %v\n", cmd.Name, err)
+				return errUsage
+			}
+			defer func() { cfg.Where = nil }()
+		}
+		cfg.Render = r
+		defer func() { cfg.Render = nil }()
+		if _, plain := r.(render.Text); !plain {
+			stdout := os.Stdout
+			cfg.Results, os.Stdout = stdout, os.Stderr
+			defer func() { os.Stdout, cfg.Results = stdout, nil }()
+		}
+		return next(cfg, args)
+	}
+}
+
+// confirmed asks before running commands that can't be undone.
+func confirmed(cmd cliCommand, next commandFunc) commandFunc {
+	if cmd.confirm == "" {
+		return next
+	}
+	return func(cfg *config, args []string) error {
+		if !confirm(cfg, cmd.confirm) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return next(cfg, args)
+	}
+}
+
+// openLog opens the command log in the logs directory.
+func openLog(cfg *config) (*log.Logger, error) {
+	f, err := os.OpenFile(filepath.Join(cfg.Paths.Logs, "commands.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return log.New(f, "", log.LstdFlags), nil
+}
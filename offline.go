@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/eymardfreire/pokedexcli/internal/color"
+	"github.com/eymardfreire/pokedexcli/internal/pack"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+)
+
+// snapshotDir holds PokeAPI data seeded for offline play, as
+// `mirror <resource>` saves it.
+func snapshotDir(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "snapshot")
+}
+
+// packPath is the snapshot packed into one file by `mirror pack`, which
+// offline mode reads before the snapshot directory.
+func packPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "snapshot.pack")
+}
+
+// openPack maps the packed snapshot into memory, if there is one.
+func openPack(cfg *config) error {
+	p, err := pack.Open(packPath(cfg))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cfg.API.SetPack(p)
+	return nil
+}
+
+// offlineTransport fails requests at once while the player is offline, so
+// downloads like cries don't wait on a network that isn't there. Online,
+// base makes them.
+type offlineTransport struct {
+	api  *pokeapi.Client
+	base http.RoundTripper
+}
+
+func (t offlineTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if t.api.Offline() {
+		return nil, fmt.Errorf("%s: %w", r.URL, pokeapi.ErrOffline)
+	}
+	return t.base.RoundTrip(r)
+}
+
+// setOffline takes the game off the network, or back on.
+func setOffline(cfg *config, offline bool) {
+	cfg.API.SetOffline(offline)
+	cfg.Chat.connect(server(cfg))
+}
+
+func commandOffline(cfg *config, args []string) error {
+	offline := !cfg.API.Offline()
+	if len(args) > 0 {
+		if args[0] != "on" && args[0] != "off" {
+			fmt.Println("Offline mode is on or off; leave it out to switch.")
+			return nil
+		}
+		offline = args[0] == "on"
+	}
+	setOffline(cfg, offline)
+	if !offline {
+		fmt.Println("You're back online.")
+		return nil
+	}
+	fmt.Println(paint(cfg, "You're offline: PokeAPI data comes from your cache and the snapshot only.", color.Dim))
+	fmt.Println(paint(cfg, fmt.Sprintf("Seed the snapshot before you go with `mirror <resource>`; it is kept in %s.", snapshotDir(cfg)), color.Dim))
+	return nil
+}
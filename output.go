@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/cli"
+	"github.com/eymardfreire/pokedexcli/internal/color"
+	"github.com/eymardfreire/pokedexcli/internal/filter"
+	"github.com/eymardfreire/pokedexcli/internal/glyph"
+	"github.com/eymardfreire/pokedexcli/internal/render"
+)
+
+// colored reports whether output may be colored.
+func colored(cfg *config) bool {
+	return !cfg.NoColor && cfg.Settings.color()
+}
+
+// paint is s in styles, if output may be colored.
+func paint(cfg *config, s string, styles ...color.Style) string {
+	if !colored(cfg) {
+		return s
+	}
+	return color.Paint(s, styles...)
+}
+
+// typeName is a type's name, in its color if output may be colored, after
+// its emoji if output may use them.
+func typeName(cfg *config, name string) string {
+	shown := name
+	if colored(cfg) {
+		shown = color.Type(name)
+	}
+	if e, ok := glyph.Types[name]; ok {
+		shown = emoji(cfg, e+" ") + shown
+	}
+	return shown
+}
+
+// emoji is s if output may use emoji, or nothing.
+func emoji(cfg *config, s string) string {
+	if cfg.Settings.glyphs() < glyph.Emoji {
+		return ""
+	}
+	return s
+}
+
+// show shows a command's result, v, in the format asked for. The text
+// format runs text instead, the command's own way of printing it.
+func show(cfg *config, v any, text func()) error {
+	if textOutput(cfg) {
+		text()
+		return nil
+	}
+	return cfg.Render.Render(cfg.Results, v)
+}
+
+// textOutput reports whether a command's result is shown in the text
+// format, by the command's own printing, which can print each part of it
+// as it comes.
+func textOutput(cfg *config) bool {
+	_, plain := cfg.Render.(render.Text)
+	return plain || cfg.Render == nil
+}
+
+// formatFlags takes --format, --template and --json out of args, and
+// returns the rest with the format and template they ask for. A template on
+// its own asks for the template format, and --json is short for
+// --format json.
+func formatFlags(args []string, format, tmpl string) ([]string, string, string) {
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(args[i], "--"), "=")
+		if !strings.HasPrefix(args[i], "--") || name != "format" && name != "template" && name != "json" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if name == "json" {
+			format = "json"
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		if name == "format" {
+			format = value
+		} else {
+			format, tmpl = "template", value
+		}
+	}
+	return rest, format, tmpl
+}
+
+// flagFormat is the format and template a command's --json, --format and
+// --template flags ask for, as formatFlags reads them, or format and tmpl
+// if it was given none.
+func flagFormat(flags map[string]string, format, tmpl string) (string, string) {
+	if flags["json"] != "" {
+		format = "json"
+	}
+	if value, ok := flags["format"]; ok {
+		format = value
+	}
+	if value, ok := flags["template"]; ok {
+		format, tmpl = "template", value
+	}
+	return format, tmpl
+}
+
+// whereFlag filters what a list command lists; see where.
+var whereFlag = cli.Flag{Name: "where", Value: "filter", Usage: "List only what matches, like \"base_experience > 200 && types contains dragon\""}
+
+// where keeps the results of a list command that match its --where filter,
+// if it was given one.
+func where[T any](cfg *config, items []T) ([]T, error) {
+	if cfg.Where == nil {
+		return items, nil
+	}
+	kept, err := filter.Apply(cfg.Where, items)
+	if err != nil {
+		return nil, fmt.Errorf("filtering: %w", err)
+	}
+	return kept, nil
+}
+
+// countable adds a count subcommand to a list command, which counts what it
+// would list instead, or with --by, how many have each value of a field.
+// formatted does the counting, so the command needs outputFlags. List
+// commands can be filtered too, with whereFlag.
+func countable(c cli.Command) cli.Command {
+	c.Flags = append(slices.Clip(c.Flags), whereFlag)
+	if len(c.Subcommands) == 0 {
+		c.Subcommands = []cli.Command{{Summary: c.Summary, Args: c.Args}}
+		c.Args = nil
+	}
+	var list cli.Command
+	for _, sub := range c.Subcommands {
+		if sub.Name == "" {
+			list = sub
+		}
+	}
+	c.Subcommands = append(c.Subcommands, cli.Command{
+		Name:    "count",
+		Summary: "Count them instead, or group them by a field",
+		Args:    list.Args,
+		Flags:   []cli.Flag{{Name: "by", Value: "field", Usage: "Count how many have each value of a field, like type"}},
+	})
+	return c
+}
+
+func setFormat(cfg *config, args []string) error {
+	format, tmpl := args[0], strings.Join(args[1:], " ")
+	if format != "template" {
+		tmpl = ""
+	}
+	if _, err := render.New(format, tmpl); err != nil {
+		fmt.Printf("Can't use that format: %v.\n", err)
+		return nil
+	}
+	cfg.Settings.Format, cfg.Settings.Template = format, tmpl
+	fmt.Printf("Commands that take --format now use %s by default.\n", format)
+	return saveSettings(cfg)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"io"
+	"os"
+	"slices"
+
+	"github.com/eymardfreire/pokedexcli/internal/lineedit"
+	"github.com/eymardfreire/pokedexcli/internal/pager"
+)
+
+// paged sends what cmd writes to stdout through a pager while it runs,
+// when the player is at a terminal long output could scroll off. Only
+// commands that don't ask for input are paged, so the pager never competes
+// with them for keys. It returns a function to call once cmd is done.
+func paged(cfg *config, cmd cliCommand) func() {
+	if !cmd.pages && !slices.Contains(cmd.FlagNames(), "format") || cfg.Settings.Accessible || !terminal() {
+		return func() {}
+	}
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return func() {}
+	}
+	stdout := os.Stdout
+	height := lineedit.Height(int(stdout.Fd()))
+	if height < 2 {
+		return func() {}
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+	pg := &pager.Pager{
+		Out:    stdout,
+		Height: height,
+		Width:  lineedit.Width(int(stdout.Fd())),
+		Key: func() (rune, error) {
+			return lineedit.ReadKey(cfg.In, int(os.Stdin.Fd()))
+		},
+	}
+	done := make(chan struct{})
+	go func() {
+		io.Copy(pg, r)
+		r.Close()
+		close(done)
+	}()
+	os.Stdout = w
+	return func() {
+		os.Stdout = stdout
+		w.Close()
+		<-done
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/eymardfreire/pokedexcli/internal/lineedit"
+)
+
+// maxHistory is how many lines of history are kept between sessions.
+const maxHistory = 500
+
+func historyPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "history")
+}
+
+// newEditor returns the line editor for the prompt, with the history from
+// earlier sessions.
+func newEditor(cfg *config, commands map[string]cliCommand) *lineedit.Editor {
+	e := &lineedit.Editor{
+		In:  cfg.In,
+		Out: os.Stdout,
+		Fd:  int(os.Stdin.Fd()),
+		Complete: func(words []string, partial string) []string {
+			return completeWord(cfg, commands, words, partial)
+		},
+	}
+	if f, err := os.Open(historyPath(cfg)); err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			e.History = append(e.History, scanner.Text())
+		}
+		e.History = e.History[max(len(e.History)-maxHistory, 0):]
+	}
+	return e
+}
+
+// completeWord completes command names and aliases, and the first argument
+// of commands: their subcommands, or names if they take one.
+func completeWord(cfg *config, commands map[string]cliCommand, words []string, partial string) []string {
+	var options []string
+	switch len(words) {
+	case 0:
+		for name, cmd := range commands {
+			if !cmd.online || cfg.State.Server != "" {
+				options = append(options, name)
+			}
+		}
+		for name := range cfg.Settings.Aliases {
+			options = append(options, name)
+		}
+	case 1:
+		cmd, ok := commands[words[0]]
+		if !ok {
+			break
+		}
+		for _, sub := range cmd.Subcommands {
+			if sub.Name != "" {
+				options = append(options, sub.Name)
+			}
+		}
+		if cmd.Kind() != "" {
+			options = append(options, nameCandidates(cfg, cmd.Kind())...)
+		}
+	}
+	var matches []string
+	for _, option := range options {
+		if strings.HasPrefix(option, partial) {
+			matches = append(matches, option)
+		}
+	}
+	return matches
+}
+
+// saveHistory rewrites the history file with the editor's history.
+func saveHistory(cfg *config, e *lineedit.Editor) {
+	history := e.History[max(len(e.History)-maxHistory, 0):]
+	data := strings.Join(history, "\n") + "\n"
+	if err := os.WriteFile(historyPath(cfg), []byte(data), 0o600); err != nil {
+		fmt.Println("Error saving your command history:", err)
+	}
+}
+
+// splitLine splits a command line into words. A word that starts with a
+// quote runs to the matching quote, spaces and all, as in a shell; if the
+// quotes don't pair up, like in "say it's 'raining", they are kept as they
+// are.
+func splitLine(line string) []string {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case !inWord && (r == '\'' || r == '"'):
+			quote, inWord = r, true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+			}
+			inWord = false
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return strings.Fields(line)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
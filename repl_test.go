@@ -0,0 +1,884 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/friendship"
+	"github.com/eymardfreire/pokedexcli/internal/ledger"
+	"github.com/eymardfreire/pokedexcli/internal/manifest"
+)
+
+// These tests play whole sessions: they build the game, type commands into
+// it, and check what it printed and saved. PokeAPI is a fake serving
+// testdata/api, and every random stream is pinned, so a session plays the
+// same way every time.
+//
+// Run go test -run TestSession -update to rewrite the expected output in
+// testdata/snapshots after changing what the game says.
+
+var updateSnapshots = flag.Bool("update", false, "rewrite the session snapshots in testdata/snapshots")
+
+// binary is the game, built once for every session.
+var binary string
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	dir, err := os.MkdirTemp("", "pokedexcli-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	binary = filepath.Join(dir, "pokedexcli")
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Stderr = os.Stderr
+	status := 1
+	if err := build.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "building the game:", err)
+	} else {
+		status = m.Run()
+	}
+	os.RemoveAll(dir)
+	os.Exit(status)
+}
+
+// fakeAPI serves testdata/api/<path>.json for each request, like
+// testdata/api/pokemon/pikachu.json for /pokemon/pikachu/. Links to
+// PokeAPI in them, like a species' evolution chain, lead back to it. Any
+// other Pokémon is made up, as a plain normal type that is always caught,
+// so sessions only need files for what they look at closely.
+func fakeAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(r.URL.Path, "/")
+		if data, err := os.ReadFile(filepath.Join("testdata", "api", path+".json")); err == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(bytes.ReplaceAll(data, []byte("https://pokeapi.co/api/v2"), []byte(srv.URL)))
+			return
+		}
+		resource, name, _ := strings.Cut(path, "/")
+		switch {
+		case name == "" || strings.Contains(name, "/"):
+			http.NotFound(w, r)
+		case resource == "pokemon":
+			fmt.Fprintf(w, `{"name":%q,"base_experience":64,"height":5,"weight":50,`+
+				`"stats":[{"base_stat":45,"stat":{"name":"hp"}},{"base_stat":45,"stat":{"name":"attack"}},`+
+				`{"base_stat":45,"stat":{"name":"defense"}},{"base_stat":45,"stat":{"name":"special-attack"}},`+
+				`{"base_stat":45,"stat":{"name":"special-defense"}},{"base_stat":45,"stat":{"name":"speed"}}],`+
+				`"types":[{"slot":1,"type":{"name":"normal"}}]}`, name)
+		case resource == "pokemon-species":
+			fmt.Fprintf(w, `{"name":%q,"capture_rate":255}`, name)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// session is a game with a data directory of its own.
+type session struct {
+	t    *testing.T
+	home string
+	api  string
+	// env is added to the game's environment.
+	env []string
+}
+
+func newSession(t *testing.T) *session {
+	t.Helper()
+	s := &session{t: t, home: t.TempDir(), api: fakeAPI(t).URL}
+	// Saves start with a trainer, whose ID would otherwise be random.
+	s.write(filepath.Join(".local", "share", "pokedexcli", "save.json"), `{"trainer":{"name":"ash","id":1}}`)
+	return s
+}
+
+func (s *session) write(name, data string) {
+	s.t.Helper()
+	path := filepath.Join(s.home, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		s.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// play types input into the game, a command a line, and returns what it
+// printed once the input runs out.
+func (s *session) play(input string, args ...string) string {
+	s.t.Helper()
+	out, status := s.run(input, args...)
+	if status != 0 {
+		s.t.Fatalf("exit status %d\n%s", status, out)
+	}
+	return out
+}
+
+// run is play for a game that may exit with an error, and returns its exit
+// status too.
+func (s *session) run(input string, args ...string) (string, int) {
+	s.t.Helper()
+	cmd := s.command(args...)
+	cmd.Stdin = strings.NewReader(input)
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	err := cmd.Run()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		s.t.Fatalf("%v\n%s", err, out.String())
+	}
+	return out.String(), cmd.ProcessState.ExitCode()
+}
+
+// command is the game, ready to start with args.
+func (s *session) command(args ...string) *exec.Cmd {
+	cmd := exec.Command(binary, args...)
+	cmd.Env = []string{
+		"HOME=" + s.home,
+		"PATH=" + os.Getenv("PATH"),
+		"POKEDEXCLI_SEED=1",
+		"POKEDEXCLI_API=" + s.api,
+	}
+	cmd.Env = append(cmd.Env, s.env...)
+	return cmd
+}
+
+// game is a game left running, for sessions stopped partway.
+type game struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// prompts has what the game printed each time it waits at the prompt,
+	// and then what it printed before it ended.
+	prompts chan string
+}
+
+// start starts the game and waits for its first prompt.
+func (s *session) start() *game {
+	s.t.Helper()
+	g := &game{cmd: s.command(), prompts: make(chan string)}
+	var err error
+	if g.stdin, err = g.cmd.StdinPipe(); err != nil {
+		s.t.Fatal(err)
+	}
+	stdout, err := g.cmd.StdoutPipe()
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	if err := g.cmd.Start(); err != nil {
+		s.t.Fatal(err)
+	}
+	s.t.Cleanup(func() { g.stdin.Close() })
+	go func() {
+		buf := make([]byte, 4096)
+		var out string
+		for {
+			n, err := stdout.Read(buf)
+			out += string(buf[:n])
+			if err != nil {
+				g.prompts <- out
+				close(g.prompts)
+				return
+			}
+			if strings.HasSuffix(out, "Pokedex > ") {
+				g.prompts <- out
+				out = ""
+			}
+		}
+	}()
+	<-g.prompts
+	return g
+}
+
+// do types a line and returns what the game printed before prompting
+// again.
+func (g *game) do(line string) string {
+	fmt.Fprintln(g.stdin, line)
+	return <-g.prompts
+}
+
+// wait waits for the game to end, and returns what it printed last.
+func (g *game) wait() (string, error) {
+	var out string
+	for rest := range g.prompts {
+		out = rest
+	}
+	return out, g.cmd.Wait()
+}
+
+// load reads one of the game's data files into v.
+func (s *session) load(name string, v any) {
+	s.t.Helper()
+	data, err := os.ReadFile(filepath.Join(s.home, ".local", "share", "pokedexcli", name))
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		s.t.Fatalf("reading %s: %v", name, err)
+	}
+}
+
+func (s *session) pokedex() map[string]Pokemon {
+	s.t.Helper()
+	var dex struct {
+		Caught map[string]Pokemon `json:"caught"`
+	}
+	s.load("pokedex.json", &dex)
+	return dex.Caught
+}
+
+var (
+	timestamps = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T[\d:.]+(Z|[+-]\d{2}:\d{2})`)
+	dates      = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	times      = regexp.MustCompile(`\b\d{2}:\d{2}(:\d{2})?\b`)
+	// Seasonal events are announced in the weeks they are on.
+	seasonal = regexp.MustCompile(`(?m)^.* is on! .*\n`)
+)
+
+// normalize takes what changes from day to day out of the game's output.
+func normalize(out string) string {
+	out = seasonal.ReplaceAllString(out, "")
+	out = timestamps.ReplaceAllString(out, "TIMESTAMP")
+	out = dates.ReplaceAllString(out, "YYYY-MM-DD")
+	return times.ReplaceAllString(out, "HH:MM")
+}
+
+// matchSnapshot compares out with testdata/snapshots/<name>.txt.
+func matchSnapshot(t *testing.T, name, out string) {
+	t.Helper()
+	out = normalize(out)
+	path := filepath.Join("testdata", "snapshots", name+".txt")
+	if *updateSnapshots {
+		if err := os.WriteFile(path, []byte(out), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if out != string(want) {
+		t.Errorf("output differs from %s (run with -update if that's expected)\ngot:\n%s\nwant:\n%s", path, out, want)
+	}
+}
+
+func TestSessionCatchInspectTrade(t *testing.T) {
+	s := newSession(t)
+	out := s.play("catch pikachu\ninspect pikachu\nwondertrade pikachu\npokedex\nexit\n")
+	matchSnapshot(t, "catch_inspect_trade", out)
+
+	caught := s.pokedex()
+	if _, ok := caught["pikachu"]; ok {
+		t.Error("pikachu is still in the Pokedex after being traded away")
+	}
+	if len(caught) != 1 {
+		t.Errorf("the Pokedex has %d Pokémon after the trade, want 1", len(caught))
+	}
+	var trades ledger.Ledger
+	s.load("trades.json", &trades)
+	if len(trades.Trades) != 1 || trades.Trades[0].SentSpecies != "pikachu" {
+		t.Errorf("the trades recorded were %+v, want pikachu sent away once", trades.Trades)
+	}
+}
+
+func TestSessionExploreAndCatch(t *testing.T) {
+	s := newSession(t)
+	out := s.play("map\nexplore viridian-forest-area\ncatch pikachu\nexit\n")
+	matchSnapshot(t, "explore_and_catch", out)
+
+	pikachu, ok := s.pokedex()["pikachu"]
+	if !ok {
+		t.Fatal("pikachu wasn't caught")
+	}
+	if pikachu.MetAt != "viridian-forest-area" {
+		t.Errorf("pikachu was met at %q, want viridian-forest-area", pikachu.MetAt)
+	}
+}
+
+func TestSessionCatchOwnedSpecies(t *testing.T) {
+	s := newSession(t)
+	out := s.play("catch pikachu\nnickname pikachu Sparky\ncatch pikachu\nexit\n")
+	if !strings.Contains(out, "You already have a pikachu") {
+		t.Errorf("expected catching a species already owned to be refused, got:\n%s", out)
+	}
+	if pikachu := s.pokedex()["pikachu"]; pikachu.Nickname != "Sparky" {
+		t.Errorf("pikachu is nicknamed %q after a second catch, want Sparky", pikachu.Nickname)
+	}
+}
+
+func TestSessionBattleFriendship(t *testing.T) {
+	s := newSession(t)
+	s.write("team.csv", "pikachu,60\n")
+	out := s.play("explore viridian-forest-area\nimport " + filepath.Join(s.home, "team.csv") + "\nbattle pikachu caterpie\nexit\n")
+	if !strings.Contains(out, "pikachu gained") {
+		t.Fatalf("expected pikachu to win the battle, got:\n%s", out)
+	}
+	if got, want := s.pokedex()["pikachu"].Friendship, friendship.Base+friendship.BattleWon; got != want {
+		t.Errorf("pikachu's friendship is %d after winning a battle, want %d", got, want)
+	}
+}
+
+func TestSessionSavesAtEndOfInput(t *testing.T) {
+	s := newSession(t)
+	s.play("catch pikachu\n")
+	if _, ok := s.pokedex()["pikachu"]; !ok {
+		t.Error("pikachu wasn't saved when the input ran out without an exit")
+	}
+}
+
+func TestSessionSavesWhenTerminated(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("there is no SIGTERM on Windows")
+	}
+	s := newSession(t)
+	g := s.start()
+	g.do("catch pikachu")
+	// The game waits at the prompt, with its input still open, when it is
+	// asked to end.
+	g.cmd.Process.Signal(syscall.SIGTERM)
+	if out, err := g.wait(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	if _, ok := s.pokedex()["pikachu"]; !ok {
+		t.Error("pikachu wasn't saved when the game was terminated")
+	}
+}
+
+func TestSessionSavesPokedexAsItChanges(t *testing.T) {
+	s := newSession(t)
+	g := s.start()
+	g.do("catch pikachu")
+	g.do("nickname pikachu Sparky")
+	g.cmd.Process.Kill()
+	g.wait()
+	if pikachu, ok := s.pokedex()["pikachu"]; !ok || pikachu.Nickname != "Sparky" {
+		t.Fatalf("the Pokedex has %+v after the game was killed, want pikachu called Sparky", s.pokedex())
+	}
+
+	// What was saved loads back as it was.
+	out := s.play("party add pikachu\nsave\nload\ny\ninspect pikachu\nparty\nexit\n")
+	for _, want := range []string{"Loaded 1 Pokémon", "Sparky"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q after loading the save\n%s", want, out)
+		}
+	}
+	var state struct {
+		Party []string `json:"party"`
+	}
+	s.load("save.json", &state)
+	if len(state.Party) != 1 || state.Party[0] != "pikachu" {
+		t.Errorf("the party is %v after loading, want pikachu", state.Party)
+	}
+
+	// A save without pikachu takes it out of the party too.
+	s.write(filepath.Join(".local", "share", "pokedexcli", "pokedex.json"), `{"saved_at":"2026-01-01T00:00:00Z","caught":{}}`)
+	s.play("load\ny\nsave\nexit\n")
+	s.load("save.json", &state)
+	if len(state.Party) != 0 {
+		t.Errorf("the party is %v after loading a save without pikachu, want it empty", state.Party)
+	}
+}
+
+func TestSessionOfferOwnedSpecies(t *testing.T) {
+	board := "POKEDEXCLI_OFFER_BOARD=" + filepath.Join(t.TempDir(), "offers.json")
+	ash, misty := newSession(t), newSession(t)
+	ash.env, misty.env = []string{board}, []string{board}
+	misty.write(filepath.Join(".local", "share", "pokedexcli", "save.json"), `{"trainer":{"name":"misty","id":2}}`)
+
+	// Balls miss now and then, so each throws until one catches.
+	misty.play("catch sentret\nnickname sentret Sparky\n" + strings.Repeat("catch pidgey\n", 5) + "exit\n")
+	ash.play("catch sentret\noffer put sentret --want pidgey\n" + strings.Repeat("catch sentret\ncatch pidgey\n", 5) + "exit\n")
+	out := ash.play("offer cancel 1\nexit\n")
+	misty.play("offer accept 1\nexit\n")
+	if sentret := misty.pokedex()["sentret"]; sentret.Nickname != "Sparky" {
+		t.Errorf("expected misty to keep Sparky, got %+v", sentret)
+	}
+	if !strings.Contains(out, "You already have a sentret") {
+		t.Errorf("expected ash not to take back a second sentret\n%s", out)
+	}
+
+	// Once misty has room, the trade goes through, and the pidgey misty
+	// sent waits on the board until ash has room for it too.
+	misty.play("release sentret\ny\noffer accept 1\nexit\n")
+	if _, ok := misty.pokedex()["sentret"]; !ok {
+		t.Fatal("expected misty to receive ash's sentret")
+	}
+	out = ash.play("release pidgey\ny\nexit\n")
+	if !strings.Contains(out, "you already have a pidgey") {
+		t.Errorf("expected misty's pidgey to wait on the board\n%s", out)
+	}
+	out = ash.play("offer collect\nexit\n")
+	if pidgey, ok := ash.pokedex()["pidgey"]; !ok || len(pidgey.Provenance) == 0 {
+		t.Errorf("expected ash to collect misty's pidgey once there was room\n%s", out)
+	}
+}
+
+func TestSessionExitStatus(t *testing.T) {
+	s := newSession(t)
+	// Balls miss now and then, so they are thrown until one catches.
+	s.play(strings.Repeat("catch sentret\n", 5) + "exit\n")
+	for _, tt := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{"pokedex"}, exitOK},
+		{[]string{"pokedex", "--where", "foo >>"}, exitUsage},
+		{[]string{"pokedex", "--format", "xml"}, exitUsage},
+		{[]string{"pokedex", "--bogus"}, exitUsage},
+		{[]string{"nickname", "pikachu", "Sparky", "--dry-run"}, exitError},
+		{[]string{"inspect", "nosuch"}, exitError},
+		{[]string{"inspect", "nosuch", "--json"}, exitError},
+		{[]string{"release", "nosuch"}, exitError},
+		{[]string{"catch", "sentret"}, exitError},
+		{[]string{"trade", "nosuch", "pikachu"}, exitError},
+		{[]string{"evolve", "nosuch"}, exitError},
+		{[]string{"use", "razz-berry"}, exitError},
+		{[]string{"inspect", "sentret"}, exitOK},
+		{[]string{"team", "bogus"}, exitUsage},
+		{[]string{"team", "load"}, exitUsage},
+		{[]string{"team", "list"}, exitOK},
+	} {
+		if out, status := s.run("", tt.args...); status != tt.want {
+			t.Errorf("%s exited with %d, want %d\n%s", strings.Join(tt.args, " "), status, tt.want, out)
+		}
+	}
+}
+
+func TestSessionMissingData(t *testing.T) {
+	s := newSession(t)
+	out := s.play("catch missingno\ninspect missingno\ninspect missingno --json\ncompare missingno pikachu\nexit\n")
+	matchSnapshot(t, "missing_data", out)
+}
+
+func TestSessionAreaDetails(t *testing.T) {
+	s := newSession(t)
+	out := s.play("explore viridian-forest-area --details\nexplore viridian-forest-area --details --sort level\n" +
+		"explore viridian-forest-area --version gold\nexplore viridian-forest-area --details --version blue --json\nexit\n")
+	matchSnapshot(t, "area_details", out)
+}
+
+func TestSessionTypeMatchups(t *testing.T) {
+	s := newSession(t)
+	out := s.play("type fire\ntype fir\nweakness pikachu\nset generation 1\nweakness pikachu\nexit\n")
+	matchSnapshot(t, "type_matchups", out)
+}
+
+func TestSessionRegionsAndPages(t *testing.T) {
+	s := newSession(t)
+	out := s.play("region\nregion kanto\nregion hoenn\nmap goto 2\nmap goto 1\nexplore viridian-forest-area\nexit\n")
+	matchSnapshot(t, "regions_and_pages", out)
+}
+
+func TestSessionMirrorUpdate(t *testing.T) {
+	s := newSession(t)
+	s.play("mirror pokemon --rate 100\n")
+	snapshot := filepath.Join(s.home, ".local", "share", "pokedexcli", "snapshot")
+
+	// Since then pikachu was renumbered, missingno added and ditto
+	// removed.
+	m, err := manifest.Load(filepath.Join(snapshot, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Record("pokemon", "pikachu", []byte(`{"id": 26}`), time.Now())
+	m.Record("pokemon", "ditto", []byte(`{"id": 132}`), time.Now())
+	m.Forget("pokemon", "missingno")
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	s.write(filepath.Join(".local", "share", "pokedexcli", "snapshot", "pokemon", "ditto.json"), `{"id": 132}`)
+	os.Remove(filepath.Join(snapshot, "pokemon", "missingno.json"))
+
+	out := s.play("mirror update --rate 100\nmirror update\n")
+	matchSnapshot(t, "mirror_update", out)
+	if _, err := os.Stat(filepath.Join(snapshot, "pokemon", "ditto.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("ditto is still mirrored after PokeAPI stopped listing it")
+	}
+	if _, err := os.Stat(filepath.Join(snapshot, "pokemon", "missingno.json")); err != nil {
+		t.Errorf("missingno wasn't mirrored: %v", err)
+	}
+	if m, err = manifest.Load(filepath.Join(snapshot, "manifest.json")); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Resources["pokemon"].Items["pikachu"].ID; got != 25 {
+		t.Errorf("pikachu is mirrored as number %d, want 25", got)
+	}
+}
+
+func TestSessionStats(t *testing.T) {
+	s := newSession(t)
+	out := s.play("stats\ncatch pikachu\ncatch missingno\ncatch missingno\ncatch missingno\nstats\nstats --json\nexit\n")
+	matchSnapshot(t, "stats", out)
+}
+
+func TestSessionBalance(t *testing.T) {
+	s := newSession(t)
+	balance := filepath.Join(".config", "pokedexcli", "balance.json")
+	s.write(balance, `{"shiny_odds": 1, "balls": {"dusk-ball": 3}, "daily_supply": {"dusk-ball": 2}}`)
+	out := s.play("catch pikachu --ball dusk\ninspect pikachu\nexit\n")
+	s.write(balance, `{"flee_chance": 0.99}`)
+	out += s.play("catch pidgey\nexit\n")
+	matchSnapshot(t, "balance", out)
+}
+
+func TestSessionItems(t *testing.T) {
+	s := newSession(t)
+	s.write(filepath.Join(".config", "pokedexcli", "balance.json"), `{"find_chance": 1}`)
+	out := s.play("explore viridian-forest-area\nexplore viridian-forest-area\ninventory\nuse razz-berry\nuse great-ball\ncatch pikachu\ninventory --json\nexit\n")
+	matchSnapshot(t, "items", out)
+}
+
+func TestSessionAlias(t *testing.T) {
+	s := newSession(t)
+	out := s.play("alias e explore\nalias hunt \"e viridian-forest-area; quest\"\nalias loop loop\nalias explore map\nalias x nothing\n" +
+		"alias\nhunt\nmap; e\nhelp e\nalias remove e\nhunt\nexit\n")
+	out += s.play("", "alias", "e", "explore")
+	out += s.play("", "e", "viridian-forest-area")
+	var settings struct {
+		Aliases map[string]string `json:"aliases"`
+	}
+	data, err := os.ReadFile(filepath.Join(s.home, ".config", "pokedexcli", "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatal(err)
+	}
+	if settings.Aliases["e"] != "explore" || settings.Aliases["hunt"] == "" {
+		t.Errorf("expected the aliases to be kept in config.json, got %v", settings.Aliases)
+	}
+	matchSnapshot(t, "alias", out)
+}
+
+func TestSessionConfig(t *testing.T) {
+	s := newSession(t)
+	out := s.play("config\nset pagesize 2\nmap\nmap goto 3\nset pagesize 0\nset cachettl 72h\nset cachebackend bolt\nset cachebackend disk\nset color off\n" +
+		"set api ftp://example.com\nset api http://localhost:8000/api/v2\nconfig show --json\nexit\n")
+	out += s.play("config\nexit\n")
+	matchSnapshot(t, "config", strings.ReplaceAll(out, s.api, "API"))
+}
+
+func TestSessionMovesSettingsToConfig(t *testing.T) {
+	s := newSession(t)
+	s.write(filepath.Join(".local", "share", "pokedexcli", "save.json"),
+		`{"trainer":{"name":"ash","id":1},"timeout":"45s","tips":true,"player":"mpv","sprites_off":true}`)
+	out := s.play("config\nexit\n")
+	for _, want := range []string{"timeout      45s", "tips         on", "player       mpv", "sprites      off"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the settings\n%s", want, out)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(s.home, ".config", "pokedexcli", "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var moved settings
+	if err := json.Unmarshal(data, &moved); err != nil {
+		t.Fatal(err)
+	}
+	if moved.Timeout != "45s" || !moved.Tips || moved.Player != "mpv" || !moved.SpritesOff {
+		t.Errorf("config.json has %+v, want the settings save.json had", moved)
+	}
+	var state map[string]any
+	s.load("save.json", &state)
+	if _, ok := state["timeout"]; ok {
+		t.Error("save.json still has the timeout after it moved to config.json")
+	}
+	if trainer, _ := state["trainer"].(map[string]any); trainer["name"] != "ash" {
+		t.Errorf("save.json lost the trainer moving the settings out: %v", state["trainer"])
+	}
+}
+
+func TestSessionPokedexFormats(t *testing.T) {
+	s := newSession(t)
+	s.play("catch pikachu\nexit\n")
+	// Pokémon caught before levels were kept have none saved.
+	dex := s.pokedex()
+	pikachu := dex["pikachu"]
+	pikachu.Level = 0
+	dex["pikachu"] = pikachu
+	data, err := json.Marshal(map[string]any{"saved_at": time.Now(), "caught": dex})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.write(filepath.Join(".local", "share", "pokedexcli", "pokedex.json"), string(data))
+
+	text := s.play("", "pokedex")
+	tmpl := s.play("", "pokedex", "--template", "{{.Name}}: Lv. {{.Level}}")
+	if !strings.Contains(text, "Lv. 5") || !strings.Contains(tmpl, "pikachu: Lv. 5") {
+		t.Errorf("expected the template to show the level the text does\ntext:\n%s\ntemplate:\n%s", text, tmpl)
+	}
+	for _, format := range []string{"csv", "table"} {
+		if out := s.play("", "pokedex", "--format", format); !strings.Contains(out, "hp=35") {
+			t.Errorf("expected the %s format to show base stats\n%s", format, out)
+		}
+	}
+	level := regexp.MustCompile(`(?i)level\W+5\b`)
+	for _, format := range []string{"json", "yaml", "table"} {
+		if out := s.play("", "inspect", "pikachu", "--format", format); !level.MatchString(out) {
+			t.Errorf("expected inspect's %s format to show level 5\n%s", format, out)
+		}
+	}
+}
+
+func TestSessionAccessible(t *testing.T) {
+	s := newSession(t)
+	out := s.play("set accessible on\nexplore viridian-forest-area\nquest\ntrainer\nset accessible off\nquest\nexit\n")
+	matchSnapshot(t, "accessible", out)
+}
+
+func TestSessionColor(t *testing.T) {
+	s := newSession(t)
+	// Output that isn't going to a terminal is left plain unless color is on.
+	out := s.play("catch pikachu\nset color on\ncatch pidgey\npokedex\nexit\n")
+	out += s.play("", "--no-color", "pokedex")
+	matchSnapshot(t, "color", out)
+}
+
+func TestSessionGlyphs(t *testing.T) {
+	s := newSession(t)
+	out := s.play("catch pikachu\nexplore viridian-forest-area\nset glyphs emoji\npokedex\nquest --format table\n" +
+		"set glyphs ascii\nquest\nquest --format table\nexit\n")
+	matchSnapshot(t, "glyphs", out)
+}
+
+func TestSessionShiny(t *testing.T) {
+	s := newSession(t)
+	s.write(filepath.Join(".config", "pokedexcli", "balance.json"), `{"shiny_odds": 1}`)
+	out := s.play("catch pikachu\npokedex\nrelease pikachu\ny\nstats\nexit\n")
+	var state struct {
+		Shinies map[string]int `json:"shinies"`
+	}
+	s.load("save.json", &state)
+	if state.Shinies["pikachu"] != 1 {
+		t.Errorf("expected the shiny pikachu to be counted, got %v", state.Shinies)
+	}
+	matchSnapshot(t, "shiny", out)
+}
+
+func TestSessionMail(t *testing.T) {
+	s := newSession(t)
+	now := time.Now().UTC()
+	s.write(filepath.Join(".local", "share", "pokedexcli", "save.json"), fmt.Sprintf(
+		`{"trainer":{"name":"ash","id":1},"farm":{"plots":[{"berry":"oran-berry","planted_at":%q}]}}`,
+		now.Add(-2*time.Hour).Format(time.RFC3339)))
+	s.write(filepath.Join(".local", "share", "pokedexcli", "mail.json"), fmt.Sprintf(
+		`{"letters":[],"checked":%q}`, now.Add(-3*time.Hour).Format(time.RFC3339)))
+	out := s.play("mail\nmail\nmail all\nmail clear\nmail all\nexit\n")
+	var inbox struct {
+		Letters []struct {
+			Text string `json:"text"`
+		} `json:"letters"`
+	}
+	s.load("mail.json", &inbox)
+	if len(inbox.Letters) != 0 {
+		t.Errorf("expected the read letter to be thrown away, got %v", inbox.Letters)
+	}
+	matchSnapshot(t, "mail", out)
+}
+
+func TestSessionExportImport(t *testing.T) {
+	s := newSession(t)
+	s.write("misty.json", `{"format": "pokedexcli", "version": 1, "trainer": {"name": "misty", "id": 2},
+  "pokemon": [
+    {"name": "pikachu", "level": 40, "original_trainer": {"name": "misty", "id": 2}},
+    {"name": "staryu", "level": 20, "nickname": "Star", "original_trainer": {"name": "misty", "id": 2}}
+  ]}`)
+	out := s.play("catch pikachu\nexport ~/pokedex.json\nexport ~/pokedex.csv\nimport ~/misty.json\n" +
+		"import ~/misty.json --conflicts higher\nimport ~/pokedex.csv\nexit\n")
+	matchSnapshot(t, "export_import", strings.ReplaceAll(out, s.home, "~"))
+
+	data, err := os.ReadFile(filepath.Join(s.home, "pokedex.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "species,level,moves,") || !strings.Contains(string(data), "\npikachu,5,") {
+		t.Errorf("unexpected CSV export:\n%s", data)
+	}
+	caught := s.pokedex()
+	if caught["pikachu"].Level != 40 || caught["staryu"].Nickname != "Star" {
+		t.Errorf("expected misty's Pokémon to be imported whole, got %+v", caught)
+	}
+	if p := caught["staryu"].Provenance; len(p) != 1 || p[0].Method != "import" || p[0].From.Name != "misty" {
+		t.Errorf("expected the import in staryu's provenance, got %+v", p)
+	}
+}
+
+func TestSessionFakemon(t *testing.T) {
+	s := newSession(t)
+	s.write("sparks.json", `{
+  "name": "sparks",
+  "author": "Brock",
+  "species": [
+    {
+      "name": "voltpup",
+      "types": ["electric"],
+      "stats": {"hp": 40, "attack": 45, "defense": 35, "special-attack": 50, "special-defense": 40, "speed": 90},
+      "base_experience": 60, "height": 5, "weight": 80, "capture_rate": 255,
+      "genus": "Spark Pup Pokémon",
+      "description": "It chases its own static around the forest.",
+      "moves": [{"name": "tackle", "level": 1}],
+      "areas": ["viridian-forest-area"], "min_level": 3, "max_level": 5,
+      "evolves_to": [{"species": "voltorbeast", "min_level": 30}],
+      "art": ["(o.o)"]
+    },
+    {"name": "voltorbeast", "types": ["electric", "steel"], "areas": ["cerulean-cave-1f"]}
+  ]
+}`)
+	out := s.play("fakemon\nfakemon import ~/sparks.json\nfakemon\nset fakemon on\n" +
+		"explore viridian-forest-area\ncatch custom/voltpup\ninspect custom/voltpup\nevolve custom/voltpup\n" +
+		"set fakemon off\nexplore viridian-forest-area\nexit\n")
+	matchSnapshot(t, "fakemon", out)
+
+	voltpup, ok := s.pokedex()["custom/voltpup"]
+	if !ok {
+		t.Fatal("custom/voltpup wasn't caught")
+	}
+	if voltpup.MetAt != "viridian-forest-area" || voltpup.Level < 3 || voltpup.Level > 5 {
+		t.Errorf("voltpup was met at %q at level %d, want viridian-forest-area at 3 to 5", voltpup.MetAt, voltpup.Level)
+	}
+}
+
+func TestSessionEvolveIntoOwnedSpecies(t *testing.T) {
+	s := newSession(t)
+	// Balls miss now and then, so each is thrown until one catches.
+	s.play(strings.Repeat("catch rattata\ncatch raticate\ncatch alakazam\ncatch sentret\n", 5) +
+		"nickname raticate Rocky\nnickname alakazam Zam\nexit\n")
+	out := s.play("evolve rattata\ntrade sentret kadabra\ny\nexit\n")
+	for _, want := range []string{"You already have a raticate", "kadabra could evolve into alakazam, but you already have one"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q\n%s", want, out)
+		}
+	}
+	caught := s.pokedex()
+	for name, nickname := range map[string]string{"raticate": "Rocky", "alakazam": "Zam"} {
+		if caught[name].Nickname != nickname {
+			t.Errorf("expected %s to still be %s, got %+v", name, nickname, caught[name])
+		}
+	}
+	for _, name := range []string{"rattata", "kadabra"} {
+		if _, ok := caught[name]; !ok {
+			t.Errorf("expected %s not to evolve", name)
+		}
+	}
+}
+
+func TestSessionTradeLeavesNoTrace(t *testing.T) {
+	s := newSession(t)
+	var state struct {
+		Party []string            `json:"party"`
+		Teams map[string][]string `json:"teams"`
+		Boxes map[string]string   `json:"boxes"`
+	}
+	s.play("catch pikachu\nparty add pikachu\nteam save travel\nbox move pikachu 2\nparty add pikachu\nexit\n")
+	s.load("save.json", &state)
+	if len(state.Party) != 1 || len(state.Teams["travel"]) != 1 || len(state.Boxes) != 1 {
+		t.Fatalf("expected pikachu in the party, a team and a box, got %+v", state)
+	}
+	s.play("wondertrade pikachu\nexit\n")
+	state.Party, state.Teams, state.Boxes = nil, nil, nil
+	s.load("save.json", &state)
+	if _, ok := s.pokedex()["pikachu"]; ok || len(state.Party) != 0 || len(state.Teams["travel"]) != 0 || len(state.Boxes) != 0 {
+		t.Errorf("expected pikachu to be gone after the wonder trade, got %+v", state)
+	}
+}
+
+func TestSessionTradeAndRelease(t *testing.T) {
+	s := newSession(t)
+	out := s.play("catch pikachu\ntrade pikachu pikachu\ntrade pikachu bulbasaur\ny\nrelease bulbasaur\nn\nsave\nexit\n")
+	matchSnapshot(t, "trade_and_release", out)
+
+	caught := s.pokedex()
+	bulbasaur, ok := caught["bulbasaur"]
+	if !ok {
+		t.Fatal("bulbasaur wasn't received, or was released though the player said no")
+	}
+	if _, ok := caught["pikachu"]; ok {
+		t.Error("pikachu is still in the Pokedex after being traded away")
+	}
+	if bulbasaur.Level != defaultLevel+tradeBonus {
+		t.Errorf("bulbasaur came at level %d, want %d", bulbasaur.Level, defaultLevel+tradeBonus)
+	}
+	if bulbasaur.OriginalTrainer.Name == "ash" || len(bulbasaur.Provenance) != 1 {
+		t.Errorf("bulbasaur should come from the trainer it was traded with, got %+v", bulbasaur.OriginalTrainer)
+	}
+
+	s.play("release bulbasaur\ny\nexit\n")
+	if _, ok := s.pokedex()["bulbasaur"]; ok {
+		t.Error("bulbasaur is still in the Pokedex after being released")
+	}
+}
+
+func TestSessionReleaseDryRun(t *testing.T) {
+	s := newSession(t)
+	s.play("catch pikachu\nparty add pikachu\nteam save travel\nexit\n")
+	out, status := s.run("", "release", "pikachu", "--dry-run")
+	if status != exitOK {
+		t.Fatalf("release --dry-run exited with %d\n%s", status, out)
+	}
+	for _, want := range []string{"pikachu would be released", "It would leave your party.", "It would leave your teams: travel."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the preview\n%s", want, out)
+		}
+	}
+	if _, ok := s.pokedex()["pikachu"]; !ok {
+		t.Error("pikachu was released in a dry run")
+	}
+}
+
+func TestSessionUseDryRun(t *testing.T) {
+	s := newSession(t)
+	s.write(filepath.Join(".local", "share", "pokedexcli", "save.json"), fmt.Sprintf(
+		`{"trainer":{"name":"ash","id":1},"items":{"razz-berry":1,"lemonade":1},"stamina":{"spent":50,"at":%q}}`,
+		time.Now().Format(time.RFC3339)))
+	out := s.play("explore viridian-forest-area\nuse razz-berry --dry-run\nuse lemonade --dry-run\nexit\n")
+	for _, want := range []string{"1.5× as likely, leaving you 0", "pikachu: ", "stamina back (100/100)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the previews\n%s", want, out)
+		}
+	}
+	var state struct {
+		Items map[string]int `json:"items"`
+		Berry string         `json:"berry"`
+	}
+	s.load("save.json", &state)
+	if state.Items["razz-berry"] != 1 || state.Items["lemonade"] != 1 || state.Berry != "" {
+		t.Errorf("expected a dry run to use nothing, got %+v", state)
+	}
+}
+
+func TestSessionTradeEvolutionWithHeldItem(t *testing.T) {
+	board := "POKEDEXCLI_OFFER_BOARD=" + filepath.Join(t.TempDir(), "offers.json")
+	ash, misty := newSession(t), newSession(t)
+	ash.env, misty.env = []string{board}, []string{board}
+	misty.write(filepath.Join(".local", "share", "pokedexcli", "save.json"), `{"trainer":{"name":"misty","id":2},"items":{"metal-coat":1}}`)
+
+	// Balls miss now and then, so each throws until one catches.
+	misty.play(strings.Repeat("catch onix\n", 5) + "give onix metal-coat\noffer put onix --want pidgey\nexit\n")
+	out := ash.play(strings.Repeat("catch pidgey\n", 5) + "offer accept 1\ny\nexit\n")
+	caught := ash.pokedex()
+	steelix, ok := caught["steelix"]
+	if !ok {
+		t.Fatalf("expected onix holding a metal-coat to evolve into steelix\n%s", out)
+	}
+	if _, ok := caught["onix"]; ok || steelix.HeldItem != "" {
+		t.Errorf("expected the evolution to use up the metal-coat, got %+v", steelix)
+	}
+}
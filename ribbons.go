@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/ribbon"
+	"github.com/eymardfreire/pokedexcli/internal/seasons"
+)
+
+// watchRibbons awards ribbons to the player's Pokémon for their feats,
+// and marks to those caught during seasonal events.
+func watchRibbons(cfg *config) {
+	cfg.Events.Subscribe(events.PokemonCaught, func(e events.Event) {
+		now := time.Now()
+		for _, season := range seasons.Active(cfg.Seasons, now) {
+			awardRibbon(cfg, e.Subject, ribbon.Mark(season.Name), now)
+		}
+		cfg.State.CatchChain++
+		if cfg.State.CatchChain == ribbon.ChainLength {
+			fmt.Printf("That's %d catches in a row!\n", ribbon.ChainLength)
+			awardRibbon(cfg, e.Subject, ribbon.Chain, now)
+		}
+		saveRibbons(cfg)
+	})
+	cfg.Events.Subscribe(events.PokemonEscaped, func(e events.Event) {
+		cfg.State.CatchChain = 0
+		saveRibbons(cfg)
+	})
+	cfg.Events.Subscribe(events.TowerStreak, func(e events.Event) {
+		if e.Count >= ribbon.TowerStreak {
+			awardRibbon(cfg, e.Subject, ribbon.Tower, time.Now())
+			saveRibbons(cfg)
+		}
+	})
+	cfg.Events.Subscribe(events.RankedWon, func(e events.Event) {
+		awardRibbon(cfg, e.Subject, ribbon.Champion, time.Now())
+		saveRibbons(cfg)
+	})
+}
+
+// awardRibbon gives a caught Pokémon a ribbon, unless it has it already.
+func awardRibbon(cfg *config, name, r string, at time.Time) {
+	pokemon, ok := cfg.Caught[name]
+	if !ok {
+		return
+	}
+	var added bool
+	if pokemon.Ribbons, added = ribbon.Award(pokemon.Ribbons, r, at); added {
+		cfg.Caught[name] = pokemon
+		fmt.Printf("%s earned the %s!\n", name, r)
+	}
+}
+
+func saveRibbons(cfg *config) {
+	if err := saveState(cfg); err != nil {
+		fmt.Println("Error saving progress:", err)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/rules"
+)
+
+// rulesetsDir holds the rulesets the player added, one JSON file each.
+func rulesetsDir(cfg *config) string {
+	return filepath.Join(cfg.Paths.Config, "rulesets")
+}
+
+// loadRuleset looks up the ruleset called name, or the default if name is
+// empty. It reports false, having told the player which there are, if
+// there is no such ruleset.
+func loadRuleset(cfg *config, name string) (rules.Ruleset, bool, error) {
+	if name == "" {
+		name = rules.Default
+	}
+	rulesets, err := rules.Load(rulesetsDir(cfg))
+	if err != nil {
+		return rules.Ruleset{}, false, err
+	}
+	ruleset, ok := rulesets[name]
+	if !ok {
+		fmt.Printf("There is no %s ruleset. Try %s, or add your own to %s.\n", name, strings.Join(rules.Names(rulesets), ", "), rulesetsDir(cfg))
+	}
+	return ruleset, ok, nil
+}
+
+// teamMembers describes caught Pokémon for the rules, with the moves they
+// battle with.
+func teamMembers(cfg *config, names []string) []rules.Member {
+	var team []rules.Member
+	for _, name := range names {
+		if pokemon, ok := cfg.Caught[name]; ok {
+			level := levelOf(pokemon)
+			team = append(team, rules.Member{Species: pokemon.Name, Level: level, Moves: knownMoves(pokemon, level)})
+		}
+	}
+	return team
+}
+
+// battleReady checks the Pokémon the player battles with against a
+// ruleset, and reports whether they follow it, having said why not.
+func battleReady(cfg *config, ruleset rules.Ruleset, names []string) bool {
+	problems := ruleset.Validate(teamMembers(cfg, names))
+	if len(problems) == 0 {
+		return true
+	}
+	fmt.Printf("Not allowed under %s:\n", ruleset.Name)
+	for _, problem := range problems {
+		fmt.Printf(" - %s\n", problem)
+	}
+	return false
+}
+
+// partyValidate checks the party against a ruleset.
+func partyValidate(cfg *config, name string) error {
+	ruleset, ok, err := loadRuleset(cfg, name)
+	if !ok {
+		return err
+	}
+	if len(cfg.State.Party) == 0 {
+		fmt.Println("Your party is empty. Use `party add <pokemon_name>` first.")
+		return nil
+	}
+	if !battleReady(cfg, ruleset, cfg.State.Party) {
+		return nil
+	}
+	fmt.Printf("Your party is ready for %s battles.\n", ruleset.Name)
+	if len(ruleset.BannedItems) > 0 {
+		fmt.Printf("You won't be able to use %s.\n", strings.Join(ruleset.BannedItems, ", "))
+	}
+	return nil
+}
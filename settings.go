@@ -0,0 +1,353 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/glyph"
+	"github.com/eymardfreire/pokedexcli/internal/pokeapi"
+	"github.com/eymardfreire/pokedexcli/internal/pokecache"
+	"github.com/eymardfreire/pokedexcli/internal/render"
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+)
+
+// settings are how the program behaves, as opposed to the game's progress
+// in save.json. They are kept in config.json, which can be edited by hand
+// or changed with set.
+type settings struct {
+	// PageSize is how many location areas a page of the map lists; 0
+	// means areasPerPage.
+	PageSize int `json:"page_size,omitempty"`
+	// CacheTTL is how long PokeAPI responses are kept on disk, like 72h;
+	// empty means diskCacheTTL.
+	CacheTTL string `json:"cache_ttl,omitempty"`
+	// Color is auto, on or off; auto colors output sent to a terminal
+	// unless NO_COLOR is set. Empty means auto.
+	Color string `json:"color,omitempty"`
+	// API is the copy of PokeAPI to use; empty means PokeAPI itself.
+	// POKEDEXCLI_API takes its place for a session.
+	API string `json:"api,omitempty"`
+	// CacheBackend is what responses are stored in between sessions, one
+	// of pokecache.Backends; empty means on disk.
+	CacheBackend string `json:"cache_backend,omitempty"`
+	// Glyphs is what output can use beyond ASCII: ascii, unicode or emoji,
+	// or auto to guess from the terminal. Empty means auto.
+	Glyphs string `json:"glyphs,omitempty"`
+	// Accessible writes output for screen readers: in words rather than
+	// symbols, without color, and never redrawing a line.
+	Accessible bool `json:"accessible,omitempty"`
+	// Aliases are the player's names for commands, or for several commands
+	// separated by semicolons, expanded at the prompt; see alias.
+	Aliases map[string]string `json:"aliases,omitempty"`
+	// CacheOff keeps PokeAPI responses in memory only, rather than in the
+	// cache backend too.
+	CacheOff bool `json:"cache_off,omitempty"`
+	// Timeout is how long a request to PokeAPI may take, like 10s; empty
+	// means apiTimeout.
+	Timeout string `json:"timeout,omitempty"`
+	// Format is how commands that take --format show their results unless
+	// told otherwise, and Template is the template for the template format.
+	Format   string `json:"format,omitempty"`
+	Template string `json:"template,omitempty"`
+	// SpritesOff stops sprites being drawn in the terminal.
+	SpritesOff bool `json:"sprites_off,omitempty"`
+	// Player is the command cries are played with; empty means none.
+	Player string `json:"player,omitempty"`
+	// Tips turns on suggestions of what to run next.
+	Tips bool `json:"tips,omitempty"`
+}
+
+// maxPageSize is the most location areas a page of the map can list.
+const maxPageSize = 100
+
+func settingsPath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Config, "config.json")
+}
+
+// loadSettings reads config.json, so a mistake made editing it by hand is
+// caught before the game starts.
+func loadSettings(cfg *config) error {
+	var s settings
+	if err := storage.ReadJSON(settingsPath(cfg), &s); err != nil {
+		return err
+	}
+	if problem := s.check(); problem != "" {
+		return fmt.Errorf("%s: %s", settingsPath(cfg), problem)
+	}
+	cfg.Settings = s
+	return nil
+}
+
+// movedSettings are the settings save.json kept before they moved to
+// config.json, by the names they had there.
+type movedSettings struct {
+	DiskCacheOff bool   `json:"disk_cache_off"`
+	Timeout      string `json:"timeout"`
+	Format       string `json:"format"`
+	Template     string `json:"template"`
+	SpritesOff   bool   `json:"sprites_off"`
+	Player       string `json:"player"`
+	Tips         bool   `json:"tips"`
+}
+
+// migrateSettings moves the settings an older save.json kept into
+// config.json, where config.json doesn't have them already, and saves
+// save.json without them. It goes after loadSettings and loadState.
+func migrateSettings(cfg *config) error {
+	var old movedSettings
+	if err := storage.ReadJSON(statePath(cfg), &old); err != nil || old == (movedSettings{}) {
+		return err
+	}
+	s := &cfg.Settings
+	s.CacheOff = s.CacheOff || old.DiskCacheOff
+	s.SpritesOff = s.SpritesOff || old.SpritesOff
+	s.Tips = s.Tips || old.Tips
+	if s.Timeout == "" {
+		s.Timeout = old.Timeout
+	}
+	if s.Format == "" {
+		s.Format, s.Template = old.Format, old.Template
+	}
+	if s.Player == "" {
+		s.Player = old.Player
+	}
+	if cfg.ReadOnly {
+		return nil
+	}
+	if err := saveSettings(cfg); err != nil {
+		return err
+	}
+	// Only save.json is written: the Pokedex may not be loaded yet.
+	return storage.WriteJSON(statePath(cfg), cfg.State)
+}
+
+func saveSettings(cfg *config) error {
+	if cfg.ReadOnly {
+		return nil
+	}
+	return storage.WriteJSON(settingsPath(cfg), cfg.Settings)
+}
+
+// check returns what is wrong with the settings, if anything.
+func (s settings) check() string {
+	if s.PageSize < 0 || s.PageSize > maxPageSize {
+		return fmt.Sprintf("page_size must be from 1 to %d", maxPageSize)
+	}
+	if s.CacheTTL != "" {
+		if d, err := time.ParseDuration(s.CacheTTL); err != nil || d <= 0 {
+			return "cache_ttl must be a duration, like 72h"
+		}
+	}
+	if s.CacheBackend != "" && !slices.Contains(pokecache.Backends(), s.CacheBackend) {
+		return fmt.Sprintf("cache_backend must be one of %s", strings.Join(pokecache.Backends(), ", "))
+	}
+	switch s.Color {
+	case "", "auto", "on", "off":
+	default:
+		return "color must be auto, on or off"
+	}
+	if _, ok := glyph.Parse(s.Glyphs); !ok && s.Glyphs != "" && s.Glyphs != "auto" {
+		return "glyphs must be auto, ascii, unicode or emoji"
+	}
+	if s.API != "" && !isWebURL(s.API) {
+		return "api must be an http:// or https:// URL"
+	}
+	if s.Timeout != "" {
+		if d, err := time.ParseDuration(s.Timeout); err != nil || d <= 0 || d > maxTimeout {
+			return fmt.Sprintf("timeout must be a duration up to %s, like 10s", maxTimeout)
+		}
+	}
+	if s.Format != "" {
+		if _, err := render.New(s.Format, s.Template); err != nil {
+			return fmt.Sprintf("format: %v", err)
+		}
+	}
+	for name, expansion := range s.Aliases {
+		if problem := aliasProblem(name, expansion); problem != "" {
+			return fmt.Sprintf("alias %q: %s", name, problem)
+		}
+	}
+	return ""
+}
+
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (s settings) pageSize() int {
+	if s.PageSize == 0 {
+		return areasPerPage
+	}
+	return s.PageSize
+}
+
+func (s settings) cacheBackend() string {
+	if s.CacheBackend == "" {
+		return "disk"
+	}
+	return s.CacheBackend
+}
+
+func (s settings) cacheTTL() time.Duration {
+	if d, err := time.ParseDuration(s.CacheTTL); err == nil && d > 0 {
+		return d
+	}
+	return diskCacheTTL
+}
+
+func (s settings) requestTimeout() time.Duration {
+	if d, err := time.ParseDuration(s.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return apiTimeout
+}
+
+// color reports whether output may be colored.
+func (s settings) color() bool {
+	if s.Accessible {
+		return false
+	}
+	switch s.Color {
+	case "on":
+		return true
+	case "off":
+		return false
+	}
+	return os.Getenv("NO_COLOR") == "" && terminal()
+}
+
+// terminal reports whether output goes to a terminal, rather than a file
+// or another program.
+func terminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// glyphs is what output can use beyond ASCII. Accessible mode keeps to
+// ASCII, as screen readers read emoji and box-drawing lines out by name.
+func (s settings) glyphs() glyph.Level {
+	if s.Accessible {
+		return glyph.ASCII
+	}
+	if l, ok := glyph.Parse(s.Glyphs); ok {
+		return l
+	}
+	return glyph.Detect(os.Getenv)
+}
+
+// apiURL is the copy of PokeAPI the session uses.
+func apiURL(cfg *config) string {
+	if u := os.Getenv("POKEDEXCLI_API"); u != "" {
+		return strings.TrimSuffix(u, "/")
+	}
+	if cfg.Settings.API != "" {
+		return strings.TrimSuffix(cfg.Settings.API, "/")
+	}
+	return pokeapi.BaseURL
+}
+
+func setPageSize(cfg *config, value string) error {
+	n := 0
+	if value != "default" {
+		var err error
+		if n, err = strconv.Atoi(value); err != nil || n < 1 || n > maxPageSize {
+			fmt.Printf("The page size must be from 1 to %d, or default.\n", maxPageSize)
+			return nil
+		}
+	}
+	cfg.Settings.PageSize = n
+	// The pages the map was on no longer line up.
+	cfg.Next, cfg.Previous = "", ""
+	fmt.Printf("The map now lists %d areas a page.\n", cfg.Settings.pageSize())
+	return saveSettings(cfg)
+}
+
+func setCacheTTL(cfg *config, value string) error {
+	if value == "default" {
+		cfg.Settings.CacheTTL = ""
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			fmt.Println("The cache duration must be a duration, like 72h, or default.")
+			return nil
+		}
+		cfg.Settings.CacheTTL = d.String()
+	}
+	cfg.API.SetCache(apiCache(cfg))
+	fmt.Printf("PokeAPI responses are now kept on disk for %s.\n", cfg.Settings.cacheTTL())
+	return saveSettings(cfg)
+}
+
+func setCacheBackend(cfg *config, value string) error {
+	if !slices.Contains(pokecache.Backends(), value) {
+		fmt.Printf("The cache backend can be %s.\n", strings.Join(pokecache.Backends(), ", "))
+		return nil
+	}
+	cfg.Settings.CacheBackend = value
+	if value == "disk" {
+		cfg.Settings.CacheBackend = ""
+	}
+	cfg.API.SetCache(apiCache(cfg))
+	fmt.Printf("PokeAPI responses are now stored with the %s cache.\n", value)
+	if cfg.Settings.CacheOff {
+		fmt.Println("It is used once `set cache on` turns the stored cache back on.")
+	}
+	return saveSettings(cfg)
+}
+
+func setColor(cfg *config, value string) error {
+	switch value {
+	case "auto", "on", "off":
+	default:
+		fmt.Println("Color can be auto, on or off.")
+		return nil
+	}
+	cfg.Settings.Color = value
+	if value == "auto" {
+		cfg.Settings.Color = ""
+	}
+	fmt.Printf("Color is now %s.\n", value)
+	return saveSettings(cfg)
+}
+
+func setGlyphs(cfg *config, value string) error {
+	if _, ok := glyph.Parse(value); !ok && value != "auto" {
+		fmt.Println("Glyphs can be auto, ascii, unicode or emoji.")
+		return nil
+	}
+	cfg.Settings.Glyphs = value
+	if value == "auto" {
+		cfg.Settings.Glyphs = ""
+	}
+	fmt.Printf("Output now uses %s.\n", cfg.Settings.glyphs())
+	return saveSettings(cfg)
+}
+
+func setAPI(cfg *config, value string) error {
+	if value == "default" {
+		value = ""
+	} else if !isWebURL(value) {
+		fmt.Println("The API must be an http:// or https:// URL, like http://localhost:8000/api/v2, or default.")
+		return nil
+	}
+	cfg.Settings.API = value
+	if os.Getenv("POKEDEXCLI_API") != "" {
+		fmt.Println("Saved, but POKEDEXCLI_API is set, so it is used instead until it is unset.")
+		return saveSettings(cfg)
+	}
+	cfg.API.SetBaseURL(apiURL(cfg))
+	cfg.Next, cfg.Previous = "", ""
+	fmt.Printf("PokeAPI data now comes from %s.\n", cfg.API.BaseURL)
+	if err := saveSettings(cfg); err != nil {
+		return err
+	}
+	// Homebrew species are served from under the API's address.
+	return loadFakemon(cfg)
+}
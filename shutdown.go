@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/hooks"
+	"github.com/eymardfreire/pokedexcli/internal/lineedit"
+)
+
+// interrupts turns Ctrl-C into cancelling the command that is running, so
+// it gives up on what it is waiting for instead of the game being killed
+// halfway through. At the prompt the line editor sees Ctrl-C itself and
+// just clears the line.
+type interrupts struct {
+	mu sync.Mutex
+	// cancel cancels the running command, or is nil between commands.
+	cancel context.CancelFunc
+	// interrupted is set once the running command has been interrupted,
+	// and stopping once the game has been asked to end.
+	interrupted, stopping bool
+	// quit is closed when the game is asked to end at the prompt.
+	quit chan struct{}
+}
+
+// handleInterrupts catches interrupts and requests to terminate for the
+// rest of the session. Between commands either one ends the game. During a
+// command, an interrupt cancels the command, and a second one ends the
+// game once the command has stopped; terminating does both at once. If the
+// command won't stop, one more quits without waiting for it. The game is
+// saved and ended by the main goroutine, never while a command is still
+// changing it.
+func handleInterrupts(cfg *config) {
+	in := &interrupts{quit: make(chan struct{})}
+	cfg.Interrupts = in
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			in.handle(sig)
+		}
+	}()
+}
+
+func (in *interrupts) handle(sig os.Signal) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	switch {
+	case in.cancel == nil:
+		if !in.stopping {
+			in.stopping = true
+			close(in.quit)
+		}
+	case !in.interrupted:
+		in.cancel()
+		in.interrupted = true
+		in.stopping = sig != os.Interrupt
+	case !in.stopping:
+		in.stopping = true
+		fmt.Fprintln(os.Stderr, "\nThe game ends once the command stops; interrupt again to quit without waiting.")
+	default:
+		lineedit.Restore()
+		fmt.Fprintln(os.Stderr, "\nQuit without waiting for the command to stop.")
+		os.Exit(exitError)
+	}
+}
+
+// readLine reads a line at the prompt, or returns errStopped if the game is
+// asked to end first.
+func (in *interrupts) readLine(editor *lineedit.Editor, prompt string) (string, error) {
+	if in == nil {
+		return editor.ReadLine(prompt)
+	}
+	type line struct {
+		text string
+		err  error
+	}
+	read := make(chan line, 1)
+	go func() {
+		text, err := editor.ReadLine(prompt)
+		read <- line{text, err}
+	}()
+	select {
+	case l := <-read:
+		return l.text, l.err
+	case <-in.quit:
+		// The line being read is given up on, so the terminal is left in
+		// raw mode.
+		lineedit.Restore()
+		return "", errStopped
+	}
+}
+
+// errStopped is returned at the prompt when the game was asked to end.
+var errStopped = errors.New("stopped")
+
+// command returns the context for a command about to run, which ends
+// after timeout if that isn't 0, and a function to call once it has run.
+func (in *interrupts) command(timeout time.Duration) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	}
+	if in == nil {
+		return ctx, cancel
+	}
+	in.mu.Lock()
+	in.cancel, in.interrupted = cancel, false
+	in.mu.Unlock()
+	return ctx, func() {
+		in.mu.Lock()
+		in.cancel = nil
+		in.mu.Unlock()
+		cancel()
+	}
+}
+
+// stopped reports whether the game was asked to end while a command ran.
+func (in *interrupts) stopped() bool {
+	if in == nil {
+		return false
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.stopping
+}
+
+// timedOut reports whether err is a command or request taking too long.
+func timedOut(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// closeSession saves what isn't saved as it changes, and runs the exit
+// hooks, before the game ends.
+func closeSession(cfg *config) error {
+	if err := savePokedex(cfg); err != nil {
+		return fmt.Errorf("saving your Pokedex: %w", err)
+	}
+	runHooks(cfg, hooks.Exit, hooks.Data{})
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eymardfreire/pokedexcli/internal/lineedit"
+	"github.com/eymardfreire/pokedexcli/internal/sprite"
+)
+
+// spriteWidth is the widest sprites are drawn, in columns, however wide the
+// terminal is.
+const spriteWidth = 48
+
+// showSprite draws a Pokémon's sprite, if stdout is a terminal and sprites
+// are on. A sprite that can't be had is left out without a word.
+func showSprite(cfg *config, pokemon Pokemon) {
+	width := min(spriteWidth, lineedit.Width(int(os.Stdout.Fd())))
+	// Sprites are drawn in color, so can't be drawn without it.
+	if cfg.Settings.SpritesOff || width == 0 || !colored(cfg) {
+		return
+	}
+	if art, ok := cfg.Fakemon.Art(pokemon.Name); ok {
+		fmt.Println(strings.Join(art, "\n"))
+		return
+	}
+	art, err := spriteArt(cfg, pokemon, width)
+	if err != nil {
+		if cfg.Log != nil {
+			cfg.Log.Printf("drawing %s: %v", pokemon.Name, err)
+		}
+		return
+	}
+	fmt.Print(art)
+}
+
+// spriteArt draws a Pokémon's sprite width columns wide, in its shiny
+// colors if it is one. The art is kept in
+// the cache, so each sprite is only converted once for each width.
+func spriteArt(cfg *config, pokemon Pokemon, width int) (string, error) {
+	sprites := pokemon.Sprites
+	if sprites.FrontDefault == "" || pokemon.Shiny && sprites.FrontShiny == "" {
+		// Pokémon caught before sprites were kept have no link.
+		species, err := fetchPokemon(cfg, pokemon.Name)
+		if err != nil {
+			return "", err
+		}
+		sprites = species.Sprites
+	}
+	url := sprites.FrontDefault
+	if pokemon.Shiny && sprites.FrontShiny != "" {
+		url = sprites.FrontShiny
+	}
+	if url == "" {
+		return "", nil
+	}
+	path := filepath.Join(cfg.Paths.Cache, "sprites", fmt.Sprintf("%x-%d.ans", sha256.Sum256([]byte(url)), width))
+	if art, err := os.ReadFile(path); err == nil {
+		return string(art), nil
+	}
+
+	file, err := cfg.Media.Fetch(url)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	img, err := sprite.Decode(f)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", url, err)
+	}
+	art := sprite.Render(img, width)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	return art, os.WriteFile(path, []byte(art), 0o644)
+}
+
+func setSprites(cfg *config, value string) error {
+	switch value {
+	case "on":
+		cfg.Settings.SpritesOff = false
+		fmt.Println("Pokémon are drawn in inspect and when you catch them.")
+	case "off":
+		cfg.Settings.SpritesOff = true
+		fmt.Println("Pokémon are no longer drawn.")
+	default:
+		fmt.Println("Sprites can be on or off.")
+		return nil
+	}
+	return saveSettings(cfg)
+}
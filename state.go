@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os/user"
+	"path/filepath"
+
+	"github.com/eymardfreire/pokedexcli/internal/biome"
+	"github.com/eymardfreire/pokedexcli/internal/dex"
+	"github.com/eymardfreire/pokedexcli/internal/elo"
+	"github.com/eymardfreire/pokedexcli/internal/farm"
+	"github.com/eymardfreire/pokedexcli/internal/gift"
+	"github.com/eymardfreire/pokedexcli/internal/npc"
+	"github.com/eymardfreire/pokedexcli/internal/quest"
+	"github.com/eymardfreire/pokedexcli/internal/roamer"
+	"github.com/eymardfreire/pokedexcli/internal/sizes"
+	"github.com/eymardfreire/pokedexcli/internal/stamina"
+	"github.com/eymardfreire/pokedexcli/internal/storage"
+	"github.com/eymardfreire/pokedexcli/internal/tower"
+	"github.com/eymardfreire/pokedexcli/internal/trainer"
+	"github.com/eymardfreire/pokedexcli/internal/tutorial"
+	"github.com/eymardfreire/pokedexcli/internal/types"
+	"github.com/eymardfreire/pokedexcli/internal/wondertrade"
+)
+
+// trainerID identifies a save, so Pokémon keep their original trainer even
+// if the trainer is renamed.
+type trainerID struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+func (t trainerID) String() string {
+	return fmt.Sprintf("%s (ID %05d)", t.Name, t.ID)
+}
+
+// gameState is the progress that survives between sessions.
+type gameState struct {
+	Trainer trainerID       `json:"trainer"`
+	Profile trainer.Profile `json:"profile"`
+	Items   map[string]int  `json:"items"`
+	// Berry is the berry held out for the next ball thrown, already taken
+	// from Items; empty means none.
+	Berry    string                  `json:"berry,omitempty"`
+	Tutorial tutorial.Progress       `json:"tutorial"`
+	Farm     farm.Farm               `json:"farm"`
+	Gifts    []gift.Redemption       `json:"gifts"`
+	Roamer   roamer.Roamer           `json:"roamer"`
+	Records  map[string]sizes.Record `json:"records"`
+	Dex      dex.Dex                 `json:"dex"`
+	// CatchChain is how many Pokémon have been caught in a row without
+	// one getting away.
+	CatchChain int `json:"catch_chain,omitempty"`
+	// Areas are the encounter levels of every area explored.
+	Areas map[string]levelRange `json:"areas"`
+	// Biomes are the biomes of the areas explored, and BiomeCatches how
+	// many Pokémon have been caught in each.
+	Biomes       map[string]biome.Biome `json:"biomes"`
+	BiomeCatches map[biome.Biome]int    `json:"biome_catches"`
+	// Party is the caught Pokémon the player travels with; Teams are saved
+	// parties to switch between.
+	Party []string            `json:"party"`
+	Teams map[string][]string `json:"teams"`
+	// Boxes is the storage box each caught Pokémon is kept in while it
+	// isn't in the party. Pokémon not listed are in the first box.
+	Boxes map[string]string `json:"boxes"`
+	// Released are Pokémon the player let go, kept for a while in case they
+	// want them back; see releaseKeep.
+	Released []released `json:"released,omitempty"`
+	// Notes are what the player wrote down about each area.
+	Notes map[string][]areaNote `json:"notes"`
+	// Bookmarks are names for areas to travel back to.
+	Bookmarks map[string]string `json:"bookmarks"`
+	// Explored is the area the player last explored.
+	Explored string `json:"explored,omitempty"`
+	// Quests are the day's quests.
+	Quests quest.Board `json:"quests"`
+	// Place is the area the player last travelled to.
+	Place      stamina.Place   `json:"place"`
+	Stamina    stamina.Stamina `json:"stamina"`
+	StaminaOff bool            `json:"stamina_off,omitempty"`
+	// DryRun runs every command as a dry run.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Fakemon puts the species of installed homebrew packs in the game.
+	Fakemon bool `json:"fakemon,omitempty"`
+
+	WonderTrades wondertrade.Allowance `json:"wonder_trades"`
+	// Shinies is how many shiny Pokémon of each species were ever caught,
+	// counting those since released or traded away.
+	Shinies map[string]int `json:"shinies,omitempty"`
+	// Supplied is the day the daily balls were last handed out.
+	Supplied string            `json:"supplied,omitempty"`
+	Tower    tower.Leaderboard `json:"tower"`
+	Trainers npc.Registry      `json:"trainers"`
+	Duels    elo.Record        `json:"duels"`
+
+	// Generation picks the battle rules; 0 means the latest.
+	Generation int `json:"generation,omitempty"`
+	// Server is the community server used for online play; empty means
+	// the player hasn't opted in.
+	Server string `json:"server,omitempty"`
+	// FriendCode is the player's code on Server, once they have one.
+	FriendCode string `json:"friend_code,omitempty"`
+	// Gifted is the day a gift was last sent to each friend, by code.
+	Gifted map[string]string `json:"gifted"`
+}
+
+func (s *gameState) generation() int {
+	if s.Generation == 0 {
+		return types.Latest
+	}
+	return s.Generation
+}
+
+func statePath(cfg *config) string {
+	return filepath.Join(cfg.Paths.Data, "save.json")
+}
+
+func loadState(cfg *config) error {
+	state := &gameState{}
+	if err := storage.ReadJSON(statePath(cfg), state); err != nil {
+		return err
+	}
+	if state.Items == nil {
+		state.Items = make(map[string]int)
+	}
+	if state.Records == nil {
+		state.Records = make(map[string]sizes.Record)
+	}
+	if state.Dex == nil {
+		state.Dex = make(dex.Dex)
+	}
+	if state.Areas == nil {
+		state.Areas = make(map[string]levelRange)
+	}
+	if state.Biomes == nil {
+		state.Biomes = make(map[string]biome.Biome)
+	}
+	if state.BiomeCatches == nil {
+		state.BiomeCatches = make(map[biome.Biome]int)
+	}
+	if state.Teams == nil {
+		state.Teams = make(map[string][]string)
+	}
+	if state.Boxes == nil {
+		state.Boxes = make(map[string]string)
+	}
+	if state.Notes == nil {
+		state.Notes = make(map[string][]areaNote)
+	}
+	if state.Bookmarks == nil {
+		state.Bookmarks = make(map[string]string)
+	}
+	if state.Gifted == nil {
+		state.Gifted = make(map[string]string)
+	}
+	if state.Trainer.ID == 0 {
+		state.Trainer = trainerID{Name: defaultTrainerName(), ID: 1 + rand.Intn(99999)}
+	}
+	cfg.State = state
+	return nil
+}
+
+func defaultTrainerName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "Trainer"
+}
+
+// saveState saves the game's progress in save.json, and the Pokedex with
+// it.
+func saveState(cfg *config) error {
+	if cfg.ReadOnly {
+		return nil
+	}
+	// The Pokedex is saved first, so a crash in between keeps a Pokémon
+	// that was caught rather than the ball that caught it.
+	if err := savePokedex(cfg); err != nil {
+		return err
+	}
+	return storage.WriteJSON(statePath(cfg), cfg.State)
+}
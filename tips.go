@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eymardfreire/pokedexcli/internal/suggest"
+)
+
+// tipper suggests the command the player usually runs next, when tips are
+// on, from the prompt's history.
+type tipper struct {
+	model *suggest.Model
+	// last is the last tip given, which isn't repeated straight away.
+	last string
+}
+
+func newTipper(history []string) *tipper {
+	t := &tipper{model: suggest.New()}
+	for _, line := range history {
+		t.model.Observe(line)
+	}
+	return t
+}
+
+// ran learns from a line the player entered, then gives a tip if there is
+// a new one.
+func (t *tipper) ran(cfg *config, line string) {
+	t.model.Observe(line)
+	next, ok := t.model.Predict()
+	if !ok || !cfg.Settings.Tips || next == t.last {
+		return
+	}
+	t.last = next
+	fmt.Printf("Tip: you often run `%s` next.\n", next)
+}
+
+func setTips(cfg *config, value string) error {
+	switch value {
+	case "on":
+		cfg.Settings.Tips = true
+		fmt.Println("You'll get tips about what you usually run next.")
+	case "off":
+		cfg.Settings.Tips = false
+		fmt.Println("No more tips.")
+	default:
+		fmt.Println("Tips can be on or off.")
+		return nil
+	}
+	return saveSettings(cfg)
+}
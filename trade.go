@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/evolution"
+)
+
+// receiveTraded stores a Pokémon that just arrived from another trainer in
+// exchange for sent, records the trade in the ledger and lets the Pokémon
+// evolve if trading triggers an evolution. sent is nil if what the player
+// gave is no longer known.
+func receiveTraded(cfg *config, sent *Pokemon, pokemon Pokemon, from trainerID, method string) (Pokemon, error) {
+	pokemon.Provenance = append(pokemon.Provenance, transfer{
+		From:   from,
+		To:     cfg.State.Trainer,
+		Method: method,
+		At:     time.Now(),
+	})
+	cfg.Caught[pokemon.Name] = pokemon
+	if err := recordTrade(cfg, sent, pokemon, from, method); err != nil {
+		fmt.Println("Error recording the trade:", err)
+	}
+
+	chain, err := fetchEvolutionChain(cfg, pokemon.Name)
+	if err != nil {
+		fmt.Println("Could not check for evolutions:", err)
+		return pokemon, nil
+	}
+	partner := ""
+	if sent != nil {
+		partner = sent.Name
+	}
+	evo, ok := evolution.TradeEvolution(chain, pokemon.Name, pokemon.HeldItem, partner)
+	if !ok {
+		return pokemon, nil
+	}
+	if _, owned := cfg.Caught[evo.Species]; owned {
+		fmt.Printf("%s could evolve into %s, but you already have one; release or trade it first.\n", pokemon.Name, evo.Species)
+		return pokemon, nil
+	}
+	if !confirm(cfg, fmt.Sprintf("What? %s is evolving! Let it evolve into %s?", pokemon.Name, evo.Species)) {
+		fmt.Printf("%s stopped evolving.\n", pokemon.Name)
+		return pokemon, nil
+	}
+	if evo.Detail.HeldItem != nil {
+		// The item is used up by the evolution.
+		pokemon.HeldItem = ""
+	}
+	return evolvePokemon(cfg, pokemon, evo.Species)
+}
+
+// evolvePokemon replaces a caught Pokémon with its evolved form, keeping
+// everything that belongs to the individual. Callers make sure the player
+// doesn't have one of species already.
+func evolvePokemon(cfg *config, pokemon Pokemon, species string) (Pokemon, error) {
+	evolved, err := fetchPokemon(cfg, species)
+	if err != nil {
+		return pokemon, err
+	}
+	from := pokemon.Name
+	pokemon.Name = evolved.Name
+	pokemon.BaseExperience = evolved.BaseExperience
+	pokemon.Height = evolved.Height
+	pokemon.Weight = evolved.Weight
+	pokemon.Stats = evolved.Stats
+	pokemon.Types = evolved.Types
+
+	delete(cfg.Caught, from)
+	cfg.Caught[pokemon.Name] = pokemon
+	renameCaught(cfg, from, pokemon.Name)
+	cfg.State.Dex.Catch(pokemon.Name, time.Now())
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", from, pokemon.Name)
+	cfg.Events.Publish(events.Event{Kind: events.PokemonEvolved, Subject: pokemon.Name, Other: from})
+	return pokemon, nil
+}
+
+func fetchEvolutionChain(cfg *config, name string) (evolution.Chain, error) {
+	species, err := cfg.API.GetSpecies(name)
+	if err != nil || species.EvolutionChain.URL == "" {
+		return evolution.Chain{}, err
+	}
+	return cfg.API.GetEvolutionChain(species.EvolutionChain.URL)
+}
+
+// confirm asks a yes/no question and reports whether the answer was yes.
+func confirm(cfg *config, question string) bool {
+	fmt.Printf("%s (y/n) ", question)
+	answer, _ := cfg.In.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
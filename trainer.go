@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eymardfreire/pokedexcli/internal/balance"
+	"github.com/eymardfreire/pokedexcli/internal/events"
+	"github.com/eymardfreire/pokedexcli/internal/trainer"
+)
+
+// watchTrainer gives the trainer experience for their catches, keeps
+// track of the regions they explore and unlocks their achievements.
+func watchTrainer(cfg *config) {
+	cfg.Events.Subscribe(events.PokemonCaught, func(e events.Event) {
+		pokemon, ok := cfg.Caught[e.Subject]
+		if !ok {
+			return
+		}
+		now := time.Now()
+		profile := &cfg.State.Profile
+		xp := balance.Scale(trainer.CatchXP(pokemon.BaseExperience), cfg.Balance.TrainerXP)
+		if profile.Catch(typeNames(pokemon), xp, now) > 0 {
+			fmt.Printf("+%d trainer XP. You're now trainer level %d!\n", xp, profile.Level())
+		} else {
+			fmt.Printf("+%d trainer XP.\n", xp)
+		}
+		unlockAchievements(cfg, now)
+	})
+	cfg.Events.Subscribe(events.AreaExplored, func(e events.Event) {
+		if err := exploreRegion(cfg, e.Subject); err != nil && cfg.Log != nil {
+			cfg.Log.Printf("region of %s: %v", e.Subject, err)
+		}
+		unlockAchievements(cfg, time.Now())
+	})
+}
+
+// exploreRegion records exploring an area's location in its region, and
+// the first time the region comes up, how many of its locations can be
+// explored.
+func exploreRegion(cfg *config, name string) error {
+	area, err := cfg.API.GetLocationArea(name)
+	if err != nil {
+		return err
+	}
+	place, err := placeOf(cfg, area)
+	if err != nil || place.Region == "" {
+		return err
+	}
+	profile := &cfg.State.Profile
+	profile.Explore(place.Region, place.Location)
+	if region := profile.Regions[place.Region]; region.Explorable == 0 {
+		region.Explorable, err = explorableLocations(cfg, place.Region)
+	}
+	return err
+}
+
+// explorableLocations counts a region's locations that have areas to
+// explore. It looks every location up, so is only done once per region.
+func explorableLocations(cfg *config, name string) (int, error) {
+	locations, _, err := regionLocations(cfg, name, 0, nil)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, location := range locations {
+		if len(location.Areas) > 0 {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// unlockAchievements announces the achievements the trainer has just
+// met, and saves their progress.
+func unlockAchievements(cfg *config, now time.Time) {
+	for _, a := range cfg.State.Profile.Unlock(now) {
+		fmt.Printf("Achievement unlocked: %s (%s)!\n", a.Name, a.Description)
+	}
+	if err := saveState(cfg); err != nil {
+		fmt.Println("Error saving progress:", err)
+	}
+}
+
+// profileView is the profile as `trainer` shows it in other formats.
+type profileView struct {
+	Name         string             `json:"name"`
+	ID           int                `json:"id"`
+	Level        int                `json:"level"`
+	XP           int                `json:"xp"`
+	NextLevel    int                `json:"next_level_xp,omitempty"`
+	Streak       int                `json:"streak"`
+	BestStreak   int                `json:"best_streak"`
+	Achievements []trainer.Unlocked `json:"achievements"`
+}
+
+func commandTrainer(cfg *config, args []string) error {
+	profile := &cfg.State.Profile
+	view := profileView{
+		Name:         cfg.State.Trainer.Name,
+		ID:           cfg.State.Trainer.ID,
+		Level:        profile.Level(),
+		XP:           profile.XP,
+		Streak:       profile.CurrentStreak(time.Now()),
+		BestStreak:   profile.Best,
+		Achievements: profile.Achievements,
+	}
+	if view.Level < trainer.MaxLevel {
+		view.NextLevel = trainer.XPFor(view.Level + 1)
+	}
+	return show(cfg, view, func() {
+		fmt.Println(cfg.State.Trainer)
+		if view.NextLevel > 0 {
+			fmt.Printf("Level %d (%d/%d XP)\n", view.Level, view.XP, view.NextLevel)
+		} else {
+			fmt.Printf("Level %d (%d XP)\n", view.Level, view.XP)
+		}
+		fmt.Printf("Catch streak: %d days (best %d)\n", view.Streak, view.BestStreak)
+		fmt.Println("Achievements:")
+		for _, a := range trainer.Achievements {
+			if profile.Has(a.Name) {
+				fmt.Printf(say(cfg, "  ★ %s: %s\n"), a.Name, a.Description)
+			} else {
+				fmt.Printf(say(cfg, "  ☆ %s: %s\n"), a.Name, a.Description)
+			}
+		}
+	})
+}